@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alignToFlag     string
+	alignDryRunFlag bool
+)
+
+// alignCmd aligns a Go module family to a single release train.
+var alignCmd = &cobra.Command{
+	Use:   "align <module-prefix> --to <version>",
+	Short: "Align every Go module under a path prefix to one release line",
+	Long: `Align moves every required module under a path prefix to the newest version
+on a release line, e.g. all k8s.io modules to v0.30.x:
+
+  faro align k8s.io --to v0.30
+
+Every module in the family must publish a matching version, otherwise nothing is changed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Align(
+			cmd.Context(),
+			app.AlignOptions{
+				Prefix:         args[0],
+				Target:         alignToFlag,
+				DryRun:         alignDryRunFlag,
+				GoModPath:      goModFlag,
+				NoExec:         noExecFlag,
+				AuditLog:       auditLogFlag,
+				OverrideFreeze: overrideFreezeFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	alignCmd.Flags().StringVar(&alignToFlag, "to", "", "Target release line or version (e.g. v0.30 or v0.30.2)")
+	alignCmd.Flags().BoolVar(&alignDryRunFlag, "dry-run", false, "Show the alignment plan without changing go.mod")
+	_ = alignCmd.MarkFlagRequired("to")
+	alignCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to align (runs go commands in its directory)")
+	alignCmd.Flags().BoolVar(&overrideFreezeFlag, "override-freeze", false, "Apply the alignment even during a freeze window configured in .faro.json")
+	rootCmd.AddCommand(alignCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var bisectToFlag string
+
+// bisectCmd finds the first version of a module that breaks the project.
+var bisectCmd = &cobra.Command{
+	Use:   "bisect <module> [--to <version>]",
+	Short: "Find the first version of a Go module that breaks the build or tests",
+	Long: `Bisect binary-searches the releases of a module between the version in go.mod
+and the latest (or --to) version, upgrading and running the verification
+command at each step, and reports the first version that breaks the project:
+
+  faro bisect github.com/jackc/pgx/v5
+
+Verification runs go build ./... and go test, or the doctor command from .faro.json.
+go.mod and go.sum are restored when bisecting finishes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Bisect(
+			cmd.Context(),
+			app.BisectOptions{
+				Module:    args[0],
+				Target:    bisectToFlag,
+				GoModPath: goModFlag,
+				NoExec:    noExecFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	bisectCmd.Flags().StringVar(&bisectToFlag, "to", "", "Newest version to test (default: latest)")
+	bisectCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to bisect (runs go commands in its directory)")
+	rootCmd.AddCommand(bisectCmd)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd groups commands that manage faro's on-disk cache.
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the on-disk cache of scans and lookups",
+}
+
+// cacheClearCmd removes the cache directory.
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached scans, vulnerability and publish-time lookups, and vanity import resolutions",
+	Long: `Clear removes faro's cache directory ($XDG_CACHE_HOME/faro, or the
+platform's user cache directory). The next run queries everything again.
+How long lookups are reused is set with cache.ttl and scanCache.ttl in
+.faro.json; --refresh bypasses the cache for a single run.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.CacheClear(app.CacheClearOptions{}, app.Deps{
+			Out: cmd.OutOrStdout(),
+			Now: time.Now,
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd applies updates one at a time and reverts the breaking ones.
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Apply each Go update, build and test, and revert the ones that break",
+	Long: `Doctor applies every pending Go update in turn, runs go build ./... and
+go test ./... (or the doctor command from .faro.json), and reverts go.mod and
+go.sum for updates that break the project. Kept updates stay applied, so each
+update is tried on top of the ones before it. The project is verified once
+before any change, and critical modules are left out.
+
+It finishes with a report of the safe and the breaking upgrades. With
+--coverprofile, it also warns about kept upgrades whose importing code no
+test executes: their passing tests say little.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Doctor(
+			cmd.Context(),
+			app.DoctorOptions{
+				Filter:         filterFlag,
+				All:            allFlag,
+				Cooldown:       cooldownFlag,
+				CooldownSet:    cmd.Flags().Changed("cooldown"),
+				GoModPath:      goModFlag,
+				NoExec:         noExecFlag,
+				Progress:       term.IsTerminal(os.Stdout.Fd()),
+				AuditLog:       auditLogFlag,
+				OverrideFreeze: overrideFreezeFlag,
+				CoverProfile:   coverProfileFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&filterFlag, "filter", "f", "", "Only try modules whose path matches this regex")
+	doctorCmd.Flags().BoolVar(&allFlag, "all", false, "Include transitive updates (not listed in go.mod)")
+	doctorCmd.Flags().IntVarP(&cooldownFlag, "cooldown", "c", 0, "Only try updates at least this many days old")
+	doctorCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to doctor (runs go commands in its directory)")
+	doctorCmd.Flags().StringVar(&auditLogFlag, "audit-log", "", "Append a JSON record of the kept upgrades to this file")
+	doctorCmd.Flags().BoolVar(&overrideFreezeFlag, "override-freeze", false, "Try the updates even during a freeze window configured in .faro.json")
+	doctorCmd.Flags().StringVar(&coverProfileFlag, "coverprofile", "", "Go cover profile (go test -coverprofile); warns about kept updates whose importing code no test executes")
+	rootCmd.AddCommand(doctorCmd)
+}
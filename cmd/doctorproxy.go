@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var doctorProxyTimeoutFlag time.Duration
+
+// doctorProxyCmd diagnoses the module proxy and checksum database configuration.
+var doctorProxyCmd = &cobra.Command{
+	Use:   "doctor-proxy",
+	Short: "Check connectivity, latency and auth for GOPROXY and the checksum database",
+	Long: `Doctor-proxy reads GOPROXY and GOSUMDB from go env, requests a small resource
+from every proxy and the checksum database, and explains each failure: DNS,
+TLS certificates, timeouts, authentication, or proxies that do not mirror
+public modules. It exits non-zero when any endpoint is unhealthy.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.DoctorProxy(
+			cmd.Context(),
+			app.DoctorProxyOptions{Timeout: doctorProxyTimeoutFlag},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	doctorProxyCmd.Flags().DurationVar(&doctorProxyTimeoutFlag, "timeout", 10*time.Second, "Timeout for each endpoint request")
+	rootCmd.AddCommand(doctorProxyCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	driftModulesFlag []string
+	driftJSONFlag    bool
+)
+
+// driftCmd reports shared dependencies on different versions across a monorepo.
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Show shared dependencies required at different versions across monorepo modules",
+	Long: `Drift reads the go.mod of every module in a repository that does not use
+go.work and prints a matrix of the dependencies they share at different versions.
+
+Modules come from go.work, or are listed in .faro.json:
+
+  {"monorepo": {"modules": ["services/api", "services/worker", "libs/common"]}}
+
+or passed with --module.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Drift(
+			cmd.Context(),
+			app.DriftOptions{
+				Modules: driftModulesFlag,
+				JSON:    driftJSONFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	driftCmd.Flags().StringSliceVar(&driftModulesFlag, "module", nil, "Module directory to compare (repeatable; overrides go.work and monorepo.modules)")
+	driftCmd.Flags().BoolVar(&driftJSONFlag, "json", false, "Write the drift matrix as JSON")
+	rootCmd.AddCommand(driftCmd)
+}
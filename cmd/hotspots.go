@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hotspotsModulesFlag []string
+	hotspotsDeepFlag    bool
+	hotspotsAllFlag     bool
+	hotspotsNoVulnsFlag bool
+	hotspotsTopFlag     int
+	hotspotsJSONFlag    bool
+)
+
+// hotspotsCmd ranks the dependencies whose upgrade pays off most across a monorepo.
+var hotspotsCmd = &cobra.Command{
+	Use:   "hotspots",
+	Short: "Rank the outdated dependencies of a monorepo by how many modules they affect, staleness and vulnerabilities",
+	Long: `Hotspots scans every module of a repository and ranks the dependencies they
+have updates for by
+
+  modules behind × days behind × (1 + known vulnerabilities)
+
+so the upgrades that give the most leverage across the monorepo come first.
+
+Modules come from go.work, or are listed in .faro.json:
+
+  {"monorepo": {"modules": ["services/api", "services/worker", "libs/common"]}}
+
+or passed with --module. --deep ranks every project below the working
+directory instead, in any supported ecosystem.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Hotspots(
+			cmd.Context(),
+			app.HotspotsOptions{
+				Modules: hotspotsModulesFlag,
+				Deep:    hotspotsDeepFlag,
+				All:     hotspotsAllFlag,
+				NoVulns: hotspotsNoVulnsFlag,
+				Top:     hotspotsTopFlag,
+				JSON:    hotspotsJSONFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	hotspotsCmd.Flags().StringSliceVar(&hotspotsModulesFlag, "module", nil, "Module directory to include (repeatable; overrides go.work and monorepo.modules)")
+	hotspotsCmd.Flags().BoolVar(&hotspotsDeepFlag, "deep", false, "Rank the dependencies of every project below the working directory")
+	hotspotsCmd.Flags().BoolVarP(&hotspotsAllFlag, "all", "a", false, "Include indirect dependencies")
+	hotspotsCmd.Flags().BoolVar(&hotspotsNoVulnsFlag, "no-vulns", false, "Skip vulnerability lookups; scores ignore vulnerabilities")
+	hotspotsCmd.Flags().IntVar(&hotspotsTopFlag, "top", 20, "Show only the N highest-scoring dependencies (0 for all)")
+	hotspotsCmd.Flags().BoolVar(&hotspotsJSONFlag, "json", false, "Write the ranking as JSON")
+	rootCmd.AddCommand(hotspotsCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var infoJSONFlag bool
+
+// infoCmd describes a single module.
+var infoCmd = &cobra.Command{
+	Use:   "info <module>",
+	Short: "Show everything faro knows about one Go module",
+	Long: `Info prints the version of a module required in go.mod, its latest version,
+recent releases with their publish dates, known vulnerabilities, deprecation
+and retraction notices, license and release cadence:
+
+  faro info github.com/jackc/pgx/v5
+  faro info golang.org/x/net --json
+
+It also works outside a Go project, without the required version. --json
+output is meant for scripts and editor tooltips.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Info(
+			cmd.Context(),
+			app.InfoOptions{
+				Module:    args[0],
+				GoModPath: goModFlag,
+				JSON:      infoJSONFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	infoCmd.Flags().BoolVar(&infoJSONFlag, "json", false, "Write the module information as JSON")
+	infoCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to the go.mod file whose required version to show")
+	rootCmd.AddCommand(infoCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var lintGoModOfflineFlag bool
+
+// lintGoModCmd checks go.mod for hygiene problems.
+var lintGoModCmd = &cobra.Command{
+	Use:   "lint-gomod",
+	Short: "Check go.mod for duplicated requires, wrong indirect markers, redundant replaces and retracted versions",
+	Long: `Lint-gomod is a static companion to the update scan. It reports:
+
+  - modules required more than once
+  - direct requirements no package imports (should be // indirect), and
+    indirect ones that are imported
+  - replace directives for modules outside the build list, for a version
+    the build does not select, or with the version already required
+  - required versions their authors retracted (skipped with --offline)
+  - a missing toolchain directive
+
+It exits non-zero when any problem is found.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.LintGoMod(
+			cmd.Context(),
+			app.LintGoModOptions{
+				GoModPath: goModFlag,
+				Offline:   lintGoModOfflineFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	lintGoModCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to check")
+	lintGoModCmd.Flags().BoolVar(&lintGoModOfflineFlag, "offline", false, "Skip the retraction check, which queries the module proxy")
+	rootCmd.AddCommand(lintGoModCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lspVulnerabilitiesFlag bool
+	lspCooldownFlag        int
+)
+
+// lspCmd serves go.mod diagnostics to editors.
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server publishing outdated go.mod requirements as diagnostics",
+	Long: `Lsp speaks the Language Server Protocol on stdin and stdout. Editors open
+go.mod files through it and get a diagnostic on every require line with an
+update, naming the new version and, with --vulnerabilities, the known
+vulnerabilities it fixes.
+
+A go.mod file is scanned when it is opened and each time it is saved; edits in
+between only move the diagnostics. Scans never modify files.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.ServeLSP(
+			cmd.Context(),
+			os.Stdin,
+			os.Stdout,
+			app.LSPOptions{
+				Vulnerabilities: lspVulnerabilitiesFlag,
+				Cooldown:        lspCooldownFlag,
+				CooldownSet:     cmd.Flags().Changed("cooldown"),
+			},
+			app.Deps{
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	lspCmd.Flags().BoolVarP(&lspVulnerabilitiesFlag, "vulnerabilities", "v", false, "Include vulnerability counts of the current and update versions")
+	lspCmd.Flags().IntVarP(&lspCooldownFlag, "cooldown", "c", 0, "Minimum age (days) for an update to be considered")
+	rootCmd.AddCommand(lspCmd)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	majorToFlag     string
+	majorDryRunFlag bool
+)
+
+// majorCmd moves a Go module to a new major version path.
+var majorCmd = &cobra.Command{
+	Use:   "major <module>",
+	Short: "Move a Go module to its newest major version path, rewriting imports",
+	Long: `Major moves a required Go module to a newer major version published under a
+new module path, e.g. github.com/jackc/pgx/v4 to github.com/jackc/pgx/v5:
+
+  faro major github.com/jackc/pgx/v4 --dry-run
+  faro major github.com/jackc/pgx/v4
+
+The imports of the old path are rewritten in the module's Go files, the new
+path is required and go.mod is tidied. Nothing is changed if any step fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Major(
+			cmd.Context(),
+			app.MajorOptions{
+				Module:         args[0],
+				To:             majorToFlag,
+				DryRun:         majorDryRunFlag,
+				GoModPath:      goModFlag,
+				NoExec:         noExecFlag,
+				AuditLog:       auditLogFlag,
+				OverrideFreeze: overrideFreezeFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	majorCmd.Flags().StringVar(&majorToFlag, "to", "", "Major version to move to (e.g. v3); defaults to the newest")
+	majorCmd.Flags().BoolVar(&majorDryRunFlag, "dry-run", false, "Show the new path and the files importing the module without changing anything")
+	majorCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file (runs go commands in its directory)")
+	majorCmd.Flags().BoolVar(&overrideFreezeFlag, "override-freeze", false, "Migrate even during a freeze window configured in .faro.json")
+	rootCmd.AddCommand(majorCmd)
+}
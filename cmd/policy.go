@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	policyMaxAgeFlag string
+	policyScopeFlag  string
+)
+
+// policyCmd groups commands that enforce dependency policies.
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Enforce dependency freshness policies",
+}
+
+// policyCheckCmd fails when dependencies violate the configured policy.
+var policyCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Exit non-zero when a dependency trails its latest release by more than the max age",
+	Long: `Check compares each dependency's current version with its latest release and
+lists every dependency whose current version is older than the latest by more
+than the allowed age. The age comes from --max-age or policy.maxAge in .faro.json:
+
+  {"policy": {"maxAge": "365d", "scope": "direct"}}`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.PolicyCheck(
+			cmd.Context(),
+			app.PolicyOptions{
+				MaxAge:    policyMaxAgeFlag,
+				Scope:     policyScopeFlag,
+				Manager:   managerFlag,
+				GoModPath: goModFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	policyCheckCmd.Flags().StringVar(&policyMaxAgeFlag, "max-age", "", "Maximum allowed staleness (e.g. 365d, 52w, 1y); overrides policy.maxAge")
+	policyCheckCmd.Flags().StringVar(&policyScopeFlag, "scope", "", "Dependencies to check: direct (default) or all")
+	policyCheckCmd.Flags().StringVarP(&managerFlag, "manager", "m", "", "Package manager to use (go, npm, yarn, pnpm, pip, poetry, uv)")
+	policyCheckCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to check")
+	policyCmd.AddCommand(policyCheckCmd)
+	rootCmd.AddCommand(policyCmd)
+}
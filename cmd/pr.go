@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prModulesFlag   []string
+	prPerModuleFlag bool
+	prBaseFlag      string
+	prRemoteFlag    string
+	prDraftFlag     bool
+	prDryRunFlag    bool
+)
+
+// prCmd opens pull requests with pending upgrades.
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Apply updates on a branch, push it and open a GitHub pull request",
+	Long: `PR applies pending updates of direct dependencies on a new branch, commits
+the manifests (go.mod and go.sum for Go) with the commit message from
+.faro.json, pushes the branch and opens a GitHub pull request, then switches
+back to the branch you were on:
+
+  faro pr                            # one pull request with every update
+  faro pr --per-module               # one pull request per dependency
+  faro pr --module github.com/google/uuid
+
+Branches are named faro/<manager>-updates, or faro/<module>-<version> with
+--per-module. Branches that already have an open pull request are skipped,
+so it is safe to run on a schedule, e.g. from a GitHub Actions workflow with
+contents and pull-requests write permissions. Critical modules are left out
+and freeze windows are honored.
+
+The token comes from github.tokenEnv in .faro.json, GITHUB_TOKEN or
+GH_TOKEN. The repository is GITHUB_REPOSITORY, or the one the remote points at.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.PullRequests(
+			cmd.Context(),
+			app.PROptions{
+				Manager:        managerFlag,
+				GoModPath:      goModFlag,
+				Filter:         filterFlag,
+				Modules:        prModulesFlag,
+				All:            allFlag,
+				Cooldown:       cooldownFlag,
+				CooldownSet:    cmd.Flags().Changed("cooldown"),
+				PerModule:      prPerModuleFlag,
+				Base:           prBaseFlag,
+				Remote:         prRemoteFlag,
+				Draft:          prDraftFlag,
+				DryRun:         prDryRunFlag,
+				NoExec:         noExecFlag,
+				OverrideFreeze: overrideFreezeFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	prCmd.Flags().StringSliceVar(&prModulesFlag, "module", nil, "Only upgrade this dependency (repeatable)")
+	prCmd.Flags().BoolVar(&prPerModuleFlag, "per-module", false, "Open one pull request per dependency instead of one for all of them")
+	prCmd.Flags().StringVar(&prBaseFlag, "base", "", "Branch the pull requests merge into (default: the current branch)")
+	prCmd.Flags().StringVar(&prRemoteFlag, "remote", "origin", "Git remote to push the branches to")
+	prCmd.Flags().BoolVar(&prDraftFlag, "draft", false, "Open draft pull requests")
+	prCmd.Flags().BoolVar(&prDryRunFlag, "dry-run", false, "Print the pull requests instead of opening them")
+	prCmd.Flags().StringVarP(&filterFlag, "filter", "f", "", "Only upgrade modules whose path matches this regex")
+	prCmd.Flags().BoolVar(&allFlag, "all", false, "Also upgrade indirect dependencies")
+	prCmd.Flags().IntVarP(&cooldownFlag, "cooldown", "c", 0, "Only apply updates at least this many days old")
+	prCmd.Flags().StringVarP(&managerFlag, "manager", "m", "", "Package manager to use (go, npm, yarn, pnpm, pip, poetry, uv)")
+	prCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to upgrade")
+	prCmd.Flags().BoolVar(&overrideFreezeFlag, "override-freeze", false, "Open pull requests even during a freeze window configured in .faro.json")
+	rootCmd.AddCommand(prCmd)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// prewarmCmd downloads pending update versions into the module cache.
+var prewarmCmd = &cobra.Command{
+	Use:   "prewarm",
+	Short: "Download the versions of pending Go updates into the module cache",
+	Long: `Prewarm runs go mod download for the target version of every pending update,
+so the eventual upgrade or CI run finds them in the module cache instead of
+waiting on the network.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Prewarm(
+			cmd.Context(),
+			app.PrewarmOptions{
+				Filter:      filterFlag,
+				All:         allFlag,
+				Cooldown:    cooldownFlag,
+				CooldownSet: cmd.Flags().Changed("cooldown"),
+				GoModPath:   goModFlag,
+				NoExec:      noExecFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	prewarmCmd.Flags().StringVarP(&filterFlag, "filter", "f", "", "Only prewarm modules whose path matches this regex")
+	prewarmCmd.Flags().BoolVar(&allFlag, "all", false, "Include transitive updates (not listed in go.mod)")
+	prewarmCmd.Flags().IntVarP(&cooldownFlag, "cooldown", "c", 0, "Only prewarm updates at least this many days old")
+	prewarmCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to prewarm updates for")
+	rootCmd.AddCommand(prewarmCmd)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reconcileRepoFlag string
+	reconcileJSONFlag bool
+)
+
+// reconcileCmd matches open Dependabot alerts against the local scan.
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Cross-reference open Dependabot alerts with available updates",
+	Long: `Reconcile fetches the open Dependabot alerts of a GitHub repository and
+matches them against a scan of the current project, listing the alerts an
+available update would close, the alerts already fixed locally, and the
+alerts no update can fix (no patched release, or the fix needs a version
+faro cannot reach).
+
+The GitHub token (GITHUB_TOKEN, GH_TOKEN or github.tokenEnv in .faro.json)
+needs read access to the repository's Dependabot alerts.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Reconcile(
+			cmd.Context(),
+			app.ReconcileOptions{
+				Repo:      reconcileRepoFlag,
+				Manager:   managerFlag,
+				GoModPath: goModFlag,
+				JSON:      reconcileJSONFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reconcileCmd.Flags().StringVar(&reconcileRepoFlag, "github-repo", "", "GitHub repository whose Dependabot alerts to reconcile (owner/name)")
+	_ = reconcileCmd.MarkFlagRequired("github-repo")
+	reconcileCmd.Flags().BoolVar(&reconcileJSONFlag, "json", false, "Write the reconciliation as JSON")
+	reconcileCmd.Flags().StringVarP(&managerFlag, "manager", "m", "", "Package manager to use (go, npm, yarn, pnpm, pip, poetry, uv)")
+	reconcileCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to scan")
+	rootCmd.AddCommand(reconcileCmd)
+}
@@ -1,12 +1,24 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
 	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/pragmaticivan/faro/internal/pager"
 	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
 	"github.com/pragmaticivan/faro/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -19,59 +31,273 @@ var (
 	allFlag             bool
 	cooldownFlag        int
 	formatFlag          string
+	timeStyleFlag       string
 	vulnerabilitiesFlag bool
 	managerFlag         string // Package manager override
+	goModFlag           string
+	compatibleGoFlag    bool
+	riskFlag            bool
+	riskKeywordsFlag    []string
+	commitFlag          bool
+	templateFlag        string
+	noExecFlag          bool
+	platformFlag        []string
+	tagsFlag            []string
+	previewFlag         bool
+	coverProfileFlag    string
+	fixEnvFlag          bool
+	topFlag             int
+	allResultsFlag      bool
+	noWrapFlag          bool
+	noPagerFlag         bool
+	auditLogFlag        string
+	checkOwnersFlag     bool
+	provenanceFlag      bool
+	effortFlag          bool
+	targetFlag          string
+	asOfFlag            string
+	refreshFlag         bool
+	yesFlag             bool
+	pinIndirectFlag     bool
+	includeTestDepsFlag bool
+	dryRunFlag          bool
+	oneByOneFlag        bool
+	recursiveFlag       bool
+	ecosystemFlag       string
+	githubStatusFlag    bool
+	ciFlag              bool
+	proxyScanFlag       bool
+	showDeprecatedFlag  bool
+	onlySafeFlag        bool
+	preFlag             bool
+	majorPathsFlag      bool
+	exitCodeFlag        bool
+	vulnThresholdFlag   string
+	vulnModeFlag        string
+	vulnDetailsFlag     bool
+	overrideFreezeFlag  bool
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "faro",
+	Use:   "faro [module...]",
 	Short: "Check for updates to project dependencies",
 	Long: `faro is a unified dependency management utility.
 
-It allows you to list available updates, interactively select them, and upgrade your lockfiles for Go, Node.js, and Python projects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+It allows you to list available updates, interactively select them, and upgrade your lockfiles for Go, Node.js, and Python projects.
+
+Name modules to check only those; Go scans then query just those modules.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if ciFlag {
+			lipgloss.SetColorProfile(termenv.Ascii)
+			formatFlag = app.CIFormat(formatFlag)
+		}
+		// Page reports only; interactive and upgrade runs need the terminal.
+		var out io.Writer = os.Stdout
+		closePager := func() {}
+		if !ciFlag && !noPagerFlag && !verifyFlag && !upgradeFlag && !commitFlag {
+			out, closePager = pager.Start(os.Stdout)
+		}
 		err := app.Run(
+			cmd.Context(),
 			app.RunOptions{
 				Upgrade:             upgradeFlag,
 				Interactive:         verifyFlag,
 				Filter:              filterFlag,
+				Modules:             args,
 				All:                 allFlag,
 				Cooldown:            cooldownFlag,
+				CooldownSet:         cmd.Flags().Changed("cooldown"),
 				FormatFlag:          formatFlag,
+				TimeStyle:           timeStyleFlag,
 				ShowVulnerabilities: vulnerabilitiesFlag,
 				Manager:             managerFlag,
+				GoModPath:           goModFlag,
+				CompatibleGoOnly:    compatibleGoFlag,
+				RiskScan:            riskFlag || len(riskKeywordsFlag) > 0,
+				RiskKeywords:        riskKeywordsFlag,
+				Commit:              commitFlag,
+				TemplatePath:        templateFlag,
+				NoExec:              noExecFlag,
+				Platforms:           platformFlag,
+				BuildTags:           tagsFlag,
+				Preview:             previewFlag,
+				CoverProfile:        coverProfileFlag,
+				FixEnv:              fixEnvFlag,
+				Top:                 topFlag,
+				AllResults:          allResultsFlag,
+				NoWrap:              noWrapFlag,
+				AuditLog:            auditLogFlag,
+				CheckOwners:         checkOwnersFlag,
+				VerifyProvenance:    provenanceFlag,
+				Effort:              effortFlag,
+				Target:              targetFlag,
+				AsOf:                asOfFlag,
+				Refresh:             refreshFlag,
+				Yes:                 yesFlag,
+				PinIndirect:         pinIndirectFlag,
+				IncludeTestDeps:     includeTestDepsFlag,
+				DryRun:              dryRunFlag,
+				OneByOne:            oneByOneFlag,
+				Recursive:           recursiveFlag,
+				Ecosystem:           ecosystemFlag,
+				GitHubStatus:        githubStatusFlag,
+				CI:                  ciFlag,
+				ProxyScan:           proxyScanFlag,
+				ShowDeprecated:      showDeprecatedFlag,
+				OnlySafe:            onlySafeFlag,
+				Pre:                 preFlag,
+				MajorPaths:          majorPathsFlag,
+				ExitCode:            exitCodeFlag,
+				VulnThreshold:       vulnThresholdFlag,
+				VulnMode:            vulnModeFlag,
+				VulnDetails:         vulnDetailsFlag,
+				OverrideFreeze:      overrideFreezeFlag,
 			},
 			app.Deps{
-				Out: os.Stdout,
-				Now: time.Now,
-				StartInteractive: func(direct, indirect, transitive []scanner.Module, opts tui.Options) {
-					tui.StartInteractiveGroupedWithOptions(direct, indirect, transitive, opts)
+				Out:     out,
+				Err:     os.Stderr,
+				Now:     time.Now,
+				Width:   func() int { return style.TerminalWidth(os.Stdout) },
+				Confirm: terminalConfirm(),
+				StartInteractive: func(ctx context.Context, direct, indirect, transitive []scanner.Module, opts tui.Options) {
+					tui.StartInteractiveGroupedWithOptions(ctx, direct, indirect, transitive, opts)
 				},
 			},
 		)
+		closePager()
+		if app.IsStatus(err) {
+			return exitWith(cmd, app.ExitCode(err, exitCodeFlag))
+		}
+		if err != nil && (ciFlag || app.WantsJSONErrors(formatFlag)) {
+			_ = app.WriteJSONError(os.Stdout, err)
+			return exitWith(cmd, app.ExitCode(err, exitCodeFlag))
+		}
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, app.ExitCanceled)
+		}
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			return exitWith(cmd, app.ExitCode(err, exitCodeFlag))
 		}
+		return nil
 	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// An interrupt or termination signal cancels the context shared by the whole run.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	if code := execute(); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// execute runs the command and returns the process exit code. Commands end
+// with exitWith instead of os.Exit so that the console mode is restored first.
+func execute() int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	restoreConsole := style.EnableANSI()
+	defer func() { _ = restoreConsole() }()
+	style.Glyphs = style.DetectGlyphs(os.Getenv)
+
+	err := rootCmd.ExecuteContext(ctx)
+	var status exitStatus
+	if errors.As(err, &status) {
+		return int(status)
+	}
+	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		return 1
+	}
+	return 0
+}
+
+// exitStatus is the exit code a command returns to execute once it has
+// reported its error.
+type exitStatus int
+
+func (s exitStatus) Error() string { return fmt.Sprintf("exit status %d", int(s)) }
+
+// exitWith ends cmd with the exit code, keeping cobra from printing the error
+// and usage again.
+func exitWith(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return exitStatus(code)
+}
+
+// terminalConfirm asks yes/no questions on the terminal, or returns nil when
+// stdin is not one.
+func terminalConfirm() app.ConfirmFunc {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return nil
+	}
+	return func(prompt string) (bool, error) {
+		fmt.Printf("%s [y/N] ", prompt)
+		answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && answer == "" {
+			return false, err
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		return answer == "y" || answer == "yes", nil
 	}
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&auditLogFlag, "audit-log", "", "Append a JSON audit record of changes applied by -u, align and sync to this file")
+	rootCmd.PersistentFlags().BoolVar(&noExecFlag, "no-exec", false, "Read-only mode: never run go get, npm install, git or other modifying commands")
 	rootCmd.Flags().BoolVarP(&upgradeFlag, "upgrade", "u", false, "Upgrade all packages to the latest version")
 	rootCmd.Flags().BoolVarP(&verifyFlag, "interactive", "i", false, "Interactive mode")
 	rootCmd.Flags().StringVarP(&filterFlag, "filter", "f", "", "Filter packages using regex")
 	rootCmd.Flags().BoolVar(&allFlag, "all", false, "Include transitive updates (not listed in go.mod)")
+	rootCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Do not ask before -u --all upgrades Go transitive modules")
+	rootCmd.Flags().BoolVar(&includeTestDepsFlag, "include-test-deps", false, "Go: also report modules needed only by _test files, tagged [test], even when go.mod does not require them")
+	rootCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "With -u, print the go.mod and go.sum diff the upgrade would make, computed on a temporary copy, without changing files")
+	rootCmd.Flags().BoolVar(&recursiveFlag, "recursive", false, "Check every project (go.mod, package.json, pyproject.toml, ...) found below the working directory, with a heading per project")
+	rootCmd.Flags().BoolVar(&recursiveFlag, "deep", false, "Alias for --recursive")
+	rootCmd.Flags().BoolVar(&githubStatusFlag, "github-status", false, "In GitHub Actions, post a commit status summarizing the updates (never fails the build)")
+	rootCmd.Flags().BoolVar(&ciFlag, "ci", false, "Non-interactive pipeline mode: json output (or --format jsonl/lines), no color, pager or prompts, JSON errors and sorted results")
+	rootCmd.Flags().BoolVar(&oneByOneFlag, "one-by-one", false, "With -u, upgrade modules one at a time, continuing past failures, and summarize which failed")
+	rootCmd.Flags().BoolVar(&pinIndirectFlag, "pin-indirect", false, "Re-require Go indirect upgrades that go mod tidy reverts, with a comment")
 	rootCmd.Flags().IntVarP(&cooldownFlag, "cooldown", "c", 0, "Minimum age (days) for an update to be considered")
-	rootCmd.Flags().StringVar(&formatFlag, "format", "", "Output format modifiers: group,lines,time (comma-delimited)")
+	rootCmd.Flags().StringVar(&formatFlag, "format", "", "Output format modifiers: group,lines,time,upgraded,json,jsonl,markdown,script (comma-delimited); script prints the upgrade commands as a shell script")
+	rootCmd.Flags().StringVar(&timeStyleFlag, "time-style", "", "How --format time and upgraded render times: date (default), relative or iso, plus utc (default) or local (e.g. iso,local)")
+	rootCmd.Flags().StringVar(&templateFlag, "template", "", "Go template file for the markdown report (e.g. a pull request body)")
 	rootCmd.Flags().BoolVarP(&vulnerabilitiesFlag, "vulnerabilities", "v", false, "Show vulnerability counts for current and updated versions")
 	rootCmd.Flags().StringVarP(&managerFlag, "manager", "m", "", "Package manager to use (go, npm, yarn, pnpm, pip, poetry, uv)")
+	rootCmd.Flags().StringVar(&ecosystemFlag, "ecosystem", "", "Only check the go, npm or pypi project of the directory, detecting its manager from the lockfile")
+	rootCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to scan and upgrade (runs go commands in its directory)")
+	rootCmd.Flags().BoolVar(&onlySafeFlag, "only-safe", false, "Only list patch updates past the cooldown whose target has no known vulnerabilities (combine with -u --target patch)")
+	rootCmd.Flags().BoolVar(&preFlag, "pre", false, "Go: also offer pre-release versions (v2.0.0-rc.1) as updates; see prerelease.modules for per-module opt-in")
+	rootCmd.Flags().BoolVar(&majorPathsFlag, "major-paths", false, "Go: probe the module proxy for newer major versions published under /vN module paths")
+	rootCmd.Flags().BoolVar(&exitCodeFlag, "exit-code", false, "Exit with 2 when updates are available, 3 for vulnerable current versions, 4 when lookups failed and 5 when the upgrade failed")
+	rootCmd.Flags().BoolVar(&vulnDetailsFlag, "vuln-details", false, "List the ID, severity, summary and fixed version of each vulnerability under its module (implies -v)")
+	rootCmd.Flags().BoolVar(&overrideFreezeFlag, "override-freeze", false, "Apply upgrades even during a freeze window configured in .faro.json")
+	rootCmd.Flags().StringVar(&vulnModeFlag, "vuln-mode", "osv", "Vulnerability counts: osv, or callgraph to also count the ones reachable from your code with govulncheck (Go; implies -v)")
+	rootCmd.Flags().StringVar(&vulnThresholdFlag, "vuln-threshold", "", "Lowest vulnerability severity --exit-code reports: low (default), medium, high or critical (implies -v)")
+	rootCmd.Flags().BoolVar(&showDeprecatedFlag, "show-deprecated", false, "Go: only list updates of modules whose current version is retracted or that are deprecated")
+	rootCmd.Flags().BoolVar(&proxyScanFlag, "proxy-scan", false, "Go: check go.mod requirements against $GOPROXY over HTTP instead of running go list -m -u all (used automatically without a go command)")
+	rootCmd.Flags().BoolVar(&compatibleGoFlag, "compatible-go-only", false, "Skip Go module updates whose go directive requires a newer Go than the project's")
+	rootCmd.Flags().BoolVar(&riskFlag, "risk", false, "Scan release notes between current and target versions for risk keywords")
+	rootCmd.Flags().BoolVar(&previewFlag, "preview", false, "Report how many module versions an upgrade adds to go.sum and how the build list grows, without changing files")
+	rootCmd.Flags().StringVar(&coverProfileFlag, "coverprofile", "", "Go cover profile (go test -coverprofile); warns about updates whose importing code no test executes")
+	rootCmd.Flags().IntVar(&topFlag, "top", 0, "Show only the N highest-priority updates (vulnerability fixes, direct, larger jumps, older versions first)")
+	rootCmd.Flags().BoolVar(&allResultsFlag, "all-results", false, "Show every update, ignoring --top and report.top")
+	rootCmd.Flags().BoolVar(&noPagerFlag, "no-pager", false, "Do not pipe long reports through $PAGER")
+	rootCmd.Flags().BoolVar(&noWrapFlag, "no-wrap", false, "Print full lines instead of fitting output to the terminal width")
+	rootCmd.Flags().BoolVar(&fixEnvFlag, "fix-env", false, "When go list fails on modules that look private, add them to GOPRIVATE with go env -w and rescan")
+	rootCmd.Flags().BoolVar(&checkOwnersFlag, "check-owners", false, "Flag direct updates whose maintainers (npm) or source repository (Go) differ from the current version's")
+	rootCmd.Flags().StringVar(&targetFlag, "target", "latest", "Highest semver bump to suggest: latest, minor (same major) or patch (same minor)")
+	rootCmd.Flags().BoolVar(&refreshFlag, "refresh", false, "Ignore cached scan, vulnerability and publish-time results and query everything again")
+	rootCmd.Flags().StringVar(&asOfFlag, "as-of", "", "Only consider versions published by this date (e.g. 2024-12-31), to replay a past scan")
+	rootCmd.Flags().BoolVar(&effortFlag, "effort", false, "Label each update with an estimated upgrade effort (trivial, small, medium, large)")
+	rootCmd.Flags().BoolVar(&provenanceFlag, "verify-provenance", false, "Check that each Go update's module zip matches the checksum database and that modules in provenance.attest publish a build attestation")
+	rootCmd.Flags().BoolVar(&commitFlag, "commit", false, "Commit upgraded manifests with a conventional commit message (requires -u)")
+	rootCmd.Flags().StringSliceVar(&platformFlag, "platform", nil, "GOOS/GOARCH targets (e.g. linux/amd64,windows/amd64) for Go import usage analysis; reports unused, test-only and platform-specific direct dependencies")
+	rootCmd.Flags().StringSliceVar(&tagsFlag, "tags", nil, "Build tags for Go import usage analysis (defaults --platform to the host)")
+	rootCmd.Flags().StringSliceVar(&riskKeywordsFlag, "risk-keywords", nil, "Comma-delimited keywords for --risk (default BREAKING,deprecat,security,remove)")
 }
@@ -3,6 +3,8 @@ package cmd
 import (
 	"bytes"
 	"testing"
+
+	"github.com/spf13/cobra"
 )
 
 func TestExecute_Help(t *testing.T) {
@@ -14,3 +16,25 @@ func TestExecute_Help(t *testing.T) {
 	// Execute should not os.Exit on success.
 	Execute()
 }
+
+func TestExecute_ReturnsCommandExitStatus(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	failing := &cobra.Command{
+		Use: "failing",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exitWith(cmd, 3)
+		},
+	}
+	rootCmd.AddCommand(failing)
+	defer rootCmd.RemoveCommand(failing)
+	rootCmd.SetArgs([]string{"failing"})
+
+	if code := execute(); code != 3 {
+		t.Fatalf("exit code = %d, want 3", code)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("cobra printed %q; the command reports its own error", buf.String())
+	}
+}
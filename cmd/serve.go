@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddrFlag     string
+	serveWorkersFlag  int
+	serveQueueFlag    int
+	serveCacheTTLFlag time.Duration
+	serveRootFlag     string
+	serveApplyFlag    bool
+)
+
+// serveCmd runs the scan API for shared CI use.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run an HTTP and gRPC API that queues scans of Go modules",
+	Long: `Serve accepts scan jobs over HTTP and runs them on a bounded pool of workers,
+so one shared faro service can absorb bursts from many CI pipelines:
+
+  POST /scans               {"path": "services/api", "all": false, "cooldown": 0, "vulnerabilities": false}
+  GET  /scans/{id}          job status: queued, running, succeeded or failed
+  GET  /scans/{id}/result   the JSON report (same as --format json) once finished
+
+Submitting a path that is already queued or running returns that job, and a
+successful result is reused until --cache-ttl expires. Scans never modify files.
+
+The same address serves the gRPC service faro.v1.Faro (HTTP/2 without TLS; see
+proto/faro/v1/faro.proto). Its Scan, Plan (-u --dry-run) and Apply (-u)
+RPCs stream progress events, then the result. Apply is refused unless
+--allow-apply is set.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Serve(
+			cmd.Context(),
+			app.ServeOptions{
+				Addr:       serveAddrFlag,
+				Workers:    serveWorkersFlag,
+				QueueSize:  serveQueueFlag,
+				CacheTTL:   serveCacheTTLFlag,
+				Root:       serveRootFlag,
+				AllowApply: serveApplyFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddrFlag, "addr", app.DefaultServeAddr, "Address to listen on")
+	serveCmd.Flags().IntVar(&serveWorkersFlag, "workers", app.DefaultServeWorkers, "Number of scans to run at once")
+	serveCmd.Flags().IntVar(&serveQueueFlag, "queue", app.DefaultServeQueue, "Maximum scans waiting for a worker before submissions are refused with 503")
+	serveCmd.Flags().DurationVar(&serveCacheTTLFlag, "cache-ttl", app.DefaultServeCacheTTL, "How long finished scans are kept and successful results reused for the same request")
+	serveCmd.Flags().StringVar(&serveRootFlag, "root", "", "Only scan projects under this directory; relative request paths resolve against it")
+	serveCmd.Flags().BoolVar(&serveApplyFlag, "allow-apply", false, "Let the gRPC Apply RPC upgrade projects on this host")
+	rootCmd.AddCommand(serveCmd)
+}
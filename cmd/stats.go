@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsJSONFlag  bool
+	statsClearFlag bool
+)
+
+// statsCmd summarizes the usage statistics recorded on this machine.
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the usage statistics recorded on this machine",
+	Long: `Stats summarizes the anonymous usage statistics faro recorded on this
+machine: runs per command and package manager, how often each option is used,
+and typical scan sizes. It only reads the local file and never sends anything.
+
+Recording is off unless you opt in, with FARO_TELEMETRY=local in the
+environment or in .faro.json:
+
+  {"telemetry": {"mode": "local"}}
+
+"share" also posts each event to telemetry.endpoint, for example a
+collector run by your platform team. Events name the options a run set and
+count dependencies; they never contain paths, module names, hosts or users.
+DO_NOT_TRACK=1 turns recording off everywhere.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Stats(
+			app.StatsOptions{
+				JSON:  statsJSONFlag,
+				Clear: statsClearFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsJSONFlag, "json", false, "Write the summary as JSON")
+	statsCmd.Flags().BoolVar(&statsClearFlag, "clear", false, "Remove the recorded usage")
+	rootCmd.AddCommand(statsCmd)
+}
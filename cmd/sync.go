@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncModulesFlag []string
+	syncDryRunFlag  bool
+)
+
+// syncCmd aligns shared dependencies across the modules of a workspace or monorepo.
+var syncCmd = &cobra.Command{
+	Use:   "sync [dependency...]",
+	Short: "Align shared dependencies to one version across workspace or monorepo modules",
+	Long: `Sync upgrades every module that requires a shared dependency to the highest
+version any module requires, so services don't drift on shared libraries:
+
+  faro sync github.com/google/uuid
+  faro sync --dry-run                 # every drifting dependency
+
+Modules come from go.work, monorepo.modules in .faro.json, or --module.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Sync(
+			cmd.Context(),
+			app.SyncOptions{
+				Dependencies:   args,
+				Modules:        syncModulesFlag,
+				DryRun:         syncDryRunFlag,
+				NoExec:         noExecFlag,
+				AuditLog:       auditLogFlag,
+				OverrideFreeze: overrideFreezeFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	syncCmd.Flags().StringSliceVar(&syncModulesFlag, "module", nil, "Module directory to sync (repeatable; overrides go.work and monorepo.modules)")
+	syncCmd.Flags().BoolVar(&syncDryRunFlag, "dry-run", false, "Show the sync plan without changing any go.mod")
+	syncCmd.Flags().BoolVar(&overrideFreezeFlag, "override-freeze", false, "Sync even during a freeze window configured in .faro.json")
+	rootCmd.AddCommand(syncCmd)
+}
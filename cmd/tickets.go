@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var ticketsDryRunFlag bool
+
+// ticketsCmd files tracker tickets for pending major upgrades.
+var ticketsCmd = &cobra.Command{
+	Use:   "tickets",
+	Short: "File tracker tickets for pending major upgrades",
+	Long: `Tickets files one ticket per pending major upgrade of a direct dependency
+in GitHub Issues or Jira, with the current and target versions, risk notes
+scanned from the changelog, and the release notes in between. Upgrades
+that already have an open ticket with the same title are skipped, so the
+command is safe to run on a schedule.
+
+Configure the tracker under "tickets" in .faro.json. Jira credentials are
+read from the environment (tickets.jira.tokenEnv, default JIRA_API_TOKEN).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := app.Tickets(
+			cmd.Context(),
+			app.TicketOptions{
+				Manager:   managerFlag,
+				GoModPath: goModFlag,
+				Filter:    filterFlag,
+				DryRun:    ticketsDryRunFlag,
+			},
+			app.Deps{
+				Out: cmd.OutOrStdout(),
+				Now: time.Now,
+			},
+		)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Interrupted.")
+			return exitWith(cmd, 130)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitWith(cmd, 1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ticketsCmd.Flags().BoolVar(&ticketsDryRunFlag, "dry-run", false, "Print the tickets instead of filing them")
+	ticketsCmd.Flags().StringVarP(&filterFlag, "filter", "f", "", "Only file tickets for modules whose path matches this regex")
+	ticketsCmd.Flags().StringVarP(&managerFlag, "manager", "m", "", "Package manager to use (go, npm, yarn, pnpm, pip, poetry, uv)")
+	ticketsCmd.Flags().StringVar(&goModFlag, "gomod", "", "Path to a go.mod file to scan")
+	rootCmd.AddCommand(ticketsCmd)
+}
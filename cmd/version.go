@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// versionCmd prints build metadata.
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version and build information",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		_, _ = fmt.Fprint(cmd.OutOrStdout(), buildInfo())
+	},
+}
+
+// buildInfo returns the version report including the features compiled into this binary.
+func buildInfo() version.Info {
+	info := version.Get()
+	for _, pm := range detector.Supported() {
+		info.Features = append(info.Features, pm.String())
+	}
+	info.Features = append(info.Features, "osv")
+	return info
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	// --version prints the same report as the version subcommand.
+	info := buildInfo()
+	rootCmd.Version = info.Version
+	rootCmd.SetVersionTemplate(info.String())
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestVersionCommand(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"version"})
+	defer rootCmd.SetArgs(nil)
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"faro ", "go:", "platform:", "features: go, pnpm"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in version output, got: %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package align plans release-train upgrades that move every module under a
+// path prefix (e.g. k8s.io, go.opentelemetry.io/otel) to the same version line.
+package align
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// VersionLister returns the published versions of a module in ascending semver order.
+type VersionLister func(ctx context.Context, modulePath string) ([]string, error)
+
+// Plan is the result of aligning a module family.
+type Plan struct {
+	Prefix  string
+	Target  string
+	Updates []scanner.Module // Modules that need to move to the resolved version
+	Aligned []scanner.Module // Modules already at the resolved version
+}
+
+// Matches reports whether modulePath belongs to the family rooted at prefix.
+func Matches(prefix, modulePath string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return modulePath == prefix || strings.HasPrefix(modulePath, prefix+"/")
+}
+
+// SelectVersion picks the newest version in versions that belongs to the
+// target release line. A partial target such as "v0.30" matches v0.30.x; a
+// full version matches only itself. Pre-releases are skipped unless the
+// target names one.
+func SelectVersion(versions []string, target string) (string, bool) {
+	target = normalizeTarget(target)
+	allowPre := strings.Contains(target, "-")
+	best := ""
+	for _, v := range versions {
+		if v != target && !strings.HasPrefix(v, target+".") && !strings.HasPrefix(v, target+"-") {
+			continue
+		}
+		if !allowPre && strings.Contains(v, "-") {
+			continue
+		}
+		best = v // versions are ascending; keep the last match
+	}
+	return best, best != ""
+}
+
+// BuildPlan resolves the target version for every required module under
+// prefix. It fails if no module matches or if any module has no published
+// version on the target line, so a train is never applied partially.
+func BuildPlan(ctx context.Context, requires []gomod.Require, prefix, target string, list VersionLister) (Plan, error) {
+	plan := Plan{Prefix: prefix, Target: normalizeTarget(target)}
+
+	seen := make(map[string]bool)
+	var missing []error
+	for _, r := range requires {
+		if !Matches(prefix, r.Path) || seen[r.Path] {
+			continue
+		}
+		seen[r.Path] = true
+
+		versions, err := list(ctx, r.Path)
+		if err != nil {
+			return Plan{}, fmt.Errorf("failed to list versions of %s: %w", r.Path, err)
+		}
+		resolved, ok := SelectVersion(versions, plan.Target)
+		if !ok {
+			missing = append(missing, fmt.Errorf("%s has no version matching %s", r.Path, plan.Target))
+			continue
+		}
+
+		depType := "direct"
+		if r.Indirect {
+			depType = "indirect"
+		}
+		m := scanner.Module{
+			Name:           r.Path,
+			Version:        r.Version,
+			Direct:         !r.Indirect,
+			DependencyType: depType,
+			Update:         &scanner.UpdateInfo{Version: resolved},
+			Path:           r.Path,
+			Indirect:       r.Indirect,
+		}
+		if r.Version == resolved {
+			plan.Aligned = append(plan.Aligned, m)
+		} else {
+			plan.Updates = append(plan.Updates, m)
+		}
+	}
+
+	if len(seen) == 0 {
+		return Plan{}, fmt.Errorf("no required modules match %s", prefix)
+	}
+	if len(missing) > 0 {
+		return Plan{}, errors.Join(missing...)
+	}
+	return plan, nil
+}
+
+// GoListVersions returns a VersionLister backed by `go list -m -versions` run in workDir.
+func GoListVersions(workDir string) VersionLister {
+	return func(ctx context.Context, modulePath string) ([]string, error) {
+		out, err := execx.Command(ctx, workDir, "go", "list", "-m", "-versions", "-json", modulePath).Output()
+		if err != nil {
+			return nil, err
+		}
+		var m struct {
+			Versions []string `json:"Versions"`
+		}
+		if err := json.Unmarshal(out, &m); err != nil {
+			return nil, fmt.Errorf("failed to decode json: %w", err)
+		}
+		return m.Versions, nil
+	}
+}
+
+// normalizeTarget ensures the target carries the "v" prefix Go versions use.
+func normalizeTarget(target string) string {
+	target = strings.TrimSpace(target)
+	if target != "" && !strings.HasPrefix(target, "v") {
+		target = "v" + target
+	}
+	return target
+}
@@ -0,0 +1,94 @@
+package align
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/gomod"
+)
+
+func TestMatches(t *testing.T) {
+	if !Matches("k8s.io", "k8s.io/api") || !Matches("k8s.io/", "k8s.io/client-go") || !Matches("k8s.io/api", "k8s.io/api") {
+		t.Fatalf("expected family members to match")
+	}
+	if Matches("k8s.io", "k8s.iox/api") || Matches("k8s.io/api", "k8s.io/apimachinery") {
+		t.Fatalf("did not expect sibling paths to match")
+	}
+}
+
+func TestSelectVersion(t *testing.T) {
+	versions := []string{"v0.29.0", "v0.30.0-rc.1", "v0.30.0", "v0.30.2", "v0.31.0-alpha.0", "v0.31.0"}
+	tests := []struct {
+		target string
+		want   string
+		ok     bool
+	}{
+		{"v0.30", "v0.30.2", true},
+		{"0.30", "v0.30.2", true},
+		{"v0.30.0", "v0.30.0", true},
+		{"v0.30.0-rc.1", "v0.30.0-rc.1", true},
+		{"v0.3", "", false},
+		{"v0.32", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := SelectVersion(versions, tt.target)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("SelectVersion(%q) = %q, %v; want %q, %v", tt.target, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	requires := []gomod.Require{
+		{Path: "k8s.io/api", Version: "v0.29.1"},
+		{Path: "k8s.io/client-go", Version: "v0.30.1", Indirect: true},
+		{Path: "k8s.io/utils", Version: "v0.30.1"},
+		{Path: "github.com/other/mod", Version: "v1.0.0"},
+	}
+	versions := map[string][]string{
+		"k8s.io/api":       {"v0.29.1", "v0.30.0", "v0.30.1"},
+		"k8s.io/client-go": {"v0.30.0", "v0.30.1"},
+		"k8s.io/utils":     {"v0.30.1"},
+	}
+	list := func(_ context.Context, path string) ([]string, error) { return versions[path], nil }
+
+	plan, err := BuildPlan(context.Background(), requires, "k8s.io", "v0.30", list)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(plan.Updates) != 1 || plan.Updates[0].Name != "k8s.io/api" || plan.Updates[0].Update.Version != "v0.30.1" {
+		t.Fatalf("unexpected updates: %+v", plan.Updates)
+	}
+	if len(plan.Aligned) != 2 {
+		t.Fatalf("expected 2 aligned modules, got %+v", plan.Aligned)
+	}
+	if !plan.Aligned[0].Indirect {
+		t.Fatalf("expected indirect classification to be preserved")
+	}
+}
+
+func TestBuildPlan_MissingVersionFails(t *testing.T) {
+	requires := []gomod.Require{
+		{Path: "k8s.io/api", Version: "v0.29.1"},
+		{Path: "k8s.io/kubelet", Version: "v0.29.1"},
+	}
+	list := func(_ context.Context, path string) ([]string, error) {
+		if path == "k8s.io/api" {
+			return []string{"v0.30.0"}, nil
+		}
+		return []string{"v0.29.1"}, nil
+	}
+
+	_, err := BuildPlan(context.Background(), requires, "k8s.io", "v0.30", list)
+	if err == nil || !strings.Contains(err.Error(), "k8s.io/kubelet has no version matching v0.30") {
+		t.Fatalf("expected missing version error, got: %v", err)
+	}
+}
+
+func TestBuildPlan_NoMatches(t *testing.T) {
+	_, err := BuildPlan(context.Background(), []gomod.Require{{Path: "a", Version: "v1.0.0"}}, "k8s.io", "v0.30", nil)
+	if err == nil {
+		t.Fatalf("expected error when no modules match")
+	}
+}
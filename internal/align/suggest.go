@@ -0,0 +1,154 @@
+package align
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// Suggestion describes a family of selected modules whose target versions differ.
+type Suggestion struct {
+	Family  string   // Shared path prefix, e.g. "go.opentelemetry.io/otel" or "@babel"
+	Modules []string // Selected members of the family, sorted
+	Lines   []string // Distinct target release lines (vMAJOR.MINOR, or MAJOR.MINOR for npm), ascending
+	Target  string   // Suggested release line: the newest among Lines
+	Mixed   bool     // True when the targets span more than one release line
+}
+
+// repoHosts are hosts whose module paths start with host/org/repo, so
+// every module under that prefix comes from one repository.
+var repoHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+	"golang.org":    true, // golang.org/x/<repo>
+}
+
+// trains are vanity hosts whose modules live in separate repositories but
+// are released in lockstep, so they still form one family.
+var trains = map[string]bool{
+	"k8s.io": true,
+}
+
+// FamilyKey returns the family a package of the given ecosystem ("go",
+// "npm") belongs to, or "" when it has none. Scoped npm packages group by
+// "@scope". Go modules group by repository: "host/org/repo" on common code
+// hosts (so golang.org/x/net and golang.org/x/text stay apart), the first
+// two path elements on vanity hosts (e.g. "go.opentelemetry.io/otel"), and
+// the whole host for release trains such as k8s.io. Other ecosystems have
+// no families.
+func FamilyKey(ecosystem, modulePath string) string {
+	parts := strings.Split(modulePath, "/")
+	switch ecosystem {
+	case "npm":
+		if len(parts) == 2 && strings.HasPrefix(parts[0], "@") {
+			return parts[0]
+		}
+		return ""
+	case "go":
+		if len(parts) < 2 {
+			return ""
+		}
+		if trains[parts[0]] {
+			return parts[0]
+		}
+		if repoHosts[parts[0]] {
+			if len(parts) < 3 {
+				return ""
+			}
+			return strings.Join(parts[:3], "/")
+		}
+		return parts[0] + "/" + parts[1]
+	}
+	return ""
+}
+
+// Suggest groups modules of the given ecosystem by family and returns a
+// suggestion for every family with two or more members whose update
+// versions are not identical.
+func Suggest(ecosystem string, modules []scanner.Module) []Suggestion {
+	type member struct {
+		name    string
+		version string
+	}
+	families := make(map[string][]member)
+	for _, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		name := m.Name
+		if name == "" {
+			name = m.Path
+		}
+		key := FamilyKey(ecosystem, name)
+		if key == "" {
+			continue
+		}
+		families[key] = append(families[key], member{name, m.Update.Version})
+	}
+
+	var out []Suggestion
+	for family, members := range families {
+		if len(members) < 2 {
+			continue
+		}
+		versions := make(map[string]bool)
+		lines := make(map[string][2]int)
+		var names []string
+		for _, mb := range members {
+			names = append(names, mb.name)
+			versions[mb.version] = true
+			if line, major, minor, ok := releaseLine(mb.version); ok {
+				lines[line] = [2]int{major, minor}
+			}
+		}
+		if len(versions) < 2 || len(lines) == 0 {
+			continue
+		}
+
+		s := Suggestion{Family: family, Modules: names, Mixed: len(lines) > 1}
+		for line := range lines {
+			s.Lines = append(s.Lines, line)
+		}
+		sort.Strings(s.Modules)
+		sort.Slice(s.Lines, func(i, j int) bool {
+			a, b := lines[s.Lines[i]], lines[s.Lines[j]]
+			if a[0] != b[0] {
+				return a[0] < b[0]
+			}
+			return a[1] < b[1]
+		})
+		s.Target = s.Lines[len(s.Lines)-1]
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Family < out[j].Family })
+	return out
+}
+
+// releaseLine returns the MAJOR.MINOR line of a version, keeping its "v"
+// prefix if it has one.
+func releaseLine(v string) (line string, major, minor int, ok bool) {
+	core, prefixed := strings.CutPrefix(v, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) < 2 {
+		return "", 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, 0, false
+	}
+	line = parts[0] + "." + parts[1]
+	if prefixed {
+		line = "v" + line
+	}
+	return line, major, minor, true
+}
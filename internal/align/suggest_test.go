@@ -0,0 +1,69 @@
+package align
+
+import (
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+func TestFamilyKey(t *testing.T) {
+	tests := []struct {
+		ecosystem, path, want string
+	}{
+		{"go", "k8s.io/api", "k8s.io"},
+		{"go", "go.opentelemetry.io/otel/sdk", "go.opentelemetry.io/otel"},
+		{"go", "google.golang.org/grpc", "google.golang.org/grpc"},
+		{"go", "github.com/aws/aws-sdk-go-v2/config", "github.com/aws/aws-sdk-go-v2"},
+		{"go", "github.com/pkg/errors", "github.com/pkg/errors"},
+		{"go", "github.com/pkg", ""},
+		{"go", "golang.org/x/net", "golang.org/x/net"},
+		{"npm", "@babel/core", "@babel"},
+		{"npm", "express", ""},
+		{"go", "@babel/core", "@babel/core"},
+		{"pypi", "zope.interface", ""},
+	}
+	for _, tt := range tests {
+		if got := FamilyKey(tt.ecosystem, tt.path); got != tt.want {
+			t.Errorf("FamilyKey(%q, %q) = %q, want %q", tt.ecosystem, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	mods := []scanner.Module{
+		{Name: "k8s.io/api", Version: "v0.29.0", Update: &scanner.UpdateInfo{Version: "v0.30.2"}},
+		{Name: "k8s.io/client-go", Version: "v0.29.0", Update: &scanner.UpdateInfo{Version: "v0.31.0"}},
+		{Name: "go.opentelemetry.io/otel", Version: "v1.20.0", Update: &scanner.UpdateInfo{Version: "v1.24.0"}},
+		{Name: "go.opentelemetry.io/otel/sdk", Version: "v1.20.0", Update: &scanner.UpdateInfo{Version: "v1.24.1"}},
+		{Name: "google.golang.org/grpc", Version: "v1.60.0", Update: &scanner.UpdateInfo{Version: "v1.62.0"}},
+		{Name: "github.com/a/one", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+		{Name: "github.com/a/two", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.2.0"}},
+		{Name: "golang.org/x/net", Version: "v0.20.0", Update: &scanner.UpdateInfo{Version: "v0.25.0"}},
+		{Name: "golang.org/x/text", Version: "v0.14.0", Update: &scanner.UpdateInfo{Version: "v0.15.0"}},
+	}
+
+	got := Suggest("go", mods)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 suggestions, got %+v", got)
+	}
+	otel, k8s := got[0], got[1]
+	if otel.Family != "go.opentelemetry.io/otel" || otel.Mixed || otel.Target != "v1.24" {
+		t.Fatalf("unexpected otel suggestion: %+v", otel)
+	}
+	if k8s.Family != "k8s.io" || !k8s.Mixed || k8s.Target != "v0.31" || len(k8s.Lines) != 2 || k8s.Lines[0] != "v0.30" {
+		t.Fatalf("unexpected k8s suggestion: %+v", k8s)
+	}
+}
+
+func TestSuggest_Ecosystem(t *testing.T) {
+	mods := []scanner.Module{
+		{Name: "@babel/core", Version: "7.20.0", Update: &scanner.UpdateInfo{Version: "7.24.0"}},
+		{Name: "@babel/preset-env", Version: "7.20.0", Update: &scanner.UpdateInfo{Version: "7.23.0"}},
+	}
+	if got := Suggest("npm", mods); len(got) != 1 || got[0].Family != "@babel" || got[0].Target != "7.24" {
+		t.Fatalf("unexpected npm suggestions: %+v", got)
+	}
+	if got := Suggest("pypi", mods); len(got) != 0 {
+		t.Fatalf("expected no suggestions for pypi, got %+v", got)
+	}
+}
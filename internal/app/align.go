@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/align"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// AlignOptions configures Align.
+type AlignOptions struct {
+	Prefix         string // Module path prefix identifying the family (e.g. k8s.io)
+	Target         string // Release line or exact version (e.g. v0.30)
+	DryRun         bool   // Print the plan without applying it
+	GoModPath      string // Optional go.mod path; defaults to the working directory
+	NoExec         bool   // Read-only: only a dry run is allowed
+	AuditLog       string // JSON lines file recording the applied alignment (overrides audit.file)
+	OverrideFreeze bool   // Apply the alignment even during a freeze window from .faro.json
+}
+
+// Align moves every required Go module under opts.Prefix to the newest version
+// on the opts.Target release line. Nothing is changed unless every module in
+// the family has a matching version.
+func Align(ctx context.Context, opts AlignOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if deps.Now == nil {
+		deps.Now = time.Now
+	}
+	if opts.NoExec {
+		if !opts.DryRun {
+			return fmt.Errorf("--no-exec forbids applying an alignment; add --dry-run to preview the plan")
+		}
+		deps.Updater = updater.ReadOnly{}
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if opts.GoModPath != "" {
+		workDir, err = resolveGoModDir(opts.GoModPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	requires, err := gomod.ReadRequires(filepath.Join(workDir, "go.mod"))
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	list := deps.ListVersions
+	if list == nil {
+		list = align.GoListVersions(workDir)
+	}
+
+	_, _ = fmt.Fprintf(deps.Out, "Resolving %s versions for modules under %s...\n", opts.Target, opts.Prefix)
+	plan, err := align.BuildPlan(ctx, requires, opts.Prefix, opts.Target, list)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return err
+	}
+	if err := applyGlyphs(cfg.Glyphs); err != nil {
+		return err
+	}
+	var critical []string
+	for i, m := range plan.Updates {
+		if cfg.Critical.Matches(m.Name) {
+			plan.Updates[i].Critical = true
+			critical = append(critical, m.Name)
+		}
+	}
+
+	all := append(append([]scanner.Module{}, plan.Updates...), plan.Aligned...)
+	maxPathLen := scanner.MaxPathLength(all)
+	for _, m := range plan.Updates {
+		line := " " + style.FormatUpdate(m.Name, m.Version, m.Update.Version, maxPathLen)
+		if m.Critical {
+			line += " " + criticalTag()
+		}
+		_, _ = fmt.Fprintln(deps.Out, line)
+	}
+	for _, m := range plan.Aligned {
+		_, _ = fmt.Fprintf(deps.Out, " %-*s  %s  (already aligned)\n", maxPathLen, m.Name, m.Version)
+	}
+
+	if len(plan.Updates) == 0 {
+		_, _ = fmt.Fprintf(deps.Out, "\nAll modules under %s are already on %s.\n", plan.Prefix, plan.Target)
+		return nil
+	}
+	if opts.DryRun {
+		_, _ = fmt.Fprintln(deps.Out, "\nRun without --dry-run to apply.")
+		return nil
+	}
+	if reason, frozen := cfg.Freeze.Active(deps.Now()); frozen && !opts.OverrideFreeze {
+		_, _ = fmt.Fprintf(deps.Out, "\nUpgrades are frozen (%s); not aligning %s.\n", reason, plan.Prefix)
+		_, _ = fmt.Fprintln(deps.Out, "Pass --override-freeze to apply the plan anyway.")
+		return nil
+	}
+	if len(critical) > 0 {
+		return fmt.Errorf("alignment would upgrade critical %s %s; upgrade %s with faro -i",
+			plural(len(critical), "module", "modules"), strings.Join(critical, ", "), plural(len(critical), "it", "them"))
+	}
+
+	var updaterInstance updater.Updater
+	if deps.Updater != nil {
+		updaterInstance = deps.Updater
+	} else {
+		updaterInstance, err = factory.CreateUpdater(detector.Go, workDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	auditLog := startAudit(cfg.Audit, opts.AuditLog, workDir, "align", detector.Go)
+	_, _ = fmt.Fprintln(deps.Out, "\nAligning...")
+	if err := updaterInstance.UpdatePackages(ctx, plan.Updates); err != nil {
+		auditFailed(deps, auditLog.finish(ctx, plan.Updates, err, deps))
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	_, _ = fmt.Fprintln(deps.Out, "Done.")
+	return auditLog.finish(ctx, plan.Updates, nil, deps)
+}
@@ -2,20 +2,38 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/align"
+	"github.com/pragmaticivan/faro/internal/blame"
+	"github.com/pragmaticivan/faro/internal/changelog"
+	"github.com/pragmaticivan/faro/internal/commit"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/coverage"
 	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/diskcache"
 	"github.com/pragmaticivan/faro/internal/factory"
 	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/goprivate"
+	"github.com/pragmaticivan/faro/internal/report"
+	"github.com/pragmaticivan/faro/internal/scancache"
 	"github.com/pragmaticivan/faro/internal/scanner"
 	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/suspect"
+	"github.com/pragmaticivan/faro/internal/tracker"
 	"github.com/pragmaticivan/faro/internal/tui"
 	"github.com/pragmaticivan/faro/internal/updater"
+	gomodUpdater "github.com/pragmaticivan/faro/internal/updater/gomod"
+	"github.com/pragmaticivan/faro/internal/usage"
 	"github.com/pragmaticivan/faro/internal/vuln"
 )
 
@@ -23,76 +41,205 @@ type RunOptions struct {
 	Upgrade             bool
 	Interactive         bool
 	Filter              string
+	Modules             []string // Only check these packages
 	All                 bool
+	IncludeTestDeps     bool // Go: also report modules only the tests need, tagged [test]
 	Cooldown            int
+	CooldownSet         bool // Cooldown was given explicitly and overrides the configured default
 	FormatFlag          string
+	TimeStyle           string // --time-style: date, relative or iso, optionally with utc or local
 	ShowVulnerabilities bool
-	Manager             string // Package manager override
+	Manager             string   // Package manager override
+	GoModPath           string   // Path to a go.mod file (or its directory); implies the go manager
+	CompatibleGoOnly    bool     // Drop updates whose go directive exceeds the project's
+	RiskScan            bool     // Scan release notes between current and target for risk keywords
+	RiskKeywords        []string // Keywords for RiskScan; defaults to changelog.DefaultKeywords
+	Commit              bool     // Commit upgraded manifests with a conventional commit message
+	TemplatePath        string   // Template file for the markdown report; implies --format markdown
+	NoExec              bool     // Read-only: never run install/get/git commands
+	Platforms           []string // GOOS/GOARCH targets for Go import usage analysis
+	BuildTags           []string // Build tags for Go import usage analysis
+	Preview             bool     // Report the go.sum and build list change an upgrade would cause
+	CoverProfile        string   // Go cover profile used to flag updates no test exercises
+	FixEnv              bool     // Write suggested GOPRIVATE settings with go env -w and rescan
+	Top                 int      // Show only the N highest-priority updates in text output (0 = report.top or all)
+	AllResults          bool     // Ignore Top and report.top
+	NoWrap              bool     // Never wrap or truncate text output to the terminal width
+	AuditLog            string   // JSON lines file recording applied upgrades (overrides audit.file)
+	CheckOwners         bool     // Flag updates whose owners differ from the current version's (or supplyChain.owners)
+	VerifyProvenance    bool     // Check Go update zips against the checksum database and look for attestations
+	Effort              bool     // Estimate the upgrade effort of every update (implied by policy.autoApply)
+	Target              string   // Highest semver bump to suggest: latest (default), minor or patch
+	AsOf                string   // Only consider versions published by this date (2024-12-31) or RFC3339 time
+	Refresh             bool     // Ignore cached scan and lookup results and query everything again
+	Yes                 bool     // Skip the confirmation for upgrading Go transitive modules with --all
+	PinIndirect         bool     // Re-require Go indirect upgrades that go mod tidy reverts, with a comment
+	DryRun              bool     // With Upgrade: print the manifest diff the upgrade would make instead of applying it
+	OneByOne            bool     // With Upgrade: apply modules one at a time, continuing past failures
+	GitHubStatus        bool     // In GitHub Actions, post a summary of the updates as a commit status
+	Recursive           bool     // Scan every project found below the working directory, one after another
+	Dir                 string   // Project directory; defaults to the working directory
+	Ecosystem           string   // go, npm or pypi: detect the manager within this ecosystem only
+	ProxyScan           bool     // Go: query the module proxy over HTTP instead of running go list
+	CI                  bool     // Non-interactive pipeline defaults: json output, no wrapping or prompts, sorted results
+	ShowDeprecated      bool     // Only report modules whose current version is retracted or that are deprecated
+	OnlySafe            bool     // Only report patch updates past the cooldown whose target has no known vulnerabilities
+	Pre                 bool     // Go: offer pre-release versions of every module as updates (or prerelease.modules)
+	MajorPaths          bool     // Go: probe the module proxy for newer major versions under /vN module paths
+	ExitCode            bool     // Exit with a code telling updates, vulnerabilities, partial scans and failed upgrades apart
+	VulnThreshold       string   // Lowest severity of current-version vulnerabilities ExitCode reports (implies ShowVulnerabilities)
+	VulnDetails         bool     // List the ID, summary, severity and fixed version of each advisory under its module (implies ShowVulnerabilities)
+	VulnMode            string   // Go: "osv" (default) or "callgraph", which also counts vulnerabilities reachable from the code with govulncheck (implies ShowVulnerabilities)
+	OverrideFreeze      bool     // Apply upgrades even during a freeze window configured in .faro.json
+
+	project    string // Heading of the project in a recursive run, shown in the picker
+	keepGlyphs bool   // Leave style.Glyphs alone; set by hosts that run scans concurrently
 }
 
+// CommitFunc commits files in dir with message.
+type CommitFunc func(ctx context.Context, dir string, files []string, message string) error
+
 type Deps struct {
 	Out              io.Writer
 	Now              func() time.Time
-	StartInteractive func(direct, indirect, transitive []scanner.Module, opts tui.Options)
-	Scanner          scanner.Scanner // Optional: verify overrides for testing
-	Updater          updater.Updater // Optional: verify overrides for testing
+	StartInteractive func(ctx context.Context, direct, indirect, transitive []scanner.Module, opts tui.Options)
+	Scanner          scanner.Scanner       // Optional: verify overrides for testing
+	Updater          updater.Updater       // Optional: verify overrides for testing
+	VulnClient       vuln.Client           // Optional: verify overrides for testing
+	Err              io.Writer             // Optional: destination for warnings when Out must stay machine-readable
+	ListVersions     align.VersionLister   // Optional: verify overrides for testing
+	FetchGoMod       GoModFetcher          // Optional: verify overrides for testing
+	ReleaseNotes     changelog.Source      // Optional: verify overrides for testing
+	Commit           CommitFunc            // Optional: verify overrides for testing
+	ReleaseAssets    ReleaseAssetLister    // Optional: verify overrides for testing
+	ListImports      usage.Lister          // Optional: verify overrides for testing
+	BlameManifest    ManifestBlamer        // Optional: verify overrides for testing
+	ListGoFiles      GoFileLister          // Optional: verify overrides for testing
+	Verify           VerifyFunc            // Optional: verify overrides for testing
+	GoEnv            GoEnvReader           // Optional: verify overrides for testing
+	WriteGoEnv       GoEnvWriter           // Optional: verify overrides for testing
+	Download         ModuleDownloader      // Optional: verify overrides for testing
+	Width            func() int            // Optional: verify overrides for testing
+	Releases         suspect.ReleaseLookup // Optional: verify overrides for testing
+	Owners           suspect.OwnerLookup   // Optional: verify overrides for testing
+	VerifyChecksum   ChecksumVerifier      // Optional: verify overrides for testing
+	DependabotAlerts AlertLister           // Optional: verify overrides for testing
+	Installed        InstalledLister       // Optional: verify overrides for testing
+	PostStatus       StatusPoster          // Optional: verify overrides for testing
+	RunCommand       CommandRunner         // Optional: verify overrides for testing
+	Tracker          tracker.Tracker       // Optional: verify overrides for testing
+	Channels         ChannelSource         // Optional: verify overrides for testing
+	GoModFacts       GoModFacts            // Optional: verify overrides for testing
+	License          LicenseLookup         // Optional: verify overrides for testing
+	Confirm          ConfirmFunc           // Optional: asks the user a yes/no question; nil when stdin is not a terminal
+	Progress         ProgressFunc          // Optional: receives progress events, for hosts that render their own progress
+	Govulncheck      GovulncheckRunner     // Optional: verify overrides for testing
+	Git              GitRunner             // Optional: verify overrides for testing
+	PullRequests     PullRequester         // Optional: verify overrides for testing
 }
 
-// checkVulnerabilities checks for vulnerabilities in current and update versions
-func checkVulnerabilities(ctx context.Context, modules []scanner.Module, vulnClient vuln.Client) {
-	for i := range modules {
-		if modules[i].Update != nil {
-			// Use Name field, fallback to Path for backward compatibility
-			pkgName := modules[i].Name
-			if pkgName == "" {
-				pkgName = modules[i].Path
-			}
+// checkVulnerabilities annotates modules with vulnerability counts for their
+// current and update versions. Lookup failures are recorded as warnings rather
+// than aborting the run. Modules matching the private patterns (GOPRIVATE for
+// Go projects) are not looked up: public databases do not know them, and the
+// lookup would disclose their names.
+func checkVulnerabilities(ctx context.Context, modules []scanner.Module, vulnClient vuln.Client, private string, events progress, w *warnings) {
+	isPrivate := func(name string) bool { return goprivate.Covered(name, private) }
+	skipped := 0
+	for _, m := range modules {
+		if m.Update != nil && isPrivate(moduleName(m)) {
+			skipped++
+		}
+	}
+	if skipped > 0 {
+		w.add("", "skipped vulnerability checks for %d private %s (GOPRIVATE)", skipped, plural(skipped, "module", "modules"))
+	}
+	failures, err := vuln.AnnotateModules(ctx, modules, vuln.Options{
+		Client: vulnClient,
+		Skip:   isPrivate,
+		OnProgress: func(done, total int) {
+			events.emit(Event{Kind: EventVulnChecked, Done: done, Total: total})
+		},
+	})
+	if err != nil {
+		w.add("", "vulnerability check interrupted: %v", err)
+		return
+	}
+	for _, f := range failures {
+		w.fail(f.Module, "vulnerability check failed for %s: %v", f.Version, f.Err)
+	}
+}
 
-			// Check current version
-			if currentCounts, err := vulnClient.CheckModule(ctx, pkgName, modules[i].Version); err == nil {
-				modules[i].VulnCurrent = scanner.VulnInfo{
-					Low:      currentCounts.Low,
-					Medium:   currentCounts.Medium,
-					High:     currentCounts.High,
-					Critical: currentCounts.Critical,
-					Total:    currentCounts.Total,
-				}
-			}
+// newVulnClient returns the OSV client for pm, merged with the configured
+// databases and, for Go projects, any custom databases in GOVULNDB.
+func newVulnClient(pm detector.PackageManager, cfg config.Vulnerabilities) vuln.Client {
+	osv := factory.CreateVulnClient(pm)
+	urls := vulnDatabases(pm, cfg)
+	if len(urls) == 0 {
+		return osv
+	}
+	clients := []vuln.Client{osv}
+	for _, u := range urls {
+		clients = append(clients, vuln.NewDBClient(u))
+	}
+	return vuln.Merge(clients...)
+}
 
-			// Check update version
-			if updateCounts, err := vulnClient.CheckModule(ctx, pkgName, modules[i].Update.Version); err == nil {
-				modules[i].VulnUpdate = scanner.VulnInfo{
-					Low:      updateCounts.Low,
-					Medium:   updateCounts.Medium,
-					High:     updateCounts.High,
-					Critical: updateCounts.Critical,
-					Total:    updateCounts.Total,
-				}
+// vulnDatabases returns the databases newVulnClient queries for pm besides
+// OSV: the configured ones and, for Go, those in GOVULNDB.
+func vulnDatabases(pm detector.PackageManager, cfg config.Vulnerabilities) []string {
+	urls := append([]string{}, cfg.Databases...)
+	if pm == detector.Go {
+		for _, u := range strings.Split(os.Getenv("GOVULNDB"), ",") {
+			// The public Go database is already part of OSV.
+			if u = strings.TrimSpace(u); u != "" && strings.TrimRight(u, "/") != vuln.GoVulnDB {
+				urls = append(urls, u)
 			}
 		}
 	}
+	return urls
+}
+
+// cachedVulnClient returns newVulnClient backed by the lookup cache.
+func cachedVulnClient(pm detector.PackageManager, cfg config.Vulnerabilities, lookups *diskcache.Store) vuln.Client {
+	return vuln.Cached(newVulnClient(pm, cfg), lookups, pm.Ecosystem(), vulnDatabases(pm, cfg)...)
+}
+
+// openLookupCache returns the shared cache of vulnerability and
+// publish-time lookups, or nil when refresh is set, the cache is disabled,
+// or there is no cache directory.
+func openLookupCache(cfg config.Cache, refresh bool, now func() time.Time) *diskcache.Store {
+	dir := diskcache.Dir()
+	if refresh || cfg.Duration() == 0 || dir == "" {
+		return nil
+	}
+	return diskcache.Open(dir, "lookups.json", cfg.Duration(), now)
+}
+
+// openScanCache returns the scan cache of the project in workDir, or nil
+// when refresh is set, the cache is disabled, or there is no cache directory.
+func openScanCache(workDir string, cfg config.ScanCache, refresh bool, now func() time.Time) *scancache.Cache {
+	dir := diskcache.Dir()
+	if refresh || cfg.Duration() == 0 || dir == "" {
+		return nil
+	}
+	if abs, err := filepath.Abs(workDir); err == nil {
+		workDir = abs
+	}
+	return scancache.Open(dir, workDir, cfg.Duration(), now)
 }
 
 // groupModules splits modules into direct, indirect, and transitive categories
 func groupModules(modules []scanner.Module) (direct, indirect, transitive []scanner.Module) {
 	for _, m := range modules {
-		// Check if it's a direct dependency
-		if m.Direct {
-			// Further categorize based on dependency type
-			switch m.DependencyType {
-			case "devDependencies", "dev", "indirect":
-				indirect = append(indirect, m)
-			default:
+		switch m.DependencyType {
+		case "devDependencies", "dev", "indirect":
+			indirect = append(indirect, m)
+		case "transitive":
+			transitive = append(transitive, m)
+		default:
+			if m.Direct {
 				direct = append(direct, m)
-			}
-		} else {
-			// Handle legacy Go fields for backward compatibility
-			if m.FromGoMod {
-				if m.Indirect {
-					indirect = append(indirect, m)
-				} else {
-					direct = append(direct, m)
-				}
 			} else {
 				transitive = append(transitive, m)
 			}
@@ -122,7 +269,7 @@ func printLinesFormat(out io.Writer, direct, indirect, transitive []scanner.Modu
 }
 
 // printGroupedOutput prints modules organized by group labels
-func printGroupedOutput(out io.Writer, group []scanner.Module, maxPathLen int, showVulns bool, showTime bool, now time.Time) {
+func printGroupedOutput(out io.Writer, group []scanner.Module, maxPathLen int, showVulns bool, formats format.Options, now time.Time, width int) {
 	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
 	byLabel := make(map[string][]scanner.Module)
@@ -148,66 +295,102 @@ func printGroupedOutput(out io.Writer, group []scanner.Module, maxPathLen int, s
 	for _, label := range labels {
 		_, _ = fmt.Fprintf(out, "\n%s\n", dim.Render(label))
 		for _, m := range byLabel[label] {
-			name := m.Name
-			if name == "" {
-				name = m.Path // Fallback
-			}
-			line := " " + style.FormatUpdate(name, m.Version, m.Update.Version, maxPathLen)
-			if showVulns && m.VulnCurrent.Total > 0 {
-				line += " " + formatVulnCounts(m.VulnCurrent, m.VulnUpdate)
-			}
-			if showTime {
-				pt := format.PublishTime(m.Update.Time, now)
-				if pt != "" {
-					line += "  " + dim.Render(pt)
-				}
-			}
-			_, _ = fmt.Fprintln(out, line)
+			_, _ = fmt.Fprintln(out, updateLine(m, maxPathLen, showVulns, formats, now, width))
 		}
 	}
 }
 
 // printSimpleOutput prints modules in simple list format
-func printSimpleOutput(out io.Writer, group []scanner.Module, maxPathLen int, showVulns bool, showTime bool, now time.Time) {
+func printSimpleOutput(out io.Writer, group []scanner.Module, maxPathLen int, showVulns bool, formats format.Options, now time.Time, width int) {
+	for _, m := range group {
+		_, _ = fmt.Fprintln(out, updateLine(m, maxPathLen, showVulns, formats, now, width))
+	}
+}
+
+// updateLine renders one update and its detail columns fitted to width
+// (0 = unlimited). Details that do not fit continue on indented lines
+// aligned with the current version; configured notes follow on their own
+// lines.
+func updateLine(m scanner.Module, maxPathLen int, showVulns bool, formats format.Options, now time.Time, width int) string {
 	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
-	for _, m := range group {
-		name := m.Name
-		if name == "" {
-			name = m.Path // Fallback
-		}
-		line := " " + style.FormatUpdate(name, m.Version, m.Update.Version, maxPathLen)
-		if showVulns && m.VulnCurrent.Total > 0 {
-			line += " " + formatVulnCounts(m.VulnCurrent, m.VulnUpdate)
+	name := m.Name
+	if name == "" {
+		name = m.Path // Fallback
+	}
+	head := " " + style.FormatUpdate(name, m.Version, m.Update.Version, maxPathLen)
+	var tail []string
+	if showVulns && m.VulnCurrent.Total > 0 {
+		tail = append(tail, " "+formatVulnCounts(m.VulnCurrent, m.VulnUpdate))
+		if m.VulnReachable != nil {
+			tail = append(tail, " "+reachableTag(*m.VulnReachable))
 		}
-		if showTime {
-			pt := format.PublishTime(m.Update.Time, now)
-			if pt != "" {
-				line += "  " + dim.Render(pt)
-			}
+	}
+	if m.Critical {
+		tail = append(tail, " "+criticalTag())
+	}
+	if m.Retracted != "" {
+		tail = append(tail, " "+retractedTag())
+	}
+	if m.Deprecated != "" {
+		tail = append(tail, " "+deprecatedTag())
+	}
+	if m.TestOnly {
+		tail = append(tail, " "+dim.Render("[test]"))
+	}
+	if m.OwnerChange != "" {
+		tail = append(tail, " "+ownerChangeTag())
+	}
+	if m.Provenance != "" {
+		tail = append(tail, "  "+provenanceColumn(m.Provenance))
+	}
+	if m.Effort != "" {
+		tail = append(tail, "  "+effortColumn(m.Effort))
+	}
+	if formats.Time {
+		pt := formats.TimeStyle.Format(m.Update.Time, now)
+		if pt != "" {
+			tail = append(tail, "  "+dim.Render(pt))
 		}
-		_, _ = fmt.Fprintln(out, line)
 	}
+	if m.LastUpgraded != "" {
+		tail = append(tail, "  "+dim.Render("upgraded "+formats.TimeStyle.Format(m.LastUpgraded, now)))
+	}
+	if hint := formatRiskHint(m.RiskHints); hint != "" {
+		tail = append(tail, "  "+hint)
+	}
+	return style.FitLine(head, tail, width, maxPathLen+3) + vulnDetailLines(m.VulnDetails, width) + noteLines(m.Notes, width)
 }
 
 // printGroup outputs a titled group of modules
-func printGroup(out io.Writer, title string, group []scanner.Module, maxPathLen int, grouped bool, showVulns bool, showTime bool, now time.Time) {
+func printGroup(out io.Writer, title string, group []scanner.Module, maxPathLen int, formats format.Options, showVulns bool, now time.Time, width int) {
 	if len(group) == 0 {
 		return
 	}
 	_, _ = fmt.Fprintf(out, "\n%s\n", title)
 
-	if grouped {
-		printGroupedOutput(out, group, maxPathLen, showVulns, showTime, now)
+	if formats.Group {
+		printGroupedOutput(out, group, maxPathLen, showVulns, formats, now, width)
 	} else {
-		printSimpleOutput(out, group, maxPathLen, showVulns, showTime, now)
+		printSimpleOutput(out, group, maxPathLen, showVulns, formats, now, width)
 	}
 }
 
+// outputWidth returns the column limit for text output, 0 meaning none.
+func outputWidth(opts RunOptions, deps Deps) int {
+	if opts.NoWrap {
+		return 0
+	}
+	if deps.Width != nil {
+		return deps.Width()
+	}
+	return style.TerminalWidth(deps.Out)
+}
+
 // calculateMaxPathLen finds the longest module path for alignment
-func calculateMaxPathLen(direct, indirect, transitive []scanner.Module) int {
+func calculateMaxPathLen(groups ...[]scanner.Module) int {
 	maxPathLen := 0
-	for _, group := range [][]scanner.Module{direct, indirect, transitive} {
+	for _, group := range groups {
 		for _, m := range group {
 			name := m.Name
 			if name == "" {
@@ -221,40 +404,134 @@ func calculateMaxPathLen(direct, indirect, transitive []scanner.Module) int {
 	return maxPathLen
 }
 
-func Run(opts RunOptions, deps Deps) error {
+// Run scans for updates and reports or applies them according to opts.
+// Canceling ctx aborts in-flight scans, vulnerability lookups, and upgrades.
+func Run(ctx context.Context, opts RunOptions, deps Deps) (err error) {
 	if deps.Out == nil {
 		return fmt.Errorf("missing deps.Out")
 	}
 	if deps.Now == nil {
 		deps.Now = time.Now
 	}
+	if opts.CI {
+		if opts, deps, err = ciOptions(opts, deps); err != nil {
+			return categorize(ErrorUsage, err)
+		}
+	}
+	if opts.Recursive {
+		return runRecursive(ctx, opts, deps)
+	}
+	if opts.Preview && opts.Upgrade {
+		return categorize(ErrorUsage, fmt.Errorf("--preview cannot be combined with --upgrade"))
+	}
+	if opts.Commit && !opts.Upgrade {
+		return categorize(ErrorUsage, fmt.Errorf("--commit requires --upgrade"))
+	}
+	if opts.DryRun && !opts.Upgrade {
+		return categorize(ErrorUsage, fmt.Errorf("--dry-run requires --upgrade"))
+	}
+	if opts.DryRun && opts.Commit {
+		return categorize(ErrorUsage, fmt.Errorf("--dry-run cannot be combined with --commit"))
+	}
+	if opts.OneByOne && !opts.Upgrade {
+		return categorize(ErrorUsage, fmt.Errorf("--one-by-one requires --upgrade"))
+	}
+	if opts.NoExec {
+		if err := checkNoExec(opts); err != nil {
+			return categorize(ErrorUsage, err)
+		}
+		deps.Updater = updater.ReadOnly{}
+		deps.Commit = readOnlyCommit
+	}
 
-	// Detect or validate package manager
-	workDir, err := os.Getwd()
+	target, err := parseTarget(opts.Target)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return categorize(ErrorUsage, err)
+	}
+	vulnerable, err := parseVulnThreshold(opts.VulnThreshold)
+	if err != nil {
+		return categorize(ErrorUsage, err)
+	}
+	if opts.VulnThreshold != "" {
+		opts.ShowVulnerabilities = true
+	}
+	if opts.VulnMode, err = parseVulnMode(opts.VulnMode); err != nil {
+		return categorize(ErrorUsage, err)
+	}
+	if opts.VulnMode == VulnModeCallgraph || opts.VulnDetails {
+		opts.ShowVulnerabilities = true
+	}
+	var asOf time.Time
+	if opts.AsOf != "" {
+		if asOf, err = parseAsOf(opts.AsOf); err != nil {
+			return categorize(ErrorUsage, err)
+		}
 	}
 
-	var pm detector.PackageManager
-	if opts.Manager != "" {
-		// Use explicit manager
-		pm, err = detector.Validate(opts.Manager)
-		if err != nil {
+	if opts.Ecosystem != "" {
+		if opts.Manager, err = ecosystemManager(opts.Dir, opts.Ecosystem, opts.Manager, opts.GoModPath); err != nil {
 			return err
 		}
-	} else {
-		// Auto-detect
-		result, err := detector.DetectSingle(workDir)
-		if err != nil {
-			return fmt.Errorf("failed to detect package manager: %w\nSpecify one with --manager flag", err)
+	}
+	workDir, pm, err := resolveManager(opts.Dir, opts.Manager, opts.GoModPath)
+	if err != nil {
+		return err
+	}
+
+	formats, err := format.ParseFlag(opts.FormatFlag)
+	if err != nil {
+		return categorize(ErrorUsage, err)
+	}
+	if formats.TimeStyle, err = format.ParseTimeStyle(opts.TimeStyle); err != nil {
+		return categorize(ErrorUsage, err)
+	}
+	if formats.Script && (opts.Upgrade || opts.Interactive) {
+		return categorize(ErrorUsage, fmt.Errorf("--format script prints the upgrade commands instead of running them; drop --upgrade and --interactive"))
+	}
+	if opts.TemplatePath != "" {
+		if formats.Machine() && !formats.Markdown {
+			return categorize(ErrorUsage, fmt.Errorf("--template cannot be combined with --format lines, json, jsonl or script"))
 		}
-		pm = result.Manager
+		formats.Markdown = true
 	}
 
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	if opts.keepGlyphs {
+		_, err = configuredGlyphs(cfg.Glyphs)
+	} else {
+		err = applyGlyphs(cfg.Glyphs)
+	}
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
 	// Create scanner and updater for the detected package manager
+	goProxy := proxyClient(cfg.GoProxy)
+	var warns warnings
+	outcome := runOutcome{threshold: opts.VulnThreshold}
+	if opts.ExitCode {
+		defer func() {
+			if err == nil {
+				err = outcome.status(warns.failed)
+			}
+		}()
+	}
+	usageEvent := startUsage(cfg.Telemetry, "run", pm.String(), optionNames(opts), deps)
+	defer func() { usageEvent.finish(ctx, err, deps) }()
+	// During a freeze window upgrades are only reported.
+	if (opts.Upgrade && !opts.DryRun) || opts.Interactive {
+		if reason, frozen := cfg.Freeze.Active(deps.Now()); frozen && !opts.OverrideFreeze {
+			warns.add("", "upgrades are frozen (%s); reporting only, pass --override-freeze to apply them", reason)
+			opts.Upgrade, opts.Interactive, opts.Commit, opts.OneByOne = false, false, false, false
+		}
+	}
 	var pkgScanner scanner.Scanner
 	if deps.Scanner != nil {
 		pkgScanner = deps.Scanner
+	} else if pm == detector.Go && (opts.ProxyScan || !goCommandAvailable()) {
+		pkgScanner = proxyScanner(workDir, goProxy, opts.ProxyScan, &warns)
 	} else {
 		pkgScanner, err = factory.CreateScanner(pm, workDir)
 		if err != nil {
@@ -262,45 +539,403 @@ func Run(opts RunOptions, deps Deps) error {
 		}
 	}
 
-	formats, err := format.ParseFlag(opts.FormatFlag)
-	if err != nil {
-		return err
+	opts.Cooldown = cooldownDays(opts.Cooldown, opts.CooldownSet, cfg, pm)
+	autoApply := format.EffortLarge
+	if cfg.Policy.AutoApply != "" {
+		if autoApply, err = format.ParseEffort(cfg.Policy.AutoApply); err != nil {
+			return categorize(ErrorConfig, fmt.Errorf("invalid policy.autoApply: %w", err))
+		}
+		opts.Effort = true
+	}
+	var reportText string
+	if formats.Markdown {
+		reportText, err = reportTemplate(opts.TemplatePath, cfg, workDir)
+		if err != nil {
+			return categorize(ErrorConfig, err)
+		}
 	}
 
-	if !formats.Lines {
+	if !formats.Machine() {
 		_, _ = fmt.Fprintf(deps.Out, "Using package manager: %s\n", pm)
-		_, _ = fmt.Fprintln(deps.Out, "Checking for updates...")
+		if asOf.IsZero() {
+			_, _ = fmt.Fprintln(deps.Out, "Checking for updates...")
+		} else {
+			_, _ = fmt.Fprintf(deps.Out, "Checking for updates published before %s...\n", asOf.Format(time.RFC3339))
+		}
 	}
 
+	gh := lazyGitHubClient{cfg: cfg.GitHub}
+	var repos lazyRepoResolver
+	defer repos.save()
+	lookups := openLookupCache(cfg.Cache, opts.Refresh, deps.Now)
+	defer func() { _ = lookups.Save() }()
+
 	// Get updates using the package-specific scanner
-	modules, err := pkgScanner.GetUpdates(scanner.Options{
-		Filter:       opts.Filter,
-		IncludeAll:   opts.All,
-		CooldownDays: opts.Cooldown,
-		WorkDir:      workDir,
-	})
+	var skipped scanner.SkipStats
+	scanOpts := scanner.Options{
+		Filter:          opts.Filter,
+		Modules:         opts.Modules,
+		IncludeAll:      opts.All,
+		IncludeTestDeps: opts.IncludeTestDeps,
+		CooldownDays:    opts.Cooldown,
+		KeepDeprecated:  true,
+		WorkDir:         workDir,
+		Skipped:         &skipped,
+		OnWarning: func(module, message string) {
+			warns.add(module, "%s", message)
+		},
+		OnFailure: func(module, message string) {
+			warns.fail(module, "%s", message)
+		},
+	}
+	if !asOf.IsZero() {
+		// The cooldown is measured from the --as-of cutoff instead.
+		scanOpts.CooldownDays = 0
+	}
+	if pm == detector.Go {
+		scanOpts.Cache = openScanCache(workDir, cfg.ScanCache, opts.Refresh, deps.Now)
+	}
+	events := newProgress(deps)
+	events.emit(Event{Kind: EventScanStarted})
+	modules, err := pkgScanner.GetUpdates(ctx, scanOpts)
+	if err != nil && ctx.Err() == nil && pm == detector.Go {
+		fixed, hint := fixGoPrivate(ctx, workDir, err, opts.FixEnv, deps, &warns)
+		if fixed {
+			modules, err = pkgScanner.GetUpdates(ctx, scanOpts)
+		} else if hint != "" {
+			err = fmt.Errorf("%w\n\n%s", err, hint)
+		}
+	}
 	if err != nil {
-		return err
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+	usageEvent.scanned(modules)
+	modules, upToDate := splitUpToDate(modules)
+	if err := scanOpts.Cache.Save(); err != nil {
+		warns.add("", "%v", err)
+	}
+	refine := func(modules []scanner.Module) []scanner.Module {
+		if opts.Pre || len(cfg.Prerelease.Modules) > 0 {
+			wants := func(name string) bool { return opts.Pre || cfg.Prerelease.Matches(name) }
+			src := deps.Channels
+			if src == nil && pm == detector.Go {
+				src = channelSource(pm, cfg.SupplyChain, goProxy, lookups)
+			}
+			if pm != detector.Go || src == nil {
+				warns.add("", "pre-release updates are only looked up for Go projects")
+			} else if candidates, err := prereleaseCandidates(filepath.Join(workDir, "go.mod"), modules, wants, opts); err != nil {
+				warns.add("", "%v", err)
+			} else {
+				modules = applyPrereleases(ctx, modules, candidates, wants, src, scanOpts.CooldownDays, deps.Now(), &warns)
+			}
+		}
+		if opts.ShowDeprecated {
+			if pm != detector.Go {
+				warns.add("", "retractions and deprecations are only reported for Go projects")
+			}
+			modules = keepDeprecated(modules, &skipped)
+		}
+		warnDeprecated(modules, &warns)
+		warnDeprecated(upToDate, &warns)
+		return modules
+	}
+	modules = updateFilter{
+		names:    opts.Modules,
+		target:   target,
+		asOf:     asOf,
+		cooldown: opts.Cooldown,
+		channels: func() ChannelSource {
+			if deps.Channels != nil {
+				return deps.Channels
+			}
+			return channelSource(pm, cfg.SupplyChain, goProxy, lookups)
+		},
+		refine: refine,
+	}.apply(ctx, modules, cfg, pm, deps.Now(), &skipped, &warns)
+	evalNow := deps.Now()
+	if !asOf.IsZero() {
+		evalNow = asOf
+	}
+	if opts.OnlySafe {
+		modules = keepPatches(modules, &skipped)
+	}
+	annotateNotes(modules, cfg)
+
+	if pm.Ecosystem() == "npm" || (pm == detector.Go && cfg.SupplyChain.Go) {
+		releases := deps.Releases
+		if releases == nil {
+			releases = npmReleases(cfg.SupplyChain)
+		}
+		direct := directDependencies(ctx, pm, workDir, pkgScanner)
+		checkSuspicious(ctx, modules, direct, pm, cfg.SupplyChain, releases, deps.Now(), &warns)
+	}
+	if opts.CheckOwners || cfg.SupplyChain.Owners {
+		owners := deps.Owners
+		if owners == nil {
+			owners = ownerLookup(pm, cfg.SupplyChain, goProxy)
+		}
+		if owners != nil {
+			checkOwnership(ctx, modules, owners, cfg.SupplyChain.Allow, &warns)
+		} else {
+			warns.add("", "owner checks are not supported for %s projects", pm)
+		}
+	}
+
+	if pm == detector.Go && len(modules) > 0 {
+		if projectGo := projectGoVersion(workDir); projectGo != "" {
+			fetch := deps.FetchGoMod
+			if fetch == nil {
+				fetch = goProxy.GoMod
+			}
+			incompatible := checkGoCompatibility(ctx, modules, projectGo, fetch, opts.CompatibleGoOnly, &warns)
+			if opts.CompatibleGoOnly {
+				before := len(modules)
+				modules = dropModules(modules, incompatible)
+				skipped.IncompatibleGo += before - len(modules)
+			}
+		}
+	}
+
+	if pm == detector.Go && len(modules) > 0 {
+		if tools, err := goModTools(workDir); err == nil {
+			markTools(modules, tools)
+		}
+	}
+
+	if pm == detector.Go && len(modules) > 0 && len(cfg.Tools.Platforms) > 0 {
+		platforms, err := parsePlatforms(cfg.Tools.Platforms)
+		if err != nil {
+			return categorize(ErrorConfig, err)
+		}
+		tools, err := goModTools(workDir)
+		if err != nil {
+			return categorize(ErrorScan, err)
+		}
+		if len(tools) > 0 {
+			list := deps.ReleaseAssets
+			if list == nil {
+				list = githubReleaseAssets(gh.get(), repos.get())
+			}
+			unsupported := checkToolPlatforms(ctx, modules, tools, platforms, list, &warns)
+			before := len(modules)
+			modules = dropModules(modules, unsupported)
+			skipped.MissingPlatforms += before - len(modules)
+		}
+	}
+
+	if pm == detector.Go && len(modules) > 0 && opts.VerifyProvenance {
+		verify := deps.VerifyChecksum
+		if verify == nil {
+			verify = proxyChecksums(goProxy)
+		}
+		list := deps.ReleaseAssets
+		if list == nil {
+			list = githubReleaseAssets(gh.get(), repos.get())
+		}
+		if verify != nil {
+			checkProvenance(ctx, modules, cfg.Provenance, verify, list, &warns)
+		} else {
+			warns.add("", "GOSUMDB is off; skipping provenance checks")
+		}
+	}
+
+	if pm == detector.Go && len(modules) > 0 && (len(opts.Platforms) > 0 || len(opts.BuildTags) > 0) {
+		platforms, err := usagePlatforms(opts.Platforms)
+		if err != nil {
+			return categorize(ErrorUsage, err)
+		}
+		list := deps.ListImports
+		if list == nil {
+			list = usage.GoList(workDir)
+		}
+		checkImportUsage(ctx, modules, platforms, opts.BuildTags, list, &warns)
+	}
+
+	if pm == detector.Go && len(modules) > 0 && opts.CoverProfile != "" {
+		list := deps.ListGoFiles
+		if list == nil {
+			list = coverage.ListFiles
+		}
+		checkCoverage(ctx, modules, workDir, opts.CoverProfile, list, &warns)
+	}
+
+	var env *report.Environment
+	if formats.JSON || formats.Markdown {
+		env = captureEnvironment(ctx, pm, workDir, deps, &warns)
+	}
+
+	vulnClient := deps.VulnClient
+	if vulnClient == nil {
+		vulnClient = cachedVulnClient(pm, cfg.Vulnerabilities, lookups)
+	}
+	var private string
+	if pm == detector.Go {
+		private = os.Getenv("GOPRIVATE")
+	}
+	if opts.OnlySafe && len(modules) > 0 {
+		if !formats.Machine() {
+			_, _ = fmt.Fprintln(deps.Out, "Checking vulnerabilities...")
+		}
+		if modules, err = keepVulnFree(ctx, modules, vulnClient, private, &skipped, &warns); err != nil {
+			return err
+		}
+	}
+
+	var majors []MajorPathUpdate
+	if opts.MajorPaths {
+		src := deps.Channels
+		if src == nil && pm == detector.Go {
+			src = channelSource(pm, cfg.SupplyChain, goProxy, lookups)
+		}
+		if pm != detector.Go || src == nil {
+			warns.add("", "new major version paths are only probed for Go projects")
+		} else if requires, err := selectedRequires(filepath.Join(workDir, "go.mod"), opts); err != nil {
+			warns.add("", "%v", err)
+		} else {
+			if !formats.Machine() {
+				_, _ = fmt.Fprintln(deps.Out, "Probing for new major versions...")
+			}
+			majors = probeMajorPaths(ctx, requires, src, opts.Cooldown, evalNow, &warns)
+		}
 	}
 
+	if opts.CI {
+		sortForCI(modules, &warns)
+	}
 	if len(modules) == 0 {
-		if !formats.Lines {
+		if opts.GitHubStatus {
+			postCommitStatus(ctx, modules, opts.ShowVulnerabilities, statusPoster(deps, &gh), os.Getenv, &warns)
+		}
+		var score *VulnScore
+		if opts.ShowVulnerabilities {
+			s := scoreVulnerabilities(ctx, pm, workDir, nil, nil, vulnClient, private, deps, &warns)
+			score = &s
+		}
+		if formats.JSON {
+			return writeJSONReport(deps.Out, jsonReport{Manager: pm.String(), Updates: []format.Record{}, MajorPaths: majors, Skipped: skippedStats(skipped), VulnScore: score, Warnings: warns.items, Environment: env})
+		}
+		if formats.Markdown {
+			return writeReport(deps.Out, reportText, pm.String(), nil, warns.items, env, deps.Now())
+		}
+		if !formats.Machine() {
 			_, _ = fmt.Fprintln(deps.Out, "All dependencies match the latest package versions :)")
+			printMajorPaths(deps.Out, majors, true)
+			if score != nil {
+				printVulnScore(deps.Out, *score)
+			}
+			printSkipped(deps.Out, skipped)
+			printWarnings(deps.Out, warns.items)
+		} else {
+			printWarnings(deps.Err, warns.items)
 		}
 		return nil
 	}
 
-	// Check vulnerabilities if requested
-	if opts.ShowVulnerabilities {
-		if !formats.Lines {
+	collectModuleWarnings(&warns, modules, formats.Time)
+
+	events.modules(EventModuleResolved, modules)
+	events.emit(Event{Kind: EventScanFinished, Total: len(modules)})
+
+	// Enrichers run side by side and share one limit on network requests.
+	var enrichers []enricher
+	limit := newRequestLimit(enrichConcurrency)
+	if formats.Upgraded {
+		blameFn := deps.BlameManifest
+		if blameFn == nil {
+			blameFn = blame.File
+		}
+		enrichers = append(enrichers, enricher{
+			run: func(ctx context.Context, modules []scanner.Module, w *warnings) {
+				annotateLastUpgraded(ctx, modules, workDir, pm, blameFn, w)
+			},
+			merge: func(dst *scanner.Module, src scanner.Module) { dst.LastUpgraded = src.LastUpgraded },
+		})
+	}
+	// --only-safe has already looked up every remaining module.
+	if opts.ShowVulnerabilities && !opts.OnlySafe {
+		if !formats.Machine() {
 			_, _ = fmt.Fprintln(deps.Out, "Checking vulnerabilities...")
 		}
-		vulnClient := factory.CreateVulnClient(pm)
-		ctx := context.Background()
-		checkVulnerabilities(ctx, modules, vulnClient)
+		// Details come from the databases directly: the lookup cache only
+		// keeps counts.
+		var details vuln.AdvisoryLister
+		if opts.VulnDetails {
+			client := deps.VulnClient
+			if client == nil {
+				client = newVulnClient(pm, cfg.Vulnerabilities)
+			}
+			var ok bool
+			if details, ok = client.(vuln.AdvisoryLister); !ok {
+				warns.add("", "the vulnerability source cannot list advisory details")
+			}
+		}
+		vulnClient := limitedVulnClient{Client: vulnClient, limit: limit}
+		enrichers = append(enrichers, enricher{
+			run: func(ctx context.Context, modules []scanner.Module, w *warnings) {
+				checkVulnerabilities(ctx, modules, vulnClient, private, events, w)
+				if details != nil {
+					annotateVulnDetails(ctx, modules, details, limit, w)
+				}
+			},
+			merge: func(dst *scanner.Module, src scanner.Module) {
+				dst.VulnCurrent, dst.VulnUpdate, dst.VulnDetails = src.VulnCurrent, src.VulnUpdate, src.VulnDetails
+			},
+		})
+	}
+	if opts.VulnMode == VulnModeCallgraph && pm != detector.Go {
+		warns.add("", "--vuln-mode callgraph only analyzes Go modules; showing OSV counts for %s", pm)
+	} else if opts.VulnMode == VulnModeCallgraph {
+		if !formats.Machine() {
+			_, _ = fmt.Fprintln(deps.Out, "Analyzing reachability with govulncheck...")
+		}
+		run := deps.Govulncheck
+		if run == nil {
+			run = vuln.RunGovulncheck
+		}
+		enrichers = append(enrichers, enricher{
+			run: func(ctx context.Context, modules []scanner.Module, w *warnings) {
+				annotateReachable(ctx, modules, workDir, run, w)
+			},
+			merge: func(dst *scanner.Module, src scanner.Module) { dst.VulnReachable = src.VulnReachable },
+		})
+	}
+	if opts.RiskScan {
+		if !formats.Machine() {
+			_, _ = fmt.Fprintln(deps.Out, "Scanning release notes...")
+		}
+		source := limitedSource{Source: releaseNotes(deps, &gh, &repos), limit: limit}
+		enrichers = append(enrichers, enricher{
+			run: func(ctx context.Context, modules []scanner.Module, w *warnings) {
+				annotateRisks(ctx, modules, source, opts.RiskKeywords, w)
+			},
+			merge: func(dst *scanner.Module, src scanner.Module) { dst.RiskHints = src.RiskHints },
+		})
+	}
+	enrich(ctx, modules, enrichers, &warns)
+
+	// Effort estimates weigh the release note hints, so they come after.
+	if opts.Effort {
+		list := deps.ListGoFiles
+		if list == nil {
+			list = coverage.ListFiles
+		}
+		annotateEffort(ctx, modules, pm, workDir, list, &warns)
+	}
+	if opts.GitHubStatus {
+		postCommitStatus(ctx, modules, opts.ShowVulnerabilities, statusPoster(deps, &gh), os.Getenv, &warns)
+	}
+	if opts.Upgrade && pm == detector.Go {
+		checkRegen(workDir, regenSteps(workDir, cfg), &warns)
 	}
 
+	if opts.CI {
+		sortForCI(modules, &warns)
+	}
 	direct, indirect, transitive := groupModules(modules)
+	direct, indirect, tools := splitTools(direct, indirect)
 
 	// Adapt group labels based on package manager
 	directLabel, indirectLabel, transitiveLabel := getGroupLabels(pm)
@@ -319,41 +954,151 @@ func Run(opts RunOptions, deps Deps) error {
 				return fmt.Errorf("failed to create updater: %w", err)
 			}
 		}
-		deps.StartInteractive(direct, indirect, transitive, tui.Options{
+		printWarnings(deps.Out, warns.items)
+		deps.StartInteractive(ctx, direct, indirect, transitive, tui.Options{
 			FormatGroup:     formats.Group,
 			FormatTime:      formats.Time,
+			TimeStyle:       formats.TimeStyle,
 			Updater:         updaterInstance,
 			DirectLabel:     directLabel,
 			IndirectLabel:   indirectLabel,
 			TransitiveLabel: transitiveLabel,
+			Tools:           tools,
+			ToolsLabel:      toolsLabel,
+			ReleaseNotes:    releaseNotes(deps, &gh, &repos),
+			VulnDetails:     opts.VulnDetails,
+			StatePath:       tui.StatePath(diskcache.Dir(), workDir),
+			Project:         opts.project,
+			Ecosystem:       pm.Ecosystem(),
 		})
 		return nil
 	}
 
+	packagesToUpdate := make([]scanner.Module, 0, len(direct)+len(indirect)+len(tools)+len(transitive))
+	packagesToUpdate = append(packagesToUpdate, direct...)
+	packagesToUpdate = append(packagesToUpdate, indirect...)
+	packagesToUpdate = append(packagesToUpdate, tools...)
+	if opts.All {
+		packagesToUpdate = append(packagesToUpdate, transitive...)
+	}
+	outcome.updates = len(packagesToUpdate)
+	var score *VulnScore
+	if opts.ShowVulnerabilities {
+		outcome.vulnerable = countVulnerable(packagesToUpdate, vulnerable)
+		s := scoreVulnerabilities(ctx, pm, workDir, modules, packagesToUpdate, vulnClient, private, deps, &warns)
+		score = &s
+	}
+
+	var preview *updater.Preview
+	if opts.Preview {
+		if !formats.Machine() {
+			_, _ = fmt.Fprintln(deps.Out, "Previewing upgrade...")
+		}
+		preview = previewUpgrade(ctx, pm, workDir, packagesToUpdate, deps, &warns)
+	}
+
+	if formats.Script {
+		u := deps.Updater
+		// Scripts only list commands, so read-only runs may export them too.
+		if _, readOnly := u.(updater.ReadOnly); u == nil || readOnly {
+			if u, err = factory.CreateUpdater(pm, workDir); err != nil {
+				return err
+			}
+		}
+		toUpgrade, heldBack := splitCritical(packagesToUpdate)
+		toUpgrade, tooLarge := splitEffort(toUpgrade, autoApply)
+		if err := writeUpgradeScript(deps.Out, pm, workDir, u, toUpgrade, append(heldBack, tooLarge...)); err != nil {
+			return err
+		}
+		printWarnings(deps.Err, warns.items)
+		return nil
+	}
+
 	if formats.Lines {
-		printLinesFormat(deps.Out, direct, indirect, transitive, opts.All)
+		printLinesFormat(deps.Out, direct, slices.Concat(indirect, tools), transitive, opts.All)
+		printPreview(deps.Err, preview)
+		printWarnings(deps.Err, warns.items)
 		return nil
 	}
 
+	labels := [4]string{directLabel, indirectLabel, toolsLabel, transitiveLabel}
+
+	if formats.Markdown {
+		records := buildRecords(direct, indirect, tools, transitive, opts.All, opts.ShowVulnerabilities, labels)
+		printPreview(deps.Err, preview)
+		return writeReport(deps.Out, reportText, pm.String(), records, warns.items, env, deps.Now())
+	}
+
+	if formats.JSON || formats.JSONL {
+		records := buildRecords(direct, indirect, tools, transitive, opts.All, opts.ShowVulnerabilities, labels)
+		if formats.JSONL {
+			if err := writeJSONLines(deps.Out, records); err != nil {
+				return err
+			}
+			printPreview(deps.Err, preview)
+			printWarnings(deps.Err, warns.items)
+			return nil
+		}
+		return writeJSONReport(deps.Out, jsonReport{Manager: pm.String(), Updates: records, MajorPaths: majors, Skipped: skippedStats(skipped), Preview: preview, VulnScore: score, Warnings: warns.items, Environment: env})
+	}
+
 	_, _ = fmt.Fprintln(deps.Out, "\nAvailable updates:")
 
-	maxPathLen := calculateMaxPathLen(direct, indirect, transitive)
+	shownDirect, shownIndirect, shownTransitive := direct, indirect, transitive
+	hidden := 0
+	if top := topLimit(opts, cfg.Report); top > 0 {
+		if !opts.All {
+			shownTransitive = nil
+		}
+		shownDirect, shownIndirect, shownTransitive, hidden = limitTop(top, shownDirect, shownIndirect, shownTransitive)
+	}
+
+	maxPathLen := calculateMaxPathLen(shownDirect, shownIndirect, tools, shownTransitive)
 	now := deps.Now()
+	width := outputWidth(opts, deps)
 
-	printGroup(deps.Out, directLabel, direct, maxPathLen, formats.Group, opts.ShowVulnerabilities, formats.Time, now)
-	printGroup(deps.Out, indirectLabel, indirect, maxPathLen, formats.Group, opts.ShowVulnerabilities, formats.Time, now)
+	printGroup(deps.Out, directLabel, shownDirect, maxPathLen, formats, opts.ShowVulnerabilities, now, width)
+	printGroup(deps.Out, indirectLabel, shownIndirect, maxPathLen, formats, opts.ShowVulnerabilities, now, width)
+	printGroup(deps.Out, toolsLabel, tools, maxPathLen, formats, opts.ShowVulnerabilities, now, width)
 	if opts.All {
-		printGroup(deps.Out, transitiveLabel, transitive, maxPathLen, formats.Group, opts.ShowVulnerabilities, formats.Time, now)
+		printGroup(deps.Out, transitiveLabel, shownTransitive, maxPathLen, formats, opts.ShowVulnerabilities, now, width)
+	}
+	printHiddenCount(deps.Out, hidden)
+	printMajorPaths(deps.Out, majors, true)
+	if score != nil {
+		printVulnScore(deps.Out, *score)
+	}
+	if !opts.Upgrade && !opts.OnlySafe {
+		if line := safeBatchLine(packagesToUpdate, opts.ShowVulnerabilities, opts); line != "" {
+			_, _ = fmt.Fprintf(deps.Out, "\n%s\n", line)
+		}
 	}
 
-	packagesToUpdate := make([]scanner.Module, 0, len(direct)+len(indirect)+len(transitive))
-	packagesToUpdate = append(packagesToUpdate, direct...)
-	packagesToUpdate = append(packagesToUpdate, indirect...)
-	if opts.All {
-		packagesToUpdate = append(packagesToUpdate, transitive...)
+	printSkipped(deps.Out, skipped)
+	printPreview(deps.Out, preview)
+	printWarnings(deps.Out, warns.items)
+
+	// Output gathered so far has been flushed; stop before touching any files.
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
 	if opts.Upgrade {
+		toUpgrade, heldBack := splitCritical(packagesToUpdate)
+		toUpgrade, tooLarge := splitEffort(toUpgrade, autoApply)
+		if len(heldBack) > 0 || len(tooLarge) > 0 {
+			_, _ = fmt.Fprintln(deps.Out)
+		}
+		if len(heldBack) > 0 {
+			printHeldBack(deps.Out, heldBack)
+		}
+		if len(tooLarge) > 0 {
+			printHeldBackEffort(deps.Out, tooLarge, autoApply)
+		}
+		if len(toUpgrade) == 0 {
+			return nil
+		}
+
 		var updaterInstance updater.Updater
 		if deps.Updater != nil {
 			updaterInstance = deps.Updater
@@ -363,19 +1108,284 @@ func Run(opts RunOptions, deps Deps) error {
 				return err
 			}
 		}
+		if opts.DryRun {
+			return dryRunUpgrade(ctx, pm, workDir, updaterInstance, toUpgrade, regenSteps(workDir, cfg), deps.RunCommand, deps.Out)
+		}
+
+		if pm == detector.Go && !opts.Yes {
+			ok, err := confirmTransitive(toUpgrade, deps)
+			if err != nil {
+				return categorize(ErrorUsage, err)
+			}
+			if !ok {
+				_, _ = fmt.Fprintln(deps.Out, "Upgrade canceled.")
+				return nil
+			}
+		}
 
+		// Indirect Go upgrades are verified and can be rolled back as a whole.
+		var indirectUps []scanner.Module
+		var snap *gomodUpdater.Snapshot
+		if pm == detector.Go {
+			_, ind, trans := groupModules(toUpgrade)
+			if indirectUps = append(ind, trans...); len(indirectUps) > 0 {
+				if snap, err = gomodUpdater.TakeSnapshot(workDir); err != nil {
+					return err
+				}
+			}
+		}
+
+		auditLog := startAudit(cfg.Audit, opts.AuditLog, workDir, "upgrade", pm)
 		_, _ = fmt.Fprintln(deps.Out, "\nUpgrading...")
-		if err := updaterInstance.UpdatePackages(packagesToUpdate); err != nil {
+		events.emit(Event{Kind: EventUpgradeStarted, Total: len(toUpgrade)})
+		total := len(toUpgrade)
+		var failed []updater.Result
+		if opts.OneByOne {
+			toUpgrade, failed, err = upgradeEach(ctx, updaterInstance, toUpgrade, deps.Out)
+			indirectUps = onlyApplied(indirectUps, toUpgrade)
+		} else {
+			err = updaterInstance.UpdatePackages(ctx, toUpgrade)
+		}
+		if err != nil {
+			auditFailed(deps, auditLog.finish(ctx, toUpgrade, err, deps))
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return categorize(ErrorUpdate, err)
+		}
+		if len(toUpgrade) == 0 {
+			err := eachFailed(failed, total)
+			auditFailed(deps, auditLog.finish(ctx, nil, err, deps))
 			return err
 		}
+		if len(indirectUps) > 0 {
+			results, err := settleIndirect(ctx, workDir, indirectUps, opts.PinIndirect, updaterInstance, deps)
+			if err != nil {
+				err = errors.Join(err, snap.Restore())
+				auditFailed(deps, auditLog.finish(ctx, toUpgrade, err, deps))
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return categorize(ErrorUpdate, fmt.Errorf("%w; go.mod and go.sum were restored", err))
+			}
+			printIndirectResults(deps.Out, results, opts.PinIndirect)
+		}
+		if pm == detector.Go {
+			var regenWarns warnings
+			err := runRegen(ctx, workDir, regenSteps(workDir, cfg), deps.RunCommand, &regenWarns)
+			printWarnings(deps.Out, regenWarns.items)
+			if err != nil {
+				auditFailed(deps, auditLog.finish(ctx, toUpgrade, err, deps))
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return categorize(ErrorUpdate, fmt.Errorf("%w; go.mod was upgraded but the generated files may be stale", err))
+			}
+		}
+		events.modules(EventUpgradeApplied, toUpgrade)
 		_, _ = fmt.Fprintln(deps.Out, "Done.")
-		return nil
+		outcome.updates -= len(toUpgrade)
+		if opts.ShowVulnerabilities {
+			outcome.vulnerable = countVulnerable(slices.Concat(heldBack, tooLarge), vulnerable)
+		}
+		if err := auditLog.finish(ctx, toUpgrade, nil, deps); err != nil {
+			return err
+		}
+		if opts.Commit {
+			upgraded := make(map[string]bool, len(toUpgrade))
+			for _, m := range toUpgrade {
+				upgraded[moduleName(m)] = true
+			}
+			var records []format.Record
+			for _, r := range buildRecords(direct, indirect, tools, transitive, opts.All, opts.ShowVulnerabilities, labels) {
+				if upgraded[r.Name] {
+					records = append(records, r)
+				}
+			}
+			files := detector.ManifestFiles(pm)
+			if pm == detector.Go {
+				files = append(files, regenFiles(regenSteps(workDir, cfg))...)
+			}
+			if err := commitUpgrade(ctx, workDir, pm, files, cfg.Commit, toUpgrade, records, deps); err != nil {
+				return categorize(ErrorCommit, err)
+			}
+		}
+		return eachFailed(failed, total)
 	}
 
 	_, _ = fmt.Fprintln(deps.Out, "\nRun with -u to upgrade, or -i for interactive mode.")
 	return nil
 }
 
+// checkNoExec rejects options that would modify the project or run git in
+// --no-exec mode.
+func checkNoExec(opts RunOptions) error {
+	// Parse errors are reported once the scan options are checked.
+	formats, _ := format.ParseFlag(opts.FormatFlag)
+	switch {
+	case opts.Upgrade:
+		return fmt.Errorf("--no-exec forbids --upgrade: upgrades run package manager commands; remove --no-exec to apply updates")
+	case opts.Commit:
+		return fmt.Errorf("--no-exec forbids --commit: committing runs git; remove --no-exec to commit updates")
+	case opts.Preview:
+		return fmt.Errorf("--no-exec forbids --preview: previews run go get against a temporary copy of go.mod")
+	case opts.Interactive:
+		return fmt.Errorf("--no-exec forbids --interactive: the picker applies updates; use the default report instead")
+	case opts.FixEnv:
+		return fmt.Errorf("--no-exec forbids --fix-env: it writes GOPRIVATE to the go env file")
+	case formats.Upgraded:
+		return fmt.Errorf("--no-exec forbids --format upgraded: it runs git blame on the manifest")
+	}
+	return nil
+}
+
+// readOnlyCommit is the CommitFunc used in --no-exec mode.
+func readOnlyCommit(context.Context, string, []string, string) error {
+	return updater.ErrReadOnly
+}
+
+// commitUpgrade commits files in workDir with a message built from modules
+// and the project's commit settings.
+func commitUpgrade(ctx context.Context, workDir string, pm detector.PackageManager, files []string, cfg config.Commit, modules []scanner.Module, records []format.Record, deps Deps) error {
+	msg, err := commitMessage(workDir, pm, cfg, modules, records)
+	if err != nil {
+		return err
+	}
+	commitFn := deps.Commit
+	if commitFn == nil {
+		commitFn = commit.Commit
+	}
+	if err := commitFn(ctx, workDir, files, msg); err != nil {
+		return err
+	}
+	subject, _, _ := strings.Cut(msg, "\n")
+	_, _ = fmt.Fprintf(deps.Out, "Committed: %s\n", subject)
+	return nil
+}
+
+// commitMessage renders the commit message for modules from the project's
+// commit settings.
+func commitMessage(workDir string, pm detector.PackageManager, cfg config.Commit, modules []scanner.Module, records []format.Record) (string, error) {
+	tmpl := cfg.Template
+	if tmpl == "" && cfg.TemplateFile != "" {
+		text, err := report.Load(resolveProjectPath(workDir, cfg.TemplateFile))
+		if err != nil {
+			return "", err
+		}
+		tmpl = text
+	}
+	data := commit.NewData(cfg.Type, cfg.Scope, pm.String(), modules)
+	data.Updates = records
+	return commit.Message(tmpl, data)
+}
+
+// cooldownDays returns the explicit cooldown when set, otherwise the
+// configured default for pm's manager or ecosystem.
+func cooldownDays(days int, set bool, cfg config.Config, pm detector.PackageManager) int {
+	if set {
+		return days
+	}
+	if configured, ok := cfg.Cooldown.Days(pm.String(), pm.Ecosystem()); ok {
+		return configured
+	}
+	return days
+}
+
+// resolveManager returns the project directory and package manager for a
+// run: --gomod selects Go in that module's directory, --manager overrides
+// detection, and otherwise the manager is detected in dir (the working
+// directory when empty).
+func resolveManager(dir, manager, goModPath string) (string, detector.PackageManager, error) {
+	workDir, err := projectDir(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	if goModPath != "" {
+		if manager != "" && manager != detector.Go.String() {
+			return "", "", categorize(ErrorUsage, fmt.Errorf("--gomod cannot be combined with --manager %s", manager))
+		}
+		workDir, err = resolveGoModDir(goModPath)
+		if err != nil {
+			return "", "", categorize(ErrorDetect, err)
+		}
+		return workDir, detector.Go, nil
+	}
+	if manager != "" {
+		// Use explicit manager
+		pm, err := detector.Validate(manager)
+		if err != nil {
+			return "", "", categorize(ErrorUsage, err)
+		}
+		return workDir, pm, nil
+	}
+	// Auto-detect
+	provider, err := factory.Detect(workDir)
+	if err != nil {
+		return "", "", categorize(ErrorDetect, fmt.Errorf("failed to detect package manager: %w\nSpecify one with --manager flag", err))
+	}
+	return workDir, provider.Manager, nil
+}
+
+// ecosystemManager returns the manager a run with --ecosystem uses: manager
+// when given (it must belong to ecosystem), otherwise the one detected in dir.
+func ecosystemManager(dir, ecosystem, manager, goModPath string) (string, error) {
+	ecosystem, err := detector.ValidateEcosystem(ecosystem)
+	if err != nil {
+		return "", categorize(ErrorUsage, err)
+	}
+	if goModPath != "" {
+		if ecosystem != "go" {
+			return "", categorize(ErrorUsage, fmt.Errorf("--gomod cannot be combined with --ecosystem %s", ecosystem))
+		}
+		return manager, nil
+	}
+	if manager != "" {
+		pm, err := detector.Validate(manager)
+		if err != nil {
+			return "", categorize(ErrorUsage, err)
+		}
+		if pm.Ecosystem() != ecosystem {
+			return "", categorize(ErrorUsage, fmt.Errorf("--manager %s does not belong to the %s ecosystem", pm, ecosystem))
+		}
+		return manager, nil
+	}
+	workDir, err := projectDir(dir)
+	if err != nil {
+		return "", err
+	}
+	result, err := detector.DetectEcosystem(workDir, ecosystem)
+	if err != nil {
+		return "", categorize(ErrorDetect, err)
+	}
+	return result.Manager.String(), nil
+}
+
+// resolveGoModDir returns the module directory for a --gomod argument, which
+// may name either a go.mod file or the directory containing it.
+func resolveGoModDir(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("invalid --gomod path: %w", err)
+	}
+	if info.IsDir() {
+		abs = filepath.Join(abs, "go.mod")
+		if _, err := os.Stat(abs); err != nil {
+			return "", fmt.Errorf("invalid --gomod path: %w", err)
+		}
+	} else if filepath.Base(abs) != "go.mod" {
+		return "", fmt.Errorf("invalid --gomod path %s: expected a go.mod file", path)
+	}
+	return filepath.Dir(abs), nil
+}
+
+// toolsLabel heads the group of Go modules providing tools.
+const toolsLabel = "Tools (go.mod tool directives and tools.go)"
+
 // getGroupLabels returns appropriate group labels based on the package manager.
 func getGroupLabels(pm detector.PackageManager) (direct, indirect, transitive string) {
 	switch pm {
@@ -421,15 +1431,15 @@ func formatVulnCounts(current, update scanner.VulnInfo) string {
 	if fixed > 0 {
 		// Vulnerabilities were fixed
 		if updateStr == "" {
-			return fmt.Sprintf("%s → %s", currentStr, green.Render(fmt.Sprintf("✓ (fixes %d)", fixed)))
+			return fmt.Sprintf("%s %s %s", currentStr, style.Glyphs.Arrow, green.Render(fmt.Sprintf("%s (fixes %d)", style.Glyphs.Check, fixed)))
 		}
-		return fmt.Sprintf("%s → %s %s", currentStr, updateStr, green.Render(fmt.Sprintf("(fixes %d)", fixed)))
+		return fmt.Sprintf("%s %s %s %s", currentStr, style.Glyphs.Arrow, updateStr, green.Render(fmt.Sprintf("(fixes %d)", fixed)))
 	} else if fixed < 0 {
 		// More vulnerabilities in update
-		return fmt.Sprintf("%s → %s %s", currentStr, updateStr, red.Render(fmt.Sprintf("(+%d)", -fixed)))
+		return fmt.Sprintf("%s %s %s %s", currentStr, style.Glyphs.Arrow, updateStr, red.Render(fmt.Sprintf("(+%d)", -fixed)))
 	} else if update.Total > 0 {
 		// Same count but might be different types
-		return fmt.Sprintf("%s → %s", currentStr, updateStr)
+		return fmt.Sprintf("%s %s %s", currentStr, style.Glyphs.Arrow, updateStr)
 	}
 
 	// No change or no update checked
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/audit"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/version"
+)
+
+// auditRun records one non-interactive apply: the manifests before it
+// starts and the changes once it finishes.
+type auditRun struct {
+	target audit.Target
+	rec    audit.Record
+	files  []string
+	before map[string][]byte
+}
+
+// startAudit snapshots pm's manifests in workDir when an audit log is
+// configured or logPath (the --audit-log flag) is set. It returns nil when
+// auditing is off.
+func startAudit(cfg config.Audit, logPath, workDir, command string, pm detector.PackageManager) *auditRun {
+	target := audit.Target{Endpoint: cfg.Endpoint}
+	switch {
+	case logPath != "":
+		target.File, _ = filepath.Abs(logPath)
+	case cfg.File != "":
+		target.File = resolveProjectPath(workDir, cfg.File)
+	}
+	if cfg.TokenEnv != "" {
+		target.Token = os.Getenv(cfg.TokenEnv)
+	}
+	if !target.Enabled() {
+		return nil
+	}
+
+	actor, ci := audit.Actor(os.Getenv)
+	host, _ := os.Hostname()
+	files := detector.ManifestFiles(pm)
+	return &auditRun{
+		target: target,
+		rec: audit.Record{
+			Actor:   actor,
+			CI:      ci,
+			Host:    host,
+			Faro:    version.Get().Version,
+			Command: command,
+			Project: workDir,
+			Manager: pm.String(),
+		},
+		files:  files,
+		before: audit.ReadFiles(workDir, files),
+	}
+}
+
+// finish emits the record for modules, noting applyErr if applying failed.
+// Only a failure to write the audit file is returned; a failed post is
+// printed as a warning. A nil auditRun does nothing.
+func (a *auditRun) finish(ctx context.Context, modules []scanner.Module, applyErr error, deps Deps) error {
+	if a == nil {
+		return nil
+	}
+	rec := a.rec
+	rec.Time = deps.Now()
+	for _, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		rec.Changes = append(rec.Changes, audit.Change{Module: moduleName(m), From: m.Version, To: m.Update.Version})
+	}
+	rec.Diff = audit.Diff(a.before, audit.ReadFiles(rec.Project, a.files))
+	if applyErr != nil {
+		rec.Error = applyErr.Error()
+	}
+
+	// Record even when ctx was canceled mid-apply: files may have changed.
+	ctx = context.WithoutCancel(ctx)
+	client := &http.Client{Timeout: 30 * time.Second}
+	if a.target.File != "" {
+		if err := audit.Emit(ctx, client, audit.Target{File: a.target.File}, rec); err != nil {
+			return categorize(ErrorAudit, err)
+		}
+		_, _ = fmt.Fprintf(deps.Out, "Audit record written to %s\n", a.target.File)
+	}
+	if a.target.Endpoint != "" {
+		// The changes are applied by now, so an unreachable collector must
+		// not stop the run (or its --commit) from finishing.
+		post := audit.Target{Endpoint: a.target.Endpoint, Token: a.target.Token}
+		if err := audit.Emit(ctx, client, post, rec); err != nil {
+			printWarnings(deps.Out, []Warning{{Message: err.Error()}})
+			return nil
+		}
+		_, _ = fmt.Fprintf(deps.Out, "Audit record posted to %s\n", a.target.Endpoint)
+	}
+	return nil
+}
+
+// auditFailed reports an audit error that cannot replace the error being
+// returned, such as when applying itself failed.
+func auditFailed(deps Deps, err error) {
+	if err != nil {
+		_, _ = fmt.Fprintf(deps.Out, "Audit failed: %v\n", err)
+	}
+}
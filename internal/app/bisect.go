@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pragmaticivan/faro/internal/align"
+	"github.com/pragmaticivan/faro/internal/bisect"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/updater"
+	gomodUpdater "github.com/pragmaticivan/faro/internal/updater/gomod"
+	"github.com/pragmaticivan/faro/internal/verify"
+)
+
+// VerifyFunc checks that the project still builds and passes its tests after
+// module changed.
+type VerifyFunc func(ctx context.Context, module string) error
+
+// BisectOptions configures Bisect.
+type BisectOptions struct {
+	Module    string // Module path to bisect
+	Target    string // Newest version to consider; defaults to the latest
+	GoModPath string // Optional go.mod path; defaults to the working directory
+	NoExec    bool   // Read-only mode; bisecting is refused
+}
+
+// Bisect binary-searches the versions of opts.Module between the required
+// version and opts.Target for the first one that breaks the build or tests.
+// go.mod and go.sum are restored when it finishes.
+func Bisect(ctx context.Context, opts BisectOptions, deps Deps) (err error) {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if opts.NoExec {
+		return fmt.Errorf("--no-exec forbids bisecting, which changes go.mod and runs builds")
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if opts.GoModPath != "" {
+		workDir, err = resolveGoModDir(opts.GoModPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	requires, err := gomod.ReadRequires(filepath.Join(workDir, "go.mod"))
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	current := ""
+	for _, r := range requires {
+		if r.Path == opts.Module {
+			current = r.Version
+			break
+		}
+	}
+	if current == "" {
+		return fmt.Errorf("%s is not required in go.mod", opts.Module)
+	}
+	if opts.Target != "" && !style.ValidVersion(opts.Target) {
+		return fmt.Errorf("invalid --to version %q", opts.Target)
+	}
+
+	list := deps.ListVersions
+	if list == nil {
+		list = align.GoListVersions(workDir)
+	}
+	versions, err := list(ctx, opts.Module)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to list versions of %s: %w", opts.Module, err)
+	}
+	candidates := bisect.Candidates(versions, current, opts.Target)
+	if len(candidates) == 0 {
+		_, _ = fmt.Fprintf(deps.Out, "No versions of %s newer than %s to test.\n", opts.Module, current)
+		return nil
+	}
+
+	updaterInstance := deps.Updater
+	if updaterInstance == nil {
+		updaterInstance, err = factory.CreateUpdater(detector.Go, workDir)
+		if err != nil {
+			return err
+		}
+	}
+	check := deps.Verify
+	if check == nil {
+		cfg, err := config.Load(workDir)
+		if err != nil {
+			return err
+		}
+		v := verify.New(workDir, verify.Options{Command: cfg.Doctor.Command, ScopeTests: cfg.Doctor.ScopeTests})
+		check = func(ctx context.Context, module string) error { return v.Verify(ctx, module, nil) }
+	}
+
+	snap, err := gomodUpdater.TakeSnapshot(workDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if restoreErr := snap.Restore(); restoreErr != nil {
+			err = errors.Join(err, restoreErr)
+		}
+	}()
+
+	_, _ = fmt.Fprintf(deps.Out, "Bisecting %d %s of %s after %s...\n",
+		len(candidates), plural(len(candidates), "version", "versions"), opts.Module, current)
+	res, err := bisect.Search(ctx, current, candidates, func(ctx context.Context, v string) (bool, error) {
+		// Each version is tried against the original go.mod and go.sum,
+		// not on top of the previous attempt.
+		if err := snap.Restore(); err != nil {
+			return false, err
+		}
+		problem, err := tryVersion(ctx, updaterInstance, check, opts.Module, current, v)
+		if err != nil {
+			return false, err
+		}
+		if problem != "" {
+			_, _ = fmt.Fprintf(deps.Out, " %s: broken (%s)\n", v, problem)
+			return false, nil
+		}
+		_, _ = fmt.Fprintf(deps.Out, " %s: ok\n", v)
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.FirstBad == "" {
+		_, _ = fmt.Fprintf(deps.Out, "\n%s %s works; nothing to bisect.\n", opts.Module, res.LastGood)
+		return nil
+	}
+	_, _ = fmt.Fprintf(deps.Out, "\nFirst breaking version: %s (last good: %s, %d %s tested)\n",
+		res.FirstBad, res.LastGood, res.Tested, plural(res.Tested, "version", "versions"))
+	return nil
+}
+
+// tryVersion moves module to version and verifies the project, returning a
+// short description of what broke, or "" when it works. Only cancellation is
+// returned as an error.
+func tryVersion(ctx context.Context, u updater.Updater, check VerifyFunc, module, current, version string) (string, error) {
+	m := scanner.Module{Name: module, Version: current, Update: &scanner.UpdateInfo{Version: version}}
+	if err := u.UpdatePackages(ctx, []scanner.Module{m}); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "upgrade failed", nil
+	}
+	if err := check(ctx, module); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		var f *verify.Failure
+		if errors.As(err, &f) {
+			return f.Command + " failed", nil
+		}
+		return err.Error(), nil
+	}
+	return "", nil
+}
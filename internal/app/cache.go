@@ -0,0 +1,32 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/pragmaticivan/faro/internal/diskcache"
+)
+
+// CacheClearOptions configures CacheClear.
+type CacheClearOptions struct {
+	Dir string // Cache directory (default diskcache.Dir())
+}
+
+// CacheClear removes everything faro caches on disk: scan results,
+// vulnerability and publish-time lookups, and vanity import resolutions.
+func CacheClear(opts CacheClearOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = diskcache.Dir()
+	}
+	if dir == "" {
+		return categorize(ErrorUsage, fmt.Errorf("no user cache directory; set XDG_CACHE_HOME or HOME"))
+	}
+	if err := diskcache.Clear(dir); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(deps.Out, "Cleared %s\n", dir)
+	return nil
+}
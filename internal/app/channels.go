@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/cooldown"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/diskcache"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/suspect"
+)
+
+// ChannelSource lists a module's published versions and when each was
+// published, for channel pinning and --target.
+type ChannelSource interface {
+	Versions(ctx context.Context, name string) ([]string, error)
+	PublishTime(ctx context.Context, name, version string) (string, error)
+}
+
+// goChannels reads versions and publish times from the module proxy.
+type goChannels struct {
+	proxy *goproxy.Client
+}
+
+func (g goChannels) Versions(ctx context.Context, name string) ([]string, error) {
+	return g.proxy.Versions(ctx, name)
+}
+
+func (g goChannels) PublishTime(ctx context.Context, name, version string) (string, error) {
+	info, err := g.proxy.Info(ctx, name, version)
+	return info.Time, err
+}
+
+// cachedPublishTimes answers PublishTime from a disk store before asking
+// the wrapped source; version lists change too often to cache.
+type cachedPublishTimes struct {
+	ChannelSource
+	store     *diskcache.Store
+	ecosystem string
+}
+
+func (c cachedPublishTimes) PublishTime(ctx context.Context, name, version string) (string, error) {
+	key := "time:" + c.ecosystem + ":" + name + "@" + version
+	var published string
+	if c.store.Get(key, &published) {
+		return published, nil
+	}
+	published, err := c.ChannelSource.PublishTime(ctx, name, version)
+	if err == nil && published != "" {
+		c.store.Put(key, published)
+	}
+	return published, err
+}
+
+// channelSource returns the version source for pm, or nil when channels
+// are not supported for it. Go versions come from proxy; publish times are
+// kept in lookups.
+func channelSource(pm detector.PackageManager, cfg config.SupplyChain, proxy *goproxy.Client, lookups *diskcache.Store) ChannelSource {
+	var src ChannelSource
+	switch {
+	case pm == detector.Go:
+		src = goChannels{proxy: proxy}
+	case pm.Ecosystem() == "npm":
+		src = suspect.NewNPMRegistry(&http.Client{Timeout: 15 * time.Second}, cfg.Registry)
+	default:
+		return nil
+	}
+	if lookups == nil {
+		return src
+	}
+	return cachedPublishTimes{ChannelSource: src, store: lookups, ecosystem: pm.Ecosystem()}
+}
+
+// Update targets accepted by --target.
+const (
+	TargetLatest = "latest"
+	TargetMinor  = "minor"
+	TargetPatch  = "patch"
+)
+
+// parseTarget validates a --target value; empty means TargetLatest.
+func parseTarget(target string) (string, error) {
+	switch target {
+	case "", TargetLatest:
+		return TargetLatest, nil
+	case TargetMinor, TargetPatch:
+		return target, nil
+	}
+	return "", fmt.Errorf("invalid --target %q (supported: latest, minor, patch)", target)
+}
+
+// parseAsOf parses an --as-of date ("2024-12-31", meaning the end of that
+// day in UTC) or RFC3339 time and returns the cutoff versions must be
+// published before.
+func parseAsOf(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --as-of %q: want a date (2024-12-31) or RFC3339 time", s)
+}
+
+// versionRule restricts the versions a module may be updated to.
+type versionRule struct {
+	desc   string // e.g. "channel v0.28, --target minor"
+	allows func(version string) bool
+	before time.Time          // with --as-of, versions must be published before this
+	skip   scanner.SkipReason // recorded when no allowed version is newer
+}
+
+// eligible reports whether u is allowed by r and, with --as-of, was
+// published before the cutoff and old enough for the cooldown at that time.
+func (r versionRule) eligible(u scanner.UpdateInfo, cooldownDays int, now time.Time) bool {
+	if !r.allows(u.Version) {
+		return false
+	}
+	if !r.before.IsZero() {
+		t, ok := format.ParseRFC3339ish(u.Time)
+		if !ok || !t.Before(r.before) {
+			return false
+		}
+		now = r.before
+	}
+	return cooldown.Eligible(u.Time, cooldownDays, now)
+}
+
+// keeps reports whether the scanner's update u can stay. Without --as-of
+// the scanner has already applied the cooldown.
+func (r versionRule) keeps(u scanner.UpdateInfo, cooldownDays int, now time.Time) bool {
+	if r.before.IsZero() {
+		return r.allows(u.Version)
+	}
+	return r.eligible(u, cooldownDays, now)
+}
+
+// versionRules returns the rule for each module: its pinned channel from
+// cfg, the --target bound and the --as-of cutoff, whichever apply.
+func versionRules(cfg config.Config, target string, asOf time.Time) func(m scanner.Module) (versionRule, bool) {
+	return func(m scanner.Module) (versionRule, bool) {
+		ch, pinned := cfg.ChannelFor(moduleName(m))
+		bounded := target != TargetLatest && m.Update != nil &&
+			style.GetDiffType(m.Version, m.Update.Version) != style.DiffUnknown
+		inTarget := func(v string) bool {
+			if strings.Contains(strings.SplitN(v, "+", 2)[0], "-") {
+				return false
+			}
+			switch style.GetDiffType(m.Version, v) {
+			case style.DiffPatch, style.DiffSame:
+				return true
+			case style.DiffMinor:
+				return target == TargetMinor
+			}
+			return false
+		}
+
+		rule := versionRule{before: asOf, skip: scanner.SkipAsOf}
+		var descs []string
+		if !asOf.IsZero() {
+			descs = append(descs, "--as-of "+asOf.Format(time.RFC3339))
+		}
+		if bounded {
+			descs = append([]string{"--target " + target}, descs...)
+			rule.skip = scanner.SkipTarget
+		}
+		if pinned {
+			descs = append([]string{"channel " + ch.String()}, descs...)
+			rule.skip = scanner.SkipChannel
+		}
+		if len(descs) == 0 {
+			return versionRule{}, false
+		}
+		rule.desc = strings.Join(descs, ", ")
+		rule.allows = func(v string) bool {
+			return (!pinned || ch.Allows(v)) && (!bounded || inTarget(v))
+		}
+		return rule, true
+	}
+}
+
+// applyVersionRules moves updates outside their module's rule to the
+// newest eligible version that is newer than the current one. Modules with no such version are dropped. Without a
+// version source, out-of-rule updates are dropped outright.
+func applyVersionRules(ctx context.Context, modules []scanner.Module, ruleFor func(scanner.Module) (versionRule, bool), src ChannelSource, cooldownDays int, now time.Time, skipped *scanner.SkipStats, w *warnings) []scanner.Module {
+	out := make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		rule, ok := ruleFor(m)
+		if !ok || m.Update == nil || rule.keeps(*m.Update, cooldownDays, now) {
+			out = append(out, m)
+			continue
+		}
+		if src == nil {
+			skipped.Add(rule.skip)
+			continue
+		}
+		update, err := newestAllowed(ctx, m, rule, src, cooldownDays, now)
+		if err != nil {
+			if ctx.Err() != nil {
+				return out
+			}
+			w.fail(moduleName(m), "could not list versions for %s: %v", rule.desc, err)
+			continue
+		}
+		if update == nil {
+			skipped.Add(rule.skip)
+			continue
+		}
+		m.Update = update
+		out = append(out, m)
+	}
+	return out
+}
+
+// newestAllowed returns the newest eligible version of m under rule, or nil.
+func newestAllowed(ctx context.Context, m scanner.Module, rule versionRule, src ChannelSource, cooldownDays int, now time.Time) (*scanner.UpdateInfo, error) {
+	versions, err := src.Versions(ctx, moduleName(m))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		c, _ := style.ComparePrecedence(versions[i], versions[j])
+		return c > 0
+	})
+	for _, v := range versions {
+		newer, ok := style.ComparePrecedence(v, m.Version)
+		if !ok {
+			continue
+		}
+		if newer <= 0 {
+			break
+		}
+		if !rule.allows(v) {
+			continue
+		}
+		published, err := src.PublishTime(ctx, moduleName(m), v)
+		if err != nil {
+			return nil, err
+		}
+		if u := (scanner.UpdateInfo{Version: v, Time: published}); rule.eligible(u, cooldownDays, now) {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
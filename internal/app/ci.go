@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// CIFormat returns the output format --ci uses for formatFlag: json when no
+// format was given, formatFlag otherwise.
+func CIFormat(formatFlag string) string {
+	if formatFlag == "" {
+		return "json"
+	}
+	return formatFlag
+}
+
+// ciOptions applies the --ci defaults to opts and deps: json output unless
+// json, jsonl or lines was chosen, no wrapping to a terminal width, and no
+// prompts. Interactive mode and human-oriented formats are rejected.
+func ciOptions(opts RunOptions, deps Deps) (RunOptions, Deps, error) {
+	if opts.Interactive {
+		return opts, deps, fmt.Errorf("--ci cannot be combined with --interactive")
+	}
+	if opts.TemplatePath != "" {
+		return opts, deps, fmt.Errorf("--ci cannot be combined with --template")
+	}
+	opts.FormatFlag = CIFormat(opts.FormatFlag)
+	formats, err := format.ParseFlag(opts.FormatFlag)
+	if err != nil {
+		return opts, deps, err
+	}
+	if !formats.JSON && !formats.JSONL && !formats.Lines {
+		return opts, deps, fmt.Errorf("--ci supports json, jsonl and lines output only")
+	}
+	opts.NoWrap = true
+	deps.Confirm = nil
+	deps.Width = nil
+	return opts, deps, nil
+}
+
+// sortForCI orders modules by name and warnings by module and message, so
+// that --ci output does not depend on scan or lookup timing.
+func sortForCI(modules []scanner.Module, w *warnings) {
+	sort.SliceStable(modules, func(a, b int) bool { return moduleName(modules[a]) < moduleName(modules[b]) })
+	sort.SliceStable(w.items, func(a, b int) bool {
+		if w.items[a].Module != w.items[b].Module {
+			return w.items[a].Module < w.items[b].Module
+		}
+		return w.items[a].Message < w.items[b].Message
+	})
+}
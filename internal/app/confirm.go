@@ -0,0 +1,36 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// ConfirmFunc asks the user a yes/no question.
+type ConfirmFunc func(prompt string) (bool, error)
+
+// confirmTransitive asks before -u --all runs go get on transitive modules.
+// go get adds each one to go.mod as a // indirect requirement at the new
+// version, which pins it above what the direct dependencies ask for; that is
+// rarely what was meant. Without a way to ask it refuses, so scripts have to
+// opt in with --yes.
+func confirmTransitive(modules []scanner.Module, deps Deps) (bool, error) {
+	_, _, transitive := groupModules(modules)
+	var names []string
+	for _, m := range transitive {
+		names = append(names, moduleName(m))
+	}
+	if len(names) == 0 {
+		return true, nil
+	}
+
+	n := len(names)
+	_, _ = fmt.Fprintf(deps.Out, "\n-u with --all upgrades %d transitive %s not listed in go.mod: %s\n", n, plural(n, "module", "modules"), strings.Join(names, ", "))
+	_, _ = fmt.Fprintln(deps.Out, "go get adds each one to go.mod as a // indirect requirement at the new version, pinning it")
+	_, _ = fmt.Fprintln(deps.Out, "above what your dependencies ask for. Upgrading the direct dependency that pulls it in is usually what you want.")
+	if deps.Confirm == nil {
+		return false, fmt.Errorf("refusing to upgrade transitive modules without confirmation; pass --yes to proceed")
+	}
+	return deps.Confirm(fmt.Sprintf("Upgrade %d transitive %s?", n, plural(n, "module", "modules")))
+}
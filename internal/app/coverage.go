@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+
+	"github.com/pragmaticivan/faro/internal/coverage"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// GoFileLister lists a Go project's source files with their imports.
+type GoFileLister func(ctx context.Context, workDir string) ([]coverage.File, error)
+
+// checkCoverage warns about updated modules whose importing code the cover
+// profile at profilePath never executes: a green test run says little about
+// such upgrades.
+func checkCoverage(ctx context.Context, modules []scanner.Module, workDir, profilePath string, list GoFileLister, w *warnings) {
+	executed, err := coverage.ReadProfile(profilePath)
+	if err != nil {
+		w.add("", "coverage check skipped: %v", err)
+		return
+	}
+	files, err := list(ctx, workDir)
+	if err != nil {
+		w.add("", "coverage check skipped: %v", err)
+		return
+	}
+
+	names := make([]string, len(modules))
+	for i, m := range modules {
+		names[i] = moduleName(m)
+	}
+	surfaces := coverage.Surfaces(files, executed, names)
+	for _, name := range names {
+		if s, ok := surfaces[name]; ok && s.Executed == 0 {
+			w.add(name, "zero test coverage exercises the %d %s importing it; passing tests won't vouch for this upgrade",
+				s.Files, plural(s.Files, "file", "files"))
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/cooldown"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// applyCritical marks modules tagged critical in cfg and drops critical
+// updates younger than the critical cooldown (or minDays, if longer).
+// Updates without a publish time are only dropped when minDays asks for a
+// cooldown, as for other modules; otherwise they are kept with a warning.
+func applyCritical(modules []scanner.Module, cfg config.Critical, minDays int, now time.Time, skipped *scanner.SkipStats, w *warnings) []scanner.Module {
+	if len(cfg.Modules) == 0 {
+		return modules
+	}
+	days := max(cfg.CooldownDays(), minDays)
+	out := make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		if cfg.Matches(moduleName(m)) {
+			m.Critical = true
+			switch {
+			case m.Update == nil:
+			case m.Update.Time == "" && minDays <= 0:
+				w.add(moduleName(m), "publish time of %s unknown; the %d-day critical cooldown was not checked", m.Update.Version, days)
+			case !cooldown.Eligible(m.Update.Time, days, now):
+				skipped.Add(scanner.SkipCooldown)
+				continue
+			}
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// splitCritical separates critical modules and updates with new owners,
+// which are never upgraded without interactive confirmation, from the rest.
+func splitCritical(modules []scanner.Module) (regular, critical []scanner.Module) {
+	for _, m := range modules {
+		if m.Critical || m.OwnerChange != "" {
+			critical = append(critical, m)
+		} else {
+			regular = append(regular, m)
+		}
+	}
+	return regular, critical
+}
+
+// printHeldBack reports modules splitCritical left out of a bulk upgrade.
+func printHeldBack(out io.Writer, held []scanner.Module) {
+	var critical, owners []string
+	for _, m := range held {
+		if m.Critical {
+			critical = append(critical, moduleName(m))
+		} else {
+			owners = append(owners, moduleName(m))
+		}
+	}
+	orange := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	if len(critical) > 0 {
+		_, _ = fmt.Fprintln(out, orange.Render(fmt.Sprintf("Held back %d critical %s (%s); upgrade them with -i.",
+			len(critical), plural(len(critical), "module", "modules"), strings.Join(critical, ", "))))
+	}
+	if len(owners) > 0 {
+		_, _ = fmt.Fprintln(out, orange.Render(fmt.Sprintf("Held back %d %s with new owners (%s); review them and upgrade with -i.",
+			len(owners), plural(len(owners), "module", "modules"), strings.Join(owners, ", "))))
+	}
+}
+
+// criticalTag marks critical modules in text output.
+func criticalTag() string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("[critical]")
+}
+
+// ownerChangeTag marks updates with new owners in text output.
+func ownerChangeTag() string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("[owner changed]")
+}
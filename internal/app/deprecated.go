@@ -0,0 +1,58 @@
+package app
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// splitUpToDate separates the modules without an update, which the scanner
+// only returns to report their retraction or deprecation.
+func splitUpToDate(modules []scanner.Module) (outdated, upToDate []scanner.Module) {
+	outdated = make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		if m.Update == nil {
+			upToDate = append(upToDate, m)
+		} else {
+			outdated = append(outdated, m)
+		}
+	}
+	return outdated, upToDate
+}
+
+// keepDeprecated restricts modules to those whose current version is
+// retracted or whose module is deprecated (--show-deprecated). The rest are
+// counted as filtered.
+func keepDeprecated(modules []scanner.Module, skipped *scanner.SkipStats) []scanner.Module {
+	out := make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		if m.Retracted != "" || m.Deprecated != "" {
+			out = append(out, m)
+		} else {
+			skipped.Add(scanner.SkipFiltered)
+		}
+	}
+	return out
+}
+
+// warnDeprecated reports the retraction rationale and deprecation message
+// of each module, which the tags in text output leave out.
+func warnDeprecated(modules []scanner.Module, w *warnings) {
+	for _, m := range modules {
+		if m.Retracted != "" {
+			w.add(moduleName(m), "%s is retracted: %s", m.Version, m.Retracted)
+		}
+		if m.Deprecated != "" {
+			w.add(moduleName(m), "deprecated: %s", m.Deprecated)
+		}
+	}
+}
+
+// retractedTag marks modules whose current version is retracted in text output.
+func retractedTag() string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("[retracted]")
+}
+
+// deprecatedTag marks deprecated modules in text output.
+func deprecatedTag() string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Italic(true).Render("[deprecated]")
+}
@@ -0,0 +1,243 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/coverage"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/tui"
+	gomodUpdater "github.com/pragmaticivan/faro/internal/updater/gomod"
+	"github.com/pragmaticivan/faro/internal/verify"
+)
+
+// DoctorOptions configures Doctor.
+type DoctorOptions struct {
+	Filter         string // Only try modules matching this pattern
+	All            bool   // Also try transitive dependencies
+	Cooldown       int    // Minimum update age in days
+	CooldownSet    bool   // Cooldown was given explicitly and overrides the configured default
+	GoModPath      string // Path to a go.mod file (or its directory)
+	NoExec         bool   // Read-only mode; doctor is refused
+	Progress       bool   // Show a live status table instead of one line per step
+	AuditLog       string // JSON lines file recording the kept upgrades (overrides audit.file)
+	OverrideFreeze bool   // Try the updates even during a freeze window from .faro.json
+	CoverProfile   string // Go cover profile used to flag kept updates no test exercises
+}
+
+// doctorResult is the outcome of trying one update.
+type doctorResult struct {
+	Module  scanner.Module
+	Problem string // what broke; empty when the update was kept
+}
+
+// Doctor applies each pending Go update in turn, verifies that the project
+// still builds and passes its tests, and reverts the updates that break it.
+// Kept updates stay applied, so later ones are tried on top of them.
+func Doctor(ctx context.Context, opts DoctorOptions, deps Deps) (err error) {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if opts.NoExec {
+		return categorize(ErrorUsage, fmt.Errorf("--no-exec forbids doctor, which changes go.mod and runs builds"))
+	}
+	workDir, pm, err := resolveManager("", detector.Go.String(), opts.GoModPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	if err := applyGlyphs(cfg.Glyphs); err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	opts.Cooldown = cooldownDays(opts.Cooldown, opts.CooldownSet, cfg, pm)
+	usageEvent := startUsage(cfg.Telemetry, "doctor", pm.String(), optionNames(opts), deps)
+	defer func() { usageEvent.finish(ctx, err, deps) }()
+
+	pkgScanner := deps.Scanner
+	if pkgScanner == nil {
+		if pkgScanner, err = factory.CreateScanner(pm, workDir); err != nil {
+			return err
+		}
+	}
+	updaterInstance := deps.Updater
+	if updaterInstance == nil {
+		if updaterInstance, err = factory.CreateUpdater(pm, workDir); err != nil {
+			return err
+		}
+	}
+	verifier := verify.New(workDir, verify.Options{Command: cfg.Doctor.Command, ScopeTests: cfg.Doctor.ScopeTests})
+	check := func(ctx context.Context, module string, report tui.Reporter) error {
+		if deps.Verify != nil {
+			return deps.Verify(ctx, module)
+		}
+		return verifier.Verify(ctx, module, func(s verify.Stage) {
+			if s == verify.StageBuild {
+				report(module, tui.StepBuilding, "")
+			} else {
+				report(module, tui.StepTesting, "")
+			}
+		})
+	}
+
+	_, _ = fmt.Fprintln(deps.Out, "Checking for updates...")
+	modules, err := pkgScanner.GetUpdates(ctx, scanner.Options{Filter: opts.Filter, IncludeAll: opts.All, CooldownDays: opts.Cooldown, WorkDir: workDir})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+	usageEvent.scanned(modules)
+	var warns warnings
+	modules = updateFilter{
+		cooldown: opts.Cooldown,
+		channels: registryChannels(pm, cfg, deps),
+	}.apply(ctx, modules, cfg, pm, deps.Now(), nil, &warns)
+	printWarnings(deps.Out, warns.items)
+	direct, indirect, transitive := groupModules(modules)
+	candidates := append(append([]scanner.Module{}, direct...), indirect...)
+	if opts.All {
+		candidates = append(candidates, transitive...)
+	}
+	candidates, heldBack := splitCritical(candidates)
+	if len(heldBack) > 0 {
+		printHeldBack(deps.Out, heldBack)
+	}
+	if len(candidates) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "No updates to try.")
+		return nil
+	}
+	if reason, frozen := cfg.Freeze.Active(deps.Now()); frozen && !opts.OverrideFreeze {
+		_, _ = fmt.Fprintf(deps.Out, "Upgrades are frozen (%s); not trying %d %s:\n", reason, len(candidates), plural(len(candidates), "update", "updates"))
+		for _, m := range candidates {
+			_, _ = fmt.Fprintf(deps.Out, "  %s %s %s %s\n", moduleName(m), m.Version, style.Glyphs.Arrow, m.Update.Version)
+		}
+		_, _ = fmt.Fprintln(deps.Out, "Pass --override-freeze to try them anyway.")
+		return nil
+	}
+
+	// A project that is already broken would blame every update.
+	_, _ = fmt.Fprintln(deps.Out, "Verifying the project before upgrading...")
+	baseline := func(ctx context.Context) error {
+		if deps.Verify != nil {
+			return deps.Verify(ctx, "")
+		}
+		return verify.New(workDir, verify.Options{Command: cfg.Doctor.Command}).Verify(ctx, "", nil)
+	}
+	if err := baseline(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorUpdate, fmt.Errorf("the project fails verification before any upgrade: %w", err))
+	}
+
+	auditLog := startAudit(cfg.Audit, opts.AuditLog, workDir, "doctor", pm)
+	names := make([]string, len(candidates))
+	for i, m := range candidates {
+		names[i] = moduleName(m)
+	}
+	var results []doctorResult
+	work := func(ctx context.Context, report tui.Reporter) error {
+		for _, m := range candidates {
+			name := moduleName(m)
+			snap, err := gomodUpdater.TakeSnapshot(workDir)
+			if err != nil {
+				return err
+			}
+			report(name, tui.StepUpgrading, m.Update.Version)
+			problem, err := tryVersion(ctx, updaterInstance, func(ctx context.Context, module string) error {
+				return check(ctx, module, report)
+			}, name, m.Version, m.Update.Version)
+			if err != nil {
+				return errors.Join(err, snap.Restore())
+			}
+			if problem != "" {
+				if err := snap.Restore(); err != nil {
+					report(name, tui.StepFailed, "revert failed")
+					return fmt.Errorf("failed to revert %s: %w", name, err)
+				}
+				report(name, tui.StepReverted, problem)
+			} else {
+				report(name, tui.StepOK, "")
+			}
+			results = append(results, doctorResult{Module: m, Problem: problem})
+		}
+		return nil
+	}
+	if opts.Progress {
+		err = tui.RunProgress(ctx, "Upgrading, building and testing each update", names, work)
+	} else {
+		err = work(ctx, tui.PlainReporter(deps.Out))
+	}
+
+	var kept []scanner.Module
+	for _, r := range results {
+		if r.Problem == "" {
+			kept = append(kept, r.Module)
+		}
+	}
+	if auditErr := auditLog.finish(ctx, kept, err, deps); auditErr != nil {
+		err = errors.Join(err, auditErr)
+	}
+	printDoctorReport(deps.Out, results, len(candidates))
+	// Passing tests only vouch for kept updates whose importing code they run.
+	if opts.CoverProfile != "" && len(kept) > 0 {
+		list := deps.ListGoFiles
+		if list == nil {
+			list = coverage.ListFiles
+		}
+		var coverWarns warnings
+		checkCoverage(ctx, kept, workDir, opts.CoverProfile, list, &coverWarns)
+		printWarnings(deps.Out, coverWarns.items)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorUpdate, err)
+	}
+	return nil
+}
+
+// printDoctorReport lists the kept and reverted updates.
+func printDoctorReport(out io.Writer, results []doctorResult, total int) {
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var safe, breaking []doctorResult
+	maxPathLen := 0
+	for _, r := range results {
+		maxPathLen = max(maxPathLen, len(moduleName(r.Module)))
+		if r.Problem == "" {
+			safe = append(safe, r)
+		} else {
+			breaking = append(breaking, r)
+		}
+	}
+	if len(safe) > 0 {
+		_, _ = fmt.Fprintf(out, "\n%s\n", green.Render(fmt.Sprintf("Safe upgrades (%d), kept:", len(safe))))
+		for _, r := range safe {
+			_, _ = fmt.Fprintf(out, " %s\n", style.FormatUpdate(moduleName(r.Module), r.Module.Version, r.Module.Update.Version, maxPathLen))
+		}
+	}
+	if len(breaking) > 0 {
+		_, _ = fmt.Fprintf(out, "\n%s\n", red.Render(fmt.Sprintf("Breaking upgrades (%d), reverted:", len(breaking))))
+		for _, r := range breaking {
+			_, _ = fmt.Fprintf(out, " %s  %s\n", style.FormatUpdate(moduleName(r.Module), r.Module.Version, r.Module.Update.Version, maxPathLen), dim.Render(r.Problem))
+		}
+	}
+	if untried := total - len(results); untried > 0 {
+		_, _ = fmt.Fprintf(out, "\n%d %s not tried.\n", untried, plural(untried, "update", "updates"))
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/drift"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// DriftOptions configures Drift.
+type DriftOptions struct {
+	Modules []string // Module directories; defaults to go.work, then monorepo.modules in .faro.json
+	JSON    bool     // Write the matrix as JSON
+}
+
+// Drift reports dependencies shared by the modules of a monorepo that are
+// required at different versions.
+func Drift(ctx context.Context, opts DriftOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	dirs, err := monorepoModules(workDir, opts.Modules)
+	if err != nil {
+		return err
+	}
+	members, err := drift.Load(workDir, dirs)
+	if err != nil {
+		return err
+	}
+	m := drift.Build(members)
+
+	if opts.JSON {
+		enc := json.NewEncoder(deps.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(m); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return nil
+	}
+	printDriftMatrix(deps, m)
+	return nil
+}
+
+// monorepoModules returns explicit, or the go.work modules in workDir, or
+// the modules listed under monorepo.modules in .faro.json.
+func monorepoModules(workDir string, explicit []string) ([]string, error) {
+	dirs := explicit
+	if len(dirs) == 0 {
+		uses, err := drift.WorkspaceModules(workDir)
+		if err != nil {
+			return nil, err
+		}
+		dirs = uses
+	}
+	if len(dirs) == 0 {
+		cfg, err := config.Load(workDir)
+		if err != nil {
+			return nil, err
+		}
+		dirs = cfg.Monorepo.Modules
+	}
+	if len(dirs) < 2 {
+		return nil, fmt.Errorf("at least two modules are needed; use go.work, list them under monorepo.modules in %s, or pass --module", config.FileName)
+	}
+	return dirs, nil
+}
+
+// printDriftMatrix prints one row per drifting dependency and one column per
+// module. Versions behind the highest are colored by how far behind they are.
+func printDriftMatrix(deps Deps, m drift.Matrix) {
+	if len(m.Rows) == 0 {
+		_, _ = fmt.Fprintf(deps.Out, "No version drift across %d modules.\n", len(m.Modules))
+		return
+	}
+
+	pathWidth := len("DEPENDENCY")
+	for _, r := range m.Rows {
+		pathWidth = max(pathWidth, len(r.Path))
+	}
+	widths := make([]int, len(m.Modules))
+	for i, dir := range m.Modules {
+		widths[i] = len(dir)
+		for _, r := range m.Rows {
+			widths[i] = max(widths[i], len(r.Versions[dir]))
+		}
+	}
+
+	header := []string{fmt.Sprintf("%-*s", pathWidth, "DEPENDENCY")}
+	for i, dir := range m.Modules {
+		header = append(header, fmt.Sprintf("%-*s", widths[i], dir))
+	}
+	_, _ = fmt.Fprintf(deps.Out, "Dependency drift across %d modules:\n\n", len(m.Modules))
+	_, _ = fmt.Fprintln(deps.Out, " "+strings.Join(header, "  "))
+
+	for _, r := range m.Rows {
+		cells := []string{style.ColorPath.Render(fmt.Sprintf("%-*s", pathWidth, r.Path))}
+		for i, dir := range m.Modules {
+			v, ok := r.Versions[dir]
+			cell := fmt.Sprintf("%-*s", widths[i], v)
+			switch {
+			case !ok:
+				cell = style.ColorArrow.Render(fmt.Sprintf("%-*s", widths[i], "-"))
+			case v == r.Highest:
+				cell = style.ColorReset.Render(cell)
+			default:
+				cell = style.GetVersionStyle(style.GetDiffType(v, r.Highest)).Render(cell)
+			}
+			cells = append(cells, cell)
+		}
+		_, _ = fmt.Fprintln(deps.Out, " "+strings.Join(cells, "  "))
+	}
+	_, _ = fmt.Fprintf(deps.Out, "\n%d shared %s required at different versions.\n",
+		len(m.Rows), plural(len(m.Rows), "dependency", "dependencies"))
+}
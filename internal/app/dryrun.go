@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pragmaticivan/faro/internal/audit"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// dryRunUpgrade prints the diff upgrading modules would make to the
+// project's manifests, computed by u on a temporary copy. For Go projects the
+// diff includes the files the regeneration steps (Bazel, Nix) would rewrite.
+func dryRunUpgrade(ctx context.Context, pm detector.PackageManager, workDir string, u updater.Updater, modules []scanner.Module, steps []regenStep, run CommandRunner, out io.Writer) error {
+	runner, ok := u.(updater.DryRunner)
+	if !ok {
+		return categorize(ErrorUsage, fmt.Errorf("--dry-run is not supported for %s", pm))
+	}
+	_, _ = fmt.Fprintf(out, "\nPlanning %d upgrades (dry run)...\n", len(modules))
+	after, err := runner.DryRunPackages(ctx, modules)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorUpdate, fmt.Errorf("dry run failed: %w", err))
+	}
+	if pm == detector.Go {
+		var warns warnings
+		err := dryRunRegen(ctx, workDir, steps, run, after, &warns)
+		printWarnings(out, warns.items)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return categorize(ErrorUpdate, fmt.Errorf("dry run failed: %w", err))
+		}
+	}
+	names := make([]string, 0, len(after))
+	for name := range after {
+		names = append(names, name)
+	}
+
+	diff := audit.Diff(audit.ReadFiles(workDir, names), after)
+	if diff == "" {
+		_, _ = fmt.Fprintln(out, "No manifest changes.")
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "\n%s\nNo files were changed. Run without --dry-run to apply.\n", diff)
+	return nil
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// upgradeEach applies modules one at a time with u, printing each outcome
+// as it happens and a summary of the failures. It returns the modules that
+// were applied and the failed attempts; err is only set when ctx is
+// canceled.
+func upgradeEach(ctx context.Context, u updater.Updater, modules []scanner.Module, out io.Writer) (applied []scanner.Module, failed []updater.Result, err error) {
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	maxPathLen := scanner.MaxPathLength(modules)
+
+	_, err = updater.UpdateEach(ctx, u, modules, func(r updater.Result) {
+		line := style.FormatUpdate(moduleName(r.Module), r.Module.Version, r.Module.Update.Version, maxPathLen)
+		if r.Err != nil {
+			failed = append(failed, r)
+			_, _ = fmt.Fprintf(out, " %s %s\n", red.Render(style.Glyphs.Cross), line)
+			return
+		}
+		applied = append(applied, r.Module)
+		_, _ = fmt.Fprintf(out, " %s %s\n", green.Render(style.Glyphs.Check), line)
+	})
+	printEachSummary(out, len(applied), failed)
+	return applied, failed, err
+}
+
+// printEachSummary reports how many one-by-one upgrades applied and why the
+// others failed.
+func printEachSummary(out io.Writer, applied int, failed []updater.Result) {
+	if len(failed) == 0 {
+		_, _ = fmt.Fprintf(out, "\nUpgraded %d of %d modules.\n", applied, applied)
+		return
+	}
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	_, _ = fmt.Fprintf(out, "\nUpgraded %d of %d modules; %s\n", applied, applied+len(failed), red.Render(fmt.Sprintf("%d failed:", len(failed))))
+	for _, r := range failed {
+		_, _ = fmt.Fprintf(out, " %s@%s: %v\n", moduleName(r.Module), r.Module.Update.Version, r.Err)
+	}
+}
+
+// eachFailed returns the error ending a one-by-one upgrade with failures,
+// or nil when every upgrade applied.
+func eachFailed(failed []updater.Result, total int) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return categorize(ErrorUpdate, fmt.Errorf("%d of %d upgrades failed", len(failed), total))
+}
+
+// onlyApplied returns the modules in modules that are also in applied.
+func onlyApplied(modules, applied []scanner.Module) []scanner.Module {
+	ok := make(map[string]bool, len(applied))
+	for _, m := range applied {
+		ok[moduleName(m)] = true
+	}
+	var out []scanner.Module
+	for _, m := range modules {
+		if ok[moduleName(m)] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
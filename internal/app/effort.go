@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/coverage"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// annotateEffort sets Effort on every module with an update. Go projects
+// also weigh how many files import the module; when they cannot be listed
+// the estimate goes without.
+func annotateEffort(ctx context.Context, modules []scanner.Module, pm detector.PackageManager, workDir string, list GoFileLister, w *warnings) {
+	var importers map[string]coverage.Surface
+	if pm == detector.Go {
+		files, err := list(ctx, workDir)
+		if err != nil {
+			w.add("", "effort estimates ignore import counts: %v", err)
+		} else {
+			names := make([]string, len(modules))
+			for i, m := range modules {
+				names[i] = moduleName(m)
+			}
+			importers = coverage.Surfaces(files, nil, names)
+		}
+	}
+	for i, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		n := -1
+		if importers != nil {
+			n = importers[moduleName(m)].Files
+		}
+		modules[i].Effort = format.EstimateEffort(m, n).String()
+	}
+}
+
+// splitEffort separates updates estimated above limit from the rest.
+func splitEffort(modules []scanner.Module, limit format.Effort) (within, above []scanner.Module) {
+	for _, m := range modules {
+		if e, err := format.ParseEffort(m.Effort); err == nil && e > limit {
+			above = append(above, m)
+		} else {
+			within = append(within, m)
+		}
+	}
+	return within, above
+}
+
+// printHeldBackEffort reports modules splitEffort left out of a bulk upgrade.
+func printHeldBackEffort(out io.Writer, held []scanner.Module, limit format.Effort) {
+	names := make([]string, len(held))
+	for i, m := range held {
+		names[i] = moduleName(m)
+	}
+	orange := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	_, _ = fmt.Fprintln(out, orange.Render(fmt.Sprintf("Held back %d %s above %s effort (%s); upgrade them with -i.",
+		len(held), plural(len(held), "module", "modules"), limit, strings.Join(names, ", "))))
+}
+
+// effortColumn renders an effort label, highlighting the larger ones.
+func effortColumn(effort string) string {
+	color := "240"
+	switch effort {
+	case format.EffortMedium.String():
+		color = "214"
+	case format.EffortLarge.String():
+		color = "196"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("effort: " + effort)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pragmaticivan/faro/internal/changelog"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+// enrichConcurrency bounds the network requests all enrichers of a run have
+// in flight together.
+const enrichConcurrency = 8
+
+// enricher annotates modules with one kind of data. Enrichers run at the
+// same time, each on its own copy of the modules, so they may read whole
+// modules while others write; merge copies the fields run sets back.
+type enricher struct {
+	run   func(ctx context.Context, modules []scanner.Module, w *warnings)
+	merge func(dst *scanner.Module, src scanner.Module)
+}
+
+// enrich runs enrichers concurrently and merges their results into modules.
+// Each gets its own warnings, appended to w in enricher order so the output
+// does not depend on timing.
+func enrich(ctx context.Context, modules []scanner.Module, enrichers []enricher, w *warnings) {
+	found := make([]warnings, len(enrichers))
+	copies := make([][]scanner.Module, len(enrichers))
+	var wg sync.WaitGroup
+	for i, e := range enrichers {
+		copies[i] = slices.Clone(modules)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.run(ctx, copies[i], &found[i])
+		}()
+	}
+	wg.Wait()
+	for i, e := range enrichers {
+		for j := range modules {
+			e.merge(&modules[j], copies[i][j])
+		}
+		w.items = append(w.items, found[i].items...)
+		w.failed += found[i].failed
+	}
+}
+
+// requestLimit is a semaphore shared by the enrichers' clients, so running
+// them side by side does not multiply the load on the services they call.
+type requestLimit chan struct{}
+
+func newRequestLimit(n int) requestLimit {
+	return make(requestLimit, n)
+}
+
+func (l requestLimit) acquire(ctx context.Context) error {
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l requestLimit) release() {
+	<-l
+}
+
+// limitedVulnClient makes each lookup wait for a slot in limit.
+type limitedVulnClient struct {
+	vuln.Client
+	limit requestLimit
+}
+
+func (c limitedVulnClient) CheckModule(ctx context.Context, modulePath, version string) (vuln.SeverityCounts, error) {
+	if err := c.limit.acquire(ctx); err != nil {
+		return vuln.SeverityCounts{}, err
+	}
+	defer c.limit.release()
+	return c.Client.CheckModule(ctx, modulePath, version)
+}
+
+// limitedSource makes each release notes request wait for a slot in limit.
+type limitedSource struct {
+	changelog.Source
+	limit requestLimit
+}
+
+func (s limitedSource) Releases(ctx context.Context, modulePath string) ([]changelog.Release, error) {
+	if err := s.limit.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.limit.release()
+	return s.Source.Releases(ctx, modulePath)
+}
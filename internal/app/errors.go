@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/pragmaticivan/faro/internal/format"
+)
+
+// Error categories reported in machine-readable error output.
+const (
+	ErrorUsage    = "usage"    // Invalid flags or flag combinations
+	ErrorDetect   = "detect"   // No usable package manager or go.mod
+	ErrorConfig   = "config"   // Unreadable .faro.json or template
+	ErrorScan     = "scan"     // The package manager scan failed
+	ErrorUpdate   = "update"   // Applying updates failed
+	ErrorCommit   = "commit"   // Committing updates failed
+	ErrorPolicy   = "policy"   // A policy check found violations
+	ErrorAudit    = "audit"    // Recording applied changes failed
+	ErrorCanceled = "canceled" // The run was interrupted
+	ErrorInternal = "internal" // Anything else
+)
+
+// Error attaches a category to a run failure so automation can branch on it.
+type Error struct {
+	Category string
+	Err      error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// categorize wraps err in an *Error unless it already carries a category.
+func categorize(category string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	return &Error{Category: category, Err: err}
+}
+
+// ErrorCategory returns the category of err. Cancellation always reports
+// ErrorCanceled; uncategorized errors report ErrorInternal.
+func ErrorCategory(err error) string {
+	if errors.Is(err, context.Canceled) {
+		return ErrorCanceled
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Category
+	}
+	return ErrorInternal
+}
+
+// WantsJSONErrors reports whether formatFlag selects json or jsonl output, in
+// which case failures should be written with WriteJSONError.
+func WantsJSONErrors(formatFlag string) bool {
+	formats, err := format.ParseFlag(formatFlag)
+	return err == nil && (formats.JSON || formats.JSONL)
+}
+
+type jsonError struct {
+	Error struct {
+		Category string `json:"category"`
+		Message  string `json:"message"`
+	} `json:"error"`
+}
+
+// WriteJSONError writes err as a single-line JSON object, valid in both json
+// and jsonl streams: {"error":{"category":"scan","message":"..."}}.
+func WriteJSONError(out io.Writer, err error) error {
+	var doc jsonError
+	doc.Error.Category = ErrorCategory(err)
+	doc.Error.Message = err.Error()
+	return json.NewEncoder(out).Encode(doc)
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// Exit codes. ExitError and ExitCanceled are always used; the others only
+// with --exit-code. They are stable: scripts may branch on them. When
+// several conditions hold, the highest code wins.
+const (
+	ExitOK            = 0   // Nothing to report
+	ExitError         = 1   // The run failed: bad flags or config, no project, the scan failed
+	ExitUpdates       = 2   // Updates are available
+	ExitVulnerable    = 3   // Current versions have vulnerabilities at or above --vuln-threshold
+	ExitPartialScan   = 4   // Some lookups failed, so the results are incomplete
+	ExitUpgradeFailed = 5   // Applying or committing updates failed
+	ExitCanceled      = 130 // The run was interrupted
+)
+
+// Status is returned by Run with --exit-code when the run succeeded but
+// found a condition scripts may act on. Code is one of the Exit constants.
+type Status struct {
+	Code   int
+	Reason string
+}
+
+func (s *Status) Error() string { return s.Reason }
+
+// ExitCode returns the process exit code for err, as returned by Run. With
+// extended set (--exit-code), failed upgrades and commits exit with
+// ExitUpgradeFailed and a *Status with its Code; otherwise every failure
+// exits with ExitError. Of several joined errors (--recursive), the highest
+// code wins.
+func ExitCode(err error, extended bool) int {
+	code := ExitOK
+	for _, e := range leafErrors(err) {
+		code = max(code, leafExitCode(e, extended))
+	}
+	return code
+}
+
+// IsStatus reports whether err only carries statuses and no failure, so
+// there is no error message to print.
+func IsStatus(err error) bool {
+	leaves := leafErrors(err)
+	for _, e := range leaves {
+		var s *Status
+		if !errors.As(e, &s) {
+			return false
+		}
+	}
+	return len(leaves) > 0
+}
+
+func leafExitCode(err error, extended bool) int {
+	if errors.Is(err, context.Canceled) {
+		return ExitCanceled
+	}
+	var s *Status
+	if errors.As(err, &s) {
+		if extended {
+			return s.Code
+		}
+		return ExitOK
+	}
+	if extended {
+		switch ErrorCategory(err) {
+		case ErrorUpdate, ErrorCommit:
+			return ExitUpgradeFailed
+		}
+	}
+	return ExitError
+}
+
+// leafErrors flattens errors joined with errors.Join.
+func leafErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var out []error
+	for _, e := range joined.Unwrap() {
+		out = append(out, leafErrors(e)...)
+	}
+	return out
+}
+
+// worseStatus returns whichever of a and b has the higher code.
+func worseStatus(a, b *Status) *Status {
+	if a == nil || (b != nil && b.Code > a.Code) {
+		return b
+	}
+	return a
+}
+
+// parseVulnThreshold validates a --vuln-threshold value and returns the
+// number of vulnerabilities at or above it; empty means any severity.
+func parseVulnThreshold(threshold string) (func(scanner.VulnInfo) int, error) {
+	switch strings.ToLower(threshold) {
+	case "", "low":
+		return func(v scanner.VulnInfo) int { return v.Total }, nil
+	case "medium", "moderate":
+		return func(v scanner.VulnInfo) int { return v.Medium + v.High + v.Critical }, nil
+	case "high":
+		return func(v scanner.VulnInfo) int { return v.High + v.Critical }, nil
+	case "critical":
+		return func(v scanner.VulnInfo) int { return v.Critical }, nil
+	}
+	return nil, fmt.Errorf("invalid --vuln-threshold %q (supported: low, medium, high, critical)", threshold)
+}
+
+// countVulnerable returns how many modules have vulnerabilities in their
+// current version counted by atOrAbove; when reachability was analyzed,
+// only reachable ones count.
+func countVulnerable(modules []scanner.Module, atOrAbove func(scanner.VulnInfo) int) int {
+	n := 0
+	for _, m := range modules {
+		current := m.VulnCurrent
+		if m.VulnReachable != nil {
+			current = *m.VulnReachable
+		}
+		if atOrAbove(current) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// runOutcome collects what a run found for the --exit-code status.
+type runOutcome struct {
+	updates    int // Updates reported and not applied
+	vulnerable int // Modules whose current version is at or above the threshold
+	threshold  string
+}
+
+// status returns the most severe condition of o, given the number of
+// lookups that failed, or nil when there is none.
+func (o runOutcome) status(failed int) error {
+	switch {
+	case failed > 0:
+		return &Status{Code: ExitPartialScan, Reason: fmt.Sprintf("%d %s failed; the results are incomplete", failed, plural(failed, "lookup", "lookups"))}
+	case o.vulnerable > 0:
+		level := "any"
+		if o.threshold != "" {
+			level = strings.ToLower(o.threshold) + " or higher"
+		}
+		return &Status{Code: ExitVulnerable, Reason: fmt.Sprintf("%d %s %s vulnerabilities of %s severity", o.vulnerable, plural(o.vulnerable, "module", "modules"), plural(o.vulnerable, "has", "have"), level)}
+	case o.updates > 0:
+		return &Status{Code: ExitUpdates, Reason: fmt.Sprintf("%d %s available", o.updates, plural(o.updates, "update", "updates"))}
+	}
+	return nil
+}
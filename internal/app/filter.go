@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// updateFilter narrows scan results to the updates faro offers: the packages
+// named on the command line, real upgrades, release channels, --target and
+// --as-of, and critical modules. Run reports what it leaves; commands that
+// apply updates themselves use it so that they try the same ones.
+type updateFilter struct {
+	names    []string                                // Only keep these packages; empty keeps all
+	target   string                                  // --target; empty means TargetLatest
+	asOf     time.Time                               // --as-of; zero means now
+	cooldown int                                     // Minimum update age in days
+	channels func() ChannelSource                    // Looks up older versions for the version rules; called only when some apply
+	refine   func([]scanner.Module) []scanner.Module // Optional step before the version rules, such as Run's --pre
+}
+
+// apply filters modules, counting what it drops in skipped and noting
+// anything surprising in w.
+func (f updateFilter) apply(ctx context.Context, modules []scanner.Module, cfg config.Config, pm detector.PackageManager, now time.Time, skipped *scanner.SkipStats, w *warnings) []scanner.Module {
+	modules = keepNamed(modules, f.names, skipped)
+	modules = dropNonUpgrades(modules, w, skipped)
+	if f.refine != nil {
+		modules = f.refine(modules)
+	}
+
+	target := f.target
+	if target == "" {
+		target = TargetLatest
+	}
+	if len(cfg.Channels) > 0 || target != TargetLatest || !f.asOf.IsZero() {
+		var src ChannelSource
+		if f.channels != nil {
+			src = f.channels()
+		}
+		if src == nil {
+			w.add("", "older versions cannot be looked up for %s projects; updates outside release channels or --target are hidden", pm)
+		}
+		modules = applyVersionRules(ctx, modules, versionRules(cfg, target, f.asOf), src, f.cooldown, now, skipped, w)
+	}
+	if !f.asOf.IsZero() {
+		now = f.asOf
+	}
+	return applyCritical(modules, cfg.Critical, f.cooldown, now, skipped, w)
+}
+
+// registryChannels returns the channels lookup for commands without Run's
+// proxy client and lookup cache: deps.Channels, or the registry of pm.
+func registryChannels(pm detector.PackageManager, cfg config.Config, deps Deps) func() ChannelSource {
+	return func() ChannelSource {
+		if deps.Channels != nil {
+			return deps.Channels
+		}
+		return channelSource(pm, cfg.SupplyChain, proxyClient(cfg.GoProxy), nil)
+	}
+}
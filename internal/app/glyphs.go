@@ -0,0 +1,47 @@
+package app
+
+import (
+	"os"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// applyGlyphs activates the glyphs configured in .faro.json. An empty
+// section leaves the set detected at startup in place.
+func applyGlyphs(cfg config.Glyphs) error {
+	set, err := configuredGlyphs(cfg)
+	if err == nil && set != nil {
+		style.Glyphs = *set
+	}
+	return err
+}
+
+// configuredGlyphs returns the glyph set cfg selects, or nil when the
+// section is empty.
+func configuredGlyphs(cfg config.Glyphs) (*style.GlyphSet, error) {
+	if cfg == (config.Glyphs{}) {
+		return nil, nil
+	}
+	base := style.DetectGlyphs(os.Getenv)
+	if cfg.Set != "" && cfg.Set != "auto" {
+		set, err := style.NamedGlyphs(cfg.Set)
+		if err != nil {
+			return nil, err
+		}
+		base = set
+	}
+	set := base.Override(style.GlyphSet{
+		Arrow:      cfg.Arrow,
+		Check:      cfg.Check,
+		Cross:      cfg.Cross,
+		Selected:   cfg.Selected,
+		Unselected: cfg.Unselected,
+		Cursor:     cfg.Cursor,
+		Caret:      cfg.Caret,
+		Warning:    cfg.Warning,
+		Times:      cfg.Times,
+		Ellipsis:   cfg.Ellipsis,
+	})
+	return &set, nil
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// GoModFetcher returns the go.mod file of a module version.
+type GoModFetcher func(ctx context.Context, modulePath, version string) ([]byte, error)
+
+// goCompatConcurrency bounds the number of concurrent proxy requests.
+const goCompatConcurrency = 8
+
+// checkGoCompatibility fetches the go.mod of every update target and warns
+// when its go directive is newer than projectGo. It returns the modules whose
+// targets are known to be incompatible, keyed by module name. When skip is
+// set the warning notes that those updates are left out.
+func checkGoCompatibility(ctx context.Context, modules []scanner.Module, projectGo string, fetch GoModFetcher, skip bool, w *warnings) map[string]string {
+	type result struct {
+		name     string
+		version  string
+		requires string
+		err      error
+	}
+
+	results := make([]result, len(modules))
+	sem := make(chan struct{}, goCompatConcurrency)
+	var wg sync.WaitGroup
+	for i, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, name, version string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = result{name: name, version: version, err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+
+			data, err := fetch(ctx, name, version)
+			results[i] = result{name: name, version: version, err: err}
+			if err == nil {
+				results[i].requires = gomod.ParseGoVersion(string(data))
+			}
+		}(i, moduleName(m), m.Update.Version)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		w.add("", "Go version check interrupted: %v", ctx.Err())
+		return nil
+	}
+
+	incompatible := make(map[string]string)
+	for _, r := range results {
+		switch {
+		case r.name == "":
+			continue
+		case r.err != nil:
+			w.fail(r.name, "Go version check failed for %s: %v", r.version, r.err)
+		case r.requires != "" && gomod.CompareGoVersions(r.requires, projectGo) > 0:
+			incompatible[r.name] = r.requires
+			if skip {
+				w.add(r.name, "%s requires Go %s, you have %s; skipped", r.version, r.requires, projectGo)
+			} else {
+				w.add(r.name, "%s requires Go %s, you have %s", r.version, r.requires, projectGo)
+			}
+		}
+	}
+	return incompatible
+}
+
+// projectGoVersion returns the go directive of the go.mod in workDir, or ""
+// when it cannot be read.
+func projectGoVersion(workDir string) string {
+	data, err := os.ReadFile(filepath.Join(workDir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	return gomod.ParseGoVersion(string(data))
+}
+
+// dropModules returns modules without the entries named in exclude.
+func dropModules(modules []scanner.Module, exclude map[string]string) []scanner.Module {
+	if len(exclude) == 0 {
+		return modules
+	}
+	out := make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		if _, ok := exclude[moduleName(m)]; ok {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/goprivate"
+)
+
+// GoEnvWriter persists a go env setting to the user's go env file.
+type GoEnvWriter func(ctx context.Context, workDir, name, value string) error
+
+// writeGoEnv runs `go env -w name=value`.
+func writeGoEnv(ctx context.Context, workDir, name, value string) error {
+	out, err := execx.Command(ctx, workDir, "go", "env", "-w", name+"="+value).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("go env -w %s failed: %s: %w", name, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// fixGoPrivate looks for private module failures in a failed go list. It
+// returns a hint describing the GOPRIVATE change that would fix them, or,
+// when fix is set, applies the change and reports fixed so the scan can be
+// retried. GONOPROXY and GONOSUMDB are extended too when they are set
+// explicitly, since they then no longer default to GOPRIVATE.
+func fixGoPrivate(ctx context.Context, workDir string, scanErr error, fix bool, deps Deps, w *warnings) (fixed bool, hint string) {
+	modules := goprivate.Detect(scanErr.Error())
+	if len(modules) == 0 {
+		return false, ""
+	}
+
+	read := deps.GoEnv
+	if read == nil {
+		read = readGoEnv
+	}
+	env, err := read(ctx, workDir, "GOPRIVATE", "GONOPROXY", "GONOSUMDB")
+	if err != nil {
+		env = map[string]string{}
+	}
+
+	type change struct{ name, value string }
+	var changes []change
+	for _, name := range []string{"GOPRIVATE", "GONOPROXY", "GONOSUMDB"} {
+		if name != "GOPRIVATE" && env[name] == "" {
+			continue
+		}
+		if value, added := goprivate.Suggest(env[name], modules); len(added) > 0 {
+			changes = append(changes, change{name, value})
+		}
+	}
+
+	if len(changes) == 0 {
+		return false, fmt.Sprintf("%s already match GOPRIVATE but could not be fetched; check credentials for %s in ~/.netrc or GOAUTH",
+			strings.Join(modules, ", "), plural(len(modules), "its host", "their hosts"))
+	}
+
+	if fix {
+		write := deps.WriteGoEnv
+		if write == nil {
+			write = writeGoEnv
+		}
+		for _, c := range changes {
+			if err := write(ctx, workDir, c.name, c.value); err != nil {
+				return false, fmt.Sprintf("could not update the go env file: %v", err)
+			}
+			w.add("", "set %s=%s with go env -w", c.name, c.value)
+		}
+		return true, ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s private (proxy or checksum database lookup failed). Mark %s private with:\n",
+		strings.Join(modules, ", "), plural(len(modules), "looks", "look"), plural(len(modules), "it", "them"))
+	for _, c := range changes {
+		fmt.Fprintf(&b, "  go env -w %s=%s\n", c.name, c.value)
+	}
+	b.WriteString("or rerun with --fix-env.")
+	return false, b.String()
+}
@@ -0,0 +1,274 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+// HotspotsOptions configures Hotspots.
+type HotspotsOptions struct {
+	Modules []string // Module directories; defaults to go.work, then monorepo.modules in .faro.json
+	Deep    bool     // Rank the dependencies of every project below the working directory instead
+	All     bool     // Include indirect dependencies
+	NoVulns bool     // Skip vulnerability lookups; scores ignore vulnerabilities
+	Top     int      // Show only the N highest-scoring dependencies (0 = all)
+	JSON    bool     // Write the ranking as JSON
+}
+
+// Hotspot is a dependency outdated in one or more members of a monorepo,
+// scored by how much upgrading it everywhere would pay off.
+type Hotspot struct {
+	Name            string   `json:"name"`
+	Ecosystem       string   `json:"ecosystem"`
+	Members         []string `json:"members"`         // Members with an update pending, in member order
+	Latest          string   `json:"latest"`          // Highest update offered to any member
+	DaysBehind      int      `json:"daysBehind"`      // Staleness of the most outdated member; -1 when unknown
+	Vulnerabilities int      `json:"vulnerabilities"` // Most known vulnerabilities in a member's current version
+	Score           int      `json:"score"`
+}
+
+// hotspotMember is one project of the set and the updates found in it.
+type hotspotMember struct {
+	dir     string // As shown, relative to the working directory
+	pm      detector.PackageManager
+	modules []scanner.Module
+}
+
+// Hotspots scans every member of a monorepo and ranks the dependencies
+// they have pending updates for by members × days behind × (1 +
+// vulnerabilities), so the upgrades with the most leverage come first.
+// A member that fails to scan is reported without stopping the others.
+func Hotspots(ctx context.Context, opts HotspotsOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	members, err := hotspotMembers(workDir, opts)
+	if err != nil {
+		return err
+	}
+
+	var w warnings
+	clients := make(map[detector.PackageManager]vuln.Client)
+	for i, mem := range members {
+		if !opts.JSON {
+			_, _ = fmt.Fprintf(deps.Out, "Scanning %s...\n", mem.dir)
+		}
+		dir := mem.dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workDir, dir)
+		}
+		pkgScanner := deps.Scanner
+		if pkgScanner == nil {
+			if pkgScanner, err = factory.CreateScanner(mem.pm, dir); err != nil {
+				w.fail(mem.dir, "%v", err)
+				continue
+			}
+		}
+		modules, err := pkgScanner.GetUpdates(ctx, scanner.Options{
+			IncludeAll: opts.All,
+			WorkDir:    dir,
+			OnWarning: func(module, message string) {
+				w.add(module, "%s", message)
+			},
+			OnFailure: func(module, message string) {
+				w.fail(module, "%s", message)
+			},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.fail(mem.dir, "scan failed: %v", err)
+			continue
+		}
+		if !opts.All {
+			modules, _, _ = groupModules(modules)
+		}
+		if !opts.NoVulns && len(modules) > 0 {
+			client := deps.VulnClient
+			if client == nil {
+				if clients[mem.pm] == nil {
+					clients[mem.pm] = newVulnClient(mem.pm, cfg.Vulnerabilities)
+				}
+				client = clients[mem.pm]
+			}
+			var private string
+			if mem.pm == detector.Go {
+				private = os.Getenv("GOPRIVATE")
+			}
+			checkVulnerabilities(ctx, modules, client, private, progress{}, &w)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+		members[i].modules = modules
+	}
+
+	hotspots := rankHotspots(members)
+	if opts.Top > 0 && len(hotspots) > opts.Top {
+		hotspots = hotspots[:opts.Top]
+	}
+	if opts.JSON {
+		dirs := make([]string, len(members))
+		for i, mem := range members {
+			dirs[i] = mem.dir
+		}
+		enc := json.NewEncoder(deps.Out)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(struct {
+			Modules  []string  `json:"modules"`
+			Hotspots []Hotspot `json:"hotspots"`
+			Warnings []Warning `json:"warnings,omitempty"`
+		}{dirs, hotspots, w.items})
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return nil
+	}
+	printHotspots(deps, hotspots, len(members))
+	printWarnings(deps.Out, w.items)
+	return nil
+}
+
+// hotspotMembers returns the projects below workDir with --deep, or else
+// the Go modules of the monorepo.
+func hotspotMembers(workDir string, opts HotspotsOptions) ([]hotspotMember, error) {
+	if opts.Deep {
+		if len(opts.Modules) > 0 {
+			return nil, categorize(ErrorUsage, fmt.Errorf("--deep cannot be combined with --module"))
+		}
+		found, err := detector.FindProjects(workDir)
+		if err != nil {
+			return nil, categorize(ErrorDetect, err)
+		}
+		if len(found) == 0 {
+			return nil, categorize(ErrorDetect, fmt.Errorf("no supported projects found below %s", workDir))
+		}
+		members := make([]hotspotMember, 0, len(found))
+		for _, p := range found {
+			dir := p.Dir
+			if rel, err := filepath.Rel(workDir, p.Dir); err == nil {
+				dir = rel
+			}
+			members = append(members, hotspotMember{dir: dir, pm: p.Managers[0].Manager})
+		}
+		return members, nil
+	}
+	dirs, err := monorepoModules(workDir, opts.Modules)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]hotspotMember, len(dirs))
+	for i, dir := range dirs {
+		members[i] = hotspotMember{dir: dir, pm: detector.Go}
+	}
+	return members, nil
+}
+
+// rankHotspots merges the updates of members by dependency and orders them
+// by score, highest first, then by name. Unknown publish times count as one
+// day behind, so usage and vulnerabilities still rank them.
+func rankHotspots(members []hotspotMember) []Hotspot {
+	byKey := make(map[string]*Hotspot)
+	var order []string
+	for _, mem := range members {
+		for _, m := range mem.modules {
+			if m.Update == nil {
+				continue
+			}
+			key := mem.pm.Ecosystem() + "\x00" + moduleName(m)
+			h := byKey[key]
+			if h == nil {
+				h = &Hotspot{Name: moduleName(m), Ecosystem: mem.pm.Ecosystem(), DaysBehind: -1}
+				byKey[key] = h
+				order = append(order, key)
+			}
+			h.Members = append(h.Members, mem.dir)
+			if c, ok := style.ComparePrecedence(m.Update.Version, h.Latest); h.Latest == "" || (ok && c > 0) {
+				h.Latest = m.Update.Version
+			}
+			h.DaysBehind = max(h.DaysBehind, daysBehind(m))
+			h.Vulnerabilities = max(h.Vulnerabilities, m.VulnCurrent.Total)
+		}
+	}
+
+	out := make([]Hotspot, 0, len(order))
+	for _, key := range order {
+		h := byKey[key]
+		h.Score = len(h.Members) * max(h.DaysBehind, 1) * (1 + h.Vulnerabilities)
+		out = append(out, *h)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// daysBehind returns how many days older m's current version is than its
+// update, or -1 when either publish time is unknown.
+func daysBehind(m scanner.Module) int {
+	current, ok1 := format.ParseRFC3339ish(m.Time)
+	latest, ok2 := format.ParseRFC3339ish(m.Update.Time)
+	if !ok1 || !ok2 {
+		return -1
+	}
+	return max(int(latest.Sub(current).Hours()/24), 0)
+}
+
+// printHotspots prints the ranking as a table.
+func printHotspots(deps Deps, hotspots []Hotspot, members int) {
+	if len(hotspots) == 0 {
+		_, _ = fmt.Fprintf(deps.Out, "\nNo outdated dependencies across %d %s.\n", members, plural(members, "module", "modules"))
+		return
+	}
+	nameWidth := len("DEPENDENCY")
+	for _, h := range hotspots {
+		nameWidth = max(nameWidth, len(h.Name))
+	}
+	bold := lipgloss.NewStyle().Bold(true)
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	_, _ = fmt.Fprintf(deps.Out, "\nDependency hotspots across %d %s:\n\n", members, plural(members, "module", "modules"))
+	_, _ = fmt.Fprintf(deps.Out, " %-*s  %7s  %6s  %5s  %8s  %s\n", nameWidth, "DEPENDENCY", "MODULES", "BEHIND", "VULNS", "SCORE", "LATEST")
+	for _, h := range hotspots {
+		behind := "?"
+		if h.DaysBehind >= 0 {
+			behind = strconv.Itoa(h.DaysBehind) + "d"
+		}
+		vulns := dim.Render(fmt.Sprintf("%5d", h.Vulnerabilities))
+		if h.Vulnerabilities > 0 {
+			vulns = red.Render(fmt.Sprintf("%5d", h.Vulnerabilities))
+		}
+		_, _ = fmt.Fprintf(deps.Out, " %s  %7d  %6s  %s  %s  %s\n",
+			style.ColorPath.Render(fmt.Sprintf("%-*s", nameWidth, h.Name)),
+			len(h.Members), behind, vulns, bold.Render(fmt.Sprintf("%8d", h.Score)), h.Latest)
+	}
+	times := style.Glyphs.Times
+	_, _ = fmt.Fprintln(deps.Out, dim.Render(fmt.Sprintf("\nScore: modules %s days behind %s (1 + vulnerabilities).", times, times)))
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/updater"
+	"github.com/pragmaticivan/faro/internal/verify"
+)
+
+// Outcomes of an indirect Go upgrade once go mod tidy has run.
+const (
+	indirectKept     = "kept"     // go.mod requires the new version as // indirect
+	indirectReverted = "reverted" // go mod tidy dropped or lowered the requirement
+	indirectPinned   = "pinned"   // re-required with a comment after tidy reverted it
+)
+
+// indirectResult is what happened to one indirect or transitive upgrade.
+type indirectResult struct {
+	Module  scanner.Module
+	Outcome string
+	Now     string // version go.mod requires after the upgrade; empty when none
+}
+
+// settleIndirect checks Go upgrades of modules the project does not import
+// directly. Nothing in the main module names them, so a bump is only proven
+// by building the packages that reach them through a direct dependency; a
+// failure is returned as an error. It then reports whether go mod tidy kept
+// each bump and, with pin, re-requires the reverted ones.
+func settleIndirect(ctx context.Context, workDir string, modules []scanner.Module, pin bool, u updater.Updater, deps Deps) ([]indirectResult, error) {
+	build := deps.Verify
+	if build == nil {
+		v := verify.New(workDir, verify.Options{})
+		build = v.Build
+	}
+	for _, m := range modules {
+		if err := build(ctx, moduleName(m)); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("upgrading indirect module %s to %s breaks the packages that use it: %w", moduleName(m), m.Update.Version, err)
+		}
+	}
+
+	requires, err := gomod.ReadRequires(filepath.Join(workDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	required := make(map[string]string, len(requires))
+	for _, r := range requires {
+		required[r.Path] = r.Version
+	}
+	results := make([]indirectResult, 0, len(modules))
+	var reverted []scanner.Module
+	for _, m := range modules {
+		r := indirectResult{Module: m, Outcome: indirectKept, Now: required[moduleName(m)]}
+		if c, ok := style.ComparePrecedence(r.Now, m.Update.Version); r.Now == "" || (ok && c < 0) {
+			r.Outcome = indirectReverted
+			reverted = append(reverted, m)
+		}
+		results = append(results, r)
+	}
+
+	pinner, ok := u.(updater.Pinner)
+	if !pin || len(reverted) == 0 || !ok {
+		return results, nil
+	}
+	if err := pinner.PinPackages(ctx, reverted); err != nil {
+		return results, fmt.Errorf("failed to pin indirect modules: %w", err)
+	}
+	for i := range results {
+		if results[i].Outcome == indirectReverted {
+			results[i].Outcome, results[i].Now = indirectPinned, results[i].Module.Update.Version
+		}
+	}
+	return results, nil
+}
+
+// printIndirectResults explains what happened to indirect upgrades.
+func printIndirectResults(out io.Writer, results []indirectResult, pin bool) {
+	if len(results) == 0 {
+		return
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	orange := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	maxPathLen := 0
+	for _, r := range results {
+		maxPathLen = max(maxPathLen, len(moduleName(r.Module)))
+	}
+
+	_, _ = fmt.Fprintf(out, "\n%s\n", orange.Render("Indirect upgrades:"))
+	reverted := 0
+	for _, r := range results {
+		var note string
+		switch r.Outcome {
+		case indirectKept:
+			note = "required as // indirect"
+		case indirectPinned:
+			note = "pinned with a comment after go mod tidy dropped it"
+		default:
+			reverted++
+			note = "reverted by go mod tidy"
+			if r.Now != "" {
+				note += " (back to " + r.Now + ")"
+			}
+		}
+		line := style.FormatUpdate(moduleName(r.Module), r.Module.Version, r.Module.Update.Version, maxPathLen)
+		_, _ = fmt.Fprintf(out, " %s  %s\n", line, dim.Render(note))
+	}
+	_, _ = fmt.Fprintln(out, dim.Render("Indirect requirements only hold while go.mod lists them; a later go mod tidy may drop or lower them when no dependency needs that version."))
+	if reverted > 0 && !pin {
+		_, _ = fmt.Fprintln(out, dim.Render("Run with --pin-indirect to keep reverted upgrades as commented requirements."))
+	}
+}
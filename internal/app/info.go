@@ -0,0 +1,410 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/goprivate"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+// LicenseLookup returns the SPDX identifier (or name) of a module's license.
+type LicenseLookup func(ctx context.Context, modulePath string) (string, error)
+
+// errNoLicenseHost marks modules whose license faro cannot look up.
+var errNoLicenseHost = errors.New("licenses are only looked up for GitHub-hosted modules")
+
+const (
+	// infoDatedVersions is how many of the newest versions Info looks up
+	// publish times for.
+	infoDatedVersions = 20
+	// infoShownVersions is how many versions text output lists.
+	infoShownVersions = 10
+	// infoCadenceReleases is how many recent releases the cadence is
+	// measured over.
+	infoCadenceReleases = 10
+)
+
+// InfoOptions configures Info.
+type InfoOptions struct {
+	Module    string // Module path to describe
+	GoModPath string // Optional go.mod path; defaults to the working directory
+	JSON      bool   // Write the result as JSON
+}
+
+// ModuleInfo is everything faro knows about one module.
+type ModuleInfo struct {
+	Module      string `json:"module"`
+	Current     string `json:"current,omitempty"` // Version required in go.mod; empty outside the project
+	CurrentTime string `json:"currentTime,omitempty"`
+	Latest      string `json:"latest"`
+	LatestTime  string `json:"latestTime,omitempty"`
+	// Diff is the semver change from Current to Latest: "major", "minor",
+	// "patch" or "unknown"; empty when Current is the latest or unknown.
+	Diff     string        `json:"diff,omitempty"`
+	Versions []VersionInfo `json:"versions"` // Newest first
+
+	VulnCurrent *scanner.VulnInfo `json:"vulnCurrent,omitempty"`
+	VulnLatest  *scanner.VulnInfo `json:"vulnLatest,omitempty"`
+
+	Deprecated string `json:"deprecated,omitempty"` // Deprecation message of the latest go.mod
+	Retracted  string `json:"retracted,omitempty"`  // Retraction rationale of Current
+	License    string `json:"license,omitempty"`
+	// CadenceDays is the median number of days between recent releases.
+	CadenceDays int `json:"cadenceDays,omitempty"`
+
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// VersionInfo is one published version of a module.
+type VersionInfo struct {
+	Version   string `json:"version"`
+	Time      string `json:"time,omitempty"` // Only looked up for the newest versions
+	Retracted bool   `json:"retracted,omitempty"`
+}
+
+// Info prints what faro knows about opts.Module: the required and latest
+// versions, recent releases with their publish times, vulnerabilities,
+// deprecation, retraction, license and release cadence. It works outside a
+// Go project too, without the required version.
+func Info(ctx context.Context, opts InfoOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if deps.Now == nil {
+		deps.Now = time.Now
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if opts.GoModPath != "" {
+		if workDir, err = resolveGoModDir(opts.GoModPath); err != nil {
+			return err
+		}
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+
+	info := ModuleInfo{Module: opts.Module}
+	var warns warnings
+	if requires, err := gomod.ReadRequires(filepath.Join(workDir, "go.mod")); err == nil {
+		for _, r := range requires {
+			if r.Path == opts.Module {
+				info.Current = r.Version
+			}
+		}
+	}
+
+	goProxy := proxyClient(cfg.GoProxy)
+	lookups := openLookupCache(cfg.Cache, false, deps.Now)
+	defer func() { _ = lookups.Save() }()
+	src := deps.Channels
+	if src == nil {
+		src = channelSource(detector.Go, cfg.SupplyChain, goProxy, lookups)
+	}
+	versions, err := src.Versions(ctx, opts.Module)
+	if err != nil {
+		return fmt.Errorf("failed to list versions of %s: %w", opts.Module, err)
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("%s has no tagged versions", opts.Module)
+	}
+	sort.SliceStable(versions, func(a, b int) bool {
+		cmp, ok := style.ComparePrecedence(versions[a], versions[b])
+		return ok && cmp > 0
+	})
+	info.Latest = latestVersion(versions, info.Current)
+
+	// Like go get, retractions come from the go.mod of the highest version
+	// and the latest version skips the versions it retracts.
+	fetch := deps.FetchGoMod
+	if fetch == nil {
+		fetch = goProxy.GoMod
+	}
+	var retractions []gomod.Retraction
+	if data, err := fetch(ctx, opts.Module, info.Latest); err != nil {
+		warns.add(opts.Module, "failed to read go.mod of %s: %v", info.Latest, err)
+	} else {
+		contents := string(data)
+		info.Deprecated = gomod.ParseDeprecation(contents)
+		retractions = gomod.ParseRetractions(contents)
+		if r := gomod.RetractedBy(retractions, info.Current); r != nil {
+			info.Retracted = r.Rationale
+			if info.Retracted == "" {
+				info.Retracted = "retracted by module author"
+			}
+		}
+	}
+	kept := slices.DeleteFunc(slices.Clone(versions), func(v string) bool {
+		return gomod.RetractedBy(retractions, v) != nil
+	})
+	if len(kept) > 0 {
+		info.Latest = latestVersion(kept, info.Current)
+	}
+	if info.Current != "" && info.Current != info.Latest {
+		if cmp, ok := style.ComparePrecedence(info.Latest, info.Current); ok && cmp > 0 {
+			info.Diff = format.GroupForModule(scanner.Module{Version: info.Current, Update: &scanner.UpdateInfo{Version: info.Latest}}).String()
+		}
+	}
+
+	info.Versions = make([]VersionInfo, len(versions))
+	for i, v := range versions {
+		info.Versions[i] = VersionInfo{Version: v, Retracted: gomod.RetractedBy(retractions, v) != nil}
+	}
+	times := publishTimes(ctx, src, opts.Module, infoTimeVersions(versions, info.Current), &warns)
+	for i := range info.Versions {
+		info.Versions[i].Time = times[info.Versions[i].Version]
+	}
+	info.CurrentTime, info.LatestTime = times[info.Current], times[info.Latest]
+	info.CadenceDays = releaseCadence(info.Versions)
+
+	vulnClient := deps.VulnClient
+	if vulnClient == nil {
+		vulnClient = cachedVulnClient(detector.Go, cfg.Vulnerabilities, lookups)
+	}
+	if goprivate.Covered(opts.Module, os.Getenv("GOPRIVATE")) {
+		warns.add(opts.Module, "skipped vulnerability checks for a private module (GOPRIVATE)")
+	} else {
+		m := scanner.Module{Name: opts.Module, Version: info.Current, Update: &scanner.UpdateInfo{Version: info.Latest}}
+		if m.Version == "" {
+			m.Version = info.Latest
+		}
+		modules := []scanner.Module{m}
+		failures, err := vuln.AnnotateModules(ctx, modules, vuln.Options{Client: vulnClient})
+		if err != nil {
+			return err
+		}
+		for _, f := range failures {
+			warns.add(f.Module, "vulnerability check failed for %s: %v", f.Version, f.Err)
+		}
+		if len(failures) == 0 {
+			info.VulnLatest = &modules[0].VulnUpdate
+			if info.Current != "" {
+				info.VulnCurrent = &modules[0].VulnCurrent
+			}
+		}
+	}
+
+	license := deps.License
+	if license == nil {
+		gh := lazyGitHubClient{cfg: cfg.GitHub}
+		var repos lazyRepoResolver
+		defer repos.save()
+		license = githubLicense(&gh, &repos)
+	}
+	if info.License, err = license(ctx, opts.Module); err != nil && !errors.Is(err, errNoLicenseHost) {
+		warns.add(opts.Module, "license lookup failed: %v", err)
+	}
+
+	info.Warnings = warns.items
+	if opts.JSON {
+		enc := json.NewEncoder(deps.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(info); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return nil
+	}
+	printModuleInfo(deps, info)
+	return nil
+}
+
+// latestVersion returns the version `go get module@latest` picks from
+// versions, sorted newest first: the highest release, or the highest
+// prerelease when there is none. +incompatible versions are skipped unless
+// current is one.
+func latestVersion(versions []string, current string) string {
+	incompatible := strings.HasSuffix(current, "+incompatible")
+	var pre string
+	for _, v := range versions {
+		if strings.HasSuffix(v, "+incompatible") && !incompatible {
+			continue
+		}
+		if !strings.Contains(strings.TrimSuffix(v, "+incompatible"), "-") {
+			return v
+		}
+		if pre == "" {
+			pre = v
+		}
+	}
+	if pre == "" {
+		return versions[0]
+	}
+	return pre
+}
+
+// infoTimeVersions returns the versions Info looks up publish times for:
+// the newest ones and current.
+func infoTimeVersions(versions []string, current string) []string {
+	dated := append([]string{}, versions[:min(len(versions), infoDatedVersions)]...)
+	if current != "" && !slices.Contains(dated, current) {
+		dated = append(dated, current)
+	}
+	return dated
+}
+
+// publishTimes looks up when each of versions was published. Versions
+// whose time cannot be found are left out and counted in one warning.
+func publishTimes(ctx context.Context, src ChannelSource, module string, versions []string, w *warnings) map[string]string {
+	times := make(map[string]string, len(versions))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, goCompatConcurrency)
+	missing := 0
+	for _, v := range versions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			published, err := src.PublishTime(ctx, module, v)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil || published == "" {
+				missing++
+				return
+			}
+			times[v] = published
+		}()
+	}
+	wg.Wait()
+	if missing > 0 {
+		w.add(module, "publish time unavailable for %d %s", missing, plural(missing, "version", "versions"))
+	}
+	return times
+}
+
+// releaseCadence returns the median number of days between the most recent
+// dated releases in versions, or 0 when fewer than two are dated.
+// Prereleases do not count.
+func releaseCadence(versions []VersionInfo) int {
+	var released []time.Time
+	for _, v := range versions {
+		if strings.Contains(strings.TrimSuffix(v.Version, "+incompatible"), "-") {
+			continue
+		}
+		if t, ok := format.ParseRFC3339ish(v.Time); ok {
+			released = append(released, t)
+		}
+	}
+	sort.Slice(released, func(a, b int) bool { return released[a].After(released[b]) })
+	released = released[:min(len(released), infoCadenceReleases+1)]
+	if len(released) < 2 {
+		return 0
+	}
+	gaps := make([]float64, 0, len(released)-1)
+	for i := 1; i < len(released); i++ {
+		gaps = append(gaps, released[i-1].Sub(released[i]).Hours()/24)
+	}
+	sort.Float64s(gaps)
+	median := gaps[len(gaps)/2]
+	if len(gaps)%2 == 0 {
+		median = (gaps[len(gaps)/2-1] + gaps[len(gaps)/2]) / 2
+	}
+	return max(1, int(median+0.5))
+}
+
+// githubLicense looks up licenses in the GitHub repository metadata of
+// modules hosted on GitHub, directly or behind a vanity import path.
+func githubLicense(gh *lazyGitHubClient, repos *lazyRepoResolver) LicenseLookup {
+	return func(ctx context.Context, modulePath string) (string, error) {
+		owner, repo, ok := github.ResolveRepo(ctx, repos.get(), modulePath)
+		if !ok {
+			return "", errNoLicenseHost
+		}
+		r, err := gh.get().Repository(ctx, owner, repo)
+		if err != nil || r.License == nil {
+			return "", err
+		}
+		if r.License.SPDXID != "" && r.License.SPDXID != "NOASSERTION" {
+			return r.License.SPDXID, nil
+		}
+		return r.License.Name, nil
+	}
+}
+
+// printModuleInfo prints info as an aligned list of fields.
+func printModuleInfo(deps Deps, info ModuleInfo) {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	now := deps.Now()
+	var date format.TimeStyle
+	dated := func(version, published string) string {
+		if t := date.Format(published, now); t != "" {
+			return version + "  " + dim.Render(t)
+		}
+		return version
+	}
+	field := func(name, value string) {
+		_, _ = fmt.Fprintf(deps.Out, "  %-11s %s\n", name, value)
+	}
+
+	_, _ = fmt.Fprintln(deps.Out, style.ColorPath.Render(info.Module))
+	if info.Current != "" {
+		current := dated(info.Current, info.CurrentTime)
+		if info.Retracted != "" {
+			current += " " + retractedTag() + " " + info.Retracted
+		}
+		field("current", current)
+	}
+	latest := dated(info.Latest, info.LatestTime)
+	switch {
+	case info.Diff != "":
+		latest += "  " + style.GetVersionStyle(style.GetDiffType(info.Current, info.Latest)).Render(info.Diff+" update")
+	case info.Current == info.Latest:
+		latest += "  " + dim.Render("(up to date)")
+	}
+	field("latest", latest)
+	if info.Deprecated != "" {
+		field("deprecated", deprecatedTag()+" "+info.Deprecated)
+	}
+	if info.VulnLatest != nil {
+		vulns := "none known"
+		if info.VulnCurrent != nil && info.VulnCurrent.Total > 0 {
+			vulns = info.Current + " " + formatVulnCounts(*info.VulnCurrent, *info.VulnLatest)
+		} else if info.VulnLatest.Total > 0 {
+			vulns = info.Latest + " " + style.FormatVulnInfo(*info.VulnLatest)
+		}
+		field("vulns", vulns)
+	}
+	if info.License != "" {
+		field("license", info.License)
+	}
+	if info.CadenceDays > 0 {
+		field("cadence", fmt.Sprintf("a release about every %d %s", info.CadenceDays, plural(info.CadenceDays, "day", "days")))
+	}
+	for i, v := range info.Versions[:min(len(info.Versions), infoShownVersions)] {
+		name := ""
+		if i == 0 {
+			name = "versions"
+		}
+		line := dated(v.Version, v.Time)
+		if v.Retracted {
+			line += " " + dim.Render("(retracted)")
+		}
+		field(name, line)
+	}
+	if older := len(info.Versions) - infoShownVersions; older > 0 {
+		field("", dim.Render(fmt.Sprintf("and %d older", older)))
+	}
+	printWarnings(deps.Out, info.Warnings)
+}
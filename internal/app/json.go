@@ -0,0 +1,92 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/report"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// jsonReport is the document written by --format json.
+type jsonReport struct {
+	Manager     string              `json:"manager"`
+	Updates     []format.Record     `json:"updates"`
+	MajorPaths  []MajorPathUpdate   `json:"majorPaths,omitempty"`
+	Skipped     *scanner.SkipStats  `json:"skipped,omitempty"`
+	VulnScore   *VulnScore          `json:"vulnScore,omitempty"` // With --vulnerabilities
+	Preview     *updater.Preview    `json:"preview,omitempty"`
+	Warnings    []Warning           `json:"warnings,omitempty"`
+	Environment *report.Environment `json:"environment,omitempty"`
+}
+
+// skippedStats returns s for embedding in a report, or nil when nothing was skipped.
+func skippedStats(s scanner.SkipStats) *scanner.SkipStats {
+	if s.Total() == 0 {
+		return nil
+	}
+	return &s
+}
+
+// buildRecords converts grouped modules into records ordered by category,
+// then by group sort key, then by name. labels holds the direct, indirect,
+// tool and transitive headings.
+func buildRecords(direct, indirect, tools, transitive []scanner.Module, includeAll, withVulns bool, labels [4]string) []format.Record {
+	type category struct {
+		name    string
+		label   string
+		modules []scanner.Module
+	}
+	categories := []category{
+		{format.CategoryDirect, labels[0], direct},
+		{format.CategoryIndirect, labels[1], indirect},
+		{format.CategoryTool, labels[2], tools},
+	}
+	if includeAll {
+		categories = append(categories, category{format.CategoryTransitive, labels[3], transitive})
+	}
+
+	records := make([]format.Record, 0, len(direct)+len(indirect)+len(tools)+len(transitive))
+	for _, c := range categories {
+		start := len(records)
+		for _, m := range c.modules {
+			if m.Update == nil {
+				continue
+			}
+			records = append(records, format.NewRecord(m, c.name, c.label, withVulns))
+		}
+		group := records[start:]
+		sort.SliceStable(group, func(a, b int) bool {
+			if group[a].SortKey != group[b].SortKey {
+				return group[a].SortKey < group[b].SortKey
+			}
+			return group[a].Name < group[b].Name
+		})
+	}
+	return records
+}
+
+// writeJSONReport writes the report as a single indented JSON document.
+func writeJSONReport(out io.Writer, report jsonReport) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return nil
+}
+
+// writeJSONLines writes one JSON record per line.
+func writeJSONLines(out io.Writer, records []format.Record) error {
+	enc := json.NewEncoder(out)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+	}
+	return nil
+}
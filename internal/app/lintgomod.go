@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/modlint"
+)
+
+// LintGoModOptions configures LintGoMod.
+type LintGoModOptions struct {
+	GoModPath string // Path to a go.mod file (or its directory)
+	Offline   bool   // Skip the retraction check, which asks the module proxy
+}
+
+// GoModFacts asks the go command about the module in workDir. Retractions
+// are only looked up when retracted is set.
+type GoModFacts func(ctx context.Context, workDir string, requires []gomod.Require, retracted bool) (modlint.Facts, error)
+
+// LintGoMod reports hygiene problems in go.mod: duplicated requirements,
+// wrong // indirect markers, redundant replace directives, retracted
+// versions in use and a missing toolchain directive. It returns an
+// ErrorPolicy error when there are problems.
+func LintGoMod(ctx context.Context, opts LintGoModOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	workDir, _, err := resolveManager("", detector.Go.String(), opts.GoModPath)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(workDir, "go.mod"))
+	if err != nil {
+		return categorize(ErrorConfig, fmt.Errorf("failed to read go.mod: %w", err))
+	}
+
+	gather := deps.GoModFacts
+	if gather == nil {
+		gather = goModFacts
+	}
+	_, _ = fmt.Fprintln(deps.Out, "Checking go.mod...")
+	facts, err := gather(ctx, workDir, gomod.ParseRequires(string(data)), !opts.Offline)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+
+	issues := modlint.Check(string(data), facts)
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "\nNo go.mod problems found.")
+		return nil
+	}
+
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	width := 0
+	for _, issue := range issues {
+		width = max(width, len(issue.Module))
+	}
+	_, _ = fmt.Fprintf(deps.Out, "\n%s\n", red.Render("go.mod problems:"))
+	for _, issue := range issues {
+		module := issue.Module
+		if module == "" {
+			module = "go.mod"
+		}
+		_, _ = fmt.Fprintf(deps.Out, " %-*s  %s  %s\n", width, module, issue.Message, dim.Render(string(issue.Kind)))
+	}
+	return categorize(ErrorPolicy, fmt.Errorf("%d go.mod %s", len(issues), plural(len(issues), "problem", "problems")))
+}
+
+// goModFacts gathers the main module's imports, then runs go list for the
+// build list and, when retracted is set, the retractions of the required
+// versions.
+func goModFacts(ctx context.Context, workDir string, requires []gomod.Require, retracted bool) (modlint.Facts, error) {
+	facts := modlint.Facts{
+		Imported:  make(map[string]bool),
+		BuildList: make(map[string]string),
+	}
+
+	imports, err := moduleImports(workDir)
+	if err != nil {
+		return facts, fmt.Errorf("failed to list imports: %w", err)
+	}
+	// Imports map to the required module with the longest matching path,
+	// the same rule that assigns tool packages to modules.
+	for module := range gomod.ToolModules(imports, requires) {
+		facts.Imported[module] = true
+	}
+
+	out, err := execx.Command(ctx, workDir, "go", "list", "-m", "-f", "{{.Path}} {{.Version}}", "all").Output()
+	if err != nil {
+		return facts, fmt.Errorf("failed to list the build list: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, version, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+			facts.BuildList[path] = version
+		}
+	}
+
+	if !retracted || len(requires) == 0 {
+		return facts, nil
+	}
+	facts.Retracted = make(map[string]string)
+	paths := make([]string, 0, len(requires))
+	for _, r := range requires {
+		paths = append(paths, r.Path)
+	}
+	args := append([]string{"list", "-m", "-retracted", "-f", `{{.Path}}{{with .Retracted}}{{"\t"}}{{join . "; "}}{{end}}`}, paths...)
+	out, err = execx.Command(ctx, workDir, "go", args...).Output()
+	if err != nil {
+		return facts, fmt.Errorf("failed to check retractions: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, rationale, ok := strings.Cut(strings.TrimSpace(line), "\t"); ok {
+			facts.Retracted[path] = rationale
+		}
+	}
+	return facts, nil
+}
+
+// moduleImports returns the imports of every Go file of the module in
+// workDir, tests included, under any build constraint but "ignore": go list
+// only sees the host platform, so a requirement imported only on another
+// GOOS/GOARCH would look unused. Vendored code, testdata, hidden
+// directories and nested modules are not part of the module.
+func moduleImports(workDir string) ([]string, error) {
+	var imports []string
+	err := filepath.WalkDir(workDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == workDir {
+				return nil
+			}
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		imports = append(imports, gomod.AnyTagImports(src)...)
+		return nil
+	})
+	return imports, err
+}
@@ -0,0 +1,296 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/lsp"
+	"github.com/pragmaticivan/faro/internal/version"
+)
+
+// LSPOptions configures ServeLSP.
+type LSPOptions struct {
+	Vulnerabilities bool // Look up vulnerabilities of the current and update versions
+	Cooldown        int  // Minimum update age in days
+	CooldownSet     bool // Cooldown overrides the configured default
+}
+
+// lspServer tracks the open go.mod documents and their last scan results.
+type lspServer struct {
+	conn *lsp.Conn
+	opts LSPOptions
+	deps Deps
+
+	mu       sync.Mutex
+	docs     map[string]string          // Open documents by URI
+	updates  map[string][]format.Record // Last scan of each open document
+	scanning map[string]*uriScans       // Scans of each document, one at a time
+	scans    sync.WaitGroup
+}
+
+// uriScans serializes the scans of one document. gen counts the scans
+// requested so far, so a scan can tell whether a newer one supersedes it.
+type uriScans struct {
+	run sync.Mutex // Held while a scan runs
+	gen int        // Guarded by lspServer.mu
+}
+
+// ServeLSP runs a language server on in and out that publishes a diagnostic
+// on every outdated go.mod requirement of the open documents. Documents are
+// scanned (read-only, as with --format json) when opened and saved; edits in
+// between only move the diagnostics, so typing never starts a scan. ServeLSP
+// returns when the client sends exit or closes in.
+func ServeLSP(ctx context.Context, in io.Reader, out io.Writer, opts LSPOptions, deps Deps) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s := &lspServer{
+		conn:     lsp.NewConn(in, out),
+		opts:     opts,
+		deps:     deps,
+		docs:     make(map[string]string),
+		updates:  make(map[string][]format.Record),
+		scanning: make(map[string]*uriScans),
+	}
+	defer s.scans.Wait()
+
+	for {
+		req, err := s.conn.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch req.Method {
+		case "initialize":
+			err = s.conn.Reply(req.ID, map[string]any{
+				"capabilities": map[string]any{
+					"textDocumentSync": map[string]any{"openClose": true, "change": 1, "save": map[string]any{}},
+				},
+				"serverInfo": map[string]string{"name": "faro", "version": version.Get().Version},
+			})
+		case "shutdown":
+			err = s.conn.Reply(req.ID, nil)
+		case "exit":
+			cancel()
+			return nil
+		case "textDocument/didOpen":
+			var p lsp.DidOpenParams
+			if json.Unmarshal(req.Params, &p) == nil && isGoMod(p.TextDocument.URI) {
+				s.setText(p.TextDocument.URI, p.TextDocument.Text)
+				s.scan(ctx, p.TextDocument.URI)
+			}
+		case "textDocument/didChange":
+			var p lsp.DidChangeParams
+			if json.Unmarshal(req.Params, &p) == nil && len(p.ContentChanges) > 0 && isGoMod(p.TextDocument.URI) {
+				s.setText(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+				err = s.publish(p.TextDocument.URI)
+			}
+		case "textDocument/didSave":
+			var p lsp.DocumentParams
+			if json.Unmarshal(req.Params, &p) == nil && isGoMod(p.TextDocument.URI) {
+				s.scan(ctx, p.TextDocument.URI)
+			}
+		case "textDocument/didClose":
+			var p lsp.DocumentParams
+			if json.Unmarshal(req.Params, &p) == nil && isGoMod(p.TextDocument.URI) {
+				s.mu.Lock()
+				delete(s.docs, p.TextDocument.URI)
+				delete(s.updates, p.TextDocument.URI)
+				s.mu.Unlock()
+				err = s.conn.Notify("textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []lsp.Diagnostic{}})
+			}
+		default:
+			if !req.IsNotification() {
+				err = s.conn.ReplyError(req.ID, lsp.CodeMethodNotFound, "method not supported: "+req.Method)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *lspServer) setText(uri, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs[uri] = text
+}
+
+// scan checks the module of uri in the background and publishes the result.
+// Scans of one document run one after another, and those superseded by a
+// newer scan before they finish are dropped. Scan failures are logged to
+// the client rather than shown as diagnostics.
+func (s *lspServer) scan(ctx context.Context, uri string) {
+	s.mu.Lock()
+	scans := s.scanning[uri]
+	if scans == nil {
+		scans = &uriScans{}
+		s.scanning[uri] = scans
+	}
+	scans.gen++
+	gen := scans.gen
+	s.mu.Unlock()
+	current := func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return scans.gen == gen
+	}
+
+	s.scans.Add(1)
+	go func() {
+		defer s.scans.Done()
+		scans.run.Lock()
+		defer scans.run.Unlock()
+		if !current() {
+			return
+		}
+		records, err := lspScan(ctx, uriPath(uri), s.opts, s.deps)
+		if ctx.Err() != nil || !current() {
+			return
+		}
+		if err != nil {
+			_ = s.conn.Notify("window/logMessage", lsp.LogMessageParams{Type: 1, Message: fmt.Sprintf("faro: %s: %v", uriPath(uri), err)})
+			return
+		}
+		s.mu.Lock()
+		_, open := s.docs[uri]
+		if open {
+			s.updates[uri] = records
+		}
+		s.mu.Unlock()
+		if open {
+			_ = s.publish(uri)
+		}
+	}()
+}
+
+// publish sends the diagnostics of uri's last scan, placed on its current text.
+func (s *lspServer) publish(uri string) error {
+	s.mu.Lock()
+	diags := goModDiagnostics(s.docs[uri], s.updates[uri])
+	s.mu.Unlock()
+	return s.conn.Notify("textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+}
+
+// lspScan runs a read-only JSON scan of the module whose go.mod is at path.
+func lspScan(ctx context.Context, path string, opts LSPOptions, deps Deps) ([]format.Record, error) {
+	var out bytes.Buffer
+	err := Run(ctx, RunOptions{
+		GoModPath:           path,
+		Cooldown:            opts.Cooldown,
+		CooldownSet:         opts.CooldownSet,
+		ShowVulnerabilities: opts.Vulnerabilities,
+		FormatFlag:          "json",
+		NoExec:              true,
+		// Documents are scanned concurrently and JSON output has no glyphs.
+		keepGlyphs: true,
+	}, Deps{
+		Out:        &out,
+		Err:        io.Discard,
+		Now:        deps.Now,
+		Scanner:    deps.Scanner,
+		VulnClient: deps.VulnClient,
+		FetchGoMod: deps.FetchGoMod,
+		GoEnv:      deps.GoEnv,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var report jsonReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		return nil, fmt.Errorf("failed to decode scan result: %w", err)
+	}
+	return report.Updates, nil
+}
+
+// goModDiagnostics places a diagnostic on the module path and version of
+// every require line in text that has an update in records. Updates that
+// fix vulnerabilities are warnings; the rest are informational.
+func goModDiagnostics(text string, records []format.Record) []lsp.Diagnostic {
+	byName := make(map[string]format.Record, len(records))
+	for _, r := range records {
+		byName[r.Name] = r
+	}
+	diags := []lsp.Diagnostic{}
+	inBlock := false
+	for i, raw := range strings.Split(text, "\n") {
+		line := raw
+		if j := strings.Index(line, "//"); j >= 0 {
+			line = line[:j]
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inBlock && trimmed == ")":
+			inBlock = false
+			continue
+		case trimmed == "require (" || trimmed == "require(":
+			inBlock = true
+			continue
+		case strings.HasPrefix(trimmed, "require "):
+			line = line[strings.Index(line, "require ")+len("require "):]
+		case !inBlock:
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		r, ok := byName[fields[0]]
+		if !ok || r.Update == nil {
+			continue
+		}
+		start := strings.Index(raw, fields[0])
+		after := start + len(fields[0])
+		end := after + strings.Index(raw[after:], fields[1]) + len(fields[1])
+		d := lsp.Diagnostic{
+			Range:    lsp.Range{Start: lsp.Position{Line: i, Character: start}, End: lsp.Position{Line: i, Character: end}},
+			Severity: lsp.SeverityInformation,
+			Code:     r.Diff,
+			Source:   "faro",
+			Message:  fmt.Sprintf("%s is available (%s update)", r.Update.Version, r.Diff),
+		}
+		if r.VulnCurrent != nil && r.VulnCurrent.Total > 0 {
+			fixed := r.VulnCurrent.Total
+			if r.VulnUpdate != nil {
+				fixed -= r.VulnUpdate.Total
+			}
+			d.Message += fmt.Sprintf("; %s has %d known %s", r.Version, r.VulnCurrent.Total, plural(r.VulnCurrent.Total, "vulnerability", "vulnerabilities"))
+			if fixed > 0 {
+				d.Message += fmt.Sprintf(", %d fixed by %s", fixed, r.Update.Version)
+				d.Severity = lsp.SeverityWarning
+			}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// isGoMod reports whether uri names a go.mod file.
+func isGoMod(uri string) bool {
+	return filepath.Base(uriPath(uri)) == "go.mod"
+}
+
+// uriPath returns the file path of a file:// URI, or uri itself when it is
+// not one.
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	path := u.Path
+	// file:///C:/src/go.mod on Windows.
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
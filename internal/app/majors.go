@@ -0,0 +1,326 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/cooldown"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/updater"
+	gomodUpdater "github.com/pragmaticivan/faro/internal/updater/gomod"
+)
+
+// MajorOptions configures Major.
+type MajorOptions struct {
+	Module         string // Required module to move to a new major version
+	To             string // Major version to move to (e.g. v3); defaults to the newest
+	DryRun         bool   // Print the plan without applying it
+	GoModPath      string // Optional go.mod path; defaults to the working directory
+	NoExec         bool   // Read-only: only a dry run is allowed
+	AuditLog       string // JSON lines file recording the migration (overrides audit.file)
+	OverrideFreeze bool   // Migrate even during a freeze window from .faro.json
+}
+
+// Major moves a required Go module to a newer major version published under
+// a new module path: it rewrites the imports of the old path in the module's
+// Go files, requires the new path and tidies go.mod, in the spirit of
+// gomajor. Nothing is changed when any step fails.
+func Major(ctx context.Context, opts MajorOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if deps.Now == nil {
+		deps.Now = time.Now
+	}
+	if opts.NoExec {
+		if !opts.DryRun {
+			return fmt.Errorf("--no-exec forbids migrating a module; add --dry-run to preview the plan")
+		}
+		deps.Updater = updater.ReadOnly{}
+	}
+	major := 0
+	if opts.To != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(opts.To, "v"))
+		if err != nil || n < 2 {
+			return fmt.Errorf("invalid --to %q: want a major version such as v2", opts.To)
+		}
+		major = n
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if opts.GoModPath != "" {
+		if workDir, err = resolveGoModDir(opts.GoModPath); err != nil {
+			return err
+		}
+	}
+	requires, err := gomod.ReadRequires(filepath.Join(workDir, "go.mod"))
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	var req *gomod.Require
+	for i := range requires {
+		if requires[i].Path == opts.Module {
+			req = &requires[i]
+			break
+		}
+	}
+	if req == nil {
+		return fmt.Errorf("%s is not required in go.mod", opts.Module)
+	}
+
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return err
+	}
+	if err := applyGlyphs(cfg.Glyphs); err != nil {
+		return err
+	}
+	src := deps.Channels
+	if src == nil {
+		src = channelSource(detector.Go, cfg.SupplyChain, proxyClient(cfg.GoProxy), nil)
+	}
+	days := cooldownDays(0, false, cfg, detector.Go)
+
+	_, _ = fmt.Fprintf(deps.Out, "Probing for new major versions of %s...\n", opts.Module)
+	found, err := probeMajorPath(ctx, *req, major, src, days, deps.Now())
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	if found == nil {
+		if opts.To != "" {
+			_, _ = fmt.Fprintf(deps.Out, "\nNo %s release of %s was found.\n", opts.To, opts.Module)
+		} else {
+			_, _ = fmt.Fprintf(deps.Out, "\n%s has no newer major version under a new module path.\n", opts.Module)
+		}
+		return nil
+	}
+	if c, ok := style.ComparePrecedence(found.Latest, req.Version); ok && c <= 0 {
+		_, _ = fmt.Fprintf(deps.Out, "\n%s is already at %s.\n", opts.Module, req.Version)
+		return nil
+	}
+
+	rewrites, err := gomodUpdater.ImportRewrites(workDir, found.Name, found.Path)
+	if err != nil {
+		return err
+	}
+	files := make([]string, 0, len(rewrites))
+	for path := range rewrites {
+		if rel, err := filepath.Rel(workDir, path); err == nil {
+			path = rel
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	printMajorPaths(deps.Out, []MajorPathUpdate{*found}, false)
+	_, _ = fmt.Fprintf(deps.Out, "\n%d %s %s:\n", len(files), plural(len(files), "file imports", "files import"), found.Name)
+	for _, f := range files {
+		_, _ = fmt.Fprintf(deps.Out, " %s\n", f)
+	}
+
+	if opts.DryRun {
+		_, _ = fmt.Fprintln(deps.Out, "\nRun without --dry-run to apply.")
+		return nil
+	}
+	if reason, frozen := cfg.Freeze.Active(deps.Now()); frozen && !opts.OverrideFreeze {
+		_, _ = fmt.Fprintf(deps.Out, "\nUpgrades are frozen (%s); not migrating %s.\n", reason, found.Name)
+		_, _ = fmt.Fprintln(deps.Out, "Pass --override-freeze to migrate it anyway.")
+		return nil
+	}
+	if cfg.Critical.Matches(found.Name) {
+		return fmt.Errorf("%s is critical; review its major upgrade and migrate it by hand", found.Name)
+	}
+
+	var updaterInstance updater.Updater
+	if deps.Updater != nil {
+		updaterInstance = deps.Updater
+	} else if updaterInstance, err = factory.CreateUpdater(detector.Go, workDir); err != nil {
+		return err
+	}
+	migrator, ok := updaterInstance.(updater.PathMigrator)
+	if !ok {
+		return fmt.Errorf("the Go updater cannot move modules to a new path")
+	}
+
+	m := scanner.Module{
+		Name:           found.Name,
+		Path:           found.Name,
+		Version:        found.Version,
+		Direct:         !req.Indirect,
+		DependencyType: "direct",
+		Update:         &scanner.UpdateInfo{Version: found.Latest, Time: found.Time},
+	}
+	if req.Indirect {
+		m.DependencyType = "indirect"
+	}
+	auditLog := startAudit(cfg.Audit, opts.AuditLog, workDir, "major", detector.Go)
+	_, _ = fmt.Fprintf(deps.Out, "\nMigrating to %s...\n", found.Path)
+	if _, err := migrator.MigratePath(ctx, m, found.Path); err != nil {
+		auditFailed(deps, auditLog.finish(ctx, []scanner.Module{m}, err, deps))
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	_, _ = fmt.Fprintln(deps.Out, "Done. Review the changes: the new major version may have breaking API changes.")
+	return auditLog.finish(ctx, []scanner.Module{m}, nil, deps)
+}
+
+// maxMajorProbes bounds the successive major versions probed per module.
+const maxMajorProbes = 10
+
+// MajorPathUpdate is a newer major version of a Go module published under
+// a new module path (/v2, /v3), which `go list -m -u` cannot see.
+type MajorPathUpdate struct {
+	Name    string `json:"name"`           // Required module path
+	Version string `json:"version"`        // Required version
+	Path    string `json:"path"`           // Module path of the newest major version
+	Latest  string `json:"latest"`         // Newest release under Path
+	Time    string `json:"time,omitempty"` // When Latest was published
+}
+
+// probeMajorPaths looks up newer major versions of requires by querying
+// the proxy for each successive major version path until one is missing,
+// and returns the newest release past the cooldown under the highest path
+// found, in go.mod order. Modules the proxy refuses as private are skipped.
+func probeMajorPaths(ctx context.Context, requires []gomod.Require, src ChannelSource, cooldownDays int, now time.Time, w *warnings) []MajorPathUpdate {
+	found := make([]*MajorPathUpdate, len(requires))
+	errs := make([]error, len(requires))
+	sem := make(chan struct{}, goCompatConcurrency)
+	var wg sync.WaitGroup
+	for i, r := range requires {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			found[i], errs[i] = probeMajorPath(ctx, r, 0, src, cooldownDays, now)
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	var out []MajorPathUpdate
+	for i, r := range requires {
+		switch {
+		case errs[i] != nil && !errors.Is(errs[i], goproxy.ErrPrivate):
+			w.fail(r.Path, "could not probe for a new major version: %v", errs[i])
+		case found[i] != nil:
+			out = append(out, *found[i])
+		}
+	}
+	return out
+}
+
+// probeMajorPath returns the newest release past the cooldown of the
+// highest major version of r published under its own module path, or nil.
+// With major set, only that major version is looked up.
+func probeMajorPath(ctx context.Context, r gomod.Require, major int, src ChannelSource, cooldownDays int, now time.Time) (*MajorPathUpdate, error) {
+	prefix, current := gomod.SplitPathMajor(r.Path)
+	// v3.0.0+incompatible of a path without suffix is major 3 already.
+	if n := versionMajor(r.Version); n > current {
+		current = n
+	}
+	first, last := current+1, current+maxMajorProbes
+	if major > 0 {
+		first, last = major, major
+	}
+	var best *MajorPathUpdate
+	for n := first; n <= last; n++ {
+		path := gomod.MajorPath(prefix, n)
+		versions, err := src.Versions(ctx, path)
+		if errors.Is(err, goproxy.ErrNotFound) || (err == nil && len(versions) == 0) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		latest, published, err := newestRelease(ctx, path, versions, src, cooldownDays, now)
+		if err != nil {
+			return nil, err
+		}
+		if latest != "" {
+			best = &MajorPathUpdate{Name: r.Path, Version: r.Version, Path: path, Latest: latest, Time: published}
+		}
+	}
+	return best, nil
+}
+
+// newestRelease returns the newest release among versions of path that is
+// past the cooldown, and when it was published.
+func newestRelease(ctx context.Context, path string, versions []string, src ChannelSource, cooldownDays int, now time.Time) (string, string, error) {
+	sort.Slice(versions, func(i, j int) bool {
+		c, _ := style.ComparePrecedence(versions[i], versions[j])
+		return c > 0
+	})
+	for _, v := range versions {
+		if strings.Contains(v, "-") || strings.HasSuffix(v, "+incompatible") || !style.ValidVersion(v) {
+			continue
+		}
+		published, err := src.PublishTime(ctx, path, v)
+		if err != nil && cooldownDays > 0 {
+			return "", "", err
+		}
+		if cooldown.Eligible(published, cooldownDays, now) {
+			return v, published, nil
+		}
+	}
+	return "", "", nil
+}
+
+// versionMajor returns the major version of a semantic version, or 0.
+func versionMajor(v string) int {
+	major, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), ".")
+	n, _ := strconv.Atoi(major)
+	return n
+}
+
+// printMajorPaths lists the new major versions found by --major-paths,
+// followed by a hint at the command that migrates them when hint is set.
+func printMajorPaths(out io.Writer, majors []MajorPathUpdate, hint bool) {
+	if len(majors) == 0 {
+		return
+	}
+	bold := lipgloss.NewStyle().Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	_, _ = fmt.Fprintf(out, "\n%s\n", bold.Render("New major (path change)"))
+	width := 0
+	for _, m := range majors {
+		width = max(width, len(m.Name))
+	}
+	for _, m := range majors {
+		_, _ = fmt.Fprintf(out, " %-*s  %s %s %s %s\n", width, m.Name, m.Version, style.Glyphs.Arrow,
+			m.Path, style.GetVersionStyle(style.DiffMajor).Render(m.Latest))
+	}
+	if hint {
+		_, _ = fmt.Fprintln(out, dim.Render("Migrate the imports with faro major <module>."))
+	}
+}
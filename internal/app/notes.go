@@ -0,0 +1,33 @@
+package app
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// annotateNotes attaches the configured notes to matching modules.
+func annotateNotes(modules []scanner.Module, cfg config.Config) {
+	if len(cfg.Notes) == 0 {
+		return
+	}
+	for i := range modules {
+		modules[i].Notes = cfg.NotesFor(moduleName(modules[i]))
+	}
+}
+
+// noteLines renders notes as dim lines under an update row, each fitted to
+// width (0 = unlimited).
+func noteLines(notes []string, width int) string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	var s string
+	for _, n := range notes {
+		line := "   " + dim.Render("note: "+n)
+		if width > 0 {
+			line = style.Truncate(line, width)
+		}
+		s += "\n" + line
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/suspect"
+)
+
+// checkOwnership compares who controls the current and update versions of
+// direct dependencies and sets OwnerChange on updates that share no owner
+// with the version in use, so -u holds them back for review.
+func checkOwnership(ctx context.Context, modules []scanner.Module, lookup suspect.OwnerLookup, allow []string, w *warnings) {
+	for i := range modules {
+		m := &modules[i]
+		name := moduleName(*m)
+		if m.Update == nil || !m.Direct || suspect.Allowed(name, allow) {
+			continue
+		}
+		from, err := lookup(ctx, name, m.Version)
+		var to []string
+		if err == nil {
+			to, err = lookup(ctx, name, m.Update.Version)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			w.add(name, "could not compare owners of %s and %s: %v", m.Version, m.Update.Version, err)
+			continue
+		}
+		if suspect.OwnersChanged(from, to) {
+			m.OwnerChange = fmt.Sprintf("%s %s %s", strings.Join(from, ", "), style.Glyphs.Arrow, strings.Join(to, ", "))
+			w.add(name, "owner changed between %s and %s (%s); review before upgrading", m.Version, m.Update.Version, m.OwnerChange)
+		}
+	}
+}
+
+// ownerLookup returns the owner lookup for pm: npm maintainers, or the
+// repository the Go module proxy recorded for each version. It returns nil
+// for ecosystems without owner data.
+func ownerLookup(pm detector.PackageManager, cfg config.SupplyChain, proxy *goproxy.Client) suspect.OwnerLookup {
+	switch {
+	case pm.Ecosystem() == "npm":
+		return suspect.NewNPMRegistry(&http.Client{Timeout: 15 * time.Second}, cfg.Registry).Owners
+	case pm == detector.Go:
+		return func(ctx context.Context, name, version string) ([]string, error) {
+			info, err := proxy.Info(ctx, name, version)
+			if err != nil || info.Origin == nil || info.Origin.URL == "" {
+				return nil, err
+			}
+			return []string{suspect.NormalizeRepo(info.Origin.URL)}, nil
+		}
+	}
+	return nil
+}
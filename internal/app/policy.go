@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/policy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// PolicyOptions configures PolicyCheck. Empty fields fall back to the
+// "policy" section of .faro.json.
+type PolicyOptions struct {
+	MaxAge    string // Maximum staleness, e.g. "365d"
+	Scope     string // "direct" or "all"
+	Manager   string // Package manager override
+	GoModPath string // Path to a go.mod file (or its directory); implies the go manager
+}
+
+// PolicyCheck scans for updates and reports every dependency that trails its
+// latest release by more than the allowed age. It returns an ErrorPolicy
+// error when there are violations.
+func PolicyCheck(ctx context.Context, opts PolicyOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+
+	workDir, pm, err := resolveManager("", opts.Manager, opts.GoModPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+
+	maxAgeText := opts.MaxAge
+	if maxAgeText == "" {
+		maxAgeText = cfg.Policy.MaxAge
+	}
+	if maxAgeText == "" {
+		return categorize(ErrorUsage, fmt.Errorf("no max age configured: pass --max-age or set policy.maxAge in %s", config.FileName))
+	}
+	maxAge, err := policy.ParseAge(maxAgeText)
+	if err != nil {
+		return categorize(ErrorUsage, err)
+	}
+	scope := opts.Scope
+	if scope == "" {
+		scope = cfg.Policy.Scope
+	}
+	if scope == "" {
+		scope = "direct"
+	}
+	if scope != "direct" && scope != "all" {
+		return categorize(ErrorUsage, fmt.Errorf("invalid policy scope %q (supported: direct, all)", scope))
+	}
+
+	pkgScanner := deps.Scanner
+	if pkgScanner == nil {
+		pkgScanner, err = factory.CreateScanner(pm, workDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintf(deps.Out, "Checking %s dependencies against max age %s...\n", scope, maxAgeText)
+	modules, err := pkgScanner.GetUpdates(ctx, scanner.Options{IncludeAll: scope == "all", WorkDir: workDir})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+	if scope == "direct" {
+		modules, _, _ = groupModules(modules)
+	}
+
+	violations, unknown := policy.CheckMaxAge(modules, maxAge)
+
+	if len(unknown) > 0 {
+		var w warnings
+		for _, m := range unknown {
+			w.add(moduleName(m), "publish times unavailable; age not checked")
+		}
+		printWarnings(deps.Out, w.items)
+	}
+
+	if len(violations) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "\nNo dependency exceeds the max age.")
+		return nil
+	}
+
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	vmods := make([]scanner.Module, 0, len(violations))
+	for _, v := range violations {
+		vmods = append(vmods, v.Module)
+	}
+	maxPathLen := scanner.MaxPathLength(vmods)
+
+	_, _ = fmt.Fprintf(deps.Out, "\n%s\n", red.Render("Max age violations:"))
+	for _, v := range violations {
+		m := v.Module
+		_, _ = fmt.Fprintf(deps.Out, " %s  %s\n",
+			style.FormatUpdate(moduleName(m), m.Version, m.Update.Version, maxPathLen),
+			dim.Render(fmt.Sprintf("%d days behind", v.Days())))
+	}
+	return categorize(ErrorPolicy, fmt.Errorf("%d %s exceed the max age of %s", len(violations), plural(len(violations), "dependency", "dependencies"), maxAgeText))
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,388 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/commit"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// GitRunner runs git with args in dir and returns its trimmed output.
+type GitRunner func(ctx context.Context, dir string, args ...string) (string, error)
+
+// PullRequester finds and opens GitHub pull requests; *github.Client
+// implements it.
+type PullRequester interface {
+	OpenPullRequest(ctx context.Context, owner, repo, head string) (github.PullRequest, bool, error)
+	CreatePullRequest(ctx context.Context, owner, repo string, pr github.NewPullRequest) (github.PullRequest, error)
+}
+
+// PROptions configures PullRequests.
+type PROptions struct {
+	Manager        string   // Package manager override
+	GoModPath      string   // Path to a go.mod file (or its directory); implies the go manager
+	Filter         string   // Only upgrade modules matching this pattern
+	Modules        []string // Only upgrade these dependencies
+	All            bool     // Also upgrade indirect dependencies
+	Cooldown       int      // Minimum update age in days
+	CooldownSet    bool     // Cooldown was given explicitly and overrides the configured default
+	PerModule      bool     // One pull request per dependency instead of one for all of them
+	Base           string   // Branch the pull requests merge into; defaults to the current branch
+	Remote         string   // Git remote branches are pushed to; defaults to origin
+	Draft          bool     // Open draft pull requests
+	DryRun         bool     // Print the pull requests instead of opening them
+	NoExec         bool     // Read-only mode; only a dry run is allowed
+	OverrideFreeze bool     // Open pull requests even during a freeze window from .faro.json
+}
+
+// prBranchPrefix namespaces the branches faro pushes, so reruns reuse them.
+const prBranchPrefix = "faro/"
+
+// unsafeRef matches runs of characters left out of branch names.
+var unsafeRef = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// prGroup is the set of upgrades one pull request carries.
+type prGroup struct {
+	branch  string
+	modules []scanner.Module
+}
+
+// PullRequests applies pending upgrades on new branches, commits the
+// manifests with the project's commit message, pushes the branches and
+// opens a GitHub pull request for each: one for all upgrades, or one per
+// dependency. Branches that already have an open pull request are left
+// alone, so it can run on a schedule. The token comes from github.tokenEnv,
+// GITHUB_TOKEN or GH_TOKEN.
+func PullRequests(ctx context.Context, opts PROptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if opts.NoExec && !opts.DryRun {
+		return categorize(ErrorUsage, fmt.Errorf("--no-exec forbids pr, which runs package manager and git commands; add --dry-run to preview the pull requests"))
+	}
+	if opts.Remote == "" {
+		opts.Remote = "origin"
+	}
+	workDir, pm, err := resolveManager("", opts.Manager, opts.GoModPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	if err := applyGlyphs(cfg.Glyphs); err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	opts.Cooldown = cooldownDays(opts.Cooldown, opts.CooldownSet, cfg, pm)
+	git := deps.Git
+	if git == nil {
+		git = runGit
+	}
+
+	pkgScanner := deps.Scanner
+	if pkgScanner == nil {
+		if pkgScanner, err = factory.CreateScanner(pm, workDir); err != nil {
+			return err
+		}
+	}
+	_, _ = fmt.Fprintln(deps.Out, "Checking for updates...")
+	modules, err := pkgScanner.GetUpdates(ctx, scanner.Options{Filter: opts.Filter, IncludeAll: opts.All, CooldownDays: opts.Cooldown, WorkDir: workDir})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+	var warns warnings
+	modules = updateFilter{
+		names:    opts.Modules,
+		cooldown: opts.Cooldown,
+		channels: registryChannels(pm, cfg, deps),
+	}.apply(ctx, modules, cfg, pm, deps.Now(), nil, &warns)
+	printWarnings(deps.Out, warns.items)
+	direct, indirect, _ := groupModules(modules)
+	candidates := direct
+	if opts.All {
+		candidates = append(append([]scanner.Module{}, direct...), indirect...)
+	}
+	candidates, heldBack := splitCritical(candidates)
+	if len(heldBack) > 0 {
+		printHeldBack(deps.Out, heldBack)
+	}
+	if len(candidates) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "No updates to open pull requests for.")
+		return nil
+	}
+	if reason, frozen := cfg.Freeze.Active(deps.Now()); frozen && !opts.OverrideFreeze {
+		_, _ = fmt.Fprintf(deps.Out, "Upgrades are frozen (%s); not opening pull requests for %d %s:\n", reason, len(candidates), plural(len(candidates), "update", "updates"))
+		for _, m := range candidates {
+			_, _ = fmt.Fprintf(deps.Out, "  %s %s %s %s\n", moduleName(m), m.Version, style.Glyphs.Arrow, m.Update.Version)
+		}
+		_, _ = fmt.Fprintln(deps.Out, "Pass --override-freeze to open them anyway.")
+		return nil
+	}
+
+	groups := []prGroup{{branch: prBranchPrefix + pm.String() + "-updates", modules: candidates}}
+	if opts.PerModule {
+		groups = groups[:0]
+		for _, m := range candidates {
+			name := strings.Trim(unsafeRef.ReplaceAllString(moduleName(m)+"-"+m.Update.Version, "-"), "-.")
+			groups = append(groups, prGroup{branch: prBranchPrefix + name, modules: []scanner.Module{m}})
+		}
+	}
+
+	if opts.DryRun {
+		base := opts.Base
+		if base == "" {
+			base = "the current branch"
+		}
+		for _, g := range groups {
+			msg, err := commitMessage(workDir, pm, cfg.Commit, g.modules, nil)
+			if err != nil {
+				return categorize(ErrorConfig, err)
+			}
+			title, _, _ := strings.Cut(msg, "\n")
+			_, _ = fmt.Fprintf(deps.Out, "\n=== %s (%s into %s)\n\n%s", title, g.branch, base, prBody(msg, g.modules))
+		}
+		return nil
+	}
+
+	run := prRun{workDir: workDir, pm: pm, cfg: cfg, git: git, opts: opts, deps: deps}
+	if run.start, err = currentRef(ctx, git, workDir); err != nil {
+		return categorize(ErrorUsage, err)
+	}
+	run.base = opts.Base
+	if run.base == "" {
+		if run.start.detached {
+			return categorize(ErrorUsage, fmt.Errorf("HEAD is detached; check out a branch or pass --base"))
+		}
+		run.base = run.start.name
+	}
+	if run.owner, run.repo, err = prRepo(ctx, git, workDir, opts.Remote, os.Getenv); err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	if status, err := git(ctx, workDir, "status", "--porcelain", "--untracked-files=no"); err != nil {
+		return categorize(ErrorUsage, err)
+	} else if status != "" {
+		return categorize(ErrorUsage, fmt.Errorf("the working tree has uncommitted changes; commit or stash them before opening pull requests"))
+	}
+	run.prs = deps.PullRequests
+	if run.prs == nil {
+		gh := lazyGitHubClient{cfg: cfg.GitHub}
+		run.prs = gh.get()
+	}
+	run.upd = deps.Updater
+	if run.upd == nil {
+		if run.upd, err = factory.CreateUpdater(pm, workDir); err != nil {
+			return err
+		}
+	}
+
+	opened, existing := 0, 0
+	var failed []string
+	for _, g := range groups {
+		if pr, ok, err := run.prs.OpenPullRequest(ctx, run.owner, run.repo, g.branch); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to look up pull requests: %w", err)
+		} else if ok {
+			existing++
+			_, _ = fmt.Fprintf(deps.Out, "Exists: %s (%s)\n", pr.Title, pr.HTMLURL)
+			continue
+		}
+		pr, err := run.open(ctx, g)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed = append(failed, g.branch)
+			_, _ = fmt.Fprintf(deps.Out, "Failed: %s: %v\n", g.branch, err)
+			continue
+		}
+		opened++
+		_, _ = fmt.Fprintf(deps.Out, "Opened: %s (%s)\n", pr.Title, pr.HTMLURL)
+	}
+
+	_, _ = fmt.Fprintf(deps.Out, "\nOpened %d pull %s, %d already open.\n", opened, plural(opened, "request", "requests"), existing)
+	if len(failed) > 0 {
+		return categorize(ErrorUpdate, fmt.Errorf("%d of %d pull requests failed: %s", len(failed), len(groups), strings.Join(failed, ", ")))
+	}
+	return nil
+}
+
+// prRun is the state shared by the pull requests of one PullRequests call.
+type prRun struct {
+	workDir     string
+	pm          detector.PackageManager
+	cfg         config.Config
+	base        string // Branch pull requests merge into
+	start       gitRef // Checked out before faro switched branches
+	owner, repo string
+	upd         updater.Updater
+	git         GitRunner
+	prs         PullRequester
+	opts        PROptions
+	deps        Deps
+}
+
+// open applies g on its branch, pushes it and opens the pull request. The
+// working tree is back on r.start afterwards, even on failure.
+func (r *prRun) open(ctx context.Context, g prGroup) (github.PullRequest, error) {
+	var pr github.PullRequest
+	msg, err := commitMessage(r.workDir, r.pm, r.cfg.Commit, g.modules, nil)
+	if err != nil {
+		return pr, err
+	}
+	if _, err := r.git(ctx, r.workDir, "switch", "-C", g.branch, r.base); err != nil {
+		return pr, err
+	}
+	defer func() {
+		// Drop whatever a failed upgrade left behind before leaving the branch.
+		ctx := context.WithoutCancel(ctx)
+		_, _ = r.git(ctx, r.workDir, "reset", "--hard", "--quiet")
+		_, _ = r.git(ctx, r.workDir, append([]string{"switch"}, r.start.args()...)...)
+	}()
+
+	_, _ = fmt.Fprintf(r.deps.Out, "\nUpgrading on %s...\n", g.branch)
+	if err := r.upd.UpdatePackages(ctx, g.modules); err != nil {
+		return pr, err
+	}
+	files := detector.ManifestFiles(r.pm)
+	if r.pm == detector.Go {
+		steps := regenSteps(r.workDir, r.cfg)
+		var regenWarns warnings
+		err := runRegen(ctx, r.workDir, steps, r.deps.RunCommand, &regenWarns)
+		printWarnings(r.deps.Out, regenWarns.items)
+		if err != nil {
+			return pr, err
+		}
+		files = append(files, regenFiles(steps)...)
+	}
+	commitFn := r.deps.Commit
+	if commitFn == nil {
+		commitFn = commit.Commit
+	}
+	if err := commitFn(ctx, r.workDir, files, msg); err != nil {
+		return pr, err
+	}
+	// The branch is faro's own; a stale copy from a closed pull request is
+	// replaced.
+	if _, err := r.git(ctx, r.workDir, "push", "--force", "--set-upstream", r.opts.Remote, g.branch); err != nil {
+		return pr, err
+	}
+	title, _, _ := strings.Cut(msg, "\n")
+	return r.prs.CreatePullRequest(ctx, r.owner, r.repo, github.NewPullRequest{
+		Title: title,
+		Head:  g.branch,
+		Base:  r.base,
+		Body:  prBody(msg, g.modules),
+		Draft: r.opts.Draft,
+	})
+}
+
+// prBody describes the upgrades of a pull request: the commit message
+// body, when there is one, and a table of the version changes.
+func prBody(msg string, modules []scanner.Module) string {
+	var b strings.Builder
+	if _, rest, _ := strings.Cut(msg, "\n"); strings.TrimSpace(rest) != "" {
+		b.WriteString(strings.TrimSpace(rest) + "\n\n")
+	}
+	b.WriteString("| Dependency | From | To |\n|---|---|---|\n")
+	for _, m := range modules {
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", moduleName(m), m.Version, m.Update.Version)
+	}
+	b.WriteString("\nOpened by `faro pr`.\n")
+	return b.String()
+}
+
+// gitRef is the branch, or the commit of a detached HEAD, to return to.
+type gitRef struct {
+	name     string
+	detached bool
+}
+
+// args returns the git switch arguments that check r out again.
+func (r gitRef) args() []string {
+	if r.detached {
+		return []string{"--detach", r.name}
+	}
+	return []string{r.name}
+}
+
+func currentRef(ctx context.Context, git GitRunner, dir string) (gitRef, error) {
+	branch, err := git(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return gitRef{}, err
+	}
+	if branch != "HEAD" {
+		return gitRef{name: branch}, nil
+	}
+	sha, err := git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return gitRef{}, err
+	}
+	return gitRef{name: sha, detached: true}, nil
+}
+
+// prRepo returns the GitHub repository pull requests are opened in:
+// GITHUB_REPOSITORY in GitHub Actions, otherwise the repository remote
+// points at.
+func prRepo(ctx context.Context, git GitRunner, dir, remote string, getenv func(string) string) (owner, repo string, err error) {
+	if owner, repo, ok := strings.Cut(getenv("GITHUB_REPOSITORY"), "/"); ok && owner != "" && repo != "" {
+		return owner, repo, nil
+	}
+	remoteURL, err := git(ctx, dir, "remote", "get-url", remote)
+	if err != nil {
+		return "", "", err
+	}
+	if owner, repo, ok := remoteRepo(remoteURL); ok {
+		return owner, repo, nil
+	}
+	return "", "", fmt.Errorf("cannot tell the GitHub repository from remote %s (%s); set GITHUB_REPOSITORY=owner/repo", remote, remoteURL)
+}
+
+// remoteRepo returns the owner and repository of a git remote URL, in URL
+// form ("https://github.com/acme/api.git") or scp-like form
+// ("git@github.com:acme/api.git").
+func remoteRepo(remoteURL string) (owner, repo string, ok bool) {
+	path := remoteURL
+	if u, err := url.Parse(remoteURL); err == nil && u.Scheme != "" && u.Host != "" {
+		path = u.Path
+	} else if _, p, found := strings.Cut(remoteURL, ":"); found {
+		path = p
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	owner, repo = parts[len(parts)-2], strings.TrimSuffix(parts[len(parts)-1], ".git")
+	return owner, repo, owner != "" && repo != ""
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := execx.Command(ctx, dir, "git", args...).CombinedOutput()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(string(out)))
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/cooldown"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// prereleaseCandidates returns the go.mod requirements that opted in to
+// pre-releases but are missing from modules because the scan found no
+// release to update them to.
+func prereleaseCandidates(goModPath string, modules []scanner.Module, wants func(name string) bool, opts RunOptions) ([]scanner.Module, error) {
+	requires, err := selectedRequires(goModPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		present[moduleName(m)] = true
+	}
+	var out []scanner.Module
+	for _, r := range requires {
+		if present[r.Path] || !wants(r.Path) {
+			continue
+		}
+		depType := "direct"
+		if r.Indirect {
+			depType = "indirect"
+		}
+		out = append(out, scanner.Module{
+			Name:           r.Path,
+			Version:        r.Version,
+			Direct:         !r.Indirect,
+			DependencyType: depType,
+			Path:           r.Path,
+			Indirect:       r.Indirect,
+		})
+	}
+	return out, nil
+}
+
+// selectedRequires returns the requirements in goModPath that a scan with
+// opts covers: --filter, --all and the module arguments apply as they do to
+// the scan, and locally replaced modules are left out.
+func selectedRequires(goModPath string, opts RunOptions) ([]gomod.Require, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	contents := string(data)
+
+	var filterRegex *regexp.Regexp
+	if opts.Filter != "" {
+		filterRegex, _ = regexp.Compile(opts.Filter)
+	}
+	local := make(map[string]bool)
+	for _, r := range gomod.ParseReplaces(contents) {
+		if strings.HasPrefix(r.New, ".") || strings.HasPrefix(r.New, "/") {
+			local[r.Old] = true
+		}
+	}
+	named := make(map[string]bool, len(opts.Modules))
+	for _, name := range opts.Modules {
+		named[name] = true
+	}
+
+	var out []gomod.Require
+	for _, r := range gomod.ParseRequires(contents) {
+		switch {
+		case local[r.Path]:
+		case len(named) > 0 && !named[r.Path]:
+		case r.Indirect && !opts.All:
+		case opts.Filter != "" && !strings.Contains(r.Path, opts.Filter) && (filterRegex == nil || !filterRegex.MatchString(r.Path)):
+		default:
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// applyPrereleases moves the updates of modules that opted in to
+// pre-releases (--pre or prerelease.modules) to the highest pre-release
+// newer than both their current version and the scan's update, when one is
+// past the cooldown. Candidates without an update are added when they have
+// such a pre-release. Modules the proxy refuses as private are left alone.
+func applyPrereleases(ctx context.Context, modules, candidates []scanner.Module, wants func(name string) bool, src ChannelSource, cooldownDays int, now time.Time, w *warnings) []scanner.Module {
+	all := append(append([]scanner.Module(nil), modules...), candidates...)
+	updates := make([]*scanner.UpdateInfo, len(all))
+	errs := make([]error, len(all))
+	sem := make(chan struct{}, goCompatConcurrency)
+	var wg sync.WaitGroup
+	for i, m := range all {
+		if !wants(moduleName(m)) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			updates[i], errs[i] = newestPrerelease(ctx, m, src, cooldownDays, now)
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return modules
+	}
+
+	out := make([]scanner.Module, 0, len(all))
+	for i, m := range all {
+		switch {
+		case errs[i] != nil && !errors.Is(errs[i], goproxy.ErrPrivate):
+			w.fail(moduleName(m), "could not list pre-releases: %v", errs[i])
+		case updates[i] != nil:
+			m.Update = updates[i]
+		}
+		if m.Update != nil {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// newestPrerelease returns the highest pre-release of m newer than its
+// current version and update that is past the cooldown, or nil. Like `go
+// list -m -u`, +incompatible versions are skipped unless m is on one.
+func newestPrerelease(ctx context.Context, m scanner.Module, src ChannelSource, cooldownDays int, now time.Time) (*scanner.UpdateInfo, error) {
+	versions, err := src.Versions(ctx, moduleName(m))
+	if err != nil {
+		return nil, err
+	}
+	floor := m.Version
+	if m.Update != nil {
+		floor = m.Update.Version
+	}
+	incompatible := strings.HasSuffix(m.Version, "+incompatible")
+	sort.Slice(versions, func(i, j int) bool {
+		c, _ := style.ComparePrecedence(versions[i], versions[j])
+		return c > 0
+	})
+	for _, v := range versions {
+		newer, ok := style.ComparePrecedence(v, floor)
+		if !ok {
+			continue
+		}
+		if newer <= 0 {
+			break
+		}
+		base := strings.TrimSuffix(v, "+incompatible")
+		if !strings.Contains(base, "-") || (base != v && !incompatible) {
+			continue
+		}
+		published, err := src.PublishTime(ctx, moduleName(m), v)
+		if err != nil {
+			return nil, err
+		}
+		if cooldown.Eligible(published, cooldownDays, now) {
+			return &scanner.UpdateInfo{Version: v, Time: published}, nil
+		}
+	}
+	return nil, nil
+}
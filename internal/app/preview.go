@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// previewUpgrade asks the updater how upgrading modules would change the
+// dependency graph. Failures and unsupported managers are recorded as
+// warnings and yield nil.
+func previewUpgrade(ctx context.Context, pm detector.PackageManager, workDir string, modules []scanner.Module, deps Deps, w *warnings) *updater.Preview {
+	u := deps.Updater
+	if u == nil {
+		var err error
+		if u, err = factory.CreateUpdater(pm, workDir); err != nil {
+			w.add("", "upgrade preview unavailable: %v", err)
+			return nil
+		}
+	}
+	previewer, ok := u.(updater.Previewer)
+	if !ok {
+		w.add("", "upgrade preview is not supported for %s", pm)
+		return nil
+	}
+	p, err := previewer.PreviewPackages(ctx, modules)
+	if err != nil {
+		w.add("", "upgrade preview failed: %v", err)
+		return nil
+	}
+	return &p
+}
+
+// printPreview prints the upgrade preview summary, if any.
+func printPreview(out io.Writer, p *updater.Preview) {
+	if out == nil || p == nil {
+		return
+	}
+	bold := lipgloss.NewStyle().Bold(true)
+	_, _ = fmt.Fprintf(out, "\n%s %s\n", bold.Render("Preview:"), p)
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// PrewarmOptions configures Prewarm.
+type PrewarmOptions struct {
+	Filter      string // Regex filter on module paths
+	All         bool   // Include indirect and transitive dependencies
+	Cooldown    int    // Minimum update age in days
+	CooldownSet bool   // Cooldown was given explicitly and overrides the configured default
+	GoModPath   string // Optional go.mod path; defaults to the working directory
+	NoExec      bool   // Read-only mode; prewarming is refused
+}
+
+// Download is the outcome of downloading one module version.
+type Download struct {
+	Path    string `json:"Path"`
+	Version string `json:"Version"`
+	Error   string `json:"Error"`
+}
+
+// ModuleDownloader downloads path@version queries into the module cache.
+type ModuleDownloader func(ctx context.Context, workDir string, queries []string) ([]Download, error)
+
+// goModDownload runs `go mod download -json`. Its exit status is ignored
+// when per-module results were printed, since it fails if any module does.
+func goModDownload(ctx context.Context, workDir string, queries []string) ([]Download, error) {
+	out, runErr := execx.Command(ctx, workDir, "go", append([]string{"mod", "download", "-json"}, queries...)...).Output()
+	dec := json.NewDecoder(bytes.NewReader(out))
+	var downloads []Download
+	for dec.More() {
+		var d Download
+		if err := dec.Decode(&d); err != nil {
+			return nil, fmt.Errorf("failed to decode go mod download output: %w", err)
+		}
+		downloads = append(downloads, d)
+	}
+	if runErr != nil && len(downloads) == 0 {
+		return nil, fmt.Errorf("failed to run go mod download: %w", runErr)
+	}
+	return downloads, nil
+}
+
+// Prewarm downloads the update version of every pending Go module update into
+// the module cache, so a later upgrade or CI run does not wait on the network.
+func Prewarm(ctx context.Context, opts PrewarmOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if opts.NoExec {
+		return categorize(ErrorUsage, fmt.Errorf("--no-exec forbids prewarm: it runs go mod download"))
+	}
+
+	workDir, pm, err := resolveManager("", detector.Go.String(), opts.GoModPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	pkgScanner := deps.Scanner
+	if pkgScanner == nil {
+		pkgScanner, err = factory.CreateScanner(pm, workDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintln(deps.Out, "Checking for updates...")
+	var warns warnings
+	var skipped scanner.SkipStats
+	modules, err := pkgScanner.GetUpdates(ctx, scanner.Options{
+		Filter:       opts.Filter,
+		IncludeAll:   opts.All,
+		CooldownDays: cooldownDays(opts.Cooldown, opts.CooldownSet, cfg, pm),
+		WorkDir:      workDir,
+		Skipped:      &skipped,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+	modules = dropNonUpgrades(modules, &warns, &skipped)
+	if !opts.All {
+		direct, indirect, _ := groupModules(modules)
+		modules = append(direct, indirect...)
+	}
+
+	var queries []string
+	for _, m := range modules {
+		if m.Update != nil {
+			queries = append(queries, moduleName(m)+"@"+m.Update.Version)
+		}
+	}
+	if len(queries) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "No pending updates to prewarm.")
+		printWarnings(deps.Out, warns.items)
+		return nil
+	}
+
+	download := deps.Download
+	if download == nil {
+		download = goModDownload
+	}
+	_, _ = fmt.Fprintf(deps.Out, "Downloading %d module %s into the module cache...\n", len(queries), plural(len(queries), "version", "versions"))
+	results, err := download(ctx, workDir, queries)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorUpdate, err)
+	}
+
+	cached := 0
+	for _, d := range results {
+		if d.Error != "" {
+			warns.add(d.Path, "download of %s failed: %s", d.Version, d.Error)
+			continue
+		}
+		cached++
+	}
+	_, _ = fmt.Fprintf(deps.Out, "Cached %d of %d module %s.\n", cached, len(queries), plural(len(queries), "version", "versions"))
+	printWarnings(deps.Out, warns.items)
+	if cached < len(queries) {
+		return categorize(ErrorUpdate, fmt.Errorf("%d module %s could not be downloaded", len(queries)-cached, plural(len(queries)-cached, "version", "versions")))
+	}
+	return nil
+}
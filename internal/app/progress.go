@@ -0,0 +1,65 @@
+package app
+
+import (
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// EventKind names a progress event.
+type EventKind string
+
+const (
+	EventScanStarted    EventKind = "scan_started"    // Checking for updates
+	EventModuleResolved EventKind = "module_resolved" // Module has an update from Version to Target
+	EventScanFinished   EventKind = "scan_finished"   // Total modules have updates
+	EventVulnChecked    EventKind = "vuln_checked"    // Done of Total vulnerability lookups finished
+	EventUpgradeStarted EventKind = "upgrade_started" // Total modules are being upgraded
+	EventUpgradeApplied EventKind = "upgrade_applied" // Module was upgraded from Version to Target
+)
+
+// Event reports progress of Run to hosts that render their own progress UI.
+type Event struct {
+	Kind    EventKind `json:"kind"`
+	Time    time.Time `json:"time"`
+	Module  string    `json:"module,omitempty"`
+	Version string    `json:"version,omitempty"`
+	Target  string    `json:"target,omitempty"`
+	Done    int       `json:"done,omitempty"`
+	Total   int       `json:"total,omitempty"`
+}
+
+// ProgressFunc receives progress events. Calls never overlap, but they may
+// come from goroutines other than the one that called Run.
+type ProgressFunc func(Event)
+
+// progress sends events to deps.Progress, if set, stamped with deps.Now.
+type progress struct {
+	fn  ProgressFunc
+	now func() time.Time
+}
+
+func newProgress(deps Deps) progress {
+	now := deps.Now
+	if now == nil {
+		now = time.Now
+	}
+	return progress{fn: deps.Progress, now: now}
+}
+
+func (p progress) emit(e Event) {
+	if p.fn == nil {
+		return
+	}
+	e.Time = p.now()
+	p.fn(e)
+}
+
+// modules emits kind for each module with an update.
+func (p progress) modules(kind EventKind, modules []scanner.Module) {
+	for _, m := range modules {
+		if m.Update != nil {
+			p.emit(Event{Kind: kind, Module: moduleName(m), Version: m.Version, Target: m.Update.Version})
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/goprivate"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// Provenance results shown in the provenance column.
+const (
+	provenanceVerified   = "verified"   // The proxy zip matches the checksum database
+	provenanceAttested   = "attested"   // Verified, and the release publishes an attestation
+	provenanceUnattested = "unattested" // Verified, but a required attestation is missing
+	provenanceMismatch   = "mismatch"   // The proxy zip differs from the checksum database
+)
+
+// ChecksumVerifier checks the zip of modulePath at version against the
+// checksum database, returning an error wrapping goproxy.ErrChecksumMismatch
+// when they differ.
+type ChecksumVerifier func(ctx context.Context, modulePath, version string) error
+
+// proxyChecksums verifies zips from proxy against GOSUMDB. It returns nil
+// when GOSUMDB is off.
+func proxyChecksums(proxy *goproxy.Client) ChecksumVerifier {
+	db := goproxy.NewSumDBFromEnv()
+	if db == nil {
+		return nil
+	}
+	return func(ctx context.Context, modulePath, version string) error {
+		return goproxy.VerifyZip(ctx, proxy, db, modulePath, version)
+	}
+}
+
+// checkProvenance verifies each update's zip against the checksum database
+// and, for modules listed in cfg.Attest, looks for a build attestation
+// among the target release's assets. Results are recorded in
+// Module.Provenance; modules excluded from the checksum database
+// (GONOSUMDB, or GOPRIVATE) are skipped.
+func checkProvenance(ctx context.Context, modules []scanner.Module, cfg config.Provenance, verify ChecksumVerifier, list ReleaseAssetLister, w *warnings) {
+	nosumdb := os.Getenv("GONOSUMDB")
+	if nosumdb == "" {
+		nosumdb = os.Getenv("GOPRIVATE")
+	}
+	for i := range modules {
+		m := &modules[i]
+		name := moduleName(*m)
+		if m.Update == nil || goprivate.Covered(name, nosumdb) {
+			continue
+		}
+		err := verify(ctx, name, m.Update.Version)
+		switch {
+		case errors.Is(err, goproxy.ErrChecksumMismatch):
+			m.Provenance = provenanceMismatch
+			w.add(name, "%s does not match the checksum database: %v", m.Update.Version, err)
+			continue
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			w.add(name, "could not verify %s against the checksum database: %v", m.Update.Version, err)
+			continue
+		}
+		m.Provenance = provenanceVerified
+		if !cfg.RequiresAttestation(name) {
+			continue
+		}
+
+		assets, err := list(ctx, name, m.Update.Version)
+		if err != nil && !errors.Is(err, github.ErrNotFound) {
+			if ctx.Err() != nil {
+				return
+			}
+			w.add(name, "could not look for a provenance attestation of %s: %v", m.Update.Version, err)
+			continue
+		}
+		if hasAttestation(assets) {
+			m.Provenance = provenanceAttested
+		} else {
+			m.Provenance = provenanceUnattested
+			w.add(name, "release %s publishes no provenance attestation", m.Update.Version)
+		}
+	}
+}
+
+// hasAttestation reports whether release assets include a provenance
+// attestation (SLSA in-toto statements or Sigstore bundles).
+func hasAttestation(assets []string) bool {
+	for _, a := range assets {
+		a = strings.ToLower(a)
+		if strings.HasSuffix(a, ".intoto.jsonl") || strings.HasSuffix(a, ".sigstore") ||
+			strings.HasSuffix(a, ".sigstore.json") || strings.Contains(a, "provenance") {
+			return true
+		}
+	}
+	return false
+}
+
+// provenanceColumn renders a provenance result, highlighting failures.
+func provenanceColumn(result string) string {
+	color := lipgloss.Color("240")
+	switch result {
+	case provenanceMismatch:
+		color = lipgloss.Color("196")
+	case provenanceUnattested:
+		color = lipgloss.Color("214")
+	}
+	return lipgloss.NewStyle().Foreground(color).Render("provenance: " + result)
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// DoctorProxyOptions configures DoctorProxy.
+type DoctorProxyOptions struct {
+	Timeout time.Duration // Per-endpoint request timeout; defaults to 10s
+}
+
+// DoctorProxy probes the GOPROXY endpoints and checksum database the go
+// command would use and prints a diagnosis for each. It returns an error when
+// any endpoint is unhealthy.
+func DoctorProxy(ctx context.Context, opts DoctorProxyOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	read := deps.GoEnv
+	if read == nil {
+		read = readGoEnv
+	}
+	env, err := read(ctx, workDir, "GOPROXY", "GOSUMDB", "GOPRIVATE", "GONOPROXY", "GONOSUMDB", "GOFLAGS")
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	for _, name := range []string{"GOPROXY", "GOSUMDB", "GOPRIVATE", "GONOPROXY", "GONOSUMDB", "GOFLAGS"} {
+		value := env[name]
+		if value == "" {
+			value = "(unset)"
+		}
+		_, _ = fmt.Fprintf(deps.Out, "%-9s  %s\n", name, value)
+	}
+	_, _ = fmt.Fprintln(deps.Out)
+
+	problems := 0
+	goproxyValue := strings.TrimSpace(env["GOPROXY"])
+	switch {
+	case goproxyValue == "off":
+		_, _ = fmt.Fprintln(deps.Out, "GOPROXY=off disables module downloads; faro and the go command cannot resolve updates.")
+		problems++
+	case !strings.Contains(goproxyValue, "direct"):
+		// Not a problem by itself, but worth knowing when private modules fail.
+		_, _ = fmt.Fprintln(deps.Out, "GOPROXY has no direct fallback; modules the proxies lack cannot be fetched from source.")
+	}
+	if strings.TrimSpace(env["GOSUMDB"]) == "off" {
+		_, _ = fmt.Fprintln(deps.Out, "GOSUMDB=off: downloaded modules are not verified against a checksum database.")
+	}
+
+	endpoints := goproxy.Endpoints(goproxyValue, env["GOSUMDB"])
+	if len(endpoints) == 0 && goproxyValue != "off" {
+		_, _ = fmt.Fprintln(deps.Out, "GOPROXY only lists direct; modules are fetched from their VCS hosts.")
+	}
+
+	// Like the go command, the probes only authenticate with .netrc.
+	client := goproxy.NewClientWithOptions(goproxy.Options{Timeout: opts.Timeout})
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	pad := 0
+	for _, e := range endpoints {
+		pad = max(pad, len(e.URL))
+	}
+	for _, e := range endpoints {
+		res := client.Probe(ctx, e)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		status := "---"
+		if res.Status != 0 {
+			status = fmt.Sprintf("%d", res.Status)
+		}
+		mark := green.Render(style.Glyphs.Check)
+		if !res.OK() {
+			mark = red.Render(style.Glyphs.Cross)
+		}
+		_, _ = fmt.Fprintf(deps.Out, " %s %-5s  %-*s  %s  %s\n", mark, e.Kind, pad, e.URL, status, res.Latency.Round(time.Millisecond))
+		if !res.OK() {
+			_, _ = fmt.Fprintf(deps.Out, "     %s\n", res.Problem)
+			problems++
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("%d proxy %s found", problems, plural(problems, "problem", "problems"))
+	}
+	_, _ = fmt.Fprintln(deps.Out, "\nAll endpoints healthy.")
+	return nil
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	gomodScanner "github.com/pragmaticivan/faro/internal/scanner/gomod"
+)
+
+// goCommandAvailable reports whether the go command is on PATH.
+func goCommandAvailable() bool {
+	_, err := exec.LookPath("go")
+	return err == nil
+}
+
+// proxyClient returns the module proxy client for faro's own lookups: the
+// proxy in GOPROXY, private modules sent to cfg.Private (or nowhere), and
+// the token named by cfg.TokenEnv.
+func proxyClient(cfg config.GoProxy) *goproxy.Client {
+	o := goproxy.EnvOptions()
+	o.PrivateURL = cfg.Private
+	if cfg.TokenEnv != "" {
+		o.Token = os.Getenv(cfg.TokenEnv)
+	}
+	return goproxy.NewClientWithOptions(o)
+}
+
+// proxyScanner returns a Go scanner that queries proxy instead of running
+// go list. Unless it was asked for, it is the fallback for a missing Go
+// toolchain, which is worth a warning: it only checks go.mod requirements.
+func proxyScanner(workDir string, proxy *goproxy.Client, requested bool, w *warnings) scanner.Scanner {
+	if !requested {
+		w.add("", "the go command was not found; checking go.mod requirements against the module proxy instead")
+	}
+	return gomodScanner.NewProxyScanner(workDir, proxy)
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+// Vulnerability modes (--vuln-mode).
+const (
+	VulnModeOSV       = "osv"       // Count every known vulnerability of a version
+	VulnModeCallgraph = "callgraph" // Also count those reachable from the project's code
+)
+
+// GovulncheckRunner runs govulncheck -json over the module in workDir.
+type GovulncheckRunner func(ctx context.Context, workDir string) ([]byte, error)
+
+// parseVulnMode validates a --vuln-mode value; empty means osv.
+func parseVulnMode(mode string) (string, error) {
+	switch mode {
+	case "", VulnModeOSV:
+		return VulnModeOSV, nil
+	case VulnModeCallgraph:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid --vuln-mode %q (supported: osv, callgraph)", mode)
+}
+
+// annotateReachable sets VulnReachable on every module with an update from
+// a govulncheck run over the project, so counts only cover vulnerable
+// functions its code calls. When govulncheck fails, the modules keep the
+// OSV counts alone.
+func annotateReachable(ctx context.Context, modules []scanner.Module, workDir string, run GovulncheckRunner, w *warnings) {
+	out, err := run(ctx, workDir)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.fail("", "reachability analysis failed: %v", err)
+		}
+		return
+	}
+	reachable, err := vuln.ParseGovulncheck(bytes.NewReader(out))
+	if err != nil {
+		w.fail("", "reachability analysis failed: %v", err)
+		return
+	}
+	for i, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		c := reachable[moduleName(m)]
+		modules[i].VulnReachable = &scanner.VulnInfo{Low: c.Low, Medium: c.Medium, High: c.High, Critical: c.Critical, Total: c.Total}
+	}
+}
+
+// reachableTag renders how many of a module's vulnerabilities its callers
+// reach.
+func reachableTag(reachable scanner.VulnInfo) string {
+	text := fmt.Sprintf("(%d reachable)", reachable.Total)
+	if reachable.Total == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(text)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(text)
+}
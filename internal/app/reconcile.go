@@ -0,0 +1,308 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/lockfile"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// ReconcileOptions configures Reconcile.
+type ReconcileOptions struct {
+	Repo      string // GitHub repository whose alerts to reconcile, "owner/name"
+	Manager   string // Package manager override
+	GoModPath string // Path to a go.mod file (or its directory); implies the go manager
+	JSON      bool   // Write the reconciliation as JSON
+}
+
+// AlertLister returns the open Dependabot alerts of owner/repo.
+type AlertLister func(ctx context.Context, owner, repo string) ([]github.DependabotAlert, error)
+
+// InstalledLister returns the installed version of every dependency of the
+// pm project in workDir, direct or transitive.
+type InstalledLister func(ctx context.Context, pm detector.PackageManager, workDir string) (map[string]string, error)
+
+// reconciledAlert is one Dependabot alert matched against the local scan.
+type reconciledAlert struct {
+	Number   int    `json:"number"`
+	URL      string `json:"url,omitempty"`
+	Package  string `json:"package"`
+	Manifest string `json:"manifest,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Advisory string `json:"advisory,omitempty"`
+	Patched  string `json:"patched,omitempty"`
+	Current  string `json:"current,omitempty"`
+	Target   string `json:"target,omitempty"` // Update that closes the alert
+	Reason   string `json:"reason,omitempty"` // Why faro cannot close the alert
+}
+
+// reconcileReport is the JSON output of Reconcile.
+type reconcileReport struct {
+	Repo string `json:"repo"`
+	// Closable alerts are fixed by an available update.
+	Closable []reconciledAlert `json:"closable"`
+	// Fixed alerts are already resolved locally and close once pushed.
+	Fixed []reconciledAlert `json:"fixed"`
+	// Unfixable alerts cannot be closed by upgrading this project.
+	Unfixable []reconciledAlert `json:"unfixable"`
+	// Skipped counts alerts for other ecosystems.
+	Skipped int `json:"skipped"`
+}
+
+// Reconcile cross-references the open Dependabot alerts of a GitHub
+// repository with a local scan, reporting which alerts an available update
+// would close, which are already fixed locally, and which faro cannot fix.
+func Reconcile(ctx context.Context, opts ReconcileOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	owner, repo, ok := strings.Cut(opts.Repo, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return categorize(ErrorUsage, fmt.Errorf("invalid repository %q: want owner/name", opts.Repo))
+	}
+
+	workDir, pm, err := resolveManager("", opts.Manager, opts.GoModPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+
+	list := deps.DependabotAlerts
+	if list == nil {
+		gh := &lazyGitHubClient{cfg: cfg.GitHub}
+		list = gh.get().DependabotAlerts
+	}
+	alerts, err := list(ctx, owner, repo)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to list Dependabot alerts of %s: %w", opts.Repo, err)
+	}
+
+	pkgScanner := deps.Scanner
+	if pkgScanner == nil {
+		pkgScanner, err = factory.CreateScanner(pm, workDir)
+		if err != nil {
+			return err
+		}
+	}
+	if !opts.JSON {
+		_, _ = fmt.Fprintf(deps.Out, "Reconciling %d open Dependabot %s for %s...\n", len(alerts), plural(len(alerts), "alert", "alerts"), opts.Repo)
+	}
+	modules, err := pkgScanner.GetUpdates(ctx, scanner.Options{IncludeAll: true, WorkDir: workDir})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+
+	var warns warnings
+	listInstalled := deps.Installed
+	if listInstalled == nil {
+		listInstalled = installedVersions
+	}
+	current, err := listInstalled(ctx, pm, workDir)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if pm == detector.Go {
+			warns.add("", "%v; only go.mod requirements are reconciled", err)
+		} else {
+			warns.add("", "%v; only outdated packages are reconciled", err)
+		}
+		current = requiredVersions(pm, workDir)
+	}
+
+	report := reconcileAlerts(alerts, modules, current, alertEcosystem(pm))
+	report.Repo = opts.Repo
+
+	if opts.JSON {
+		enc := json.NewEncoder(deps.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		printWarnings(deps.Err, warns.items)
+		return nil
+	}
+	printReconcile(deps, report)
+	printWarnings(deps.Out, warns.items)
+	return nil
+}
+
+// reconcileAlerts sorts alerts for ecosystem into closable, fixed and
+// unfixable using the scanned updates and the known current versions.
+func reconcileAlerts(alerts []github.DependabotAlert, modules []scanner.Module, current map[string]string, ecosystem string) reconcileReport {
+	updates := make(map[string]scanner.Module, len(modules))
+	for _, m := range modules {
+		if m.Update != nil {
+			updates[moduleName(m)] = m
+		}
+	}
+
+	report := reconcileReport{Closable: []reconciledAlert{}, Fixed: []reconciledAlert{}, Unfixable: []reconciledAlert{}}
+	for _, a := range alerts {
+		if !strings.EqualFold(a.Dependency.Package.Ecosystem, ecosystem) {
+			report.Skipped++
+			continue
+		}
+		r := reconciledAlert{
+			Number:   a.Number,
+			URL:      a.HTMLURL,
+			Package:  a.Dependency.Package.Name,
+			Manifest: a.Dependency.ManifestPath,
+			Severity: strings.ToUpper(a.SecurityAdvisory.Severity),
+			Advisory: a.SecurityAdvisory.GHSAID,
+			Patched:  a.PatchedVersion(),
+			Current:  current[installedName(ecosystem, a.Dependency.Package.Name)],
+		}
+		m, hasUpdate := updates[r.Package]
+		if hasUpdate {
+			r.Current = m.Version
+		}
+		switch {
+		case r.Patched == "":
+			r.Reason = "no patched version has been released"
+		case r.Current != "" && atLeast(r.Current, r.Patched):
+			report.Fixed = append(report.Fixed, r)
+			continue
+		case hasUpdate && atLeast(m.Update.Version, r.Patched):
+			r.Target = m.Update.Version
+			report.Closable = append(report.Closable, r)
+			continue
+		case hasUpdate:
+			r.Reason = fmt.Sprintf("fixed in %s, but the newest available update is %s", r.Patched, m.Update.Version)
+		case r.Current != "":
+			r.Reason = fmt.Sprintf("fixed in %s, but no update past %s is available", r.Patched, r.Current)
+		default:
+			r.Reason = "not a dependency of this project"
+		}
+		report.Unfixable = append(report.Unfixable, r)
+	}
+	for _, list := range [][]reconciledAlert{report.Closable, report.Fixed, report.Unfixable} {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Package < list[j].Package })
+	}
+	return report
+}
+
+// atLeast reports whether version is at or past patched. Versions that
+// cannot be compared never are.
+func atLeast(version, patched string) bool {
+	cmp, ok := style.ComparePrecedence(version, patched)
+	return ok && cmp >= 0
+}
+
+// installedVersions returns the build list of a Go project, and the
+// lockfile versions of other projects.
+func installedVersions(ctx context.Context, pm detector.PackageManager, workDir string) (map[string]string, error) {
+	if pm != detector.Go {
+		return lockfile.Versions(pm, workDir)
+	}
+	// -mod=readonly keeps a GOFLAGS=-mod=mod from rewriting go.mod and go.sum.
+	out, err := execx.Command(ctx, workDir, "go", "list", "-mod=readonly", "-m", "-f", "{{.Path}} {{.Version}}", "all").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the build list: %w", err)
+	}
+	versions := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, version, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+			versions[path] = version
+		}
+	}
+	return versions, nil
+}
+
+// requiredVersions returns the required version of every module in a Go
+// project's go.mod; other ecosystems only learn versions from the scan.
+func requiredVersions(pm detector.PackageManager, workDir string) map[string]string {
+	versions := make(map[string]string)
+	if pm != detector.Go {
+		return versions
+	}
+	requires, _ := gomod.ReadRequires(filepath.Join(workDir, "go.mod"))
+	for _, r := range requires {
+		versions[r.Path] = r.Version
+	}
+	return versions
+}
+
+// installedName returns the name an alert's package has in the installed
+// versions: the PEP 503 form for Python packages.
+func installedName(ecosystem, name string) string {
+	if ecosystem == "pip" {
+		return lockfile.Normalize(name)
+	}
+	return name
+}
+
+// alertEcosystem maps pm to the ecosystem name Dependabot uses.
+func alertEcosystem(pm detector.PackageManager) string {
+	if eco := pm.Ecosystem(); eco != "pypi" {
+		return eco
+	}
+	return "pip"
+}
+
+func printReconcile(deps Deps, report reconcileReport) {
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	orange := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	label := func(r reconciledAlert) string {
+		parts := []string{fmt.Sprintf("#%d", r.Number)}
+		if r.Severity != "" {
+			parts = append(parts, r.Severity)
+		}
+		if r.Advisory != "" {
+			parts = append(parts, r.Advisory)
+		}
+		return dim.Render(strings.Join(parts, " "))
+	}
+
+	if len(report.Closable) > 0 {
+		maxPathLen := 0
+		for _, r := range report.Closable {
+			maxPathLen = max(maxPathLen, len(r.Package))
+		}
+		_, _ = fmt.Fprintf(deps.Out, "\n%s\n", green.Render(fmt.Sprintf("Closed by upgrading (%d):", len(report.Closable))))
+		for _, r := range report.Closable {
+			_, _ = fmt.Fprintf(deps.Out, " %s  %s\n", style.FormatUpdate(r.Package, r.Current, r.Target, maxPathLen), label(r))
+		}
+	}
+	if len(report.Fixed) > 0 {
+		_, _ = fmt.Fprintf(deps.Out, "\n%s\n", green.Render(fmt.Sprintf("Already fixed locally; closes once pushed (%d):", len(report.Fixed))))
+		for _, r := range report.Fixed {
+			_, _ = fmt.Fprintf(deps.Out, " %s %s  %s\n", r.Package, r.Current, label(r))
+		}
+	}
+	if len(report.Unfixable) > 0 {
+		_, _ = fmt.Fprintf(deps.Out, "\n%s\n", orange.Render(fmt.Sprintf("Not fixable by upgrading (%d):", len(report.Unfixable))))
+		for _, r := range report.Unfixable {
+			_, _ = fmt.Fprintf(deps.Out, " %s  %s: %s\n", r.Package, label(r), r.Reason)
+		}
+	}
+	if len(report.Closable)+len(report.Fixed)+len(report.Unfixable) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "\nNo open alerts for this project's ecosystem.")
+	}
+	if report.Skipped > 0 {
+		_, _ = fmt.Fprintf(deps.Out, "\n%s\n", dim.Render(fmt.Sprintf("Skipped %d %s for other ecosystems.", report.Skipped, plural(report.Skipped, "alert", "alerts"))))
+	}
+}
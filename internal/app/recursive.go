@@ -0,0 +1,189 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/format"
+)
+
+// projectReport is one project's entry in the --recursive JSON output.
+type projectReport struct {
+	Dir     string          `json:"dir"`
+	Manager string          `json:"manager"`
+	Report  json.RawMessage `json:"report,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// projectDir returns dir, or the working directory when dir is empty.
+func projectDir(dir string) (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return wd, nil
+}
+
+// runRecursive runs Run in every project found below the working directory.
+// Text output gets a heading per project; JSON output wraps the project
+// reports in {"projects": [...]} and JSON lines records gain a "project"
+// field. A failing project does not stop the others; the failures are
+// returned together at the end, along with the most severe --exit-code
+// status of the projects.
+func runRecursive(ctx context.Context, opts RunOptions, deps Deps) error {
+	if opts.GoModPath != "" {
+		return categorize(ErrorUsage, fmt.Errorf("--recursive cannot be combined with --gomod"))
+	}
+	formats, err := format.ParseFlag(opts.FormatFlag)
+	if err != nil {
+		return categorize(ErrorUsage, err)
+	}
+	if formats.Lines || formats.Markdown || formats.Script || opts.TemplatePath != "" {
+		return categorize(ErrorUsage, fmt.Errorf("--recursive supports text, json and jsonl output only"))
+	}
+	var pm detector.PackageManager
+	if opts.Manager != "" {
+		if pm, err = detector.Validate(opts.Manager); err != nil {
+			return categorize(ErrorUsage, err)
+		}
+	}
+	if opts.Ecosystem != "" {
+		if _, err := detector.ValidateEcosystem(opts.Ecosystem); err != nil {
+			return categorize(ErrorUsage, err)
+		}
+	}
+
+	root, err := projectDir(opts.Dir)
+	if err != nil {
+		return err
+	}
+	found, err := detector.FindProjects(root)
+	if err != nil {
+		return categorize(ErrorDetect, err)
+	}
+	if len(found) == 0 {
+		return categorize(ErrorDetect, fmt.Errorf("no supported projects found below %s", root))
+	}
+
+	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	var reports []projectReport
+	var errs []error
+	var worst *Status
+	for _, p := range found {
+		manager, ok := projectManager(p, pm, opts.Ecosystem)
+		if !ok {
+			continue
+		}
+		rel, err := filepath.Rel(root, p.Dir)
+		if err != nil {
+			rel = p.Dir
+		}
+
+		sub := opts
+		sub.Recursive = false
+		sub.Dir = p.Dir
+		sub.Manager = manager.String()
+		sub.Ecosystem = ""
+		sub.project = fmt.Sprintf("%s (%s)", rel, manager)
+		subDeps := deps
+
+		var buf bytes.Buffer
+		if formats.Machine() {
+			subDeps.Out = &buf
+		} else {
+			_, _ = fmt.Fprintf(deps.Out, "\n%s\n", heading.Render("== "+sub.project+" =="))
+		}
+		err = Run(ctx, sub, subDeps)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// With --exit-code a finished project reports its status as an error.
+		var status *Status
+		if errors.As(err, &status) {
+			worst = worseStatus(worst, status)
+			err = nil
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rel, err))
+		}
+
+		switch {
+		case formats.JSON:
+			r := projectReport{Dir: rel, Manager: manager.String()}
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Report = bytes.TrimSpace(buf.Bytes())
+			}
+			reports = append(reports, r)
+		case formats.JSONL:
+			if err := tagJSONLines(deps.Out, buf.Bytes(), rel); err != nil {
+				return err
+			}
+		}
+	}
+
+	if formats.JSON {
+		if reports == nil {
+			reports = []projectReport{}
+		}
+		enc := json.NewEncoder(deps.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(struct {
+			Projects []projectReport `json:"projects"`
+		}{reports}); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+	}
+	if worst != nil {
+		errs = append(errs, worst)
+	}
+	return errors.Join(errs...)
+}
+
+// projectManager picks the manager to scan p with: want when set (and
+// detected in p), otherwise the highest-priority one detected, limited to
+// ecosystem when that is set.
+func projectManager(p detector.Project, want detector.PackageManager, ecosystem string) (detector.PackageManager, bool) {
+	for _, r := range p.Managers {
+		if (want == "" || r.Manager == want) && (ecosystem == "" || r.Manager.Ecosystem() == ecosystem) {
+			return r.Manager, true
+		}
+	}
+	return "", false
+}
+
+// tagJSONLines copies the JSON lines records in data to out, adding the
+// project they belong to.
+func tagJSONLines(out io.Writer, data []byte, project string) error {
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	lines.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	tag, err := json.Marshal(project)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	enc := json.NewEncoder(out)
+	for lines.Scan() {
+		var record map[string]json.RawMessage
+		if err := json.Unmarshal(lines.Bytes(), &record); err != nil {
+			return fmt.Errorf("failed to decode JSON output: %w", err)
+		}
+		record["project"] = tag
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+	}
+	return lines.Err()
+}
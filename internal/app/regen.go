@@ -0,0 +1,166 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/execx"
+)
+
+// CommandRunner runs name with args in dir and returns its combined output.
+type CommandRunner func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+
+// bazelCandidates are the Bazel files a Go upgrade can leave stale, checked
+// when bazel.files is not configured.
+var bazelCandidates = []string{"MODULE.bazel", "MODULE.bazel.lock", "deps.bzl", "WORKSPACE", "WORKSPACE.bazel"}
+
+// gomod2nixFile is the lock file gomod2nix generates from go.mod.
+const gomod2nixFile = "gomod2nix.toml"
+
+// regenStep is a command regenerating files derived from go.mod, run after
+// every Go upgrade.
+type regenStep struct {
+	section string   // .faro.json section configuring the step
+	command []string // Empty when the step is not configured
+	implied bool     // command is a default; a missing executable only warns
+	files   []string // Files the command rewrites, relative to the module directory
+}
+
+func runCommand(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	return execx.Command(ctx, dir, name, args...).CombinedOutput()
+}
+
+// regenSteps returns the Bazel and Nix steps for workDir, with their
+// defaults applied.
+func regenSteps(workDir string, cfg config.Config) []regenStep {
+	bazel := regenStep{section: "bazel", command: cfg.Bazel.Command, files: cfg.Bazel.Files}
+	if len(bazel.files) == 0 {
+		bazel.files = existingFiles(workDir, bazelCandidates)
+	}
+	nix := regenStep{section: "nix", command: cfg.Nix.Command, files: cfg.Nix.Files}
+	if len(nix.files) == 0 {
+		nix.files = existingFiles(workDir, []string{gomod2nixFile})
+	}
+	if len(nix.command) == 0 && len(existingFiles(workDir, []string{gomod2nixFile})) > 0 {
+		nix.command, nix.implied = []string{"gomod2nix", "generate"}, true
+	}
+	return []regenStep{bazel, nix}
+}
+
+// regenFiles returns the files the configured steps rewrite.
+func regenFiles(steps []regenStep) []string {
+	var files []string
+	for _, s := range steps {
+		if len(s.command) > 0 {
+			files = append(files, s.files...)
+		}
+	}
+	return files
+}
+
+func existingFiles(dir string, names []string) []string {
+	var files []string
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			files = append(files, name)
+		}
+	}
+	return files
+}
+
+// runRegen runs the configured steps in dir, in order.
+func runRegen(ctx context.Context, dir string, steps []regenStep, run CommandRunner, w *warnings) error {
+	if run == nil {
+		run = runCommand
+	}
+	for _, s := range steps {
+		if len(s.command) == 0 {
+			continue
+		}
+		out, err := run(ctx, dir, s.command[0], s.command[1:]...)
+		if s.implied && errors.Is(err, exec.ErrNotFound) {
+			w.add("", "%s is not installed; run %s to update %s", s.command[0], strings.Join(s.command, " "), strings.Join(s.files, ", "))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s failed: %s: %w", strings.Join(s.command, " "), strings.TrimSpace(string(out)), err)
+		}
+	}
+	return nil
+}
+
+// dryRunRegen runs the configured steps against a temporary copy of their
+// files next to the upgraded go.mod and go.sum in after, and adds the
+// regenerated files to after.
+func dryRunRegen(ctx context.Context, workDir string, steps []regenStep, run CommandRunner, after map[string][]byte, w *warnings) error {
+	files := regenFiles(steps)
+	if len(files) == 0 {
+		return nil
+	}
+	tmpDir, err := os.MkdirTemp("", "faro-regen-")
+	if err != nil {
+		return fmt.Errorf("failed to create dry run directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(workDir, name))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if err := writeCopy(tmpDir, name, data); err != nil {
+			return err
+		}
+	}
+	for name, data := range after {
+		if err := writeCopy(tmpDir, name, data); err != nil {
+			return err
+		}
+	}
+	if err := runRegen(ctx, tmpDir, steps, run, w); err != nil {
+		return err
+	}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read dry run %s: %w", name, err)
+		}
+		after[name] = data
+	}
+	return nil
+}
+
+func writeCopy(dir, name string, data []byte) error {
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", name, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", name, err)
+	}
+	return nil
+}
+
+// checkRegen warns about files derived from go.mod that no configured
+// command keeps in sync: Bazel files without bazel.command, and Nix
+// expressions pinning a vendorHash that changes with go.sum.
+func checkRegen(workDir string, steps []regenStep, w *warnings) {
+	for _, s := range steps {
+		if len(s.command) == 0 && len(s.files) > 0 {
+			w.add("", "found %s: set %s.command in .faro.json to update them after upgrades", strings.Join(s.files, ", "), s.section)
+		}
+	}
+	for _, name := range []string{"flake.nix", "default.nix"} {
+		data, err := os.ReadFile(filepath.Join(workDir, name))
+		if err == nil && bytes.Contains(data, []byte("vendorHash")) {
+			w.add("", "%s pins a vendorHash; update it after upgrading (or switch to gomod2nix)", name)
+		}
+	}
+}
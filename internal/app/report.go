@@ -0,0 +1,48 @@
+package app
+
+import (
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/report"
+)
+
+// reportTemplate returns the template text for --format markdown: the
+// --template file if given, else the configured report template (relative to
+// workDir), else report.DefaultMarkdown.
+func reportTemplate(templatePath string, cfg config.Config, workDir string) (string, error) {
+	switch {
+	case templatePath != "":
+		return report.Load(templatePath)
+	case cfg.Report.Template != "":
+		return report.Load(resolveProjectPath(workDir, cfg.Report.Template))
+	default:
+		return report.DefaultMarkdown, nil
+	}
+}
+
+// writeReport renders records, warnings and the environment snapshot through
+// the report template.
+func writeReport(out io.Writer, text, manager string, records []format.Record, warns []Warning, env *report.Environment, now time.Time) error {
+	data := report.Data{
+		Manager:     manager,
+		Generated:   now,
+		Updates:     records,
+		Environment: env,
+	}
+	for _, w := range warns {
+		data.Warnings = append(data.Warnings, w.String())
+	}
+	return report.Render(out, text, data)
+}
+
+// resolveProjectPath interprets a config-relative path against workDir.
+func resolveProjectPath(workDir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(workDir, path)
+}
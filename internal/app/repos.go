@@ -0,0 +1,46 @@
+package app
+
+import (
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/diskcache"
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/vanity"
+)
+
+// lazyRepoResolver creates the vanity resolver on first use so runs that never
+// need repository URLs don't touch the cache directory.
+type lazyRepoResolver struct {
+	resolver *vanity.Resolver
+}
+
+func (l *lazyRepoResolver) get() github.RepoResolver {
+	if l.resolver == nil {
+		l.resolver = vanity.NewResolver(diskcache.Dir())
+	}
+	return l.resolver
+}
+
+// save persists new lookups; a failure only costs a slower next run.
+func (l *lazyRepoResolver) save() {
+	if l.resolver != nil {
+		_ = l.resolver.Save()
+	}
+}
+
+// lazyGitHubClient creates one GitHub client per run on first use, so every
+// GitHub-backed feature shares its response cache and rate-limit handling.
+type lazyGitHubClient struct {
+	cfg    config.GitHub
+	client *github.Client
+}
+
+func (l *lazyGitHubClient) get() *github.Client {
+	if l.client == nil {
+		baseURL := l.cfg.BaseURL
+		if baseURL == "" {
+			baseURL = github.DefaultBaseURL
+		}
+		l.client = github.NewClientWithBaseURL(baseURL, github.Token(l.cfg.TokenEnv))
+	}
+	return l.client
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/changelog"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// riskConcurrency bounds the number of concurrent release note requests.
+const riskConcurrency = 4
+
+// riskHintWidth is the longest matched line shown in text output.
+const riskHintWidth = 60
+
+// releaseNotes returns deps.ReleaseNotes, or a source reading releases from
+// GitHub and gitlab.com.
+func releaseNotes(deps Deps, gh *lazyGitHubClient, repos *lazyRepoResolver) changelog.Source {
+	if deps.ReleaseNotes != nil {
+		return deps.ReleaseNotes
+	}
+	return changelog.Sources(
+		changelog.NewGitHubSource(gh.get(), repos.get()),
+		changelog.NewGitLabSource(&http.Client{Timeout: 15 * time.Second}, ""),
+	)
+}
+
+// annotateRisks fills RiskHints for every module with an update by scanning
+// the release notes published between its current and target versions.
+// Modules without a known release source are skipped silently.
+func annotateRisks(ctx context.Context, modules []scanner.Module, source changelog.Source, keywords []string, w *warnings) {
+	errs := make([]error, len(modules))
+	sem := make(chan struct{}, riskConcurrency)
+	var wg sync.WaitGroup
+	for i := range modules {
+		if modules[i].Update == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			m := &modules[i]
+			releases, err := source.Releases(ctx, moduleName(*m))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for _, hit := range changelog.Scan(changelog.Between(releases, m.Version, m.Update.Version), keywords) {
+				m.RiskHints = append(m.RiskHints, hit.String())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		w.add("", "release note scan interrupted: %v", ctx.Err())
+		return
+	}
+	for i, err := range errs {
+		if err == nil || errors.Is(err, changelog.ErrUnsupported) {
+			continue
+		}
+		w.add(moduleName(modules[i]), "release note scan failed: %v", err)
+	}
+}
+
+// formatRiskHint renders the first matched release note line, truncated, with
+// a count of any further matches. It returns "" when there are no hints.
+func formatRiskHint(hints []string) string {
+	if len(hints) == 0 {
+		return ""
+	}
+	orange := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+	first := []rune(hints[0])
+	hint := string(first)
+	if len(first) > riskHintWidth {
+		hint = string(first[:riskHintWidth-1]) + style.Glyphs.Ellipsis
+	}
+	if len(hints) > 1 {
+		hint += fmt.Sprintf(" (+%d more)", len(hints)-1)
+	}
+	return orange.Render(style.Glyphs.Warning + " " + hint)
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/goprivate"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+// safeBatchCommand applies every safe update in one go.
+const safeBatchCommand = "faro -u --target patch --only-safe"
+
+// keepPatches restricts modules to patch-level updates (--only-safe). The
+// cooldown in effect has already been applied by the scan.
+func keepPatches(modules []scanner.Module, skipped *scanner.SkipStats) []scanner.Module {
+	out := make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		if format.GroupForModule(m) == format.GroupPatch {
+			out = append(out, m)
+		} else {
+			skipped.Add(scanner.SkipUnsafe)
+		}
+	}
+	return out
+}
+
+// keepVulnFree looks up the vulnerabilities of modules and drops updates
+// whose target has known vulnerabilities or could not be checked
+// (--only-safe). Modules matching the private patterns are not looked up
+// and kept, as checkVulnerabilities does.
+func keepVulnFree(ctx context.Context, modules []scanner.Module, client vuln.Client, private string, skipped *scanner.SkipStats, w *warnings) ([]scanner.Module, error) {
+	failures, err := vuln.AnnotateModules(ctx, modules, vuln.Options{
+		Client: client,
+		Skip:   func(name string) bool { return goprivate.Covered(name, private) },
+	})
+	if err != nil {
+		return nil, err
+	}
+	failed := make(map[string]bool, len(failures))
+	for _, f := range failures {
+		w.fail(f.Module, "vulnerability check failed for %s: %v", f.Version, f.Err)
+		failed[f.Module] = true
+	}
+	out := make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		if failed[moduleName(m)] || m.VulnUpdate.Total > 0 {
+			skipped.Add(scanner.SkipUnsafe)
+			continue
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// safeBatchLine suggests the command applying the safe updates among
+// modules at once, or returns "" when none of them is one. Vulnerability
+// counts are only considered when they were looked up.
+func safeBatchLine(modules []scanner.Module, vulnsChecked bool, opts RunOptions) string {
+	n := 0
+	for _, m := range modules {
+		if format.GroupForModule(m) == format.GroupPatch && (!vulnsChecked || m.VulnUpdate.Total == 0) {
+			n++
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+	command := safeBatchCommand
+	if opts.GoModPath != "" {
+		command += " --gomod " + opts.GoModPath
+	} else if opts.Manager != "" {
+		command += " --manager " + opts.Manager
+	}
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	return fmt.Sprintf("%s %s", dim.Render(fmt.Sprintf("Safe batch (%d patch %s):", n, plural(n, "update", "updates"))), green.Render(command))
+}
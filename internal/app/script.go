@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// writeUpgradeScript prints a POSIX shell script running the commands u
+// would run to upgrade modules. Held back modules are listed as comments so
+// reviewers can add them by hand.
+func writeUpgradeScript(out io.Writer, pm detector.PackageManager, workDir string, u updater.Updater, modules, held []scanner.Module) error {
+	scripter, ok := u.(updater.Scripter)
+	if !ok {
+		return categorize(ErrorUsage, fmt.Errorf("--format script is not supported for %s", pm))
+	}
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "# Upgrade commands generated by faro for %d %s updates.\n", len(modules), pm)
+	fmt.Fprintf(&b, "# Review and edit, then run in %s.\n", workDir)
+	b.WriteString("set -e\n")
+	if cmds := scripter.Commands(modules); len(cmds) > 0 {
+		b.WriteString("\n")
+		for _, cmd := range cmds {
+			quoted := make([]string, len(cmd))
+			for i, arg := range cmd {
+				quoted[i] = shellQuote(arg)
+			}
+			b.WriteString(strings.Join(quoted, " ") + "\n")
+		}
+	}
+	if len(held) > 0 {
+		b.WriteString("\n# Held back (critical, new owners or above policy.autoApply):\n")
+		for _, m := range held {
+			fmt.Fprintf(&b, "#   %s %s -> %s\n", moduleName(m), m.Version, m.Update.Version)
+		}
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// shellQuote returns s as a single shell word, single-quoting it unless it
+// only holds characters the shell passes through unchanged.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,403 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/jobs"
+)
+
+// Serve defaults.
+const (
+	DefaultServeAddr     = "127.0.0.1:8080"
+	DefaultServeWorkers  = 4
+	DefaultServeQueue    = 100
+	DefaultServeCacheTTL = 10 * time.Minute
+)
+
+// ServeOptions configures Serve.
+type ServeOptions struct {
+	Addr       string        // Listen address (default DefaultServeAddr)
+	Workers    int           // Scans run at once (default DefaultServeWorkers)
+	QueueSize  int           // Scans waiting for a worker before submissions are refused (default DefaultServeQueue)
+	CacheTTL   time.Duration // How long finished scans are kept and successful ones reused (default DefaultServeCacheTTL)
+	Root       string        // Only scan projects under this directory; relative paths resolve against it
+	AllowApply bool          // Let the gRPC Apply RPC upgrade projects; scans never modify files
+}
+
+// scanRequest is the body of POST /scans.
+type scanRequest struct {
+	Path            string `json:"path"` // Go module directory or go.mod file on the server
+	Filter          string `json:"filter,omitempty"`
+	All             bool   `json:"all,omitempty"`
+	Cooldown        int    `json:"cooldown,omitempty"`
+	Vulnerabilities bool   `json:"vulnerabilities,omitempty"`
+}
+
+// Serve runs an HTTP API that queues scans of Go modules on this host:
+//
+//	POST /scans               submit a scan, returns the job (202, or 200 when cached)
+//	GET  /scans/{id}          poll the job status
+//	GET  /scans/{id}/result   fetch the JSON report once the job has finished
+//
+// The same address serves the gRPC service faro.v1.Faro over HTTP/2 without
+// TLS, whose Scan, Plan and Apply RPCs stream progress (see serveGRPC).
+// Jobs run on a bounded worker pool. Serve stops when ctx is canceled.
+func Serve(ctx context.Context, opts ServeOptions, deps Deps) error {
+	if opts.Addr == "" {
+		opts.Addr = DefaultServeAddr
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultServeWorkers
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultServeQueue
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = DefaultServeCacheTTL
+	}
+	if opts.Root != "" {
+		root, err := filepath.Abs(opts.Root)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", opts.Root, err)
+		}
+		opts.Root = root
+	}
+
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+	}
+
+	queue := jobs.New(ctx, opts.Workers, opts.QueueSize, opts.CacheTTL, deps.Now)
+	defer queue.Close()
+
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true) // gRPC clients connect with prior knowledge
+	srv := &http.Server{
+		Handler:           newServeHandler(queue, opts, deps),
+		Protocols:         &protocols,
+		ReadHeaderTimeout: 10 * time.Second,
+		// Streams end when Serve stops instead of holding up the shutdown.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	_, _ = fmt.Fprintf(deps.Out, "Listening on http://%s and grpc://%s (%d workers, queue %d)\n", ln.Addr(), ln.Addr(), opts.Workers, opts.QueueSize)
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return ctx.Err()
+}
+
+// newServeHandler routes the scan API and the gRPC service onto queue.
+func newServeHandler(queue *jobs.Queue, opts ServeOptions, deps Deps) http.Handler {
+	mux := http.NewServeMux()
+	running := &serveProgress{latest: make(map[string]Event), watchers: make(map[chan Event]string)}
+	s := &serveJobs{queue: queue, running: running, deps: deps}
+	serveGRPC(mux, s, opts)
+
+	mux.HandleFunc("POST /scans", func(w http.ResponseWriter, r *http.Request) {
+		var req scanRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			writeServeError(w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+			return
+		}
+		path, err := servePath(opts.Root, req.Path)
+		if err != nil {
+			writeServeError(w, http.StatusBadRequest, err)
+			return
+		}
+		req.Path = path
+		job, err := s.submit(serveScan, req)
+		if errors.Is(err, jobs.ErrFull) {
+			w.Header().Set("Retry-After", "5")
+			writeServeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		if err != nil {
+			writeServeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		w.Header().Set("Location", "/scans/"+job.ID)
+		status := http.StatusAccepted
+		if job.Done() {
+			status = http.StatusOK
+		}
+		writeServeJSON(w, status, job)
+	})
+
+	mux.HandleFunc("GET /scans/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := queue.Get(r.PathValue("id"))
+		if !ok {
+			writeServeError(w, http.StatusNotFound, errors.New("no such job"))
+			return
+		}
+		writeServeJSON(w, http.StatusOK, serveJob{Job: job, Progress: running.get(job.Key)})
+	})
+
+	mux.HandleFunc("GET /scans/{id}/result", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := queue.Get(r.PathValue("id"))
+		switch {
+		case !ok:
+			writeServeError(w, http.StatusNotFound, errors.New("no such job"))
+		case !job.Done():
+			writeServeJSON(w, http.StatusAccepted, job)
+		default:
+			status := http.StatusOK
+			if job.Status == jobs.Failed {
+				status = http.StatusUnprocessableEntity
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write(job.Result)
+		}
+	})
+
+	return mux
+}
+
+// serveJob is a job status with the latest progress event of a running scan.
+type serveJob struct {
+	jobs.Job
+	Progress *Event `json:"progress,omitempty"`
+}
+
+// serveKind is the work a serve job does.
+type serveKind string
+
+const (
+	serveScan  serveKind = "scan"  // Read-only JSON report
+	servePlan  serveKind = "plan"  // Text report with the diff -u would make
+	serveApply serveKind = "apply" // Text output of -u
+)
+
+// serveJobs submits scans, plans and upgrades to the queue.
+type serveJobs struct {
+	queue    *jobs.Queue
+	running  *serveProgress
+	deps     Deps
+	applied  atomic.Int64 // Numbers upgrade jobs, which are never shared
+	upgrades pathLocks
+}
+
+// key identifies the job of kind for req, so identical requests share one
+// job. Every upgrade gets its own job: a cached Apply result would claim a
+// change that was never made again.
+func (s *serveJobs) key(kind serveKind, req scanRequest) string {
+	key, _ := json.Marshal(req)
+	switch kind {
+	case serveScan:
+		return string(key)
+	case serveApply:
+		return fmt.Sprintf("%s %d %s", kind, s.applied.Add(1), key)
+	default:
+		return fmt.Sprintf("%s %s", kind, key)
+	}
+}
+
+// submitKey queues the job of kind for req under key, recording its progress.
+func (s *serveJobs) submitKey(key string, kind serveKind, req scanRequest) (jobs.Job, error) {
+	return s.queue.Submit(key, func(ctx context.Context) ([]byte, error) {
+		defer s.running.forget(key)
+		jobDeps := s.deps
+		jobDeps.Progress = func(e Event) { s.running.set(key, e) }
+		if kind == serveScan {
+			return runServeScan(ctx, req, jobDeps)
+		}
+		unlock := s.upgrades.lock(req.Path)
+		defer unlock()
+		return runServeUpgrade(ctx, req, kind == servePlan, jobDeps)
+	})
+}
+
+// submit queues the job of kind for req.
+func (s *serveJobs) submit(kind serveKind, req scanRequest) (jobs.Job, error) {
+	return s.submitKey(s.key(kind, req), kind, req)
+}
+
+// pathLocks serializes plans and upgrades of the same project, which share
+// its go.mod and module cache state.
+type pathLocks struct {
+	mu    sync.Mutex
+	paths map[string]*sync.Mutex
+}
+
+func (l *pathLocks) lock(path string) (unlock func()) {
+	l.mu.Lock()
+	if l.paths == nil {
+		l.paths = make(map[string]*sync.Mutex)
+	}
+	m, ok := l.paths[path]
+	if !ok {
+		m = new(sync.Mutex)
+		l.paths[path] = m
+	}
+	l.mu.Unlock()
+	m.Lock()
+	return m.Unlock
+}
+
+// serveProgress keeps the latest progress event of each running job by job
+// key, and passes events on to the streams watching that key.
+type serveProgress struct {
+	mu       sync.Mutex
+	latest   map[string]Event
+	watchers map[chan Event]string
+}
+
+func (p *serveProgress) set(key string, e Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latest[key] = e
+	for ch, k := range p.watchers {
+		if k != key {
+			continue
+		}
+		// A stream that falls behind loses events rather than stalling the job.
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// watch returns the events of the job with key, starting with its latest
+// one when it is already running. Call stop once done.
+func (p *serveProgress) watch(key string) (events <-chan Event, stop func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ch := make(chan Event, 64)
+	if e, ok := p.latest[key]; ok {
+		ch <- e
+	}
+	p.watchers[ch] = key
+	return ch, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.watchers, ch)
+	}
+}
+
+func (p *serveProgress) get(key string) *Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.latest[key]; ok {
+		return &e
+	}
+	return nil
+}
+
+func (p *serveProgress) forget(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.latest, key)
+}
+
+// servePath resolves a requested project path, keeping it under root when set.
+func servePath(root, path string) (string, error) {
+	if path == "" {
+		return "", errors.New("path is required")
+	}
+	if root == "" {
+		return filepath.Abs(path)
+	}
+	abs := filepath.Clean(resolveProjectPath(root, path))
+	if rel, err := filepath.Rel(root, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside %s", path, root)
+	}
+	return abs, nil
+}
+
+// runServeScan runs a read-only JSON scan. On failure the result holds the
+// JSON error document.
+func runServeScan(ctx context.Context, req scanRequest, deps Deps) ([]byte, error) {
+	var out bytes.Buffer
+	err := Run(ctx, RunOptions{
+		GoModPath:           req.Path,
+		Filter:              req.Filter,
+		All:                 req.All,
+		Cooldown:            req.Cooldown,
+		CooldownSet:         req.Cooldown > 0,
+		ShowVulnerabilities: req.Vulnerabilities,
+		FormatFlag:          "json",
+		NoExec:              true,
+		// Scans run concurrently and JSON output has no glyphs.
+		keepGlyphs: true,
+	}, Deps{
+		Out:        &out,
+		Err:        io.Discard,
+		Now:        deps.Now,
+		Scanner:    deps.Scanner,
+		VulnClient: deps.VulnClient,
+		FetchGoMod: deps.FetchGoMod,
+		GoEnv:      deps.GoEnv,
+		Progress:   deps.Progress,
+	})
+	if err != nil {
+		out.Reset()
+		_ = WriteJSONError(&out, err)
+	}
+	return out.Bytes(), err
+}
+
+// runServeUpgrade runs -u, or -u --dry-run when dryRun is set, and returns
+// the text output. On failure the output ends with the error.
+func runServeUpgrade(ctx context.Context, req scanRequest, dryRun bool, deps Deps) ([]byte, error) {
+	var out bytes.Buffer
+	err := Run(ctx, RunOptions{
+		GoModPath:           req.Path,
+		Filter:              req.Filter,
+		All:                 req.All,
+		Cooldown:            req.Cooldown,
+		CooldownSet:         req.Cooldown > 0,
+		ShowVulnerabilities: req.Vulnerabilities,
+		Upgrade:             true,
+		DryRun:              dryRun,
+		Yes:                 true,
+		NoWrap:              true,
+		keepGlyphs:          true,
+	}, Deps{
+		Out:        &out,
+		Now:        deps.Now,
+		Scanner:    deps.Scanner,
+		Updater:    deps.Updater,
+		VulnClient: deps.VulnClient,
+		FetchGoMod: deps.FetchGoMod,
+		GoEnv:      deps.GoEnv,
+		Progress:   deps.Progress,
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(&out, "Error: %v\n", err)
+	}
+	return out.Bytes(), err
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = WriteJSONError(w, err)
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/grpcwire"
+	"github.com/pragmaticivan/faro/internal/jobs"
+)
+
+// grpcService is the path prefix of the faro.v1.Faro service, defined in
+// proto/faro/v1/faro.proto.
+const grpcService = "/faro.v1.Faro/"
+
+// serveGRPC registers the Scan, Plan and Apply RPCs. Each one reads a
+// ScanRequest, queues the job like POST /scans does, and streams a
+// ScanUpdate for every progress event followed by one with the result.
+func serveGRPC(mux *http.ServeMux, s *serveJobs, opts ServeOptions) {
+	for method, kind := range map[string]serveKind{"Scan": serveScan, "Plan": servePlan, "Apply": serveApply} {
+		mux.HandleFunc("POST "+grpcService+method, func(w http.ResponseWriter, r *http.Request) {
+			if !grpcwire.IsGRPC(r) {
+				http.Error(w, "expected "+grpcwire.ContentType, http.StatusUnsupportedMediaType)
+				return
+			}
+			grpcwire.StartResponse(w)
+			code, msg := s.stream(w, r, kind, opts)
+			grpcwire.SetStatus(w, code, msg)
+		})
+	}
+	mux.HandleFunc("POST "+grpcService, func(w http.ResponseWriter, r *http.Request) {
+		grpcwire.StartResponse(w)
+		grpcwire.SetStatus(w, grpcwire.Unimplemented, "unknown method "+r.URL.Path)
+	})
+}
+
+// stream runs one RPC and returns its status.
+func (s *serveJobs) stream(w http.ResponseWriter, r *http.Request, kind serveKind, opts ServeOptions) (grpcwire.Code, string) {
+	msg, err := grpcwire.ReadMessage(r.Body)
+	if err != nil {
+		return grpcwire.InvalidArgument, fmt.Sprintf("failed to read request: %v", err)
+	}
+	req, err := decodeScanRequest(msg)
+	if err != nil {
+		return grpcwire.InvalidArgument, fmt.Sprintf("failed to parse request: %v", err)
+	}
+	if req.Path, err = servePath(opts.Root, req.Path); err != nil {
+		return grpcwire.InvalidArgument, err.Error()
+	}
+	if kind == serveApply && !opts.AllowApply {
+		return grpcwire.PermissionDenied, "upgrades are disabled; start faro serve with --allow-apply"
+	}
+
+	// Watch before submitting so that no event of a new job is missed.
+	key := s.key(kind, req)
+	events, stop := s.running.watch(key)
+	defer stop()
+	job, err := s.submitKey(key, kind, req)
+	if errors.Is(err, jobs.ErrFull) {
+		return grpcwire.ResourceExhausted, err.Error()
+	}
+	if err != nil {
+		return grpcwire.Unavailable, err.Error()
+	}
+
+	ctx := r.Context()
+	finished := make(chan jobs.Job, 1)
+	go func() {
+		if done, err := s.queue.Wait(ctx, job.ID); err == nil {
+			finished <- done
+		}
+	}()
+	for {
+		select {
+		case e := <-events:
+			if err := grpcwire.WriteMessage(w, encodeEventUpdate(e)); err != nil {
+				return grpcwire.Canceled, err.Error()
+			}
+		case done := <-finished:
+			// Events sent before the job finished are still buffered.
+			for len(events) > 0 {
+				if err := grpcwire.WriteMessage(w, encodeEventUpdate(<-events)); err != nil {
+					return grpcwire.Canceled, err.Error()
+				}
+			}
+			done.Cached = job.Cached
+			if err := grpcwire.WriteMessage(w, encodeResultUpdate(done)); err != nil {
+				return grpcwire.Canceled, err.Error()
+			}
+			if done.Status == jobs.Failed {
+				return grpcwire.Unknown, done.Error
+			}
+			return grpcwire.OK, ""
+		case <-ctx.Done():
+			return grpcwire.Canceled, ctx.Err().Error()
+		}
+	}
+}
+
+// decodeScanRequest decodes a faro.v1.ScanRequest.
+func decodeScanRequest(msg []byte) (scanRequest, error) {
+	var req scanRequest
+	err := grpcwire.Decode(msg, func(f grpcwire.Field) error {
+		switch f.Number {
+		case 1:
+			req.Path = f.String()
+		case 2:
+			req.Filter = f.String()
+		case 3:
+			req.All = f.Varint != 0
+		case 4:
+			req.Cooldown = int(int32(f.Int()))
+		case 5:
+			req.Vulnerabilities = f.Varint != 0
+		}
+		return nil
+	})
+	return req, err
+}
+
+// encodeEventUpdate encodes a faro.v1.ScanUpdate holding an Event.
+func encodeEventUpdate(e Event) []byte {
+	var event grpcwire.Encoder
+	event.String(1, string(e.Kind))
+	if !e.Time.IsZero() {
+		event.String(2, e.Time.Format(time.RFC3339Nano))
+	}
+	event.String(3, e.Module)
+	event.String(4, e.Version)
+	event.String(5, e.Target)
+	event.Int(6, int64(e.Done))
+	event.Int(7, int64(e.Total))
+
+	var update grpcwire.Encoder
+	update.Message(1, event.Bytes())
+	return update.Bytes()
+}
+
+// encodeResultUpdate encodes a faro.v1.ScanUpdate holding the Result of job.
+func encodeResultUpdate(job jobs.Job) []byte {
+	var result grpcwire.Encoder
+	result.String(1, job.ID)
+	result.Bool(2, job.Cached)
+	if len(job.Result) > 0 {
+		result.Message(3, job.Result)
+	}
+	result.String(4, job.Error)
+
+	var update grpcwire.Encoder
+	update.Message(2, result.Bytes())
+	return update.Bytes()
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// StatusPoster sets a commit status on commit sha of owner/repo.
+type StatusPoster func(ctx context.Context, owner, repo, sha string, status github.CommitStatus) error
+
+// statusContext names faro's commit status on GitHub.
+const statusContext = "faro/dependencies"
+
+// statusPoster returns deps.PostStatus, or one backed by the run's GitHub client.
+func statusPoster(deps Deps, gh *lazyGitHubClient) StatusPoster {
+	if deps.PostStatus != nil {
+		return deps.PostStatus
+	}
+	return func(ctx context.Context, owner, repo, sha string, status github.CommitStatus) error {
+		return gh.get().CreateStatus(ctx, owner, repo, sha, status)
+	}
+}
+
+// postCommitStatus summarizes modules as a success status on the commit
+// GitHub Actions is building. The status is advisory: it never fails, and
+// outside GitHub Actions or on API errors only a warning is recorded.
+func postCommitStatus(ctx context.Context, modules []scanner.Module, withVulns bool, post StatusPoster, getenv func(string) string, w *warnings) {
+	owner, repo, ok := strings.Cut(getenv("GITHUB_REPOSITORY"), "/")
+	sha := headSHA(getenv)
+	if !ok || sha == "" {
+		w.add("", "--github-status needs GITHUB_REPOSITORY and GITHUB_SHA (set by GitHub Actions); no status was posted")
+		return
+	}
+	status := github.CommitStatus{
+		State:       "success",
+		Description: statusDescription(modules, withVulns),
+		Context:     statusContext,
+	}
+	if server, run := getenv("GITHUB_SERVER_URL"), getenv("GITHUB_RUN_ID"); server != "" && run != "" {
+		status.TargetURL = fmt.Sprintf("%s/%s/%s/actions/runs/%s", server, owner, repo, run)
+	}
+	if err := post(ctx, owner, repo, sha, status); err != nil {
+		w.add("", "failed to post commit status: %v", err)
+	}
+}
+
+// headSHA returns the commit to report on: the head of the pull request for
+// pull_request events (GITHUB_SHA is then a merge commit the PR page never
+// shows), otherwise GITHUB_SHA.
+func headSHA(getenv func(string) string) string {
+	if path := getenv("GITHUB_EVENT_PATH"); path != "" {
+		var event struct {
+			PullRequest struct {
+				Head struct {
+					SHA string `json:"sha"`
+				} `json:"head"`
+			} `json:"pull_request"`
+		}
+		if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &event) == nil && event.PullRequest.Head.SHA != "" {
+			return event.PullRequest.Head.SHA
+		}
+	}
+	return getenv("GITHUB_SHA")
+}
+
+// statusDescription summarizes modules in one line, e.g. "3 major, 12
+// minor behind; 2 vulns fixable". Vulnerabilities are only counted when
+// they were checked.
+func statusDescription(modules []scanner.Module, withVulns bool) string {
+	counts := make(map[format.DiffGroup]int)
+	behind, fixable := 0, 0
+	for _, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		behind++
+		counts[format.GroupForModule(m)]++
+		if fixed := m.VulnCurrent.Total - m.VulnUpdate.Total; fixed > 0 {
+			fixable += fixed
+		}
+	}
+	var parts []string
+	for _, g := range []format.DiffGroup{format.GroupMajor, format.GroupMinor, format.GroupPatch} {
+		if counts[g] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[g], g))
+		}
+	}
+	if n := behind - counts[format.GroupMajor] - counts[format.GroupMinor] - counts[format.GroupPatch]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d other", n))
+	}
+	desc := "dependencies up to date"
+	if len(parts) > 0 {
+		desc = strings.Join(parts, ", ") + " behind"
+	}
+	if withVulns {
+		desc += fmt.Sprintf("; %d vulns fixable", fixable)
+	}
+	return desc
+}
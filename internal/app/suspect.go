@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/suspect"
+)
+
+// checkSuspicious warns about direct dependencies and updates whose names
+// are near-misses of popular packages, and npm updates published within
+// suspect.FreshWindow by someone who never published the package before.
+// Go projects are only checked when cfg.Go is set.
+func checkSuspicious(ctx context.Context, modules []scanner.Module, direct []string, pm detector.PackageManager, cfg config.SupplyChain, lookup suspect.ReleaseLookup, now time.Time, w *warnings) {
+	var popular []string
+	switch {
+	case pm.Ecosystem() == "npm":
+		popular = suspect.PopularNPM
+	case pm == detector.Go && cfg.Go:
+		popular = suspect.PopularGo
+	default:
+		return
+	}
+
+	seen := make(map[string]bool)
+	names := append([]string{}, direct...)
+	for _, m := range modules {
+		names = append(names, moduleName(m))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if seen[name] || suspect.Allowed(name, cfg.Allow) {
+			continue
+		}
+		seen[name] = true
+		if match, ok := suspect.Lookalike(name, popular); ok {
+			w.add(name, "name is close to popular package %q; make sure it is not a typosquat", match)
+		}
+	}
+
+	if pm.Ecosystem() != "npm" {
+		return
+	}
+	for _, m := range modules {
+		if m.Update == nil || suspect.Allowed(moduleName(m), cfg.Allow) {
+			continue
+		}
+		published, err := time.Parse(time.RFC3339, m.Update.Time)
+		if err != nil || now.Sub(published) >= suspect.FreshWindow {
+			continue
+		}
+		release, err := lookup(ctx, moduleName(m), m.Update.Version)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			w.add(moduleName(m), "could not check the publisher of %s: %v", m.Update.Version, err)
+			continue
+		}
+		if release.Fresh(now) {
+			w.add(moduleName(m), "%s was published %s ago by %q, who had not published this package before", m.Update.Version, ago(now.Sub(release.Time)), release.Publisher)
+		}
+	}
+}
+
+// directDependencies lists the project's direct dependencies for the
+// typosquatting check: go.mod requires for Go, the scanner's index otherwise.
+func directDependencies(ctx context.Context, pm detector.PackageManager, workDir string, s scanner.Scanner) []string {
+	var names []string
+	if pm == detector.Go {
+		requires, _ := gomod.ReadRequires(filepath.Join(workDir, "go.mod"))
+		for _, r := range requires {
+			if !r.Indirect {
+				names = append(names, r.Path)
+			}
+		}
+		return names
+	}
+	index, _ := s.GetDependencyIndex(ctx)
+	for name, info := range index {
+		if info.Direct {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// npmReleases returns the release lookup for the configured registry.
+func npmReleases(cfg config.SupplyChain) suspect.ReleaseLookup {
+	return suspect.NPMReleases(&http.Client{Timeout: 15 * time.Second}, cfg.Registry)
+}
+
+// ago renders a short duration like "12 minutes" or "5 hours".
+func ago(d time.Duration) string {
+	if d < time.Hour {
+		n := max(int(d.Minutes()), 1)
+		return fmt.Sprintf("%d %s", n, plural(n, "minute", "minutes"))
+	}
+	n := int(d.Hours())
+	return fmt.Sprintf("%d %s", n, plural(n, "hour", "hours"))
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/drift"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// SyncOptions configures Sync.
+type SyncOptions struct {
+	Dependencies   []string // Dependencies to align; all drifting ones when empty
+	Modules        []string // Module directories; defaults as for Drift
+	DryRun         bool     // Print the plan without applying it
+	NoExec         bool     // Read-only: only a dry run is allowed
+	AuditLog       string   // JSON lines file recording each module's changes (overrides audit.file)
+	OverrideFreeze bool     // Sync even during a freeze window from .faro.json
+}
+
+// Sync moves every module of a workspace or monorepo that requires a shared
+// dependency to the highest version any of them requires.
+func Sync(ctx context.Context, opts SyncOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	if deps.Now == nil {
+		deps.Now = time.Now
+	}
+	if opts.NoExec {
+		if !opts.DryRun {
+			return fmt.Errorf("--no-exec forbids syncing; add --dry-run to preview the plan")
+		}
+		deps.Updater = updater.ReadOnly{}
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	dirs, err := monorepoModules(workDir, opts.Modules)
+	if err != nil {
+		return err
+	}
+	members, err := drift.Load(workDir, dirs)
+	if err != nil {
+		return err
+	}
+	m := drift.Build(members)
+
+	rows := m.Rows
+	if len(opts.Dependencies) > 0 {
+		byPath := make(map[string]drift.Row, len(rows))
+		for _, r := range rows {
+			byPath[r.Path] = r
+		}
+		rows = nil
+		for _, dep := range opts.Dependencies {
+			r, ok := byPath[dep]
+			if !ok {
+				_, _ = fmt.Fprintf(deps.Out, "%s is already consistent across modules.\n", dep)
+				continue
+			}
+			rows = append(rows, r)
+		}
+	}
+	if len(rows) == 0 {
+		if len(opts.Dependencies) == 0 {
+			_, _ = fmt.Fprintf(deps.Out, "No version drift across %d modules.\n", len(m.Modules))
+		}
+		return nil
+	}
+
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return err
+	}
+	if err := applyGlyphs(cfg.Glyphs); err != nil {
+		return err
+	}
+
+	// Plan the upgrades per module directory, keeping configured order.
+	plan := make(map[string][]scanner.Module)
+	var critical []string
+	pad := 0
+	for _, dir := range m.Modules {
+		pad = max(pad, len(dir))
+	}
+	for _, r := range rows {
+		_, _ = fmt.Fprintf(deps.Out, "%s %s %s\n", r.Path, style.Glyphs.Arrow, r.Highest)
+		if cfg.Critical.Matches(r.Path) {
+			critical = append(critical, r.Path)
+		}
+		for _, dir := range m.Modules {
+			v, ok := r.Versions[dir]
+			if !ok || v == r.Highest {
+				continue
+			}
+			if c, ok := style.ComparePrecedence(v, r.Highest); ok && c > 0 {
+				continue
+			}
+			plan[dir] = append(plan[dir], scanner.Module{
+				Name:    r.Path,
+				Version: v,
+				Update:  &scanner.UpdateInfo{Version: r.Highest},
+			})
+			_, _ = fmt.Fprintln(deps.Out, "  "+style.FormatUpdate(dir, v, r.Highest, pad))
+		}
+	}
+
+	if opts.DryRun {
+		_, _ = fmt.Fprintln(deps.Out, "\nRun without --dry-run to apply.")
+		return nil
+	}
+	if reason, frozen := cfg.Freeze.Active(deps.Now()); frozen && !opts.OverrideFreeze {
+		_, _ = fmt.Fprintf(deps.Out, "\nUpgrades are frozen (%s); not syncing.\n", reason)
+		_, _ = fmt.Fprintln(deps.Out, "Pass --override-freeze to apply the plan anyway.")
+		return nil
+	}
+	if len(critical) > 0 {
+		return fmt.Errorf("sync would upgrade critical %s %s; upgrade %s with faro -i in each module",
+			plural(len(critical), "module", "modules"), strings.Join(critical, ", "), plural(len(critical), "it", "them"))
+	}
+
+	// One audit record per module; audit.file stays relative to the root.
+	auditCfg := cfg.Audit
+	if auditCfg.File != "" {
+		auditCfg.File = resolveProjectPath(workDir, auditCfg.File)
+	}
+	for _, dir := range m.Modules {
+		modules := plan[dir]
+		if len(modules) == 0 {
+			continue
+		}
+		u := deps.Updater
+		if u == nil {
+			u, err = factory.CreateUpdater(detector.Go, moduleDir(workDir, dir))
+			if err != nil {
+				return err
+			}
+		}
+		_, _ = fmt.Fprintf(deps.Out, "\nSyncing %s...\n", dir)
+		auditLog := startAudit(auditCfg, opts.AuditLog, moduleDir(workDir, dir), "sync", detector.Go)
+		if err := u.UpdatePackages(ctx, modules); err != nil {
+			auditFailed(deps, auditLog.finish(ctx, modules, err, deps))
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to sync %s: %w", dir, err)
+		}
+		if err := auditLog.finish(ctx, modules, nil, deps); err != nil {
+			return err
+		}
+	}
+	_, _ = fmt.Fprintln(deps.Out, "Done.")
+	return nil
+}
+
+// moduleDir resolves a monorepo module directory against workDir.
+func moduleDir(workDir, dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(workDir, dir)
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/audit"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/telemetry"
+	"github.com/pragmaticivan/faro/internal/version"
+)
+
+// usageRun collects the usage event of one run, for users who opted in.
+type usageRun struct {
+	mode     telemetry.Mode
+	endpoint string
+	started  time.Time
+	event    telemetry.Event
+}
+
+// telemetryMode resolves the configured mode: DO_NOT_TRACK turns telemetry
+// off and FARO_TELEMETRY overrides the user configuration.
+func telemetryMode(cfg config.Telemetry, getenv func(string) string) telemetry.Mode {
+	if v := getenv("DO_NOT_TRACK"); v != "" && v != "0" {
+		return telemetry.Off
+	}
+	mode := cfg.Mode
+	if v := getenv("FARO_TELEMETRY"); v != "" {
+		mode = v
+	}
+	m, err := telemetry.ParseMode(mode)
+	if err != nil {
+		return telemetry.Off
+	}
+	if m == telemetry.Share && cfg.Endpoint == "" {
+		return telemetry.Local
+	}
+	return m
+}
+
+// startUsage begins the usage event of command when telemetry is on. It
+// returns nil when it is off.
+func startUsage(cfg config.Telemetry, command, manager string, features []string, deps Deps) *usageRun {
+	mode := telemetryMode(cfg, os.Getenv)
+	if mode == telemetry.Off {
+		return nil
+	}
+	_, ci := audit.Actor(os.Getenv)
+	return &usageRun{
+		mode:     mode,
+		endpoint: cfg.Endpoint,
+		started:  deps.Now(),
+		event: telemetry.Event{
+			Faro:     version.Get().Version,
+			Platform: runtime.GOOS + "/" + runtime.GOARCH,
+			CI:       ci != "",
+			Command:  command,
+			Manager:  manager,
+			Features: features,
+		},
+	}
+}
+
+// scanned counts the dependencies a scan found and those with updates.
+// A nil usageRun does nothing.
+func (u *usageRun) scanned(modules []scanner.Module) {
+	if u == nil {
+		return
+	}
+	u.event.Modules = len(modules)
+	u.event.Updates = 0
+	for _, m := range modules {
+		if m.Update != nil {
+			u.event.Updates++
+		}
+	}
+}
+
+// finish records the event locally and, in share mode, posts it. Usage
+// statistics never fail a run, so errors are dropped. A nil usageRun does
+// nothing.
+func (u *usageRun) finish(ctx context.Context, runErr error, deps Deps) {
+	if u == nil {
+		return
+	}
+	e := u.event
+	e.Time = deps.Now()
+	e.DurationMS = e.Time.Sub(u.started).Milliseconds()
+	e.Failed = runErr != nil
+	if dir := telemetry.Dir(); dir != "" {
+		_ = telemetry.Record(dir, e)
+	}
+	if u.mode == telemetry.Share {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = telemetry.Send(ctx, &http.Client{}, u.endpoint, e)
+	}
+}
+
+// optionNames lists the exported fields of an options struct that are set,
+// by name and without their values.
+func optionNames(opts any) []string {
+	v := reflect.ValueOf(opts)
+	var names []string
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Type().Field(i); f.IsExported() && !v.Field(i).IsZero() {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// StatsOptions configures Stats.
+type StatsOptions struct {
+	Dir   string // Directory holding the usage file; defaults to telemetry.Dir()
+	JSON  bool   // Write the summary as JSON
+	Clear bool   // Remove the recorded usage instead of summarizing it
+}
+
+// Stats summarizes the usage recorded on this machine. It only reads the
+// local file and never sends anything.
+func Stats(opts StatsOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = telemetry.Dir()
+	}
+	if dir == "" {
+		return categorize(ErrorUsage, fmt.Errorf("no user config directory; set XDG_CONFIG_HOME or HOME"))
+	}
+	if opts.Clear {
+		if err := telemetry.Clear(dir); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(deps.Out, "Cleared recorded usage.")
+		return nil
+	}
+	events, err := telemetry.Read(dir)
+	if err != nil {
+		return err
+	}
+	summary := telemetry.Summarize(events)
+	if opts.JSON {
+		enc := json.NewEncoder(deps.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(summary); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return nil
+	}
+	if summary.Runs == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "No usage recorded. Opt in with FARO_TELEMETRY=local or {\"telemetry\": {\"mode\": \"local\"}} in .faro.json.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(deps.Out, "%d %s from %s to %s (%d failed, %d in CI)\n", summary.Runs, plural(summary.Runs, "run", "runs"),
+		summary.Since.Format(time.DateOnly), summary.Until.Format(time.DateOnly), summary.Failed, summary.CI)
+	_, _ = fmt.Fprintf(deps.Out, "Commands:  %s\n", joinCounts(summary.Commands))
+	if len(summary.Managers) > 0 {
+		_, _ = fmt.Fprintf(deps.Out, "Managers:  %s\n", joinCounts(summary.Managers))
+	}
+	if summary.ModulesMax > 0 {
+		_, _ = fmt.Fprintf(deps.Out, "Scan size: median %d, p90 %d, max %d dependencies\n", summary.ModulesMedian, summary.ModulesP90, summary.ModulesMax)
+	}
+	_, _ = fmt.Fprintf(deps.Out, "Run time:  median %s\n", time.Duration(summary.DurationMedianMS)*time.Millisecond)
+	if len(summary.Features) > 0 {
+		_, _ = fmt.Fprintln(deps.Out, "Options:")
+		for _, c := range summary.Features {
+			_, _ = fmt.Fprintf(deps.Out, "  %-22s %d\n", c.Name, c.Runs)
+		}
+	}
+	_, _ = fmt.Fprintf(deps.Out, "\nRecorded in %s.\n", dir)
+	return nil
+}
+
+func joinCounts(counts []telemetry.Count) string {
+	parts := make([]string, len(counts))
+	for i, c := range counts {
+		parts[i] = fmt.Sprintf("%s %d", c.Name, c.Runs)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/changelog"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/factory"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/report"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/tracker"
+)
+
+// ticketNotesLines bounds the release note lines quoted per release.
+const ticketNotesLines = 30
+
+// TicketOptions configures Tickets.
+type TicketOptions struct {
+	Manager   string // Package manager override
+	GoModPath string // Path to a go.mod file (or its directory); implies the go manager
+	Filter    string // Only file tickets for matching modules
+	DryRun    bool   // Print the tickets instead of filing them
+}
+
+// Tickets files one tracker ticket per pending major upgrade of a direct
+// dependency, with risk notes and the release notes between the current
+// and target versions. Upgrades that already have an open ticket with the
+// same title are skipped.
+func Tickets(ctx context.Context, opts TicketOptions, deps Deps) error {
+	if deps.Out == nil {
+		return fmt.Errorf("missing deps.Out")
+	}
+	workDir, pm, err := resolveManager("", opts.Manager, opts.GoModPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		return categorize(ErrorConfig, err)
+	}
+	body := cfg.Tickets.Body
+	if body == "" && cfg.Tickets.BodyFile != "" {
+		if body, err = report.Load(resolveProjectPath(workDir, cfg.Tickets.BodyFile)); err != nil {
+			return categorize(ErrorConfig, err)
+		}
+	}
+
+	gh := lazyGitHubClient{cfg: cfg.GitHub}
+	repos := lazyRepoResolver{}
+	defer repos.save()
+	tr := deps.Tracker
+	if tr == nil && !opts.DryRun {
+		if tr, err = newTracker(cfg.Tickets, &gh); err != nil {
+			return categorize(ErrorConfig, err)
+		}
+	}
+
+	pkgScanner := deps.Scanner
+	if pkgScanner == nil {
+		pkgScanner, err = factory.CreateScanner(pm, workDir)
+		if err != nil {
+			return err
+		}
+	}
+	modules, err := pkgScanner.GetUpdates(ctx, scanner.Options{Filter: opts.Filter, WorkDir: workDir})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return categorize(ErrorScan, err)
+	}
+	direct, _, _ := groupModules(modules)
+	var majors []scanner.Module
+	for _, m := range direct {
+		if m.Update != nil && format.GroupForModule(m) == format.GroupMajor {
+			majors = append(majors, m)
+		}
+	}
+	if len(majors) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "No pending major upgrades.")
+		return nil
+	}
+
+	source := releaseNotes(deps, &gh, &repos)
+	var warns warnings
+	annotateRisks(ctx, majors, source, nil, &warns)
+
+	filed, existing := 0, 0
+	for _, m := range majors {
+		data := tracker.Data{
+			Module:  moduleName(m),
+			Current: m.Version,
+			Target:  m.Update.Version,
+			Manager: pm.String(),
+			Project: filepath.Base(workDir),
+			Risks:   m.RiskHints,
+		}
+		if releases, err := source.Releases(ctx, data.Module); err == nil {
+			for _, r := range changelog.Between(releases, m.Version, m.Update.Version) {
+				data.Releases = append(data.Releases, tracker.Release{Tag: r.Tag, Notes: firstLines(strings.TrimSpace(r.Body), ticketNotesLines)})
+			}
+		}
+		ticket, err := tracker.Render(cfg.Tickets.Title, body, data)
+		if err != nil {
+			return categorize(ErrorConfig, err)
+		}
+		ticket.Labels = cfg.Tickets.Labels
+
+		if opts.DryRun {
+			_, _ = fmt.Fprintf(deps.Out, "\n=== %s\n\n%s\n", ticket.Title, ticket.Body)
+			continue
+		}
+		if url, ok, err := tr.Find(ctx, ticket.Title); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to look up tickets: %w", err)
+		} else if ok {
+			existing++
+			_, _ = fmt.Fprintf(deps.Out, "Exists: %s (%s)\n", ticket.Title, url)
+			continue
+		}
+		url, err := tr.Create(ctx, ticket)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to file ticket %q: %w", ticket.Title, err)
+		}
+		filed++
+		_, _ = fmt.Fprintf(deps.Out, "Filed: %s (%s)\n", ticket.Title, url)
+	}
+
+	if !opts.DryRun {
+		_, _ = fmt.Fprintf(deps.Out, "\nFiled %d %s, %d already open.\n", filed, plural(filed, "ticket", "tickets"), existing)
+	}
+	printWarnings(deps.Out, warns.items)
+	return nil
+}
+
+// newTracker builds the tracker configured in cfg.
+func newTracker(cfg config.Tickets, gh *lazyGitHubClient) (tracker.Tracker, error) {
+	switch cfg.Tracker {
+	case "github":
+		owner, repo, ok := strings.Cut(cfg.Repo, "/")
+		if !ok || owner == "" || repo == "" {
+			return nil, fmt.Errorf("tickets.repo must be owner/name for the github tracker, got %q", cfg.Repo)
+		}
+		return tracker.NewGitHubIssues(gh.get(), owner, repo, cfg.Labels), nil
+	case "jira":
+		if cfg.Jira.URL == "" || cfg.Jira.Project == "" {
+			return nil, fmt.Errorf("the jira tracker needs tickets.jira.url in %s and tickets.jira.project in %s", config.UserPath(), config.FileName)
+		}
+		tokenEnv := cfg.Jira.TokenEnv
+		if tokenEnv == "" {
+			tokenEnv = "JIRA_API_TOKEN"
+		}
+		var user string
+		if cfg.Jira.UserEnv != "" {
+			user = os.Getenv(cfg.Jira.UserEnv)
+		}
+		return tracker.NewJira(cfg.Jira.URL, cfg.Jira.Project, cfg.Jira.IssueType, user, os.Getenv(tokenEnv)), nil
+	case "":
+		return nil, fmt.Errorf("no tracker configured: set tickets.tracker to github or jira in %s", config.FileName)
+	default:
+		return nil, fmt.Errorf("unsupported tracker %q (supported: github, jira)", cfg.Tracker)
+	}
+}
+
+// firstLines returns the first n lines of s, noting how many were cut.
+func firstLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "\n") + fmt.Sprintf("\n\n(%d more lines)", len(lines)-n)
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/platform"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// ReleaseAssetLister returns the asset names of the release of modulePath
+// tagged version. It returns github.ErrNotFound when there is no such release.
+type ReleaseAssetLister func(ctx context.Context, modulePath, version string) ([]string, error)
+
+// errNoReleaseHost marks modules whose releases faro cannot look up.
+var errNoReleaseHost = errors.New("release assets are only checked for github.com modules")
+
+// githubReleaseAssets lists release assets through the GitHub API.
+func githubReleaseAssets(client *github.Client, resolver github.RepoResolver) ReleaseAssetLister {
+	return func(ctx context.Context, modulePath, version string) ([]string, error) {
+		owner, repo, ok := github.ResolveRepo(ctx, resolver, modulePath)
+		if !ok {
+			return nil, errNoReleaseHost
+		}
+		release, err := client.ReleaseByTag(ctx, owner, repo, version)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(release.Assets))
+		for _, a := range release.Assets {
+			names = append(names, a.Name)
+		}
+		return names, nil
+	}
+}
+
+// toolsFiles are where the tools.go pattern keeps its imports, relative to
+// the module directory.
+var toolsFiles = []string{"tools.go", filepath.Join("tools", "tools.go")}
+
+// goModTools returns the modules in workDir's go.mod that provide tools,
+// mapped to the tool packages they provide. Tools come from tool directives
+// and from tools.go files.
+func goModTools(workDir string) (map[string][]string, error) {
+	data, err := os.ReadFile(filepath.Join(workDir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	contents := string(data)
+	tools := gomod.ParseTools(contents)
+	for _, name := range toolsFiles {
+		if src, err := os.ReadFile(filepath.Join(workDir, name)); err == nil {
+			tools = append(tools, gomod.ParseToolsFile(src)...)
+		}
+	}
+	return gomod.ToolModules(tools, gomod.ParseRequires(contents)), nil
+}
+
+// markTools sets Tool on the modules that provide tools.
+func markTools(modules []scanner.Module, tools map[string][]string) {
+	for i := range modules {
+		if _, ok := tools[moduleName(modules[i])]; ok {
+			modules[i].Tool = true
+		}
+	}
+}
+
+// splitTools moves the tool modules out of direct and indirect.
+func splitTools(direct, indirect []scanner.Module) (restDirect, restIndirect, tools []scanner.Module) {
+	split := func(mods []scanner.Module) []scanner.Module {
+		var rest []scanner.Module
+		for _, m := range mods {
+			if m.Tool {
+				tools = append(tools, m)
+			} else {
+				rest = append(rest, m)
+			}
+		}
+		return rest
+	}
+	restDirect = split(direct)
+	restIndirect = split(indirect)
+	return restDirect, restIndirect, tools
+}
+
+// parsePlatforms parses the configured tool platforms.
+func parsePlatforms(values []string) ([]platform.Platform, error) {
+	platforms := make([]platform.Platform, 0, len(values))
+	for _, v := range values {
+		p, err := platform.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		platforms = append(platforms, p)
+	}
+	return platforms, nil
+}
+
+// checkToolPlatforms verifies that every tool module update publishes release
+// binaries for platforms. It returns the modules whose target release lacks
+// some of them; modules whose releases cannot be inspected are kept with a
+// warning.
+func checkToolPlatforms(ctx context.Context, modules []scanner.Module, tools map[string][]string, platforms []platform.Platform, list ReleaseAssetLister, w *warnings) map[string]string {
+	unsupported := make(map[string]string)
+	for _, m := range modules {
+		name := moduleName(m)
+		if m.Update == nil || len(tools[name]) == 0 {
+			continue
+		}
+		if ctx.Err() != nil {
+			w.add("", "tool platform check interrupted: %v", ctx.Err())
+			return unsupported
+		}
+
+		assets, err := list(ctx, name, m.Update.Version)
+		switch {
+		case errors.Is(err, errNoReleaseHost):
+			continue
+		case errors.Is(err, github.ErrNotFound):
+			w.add(name, "no GitHub release for tool version %s; platform support unknown", m.Update.Version)
+			continue
+		case err != nil:
+			w.add(name, "tool platform check failed for %s: %v", m.Update.Version, err)
+			continue
+		case len(assets) == 0:
+			w.add(name, "tool release %s has no binaries; platform support unknown", m.Update.Version)
+			continue
+		}
+
+		missing := platform.Missing(assets, platforms)
+		if len(missing) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(missing))
+		for _, p := range missing {
+			names = append(names, p.String())
+		}
+		unsupported[name] = strings.Join(names, ", ")
+		w.add(name, "tool release %s lacks binaries for %s; skipped", m.Update.Version, unsupported[name])
+	}
+	return unsupported
+}
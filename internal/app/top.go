@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/config"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// topLimit returns how many updates text output shows, or 0 for all.
+func topLimit(opts RunOptions, cfg config.Report) int {
+	switch {
+	case opts.AllResults:
+		return 0
+	case opts.Top > 0:
+		return opts.Top
+	default:
+		return max(cfg.Top, 0)
+	}
+}
+
+// limitTop keeps the n highest-priority updates across the three groups,
+// preserving each group's order, and returns how many were dropped. Ties keep
+// the group order, so direct dependencies win.
+func limitTop(n int, direct, indirect, transitive []scanner.Module) ([]scanner.Module, []scanner.Module, []scanner.Module, int) {
+	groups := [][]scanner.Module{direct, indirect, transitive}
+	type ref struct{ group, index, priority int }
+	var refs []ref
+	for g, mods := range groups {
+		for i, m := range mods {
+			if m.Update != nil {
+				refs = append(refs, ref{g, i, format.Priority(m)})
+			}
+		}
+	}
+	sort.SliceStable(refs, func(a, b int) bool { return refs[a].priority > refs[b].priority })
+
+	keep := make(map[[2]int]bool)
+	for _, r := range refs[:min(n, len(refs))] {
+		keep[[2]int{r.group, r.index}] = true
+	}
+
+	out := make([][]scanner.Module, len(groups))
+	hidden := 0
+	for g, mods := range groups {
+		for i, m := range mods {
+			if m.Update != nil && !keep[[2]int{g, i}] {
+				hidden++
+				continue
+			}
+			out[g] = append(out[g], m)
+		}
+	}
+	return out[0], out[1], out[2], hidden
+}
+
+// printHiddenCount prints the footer for updates left out by --top.
+func printHiddenCount(out io.Writer, hidden int) {
+	if hidden == 0 {
+		return
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	_, _ = fmt.Fprintf(out, "\n%s\n", dim.Render(fmt.Sprintf("%sand %d more (run with --all-results)", style.Glyphs.Ellipsis, hidden)))
+}
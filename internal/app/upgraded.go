@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/blame"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// ManifestBlamer returns the blame lines of file in dir.
+type ManifestBlamer func(ctx context.Context, dir, file string) ([]blame.Line, error)
+
+// annotateLastUpgraded sets LastUpgraded on modules from the git history of
+// pm's manifest in workDir. Without history, a warning is recorded instead.
+func annotateLastUpgraded(ctx context.Context, modules []scanner.Module, workDir string, pm detector.PackageManager, blameFn ManifestBlamer, w *warnings) {
+	files := detector.ManifestFiles(pm)
+	if len(files) == 0 {
+		return
+	}
+	lines, err := blameFn(ctx, workDir, files[0])
+	if err != nil {
+		w.add("", "last-upgraded dates unavailable: %v", err)
+		return
+	}
+
+	names := make([]string, len(modules))
+	for i, m := range modules {
+		names[i] = moduleName(m)
+	}
+	dates := blame.LastChanged(lines, names)
+	for i := range modules {
+		if t, ok := dates[names[i]]; ok {
+			modules[i].LastUpgraded = t.Format(time.RFC3339)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/pragmaticivan/faro/internal/platform"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/usage"
+)
+
+// usagePlatforms returns the platforms to analyze, defaulting to the host.
+func usagePlatforms(values []string) ([]platform.Platform, error) {
+	if len(values) == 0 {
+		return []platform.Platform{{OS: runtime.GOOS, Arch: runtime.GOARCH}}, nil
+	}
+	return parsePlatforms(values)
+}
+
+// checkImportUsage warns about direct Go requirements that are unused,
+// test-only or only imported on some of platforms under tags.
+func checkImportUsage(ctx context.Context, modules []scanner.Module, platforms []platform.Platform, tags []string, list usage.Lister, w *warnings) {
+	report, err := usage.Analyze(ctx, platforms, tags, list)
+	if err != nil {
+		w.add("", "import usage analysis failed: %v", err)
+		return
+	}
+	for _, m := range modules {
+		if !m.Direct {
+			continue
+		}
+		name := moduleName(m)
+		if desc := report.Describe(name); desc != "" {
+			w.add(name, "%s", desc)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+// annotateVulnDetails sets VulnDetails on every module whose current version
+// has known vulnerabilities, from the advisories lister reports. Lookup
+// failures leave the counts alone and are recorded as warnings.
+func annotateVulnDetails(ctx context.Context, modules []scanner.Module, lister vuln.AdvisoryLister, limit requestLimit, w *warnings) {
+	errs := make([]error, len(modules))
+	sem := make(chan struct{}, goCompatConcurrency)
+	var wg sync.WaitGroup
+	for i, m := range modules {
+		if m.Update == nil || m.VulnCurrent.Total == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			if errs[i] = limit.acquire(ctx); errs[i] != nil {
+				return
+			}
+			defer limit.release()
+			advisories, err := lister.Advisories(ctx, moduleName(m), m.Version)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			details := make([]scanner.VulnDetail, len(advisories))
+			for j, a := range advisories {
+				details[j] = scanner.VulnDetail{ID: a.ID, Aliases: a.Aliases, Summary: a.Summary, Severity: a.Severity, Fixed: a.Fixed}
+			}
+			modules[i].VulnDetails = details
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+	for i, err := range errs {
+		if err != nil {
+			w.fail(moduleName(modules[i]), "could not list vulnerability details: %v", err)
+		}
+	}
+}
+
+// vulnDetailLines renders one indented line per advisory, below the module
+// row: ID, severity, summary and the version fixing it.
+func vulnDetailLines(details []scanner.VulnDetail, width int) string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	var s string
+	for _, d := range details {
+		line := "   " + severityLabel(d.Severity) + " " + d.ID
+		if d.Summary != "" {
+			line += " " + d.Summary
+		}
+		if d.Fixed != "" {
+			line += " " + dim.Render("(fixed in "+d.Fixed+")")
+		}
+		if width > 0 {
+			line = style.Truncate(line, width)
+		}
+		s += "\n" + line
+	}
+	return s
+}
+
+// severityLabel renders an advisory severity in the colors FormatVulnInfo
+// uses for its counts.
+func severityLabel(severity string) string {
+	text := fmt.Sprintf("%-8s", strings.ToUpper(severity))
+	switch severity {
+	case "CRITICAL":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(text)
+	case "HIGH":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(text)
+	case "MEDIUM":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(text)
+	}
+	return text
+}
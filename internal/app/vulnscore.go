@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/goprivate"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+// VulnScore is the severity-weighted count of known vulnerabilities in the
+// project's dependencies, at their current versions and after the upgrade.
+type VulnScore struct {
+	Current  int `json:"current"`
+	Upgraded int `json:"upgraded"`
+	Delta    int `json:"delta"` // Upgraded - Current; negative when upgrading lowers the risk
+}
+
+// scoreVulnerabilities scores every installed dependency of the project in
+// workDir (the build list or the lockfile) before and after upgrading the
+// modules in upgrading. When the installed versions cannot be listed, only
+// go.mod requirements and the scanned modules are scored.
+func scoreVulnerabilities(ctx context.Context, pm detector.PackageManager, workDir string, modules, upgrading []scanner.Module, client vuln.Client, private string, deps Deps, w *warnings) VulnScore {
+	list := deps.Installed
+	if list == nil {
+		list = installedVersions
+	}
+	installed, err := list(ctx, pm, workDir)
+	if err != nil {
+		if ctx.Err() != nil {
+			return VulnScore{}
+		}
+		w.add("", "%v; the vulnerability score only covers go.mod requirements and outdated packages", err)
+		installed = requiredVersions(pm, workDir)
+	}
+	return vulnScore(ctx, modules, upgrading, installed, alertEcosystem(pm), client, private, w)
+}
+
+// vulnScore scores the installed dependencies plus the scanned modules,
+// whose counts checkVulnerabilities already filled in. Modules in upgrading
+// count at their update version after the upgrade; every other dependency
+// is looked up at its installed version and counts the same on both sides.
+func vulnScore(ctx context.Context, modules, upgrading []scanner.Module, installed map[string]string, ecosystem string, client vuln.Client, private string, w *warnings) VulnScore {
+	upgraded := make(map[string]bool, len(upgrading))
+	for _, m := range upgrading {
+		upgraded[installedName(ecosystem, moduleName(m))] = true
+	}
+
+	var s VulnScore
+	scored := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		name := installedName(ecosystem, moduleName(m))
+		scored[name] = true
+		s.Current += weighVulns(m.VulnCurrent)
+		if upgraded[name] {
+			s.Upgraded += weighVulns(m.VulnUpdate)
+		} else {
+			s.Upgraded += weighVulns(m.VulnCurrent)
+		}
+	}
+
+	// Up-to-date dependencies are looked up as updates to themselves.
+	var rest []scanner.Module
+	for name, version := range installed {
+		if !scored[name] && version != "" {
+			rest = append(rest, scanner.Module{Name: name, Version: version, Update: &scanner.UpdateInfo{Version: version}})
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i].Name < rest[j].Name })
+	failures, err := vuln.AnnotateModules(ctx, rest, vuln.Options{
+		Client: client,
+		Skip:   func(name string) bool { return goprivate.Covered(name, private) },
+	})
+	if err != nil {
+		w.add("", "vulnerability score interrupted: %v", err)
+		return s
+	}
+	if len(failures) > 0 {
+		w.add("", "the vulnerability score leaves out %d %s whose lookup failed (first: %v)",
+			len(failures), plural(len(failures), "dependency", "dependencies"), failures[0])
+	}
+	for _, m := range rest {
+		s.Current += weighVulns(m.VulnCurrent)
+		s.Upgraded += weighVulns(m.VulnCurrent)
+	}
+	s.Delta = s.Upgraded - s.Current
+	return s
+}
+
+func weighVulns(v scanner.VulnInfo) int {
+	unrated := max(v.Total-v.Critical-v.High-v.Medium-v.Low, 0)
+	return v.Critical*format.WeightCritical + v.High*format.WeightHigh + v.Medium*format.WeightMedium + (v.Low+unrated)*format.WeightLow
+}
+
+// printVulnScore prints the score and how much upgrading changes it.
+func printVulnScore(out io.Writer, s VulnScore) {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	change := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("no change")
+	switch {
+	case s.Delta < 0:
+		change = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render(fmt.Sprintf("%d", s.Delta))
+	case s.Delta > 0:
+		change = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("+%d", s.Delta))
+	}
+	_, _ = fmt.Fprintf(out, "\nVulnerability score: %d now, %d after upgrading (%s)\n", s.Current, s.Upgraded, change)
+	_, _ = fmt.Fprintln(out, dim.Render(fmt.Sprintf("Weights: critical %d, high %d, medium %d, low %d.", format.WeightCritical, format.WeightHigh, format.WeightMedium, format.WeightLow)))
+}
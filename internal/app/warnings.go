@@ -0,0 +1,130 @@
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// Warning describes a non-fatal problem encountered during a run.
+type Warning struct {
+	Module  string `json:"module,omitempty"` // Module the warning relates to (empty for run-wide warnings)
+	Message string `json:"message"`
+}
+
+func (w Warning) String() string {
+	if w.Module == "" {
+		return w.Message
+	}
+	return fmt.Sprintf("%s: %s", w.Module, w.Message)
+}
+
+// warnings collects non-fatal problems so they can be reported once at the end of a run.
+type warnings struct {
+	items  []Warning
+	failed int // Lookups that failed, leaving the results incomplete
+}
+
+func (w *warnings) add(module, format string, args ...any) {
+	w.items = append(w.items, Warning{Module: module, Message: fmt.Sprintf(format, args...)})
+}
+
+// fail records a lookup that failed as a warning and counts it, so
+// --exit-code can report the scan as partial.
+func (w *warnings) fail(module, format string, args ...any) {
+	w.add(module, format, args...)
+	w.failed++
+}
+
+// collectModuleWarnings records warnings for data that could not be interpreted,
+// such as unparsable versions or missing publish times.
+func collectModuleWarnings(w *warnings, modules []scanner.Module, needTime bool) {
+	for _, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		name := moduleName(m)
+		if !style.ValidVersion(m.Version) {
+			w.add(name, "could not parse current version %q", m.Version)
+		}
+		if !style.ValidVersion(m.Update.Version) {
+			w.add(name, "could not parse update version %q", m.Update.Version)
+		}
+		if needTime && m.Update.Time == "" {
+			w.add(name, "publish time unavailable for %s", m.Update.Version)
+		}
+	}
+}
+
+// keepNamed restricts modules to the packages named on the command line.
+// Scanners that cannot query single packages report everything, so the
+// rest are counted as filtered here.
+func keepNamed(modules []scanner.Module, names []string, skipped *scanner.SkipStats) []scanner.Module {
+	if len(names) == 0 {
+		return modules
+	}
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	out := make([]scanner.Module, 0, len(names))
+	for _, m := range modules {
+		if wanted[moduleName(m)] {
+			out = append(out, m)
+		} else {
+			skipped.Add(scanner.SkipFiltered)
+		}
+	}
+	return out
+}
+
+// dropNonUpgrades removes modules whose suggested update does not have higher
+// semver precedence than the current version, which proxy hiccups and
+// retractions occasionally produce. Versions that cannot be parsed are kept;
+// collectModuleWarnings reports them.
+func dropNonUpgrades(modules []scanner.Module, w *warnings, skipped *scanner.SkipStats) []scanner.Module {
+	out := make([]scanner.Module, 0, len(modules))
+	for _, m := range modules {
+		if m.Update != nil {
+			if cmp, ok := style.ComparePrecedence(m.Update.Version, m.Version); ok && cmp <= 0 {
+				w.add(moduleName(m), "suggested update %s is not newer than %s; ignored", m.Update.Version, m.Version)
+				skipped.Add(scanner.SkipNotNewer)
+				continue
+			}
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// printWarnings outputs the collected warnings in a dedicated section.
+func printWarnings(out io.Writer, items []Warning) {
+	if out == nil || len(items) == 0 {
+		return
+	}
+	orange := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	_, _ = fmt.Fprintf(out, "\n%s\n", orange.Render("Warnings:"))
+	for _, w := range items {
+		_, _ = fmt.Fprintf(out, " - %s\n", w)
+	}
+}
+
+// printSkipped prints a one-line summary of outdated modules left out of the report.
+func printSkipped(out io.Writer, s scanner.SkipStats) {
+	if out == nil || s.Total() == 0 {
+		return
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	_, _ = fmt.Fprintf(out, "\n%s\n", dim.Render(fmt.Sprintf("Skipped %d outdated (%s)", s.Total(), s)))
+}
+
+// moduleName returns the display name of a module, falling back to Path.
+func moduleName(m scanner.Module) string {
+	if m.Name == "" {
+		return m.Path
+	}
+	return m.Name
+}
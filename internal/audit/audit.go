@@ -0,0 +1,151 @@
+// Package audit records dependency changes applied without a human in the
+// loop, as JSON lines in a file and optionally posted to an HTTP endpoint.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record describes one applied change set.
+type Record struct {
+	Time    time.Time `json:"time"`
+	Actor   string    `json:"actor"`          // CI user or local account that ran faro
+	CI      string    `json:"ci,omitempty"`   // CI provider, when run in CI
+	Host    string    `json:"host,omitempty"` // Machine hostname
+	Faro    string    `json:"faro"`           // faro version
+	Command string    `json:"command"`        // "upgrade", "align" or "sync"
+	Project string    `json:"project"`        // Project directory
+	Manager string    `json:"manager"`
+	Changes []Change  `json:"changes"`
+	Diff    string    `json:"diff,omitempty"`  // Unified diff of the manifest files
+	Error   string    `json:"error,omitempty"` // Why applying failed; the diff shows what changed anyway
+}
+
+// Change is one module moved from one version to another.
+type Change struct {
+	Module string `json:"module"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// ciActors maps CI providers to the variable naming the user who triggered
+// the run, checked in order.
+var ciActors = []struct{ ci, detect, actor string }{
+	{"github-actions", "GITHUB_ACTIONS", "GITHUB_ACTOR"},
+	{"gitlab-ci", "GITLAB_CI", "GITLAB_USER_LOGIN"},
+	{"buildkite", "BUILDKITE", "BUILDKITE_BUILD_CREATOR"},
+	{"circleci", "CIRCLECI", "CIRCLE_USERNAME"},
+	{"jenkins", "JENKINS_URL", "BUILD_USER_ID"},
+}
+
+// Actor returns who is running faro and the CI provider, if any: the
+// provider's triggering user, falling back to the local account.
+func Actor(getenv func(string) string) (actor, ci string) {
+	for _, p := range ciActors {
+		if getenv(p.detect) == "" {
+			continue
+		}
+		ci = p.ci
+		actor = getenv(p.actor)
+		break
+	}
+	if ci == "" && getenv("CI") != "" {
+		ci = "unknown"
+	}
+	if actor == "" {
+		actor = getenv("USER")
+	}
+	if actor == "" {
+		actor = getenv("USERNAME")
+	}
+	return actor, ci
+}
+
+// ReadFiles returns the contents of the named files in dir. Missing files
+// are recorded as empty.
+func ReadFiles(dir string, names []string) map[string][]byte {
+	files := make(map[string][]byte, len(names))
+	for _, name := range names {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		files[name] = data
+	}
+	return files
+}
+
+// Target is where records are written.
+type Target struct {
+	File     string // JSON lines file to append to
+	Endpoint string // URL records are POSTed to as JSON
+	Token    string // Bearer token for Endpoint
+}
+
+// Enabled reports whether t writes anywhere.
+func (t Target) Enabled() bool {
+	return t.File != "" || t.Endpoint != ""
+}
+
+// Emit appends rec to t.File and posts it to t.Endpoint. Both are attempted
+// even if one fails.
+func Emit(ctx context.Context, client *http.Client, t Target, rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	var errs []error
+	if t.File != "" {
+		if err := appendLine(t.File, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if t.Endpoint != "" {
+		if err := post(ctx, client, t, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func appendLine(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+func post(ctx context.Context, client *http.Client, t Target, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to post audit record: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if t.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post audit record: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to post audit record: %s returned %s", t.Endpoint, resp.Status)
+	}
+	return nil
+}
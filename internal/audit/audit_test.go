@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestActor(t *testing.T) {
+	env := map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_ACTOR": "octocat", "USER": "runner"}
+	if actor, ci := Actor(func(k string) string { return env[k] }); actor != "octocat" || ci != "github-actions" {
+		t.Fatalf("expected octocat on github-actions, got %q %q", actor, ci)
+	}
+	env = map[string]string{"USER": "dev"}
+	if actor, ci := Actor(func(k string) string { return env[k] }); actor != "dev" || ci != "" {
+		t.Fatalf("expected the local user, got %q %q", actor, ci)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := map[string][]byte{
+		"go.mod": []byte("module m\n\ngo 1.25\n\nrequire (\n\ta v1.0.0\n\tb v1.0.0\n\tc v1.0.0\n\td v1.0.0\n\te v1.0.0\n\tf v1.0.0\n\tg v1.0.0\n\th v1.0.0\n\ti v1.0.0\n)\n"),
+		"go.sum": []byte("same\n"),
+	}
+	after := map[string][]byte{
+		"go.mod": []byte(strings.Replace(strings.Replace(string(before["go.mod"]), "a v1.0.0", "a v1.1.0", 1), "i v1.0.0", "i v2.0.0", 1)),
+		"go.sum": []byte("same\n"),
+	}
+	want := `--- a/go.mod
++++ b/go.mod
+@@ -3,7 +3,7 @@
+ go 1.25
+ 
+ require (
+-	a v1.0.0
++	a v1.1.0
+ 	b v1.0.0
+ 	c v1.0.0
+ 	d v1.0.0
+@@ -11,5 +11,5 @@
+ 	f v1.0.0
+ 	g v1.0.0
+ 	h v1.0.0
+-	i v1.0.0
++	i v2.0.0
+ )
+`
+	if got := Diff(before, after); got != want {
+		t.Fatalf("unexpected diff:\n%s", got)
+	}
+	if got := Diff(before, before); got != "" {
+		t.Fatalf("expected no diff for unchanged files, got:\n%s", got)
+	}
+}
+
+func TestEmit(t *testing.T) {
+	var posted Record
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &posted)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	rec := Record{Actor: "dev", Command: "upgrade", Changes: []Change{{Module: "a", From: "v1.0.0", To: "v1.1.0"}}}
+	target := Target{File: path, Endpoint: srv.URL, Token: "secret"}
+	for range 2 {
+		if err := Emit(context.Background(), srv.Client(), target, rec); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 || !strings.Contains(lines[1], `"module":"a"`) {
+		t.Fatalf("expected two appended records, got:\n%s", data)
+	}
+	if auth != "Bearer secret" || posted.Actor != "dev" || len(posted.Changes) != 1 {
+		t.Fatalf("unexpected post: %q %+v", auth, posted)
+	}
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	if err := Emit(context.Background(), srv.Client(), Target{Endpoint: srv.URL}, rec); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("expected a post failure, got %v", err)
+	}
+}
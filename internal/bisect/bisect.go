@@ -0,0 +1,88 @@
+// Package bisect binary-searches a module's releases for the first version
+// that breaks a project.
+package bisect
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// Tester reports whether the project works with version.
+type Tester func(ctx context.Context, version string) (ok bool, err error)
+
+// Result is the outcome of Search.
+type Result struct {
+	FirstBad string // first failing version; empty when the newest candidate works
+	LastGood string // newest version known to work; current when none was tested OK
+	Tested   int    // number of versions tested
+}
+
+// Candidates returns the stable versions newer than current, up to and
+// including target (the newest version when target is empty), in ascending
+// order. Prereleases are only considered when current or target is one.
+func Candidates(versions []string, current, target string) []string {
+	withPre := isPrerelease(current) || isPrerelease(target)
+	var out []string
+	for _, v := range versions {
+		if !withPre && isPrerelease(v) {
+			continue
+		}
+		if c, ok := style.ComparePrecedence(v, current); !ok || c <= 0 {
+			continue
+		}
+		if target != "" {
+			if c, ok := style.ComparePrecedence(v, target); !ok || c > 0 {
+				continue
+			}
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// Search tests the newest candidate first; if it fails, it binary-searches
+// candidates for the first failing version, assuming current works and that
+// versions fail from some point on.
+func Search(ctx context.Context, current string, candidates []string, test Tester) (Result, error) {
+	res := Result{LastGood: current}
+	if len(candidates) == 0 {
+		return res, nil
+	}
+
+	lo, hi := -1, len(candidates)-1 // candidates[lo] works, candidates[hi] fails
+	ok, err := test(ctx, candidates[hi])
+	res.Tested++
+	if err != nil {
+		return res, err
+	}
+	if ok {
+		res.LastGood = candidates[hi]
+		return res, nil
+	}
+
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		ok, err := test(ctx, candidates[mid])
+		res.Tested++
+		if err != nil {
+			return res, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	res.FirstBad = candidates[hi]
+	if lo >= 0 {
+		res.LastGood = candidates[lo]
+	}
+	return res, nil
+}
+
+func isPrerelease(v string) bool {
+	v, _, _ = strings.Cut(v, "+")
+	return strings.Contains(v, "-") && style.ValidVersion(v)
+}
@@ -0,0 +1,54 @@
+package bisect
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+func TestCandidates(t *testing.T) {
+	versions := []string{"v1.0.0", "v1.1.0", "v1.2.0-rc.1", "v1.2.0", "v1.3.0", "v2.0.0"}
+	if got := Candidates(versions, "v1.0.0", ""); !reflect.DeepEqual(got, []string{"v1.1.0", "v1.2.0", "v1.3.0", "v2.0.0"}) {
+		t.Fatalf("unexpected candidates: %v", got)
+	}
+	if got := Candidates(versions, "v1.0.0", "v1.2.0"); !reflect.DeepEqual(got, []string{"v1.1.0", "v1.2.0"}) {
+		t.Fatalf("unexpected bounded candidates: %v", got)
+	}
+	if got := Candidates(versions, "v1.1.0", "v1.2.0-rc.1"); !reflect.DeepEqual(got, []string{"v1.2.0-rc.1"}) {
+		t.Fatalf("unexpected prerelease candidates: %v", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	candidates := []string{"v1.1.0", "v1.2.0", "v1.3.0", "v1.4.0", "v1.5.0", "v1.6.0"}
+	var tested []string
+	breaksAt := func(bad string) Tester {
+		return func(_ context.Context, v string) (bool, error) {
+			tested = append(tested, v)
+			c, _ := style.ComparePrecedence(v, bad)
+			return c < 0, nil
+		}
+	}
+
+	res, err := Search(context.Background(), "v1.0.0", candidates, breaksAt("v1.4.0"))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if res.FirstBad != "v1.4.0" || res.LastGood != "v1.3.0" || res.Tested != len(tested) || res.Tested > 4 {
+		t.Fatalf("unexpected result %+v (tested %v)", res, tested)
+	}
+
+	tested = nil
+	res, _ = Search(context.Background(), "v1.0.0", candidates, breaksAt("v1.1.0"))
+	if res.FirstBad != "v1.1.0" || res.LastGood != "v1.0.0" {
+		t.Fatalf("unexpected result when the first candidate breaks: %+v", res)
+	}
+
+	tested = nil
+	res, _ = Search(context.Background(), "v1.0.0", candidates, breaksAt("v9.0.0"))
+	if res.FirstBad != "" || res.LastGood != "v1.6.0" || res.Tested != 1 {
+		t.Fatalf("expected newest version to pass after one test, got %+v", res)
+	}
+}
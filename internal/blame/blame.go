@@ -0,0 +1,69 @@
+// Package blame finds when manifest lines were last changed using git blame,
+// which approximates when each dependency was last upgraded locally.
+package blame
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+)
+
+// Line is a file line and the author time of the commit that last changed it.
+type Line struct {
+	Text string
+	Time time.Time
+}
+
+// File runs `git blame --line-porcelain` on file inside dir.
+func File(ctx context.Context, dir, file string) ([]Line, error) {
+	out, err := execx.Command(ctx, dir, "git", "blame", "--line-porcelain", "--", file).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git blame on %s: %w", file, err)
+	}
+	return Parse(out)
+}
+
+// Parse reads `git blame --line-porcelain` output.
+func Parse(data []byte) ([]Line, error) {
+	var lines []Line
+	var authorTime time.Time
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		text := sc.Text()
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			lines = append(lines, Line{Text: text[1:], Time: authorTime})
+		case strings.HasPrefix(text, "author-time "):
+			secs, err := strconv.ParseInt(strings.TrimPrefix(text, "author-time "), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid blame author-time %q", text)
+			}
+			authorTime = time.Unix(secs, 0).UTC()
+		}
+	}
+	return lines, sc.Err()
+}
+
+// LastChanged returns, for each name, the most recent time a line mentioning
+// it as a whole token (e.g. `github.com/a/b v1.2.3`, `"express": "^4"`,
+// `requests==2.31`) was changed. Names without a matching line are omitted.
+func LastChanged(lines []Line, names []string) map[string]time.Time {
+	out := make(map[string]time.Time)
+	for _, name := range names {
+		re := regexp.MustCompile(`(^|[\s"'])` + regexp.QuoteMeta(name) + `($|[\s"'=<>~!^@\[:;,])`)
+		for _, l := range lines {
+			if re.MatchString(l.Text) && l.Time.After(out[name]) {
+				out[name] = l.Time
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package blame
+
+import (
+	"testing"
+	"time"
+)
+
+const porcelain = `1111111111111111111111111111111111111111 1 1 2
+author Alice
+author-time 1600000000
+author-tz +0000
+filename go.mod
+	module example.com/foo
+1111111111111111111111111111111111111111 2 2
+author Alice
+author-time 1600000000
+author-tz +0000
+filename go.mod
+	require github.com/a/b v1.2.3
+2222222222222222222222222222222222222222 3 3 1
+author Bob
+author-time 1700000000
+author-tz +0000
+filename go.mod
+	require github.com/a/b/v2 v2.0.0
+`
+
+func TestParseAndLastChanged(t *testing.T) {
+	lines, err := Parse([]byte(porcelain))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(lines) != 3 || lines[1].Text != "require github.com/a/b v1.2.3" {
+		t.Fatalf("unexpected lines: %+v", lines)
+	}
+
+	got := LastChanged(lines, []string{"github.com/a/b", "github.com/a/b/v2", "example.com/missing"})
+	if !got["github.com/a/b"].Equal(time.Unix(1600000000, 0)) {
+		t.Fatalf("unexpected time for github.com/a/b: %v", got["github.com/a/b"])
+	}
+	if !got["github.com/a/b/v2"].Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("unexpected time for v2: %v", got["github.com/a/b/v2"])
+	}
+	if _, ok := got["example.com/missing"]; ok {
+		t.Fatalf("expected unmatched module to be omitted")
+	}
+
+	npm := LastChanged([]Line{{Text: `    "express": "^4.18.2",`, Time: time.Unix(1, 0)}}, []string{"express", "express-session"})
+	if len(npm) != 1 {
+		t.Fatalf("expected only express to match, got %v", npm)
+	}
+}
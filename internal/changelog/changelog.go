@@ -0,0 +1,132 @@
+// Package changelog fetches release notes for module updates and scans them
+// for wording that hints at a risky upgrade.
+package changelog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// ErrUnsupported is returned for modules whose release notes cannot be located.
+var ErrUnsupported = errors.New("release notes not available for this module")
+
+// DefaultKeywords are matched case-insensitively when no custom list is given.
+var DefaultKeywords = []string{"BREAKING", "deprecat", "security", "remove"}
+
+// Release is a single published release and its notes.
+type Release struct {
+	Tag  string
+	Body string
+}
+
+// Source provides the releases published for a module.
+type Source interface {
+	Releases(ctx context.Context, modulePath string) ([]Release, error)
+}
+
+// Hit is a release note line that matched a risk keyword.
+type Hit struct {
+	Tag     string
+	Keyword string
+	Line    string
+}
+
+// String returns a compact "tag: line" form of the hit.
+func (h Hit) String() string {
+	return fmt.Sprintf("%s: %s", h.Tag, h.Line)
+}
+
+// GitHubSource reads releases from the GitHub REST API for modules hosted on
+// GitHub, including vanity paths that resolver maps to a GitHub repository.
+type GitHubSource struct {
+	client   *github.Client
+	resolver github.RepoResolver
+}
+
+// NewGitHubSource creates a source backed by client. resolver may be nil, in
+// which case only github.com module paths are supported.
+func NewGitHubSource(client *github.Client, resolver github.RepoResolver) *GitHubSource {
+	return &GitHubSource{client: client, resolver: resolver}
+}
+
+// Releases returns the most recent releases of the repository hosting modulePath.
+func (s *GitHubSource) Releases(ctx context.Context, modulePath string) ([]Release, error) {
+	owner, repo, ok := github.ResolveRepo(ctx, s.resolver, modulePath)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	raw, err := s.client.Releases(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(raw))
+	for _, r := range raw {
+		if r.Draft {
+			continue
+		}
+		releases = append(releases, Release{Tag: r.TagName, Body: r.Body})
+	}
+	return releases, nil
+}
+
+// Between returns the releases newer than from and no newer than to. Tags
+// with a subdirectory prefix ("api/v1.2.0") are compared by their version.
+func Between(releases []Release, from, to string) []Release {
+	var out []Release
+	for _, r := range releases {
+		v := r.Tag
+		if i := strings.LastIndex(v, "/"); i >= 0 {
+			v = v[i+1:]
+		}
+		lower, ok1 := style.CompareVersions(v, from)
+		upper, ok2 := style.CompareVersions(v, to)
+		if !ok1 || !ok2 {
+			continue
+		}
+		if lower > 0 && upper <= 0 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Scan returns every release note line containing one of keywords, matched
+// case-insensitively. Each line is reported at most once.
+func Scan(releases []Release, keywords []string) []Hit {
+	if len(keywords) == 0 {
+		keywords = DefaultKeywords
+	}
+	var hits []Hit
+	for _, r := range releases {
+		for _, raw := range strings.Split(r.Body, "\n") {
+			line := cleanLine(raw)
+			if line == "" {
+				continue
+			}
+			lower := strings.ToLower(line)
+			for _, kw := range keywords {
+				if kw != "" && strings.Contains(lower, strings.ToLower(kw)) {
+					hits = append(hits, Hit{Tag: r.Tag, Keyword: kw, Line: line})
+					break
+				}
+			}
+		}
+	}
+	return hits
+}
+
+// cleanLine strips markdown heading, quote and list markers from a line.
+func cleanLine(s string) string {
+	s = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(s), "#>"))
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		s = strings.TrimPrefix(s, marker)
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,94 @@
+package changelog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/github"
+)
+
+func TestBetween(t *testing.T) {
+	releases := []Release{
+		{Tag: "v1.3.0"}, {Tag: "v1.2.0"}, {Tag: "api/v1.1.0"}, {Tag: "v1.0.0"}, {Tag: "nightly"},
+	}
+	got := Between(releases, "v1.0.0", "v1.2.0")
+	if len(got) != 2 || got[0].Tag != "v1.2.0" || got[1].Tag != "api/v1.1.0" {
+		t.Fatalf("unexpected releases: %#v", got)
+	}
+}
+
+func TestScan(t *testing.T) {
+	releases := []Release{{
+		Tag:  "v2.0.0",
+		Body: "## Changes\n- **Breaking**: drop Go 1.20\n- Fix SECURITY issue in parser, remove legacy API\n- Add feature\n",
+	}}
+	hits := Scan(releases, nil)
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 hits, got %#v", hits)
+	}
+	if hits[0].Keyword != "BREAKING" || hits[0].Line != "**Breaking**: drop Go 1.20" {
+		t.Fatalf("unexpected first hit: %#v", hits[0])
+	}
+	if hits[1].Keyword != "security" {
+		t.Fatalf("expected one hit per line, got %#v", hits[1])
+	}
+
+	if hits := Scan(releases, []string{"feature"}); len(hits) != 1 || hits[0].Line != "Add feature" {
+		t.Fatalf("unexpected custom keyword hits: %#v", hits)
+	}
+}
+
+func TestGitHubSource_Releases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/spf13/cobra/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"tag_name":"v1.9.0","body":"notes"},{"tag_name":"v2.0.0","body":"wip","draft":true}]`))
+	}))
+	defer srv.Close()
+
+	s := NewGitHubSource(github.NewClientWithBaseURL(srv.URL, ""), nil)
+
+	got, err := s.Releases(context.Background(), "github.com/spf13/cobra/v2")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].Tag != "v1.9.0" || got[0].Body != "notes" {
+		t.Fatalf("unexpected releases: %#v", got)
+	}
+
+	if _, err := s.Releases(context.Background(), "golang.org/x/mod"); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestGitLabSource_ReleasesAndSources(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/acme%2Ftool/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"tag_name":"v1.3.0","description":"soon","upcoming_release":true},{"tag_name":"v1.2.0","description":"notes"}]`))
+	}))
+	defer srv.Close()
+
+	s := Sources(NewGitHubSource(github.NewClientWithBaseURL(srv.URL, ""), nil), NewGitLabSource(srv.Client(), srv.URL))
+	got, err := s.Releases(context.Background(), "gitlab.com/acme/tool/v2")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].Tag != "v1.2.0" || got[0].Body != "notes" {
+		t.Fatalf("unexpected releases: %#v", got)
+	}
+
+	if _, err := s.Releases(context.Background(), "gitlab.com/acme/missing"); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported for an unknown project, got %v", err)
+	}
+	if _, err := s.Releases(context.Background(), "golang.org/x/mod"); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultGitLabURL is gitlab.com, whose REST API is served under /api/v4.
+const DefaultGitLabURL = "https://gitlab.com"
+
+// GitLabSource reads releases from the GitLab REST API for modules hosted on
+// gitlab.com.
+type GitLabSource struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewGitLabSource creates a source for the GitLab instance at baseURL
+// (DefaultGitLabURL when empty).
+func NewGitLabSource(client *http.Client, baseURL string) *GitLabSource {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	return &GitLabSource{client: client, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+type gitLabRelease struct {
+	TagName         string `json:"tag_name"`
+	Description     string `json:"description"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+}
+
+// Releases returns the most recent releases of the gitlab.com project
+// hosting modulePath. Projects in nested groups are not supported: the
+// module path does not say where the project path ends.
+func (s *GitLabSource) Releases(ctx context.Context, modulePath string) ([]Release, error) {
+	parts := strings.Split(modulePath, "/")
+	if len(parts) < 3 || parts[0] != "gitlab.com" {
+		return nil, ErrUnsupported
+	}
+	project := url.PathEscape(parts[1] + "/" + parts[2])
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/api/v4/projects/"+project+"/releases?per_page=100", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrUnsupported
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GitLab releases for %s: %s", modulePath, resp.Status)
+	}
+
+	var raw []gitLabRelease
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode GitLab releases: %w", err)
+	}
+	releases := make([]Release, 0, len(raw))
+	for _, r := range raw {
+		if r.UpcomingRelease {
+			continue
+		}
+		releases = append(releases, Release{Tag: r.TagName, Body: r.Description})
+	}
+	return releases, nil
+}
+
+// Sources returns a Source that asks each source in turn, moving on while
+// they report ErrUnsupported.
+func Sources(sources ...Source) Source {
+	return multiSource(sources)
+}
+
+type multiSource []Source
+
+func (s multiSource) Releases(ctx context.Context, modulePath string) ([]Release, error) {
+	for _, src := range s {
+		releases, err := src.Releases(ctx, modulePath)
+		if !errors.Is(err, ErrUnsupported) {
+			return releases, err
+		}
+	}
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,128 @@
+// Package commit generates conventional commit messages for applied upgrades
+// and records them with git.
+package commit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/report"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// DefaultTemplate renders "<type>(<scope>): <subject>" followed by one line per bump.
+const DefaultTemplate = `{{.Type}}({{.Scope}}): {{.Subject}}
+{{if gt (len .Bumps) 1}}
+{{range .Bumps}}- bump {{.Name}} from {{.From}} to {{.To}}{{if .VulnsFixed}} (fixes {{.VulnsFixed}} {{if eq .VulnsFixed 1}}vulnerability{{else}}vulnerabilities{{end}}){{end}}
+{{end}}{{else}}{{range .Bumps}}{{if .VulnsFixed}}
+Fixes {{.VulnsFixed}} known {{if eq .VulnsFixed 1}}vulnerability{{else}}vulnerabilities{{end}}.
+{{end}}{{end}}{{end}}`
+
+// Bump describes one module upgrade.
+type Bump struct {
+	Name       string
+	From       string
+	To         string
+	VulnsFixed int
+}
+
+// Data is the value passed to the message template.
+type Data struct {
+	Type    string
+	Scope   string
+	Manager string
+	Subject string
+	Bumps   []Bump
+	// Updates carries the full result records, as seen by report templates.
+	Updates []format.Record
+}
+
+// NewData builds template data for modules, defaulting Type to "chore" and
+// Scope to "deps".
+func NewData(commitType, scope, manager string, modules []scanner.Module) Data {
+	if commitType == "" {
+		commitType = "chore"
+	}
+	if scope == "" {
+		scope = "deps"
+	}
+	d := Data{Type: commitType, Scope: scope, Manager: manager}
+	for _, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		name := m.Name
+		if name == "" {
+			name = m.Path
+		}
+		b := Bump{Name: name, From: m.Version, To: m.Update.Version}
+		if fixed := m.VulnCurrent.Total - m.VulnUpdate.Total; fixed > 0 {
+			b.VulnsFixed = fixed
+		}
+		d.Bumps = append(d.Bumps, b)
+	}
+
+	switch len(d.Bumps) {
+	case 0:
+		d.Subject = "update dependencies"
+	case 1:
+		d.Subject = fmt.Sprintf("bump %s from %s to %s", d.Bumps[0].Name, d.Bumps[0].From, d.Bumps[0].To)
+	default:
+		d.Subject = fmt.Sprintf("bump %d %s dependencies", len(d.Bumps), manager)
+	}
+	return d
+}
+
+// Message renders data with tmpl, or DefaultTemplate when tmpl is empty.
+func Message(tmpl string, data Data) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	t, err := report.Parse("commit", tmpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render commit template: %w", err)
+	}
+	msg := strings.TrimSpace(buf.String())
+	if msg == "" {
+		return "", fmt.Errorf("commit template rendered an empty message")
+	}
+	return msg + "\n", nil
+}
+
+// Commit stages the files in dir that exist and commits them with message.
+func Commit(ctx context.Context, dir string, files []string, message string) error {
+	var paths []string
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(dir, f)); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		paths = append(paths, f)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no files to commit")
+	}
+
+	add := execx.Command(ctx, dir, "git", append([]string{"add", "--"}, paths...)...)
+	if out, err := add.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stage files: %w\n%s", err, out)
+	}
+
+	cmd := execx.Command(ctx, dir, "git", "commit", "-F", "-")
+	cmd.Stdin = strings.NewReader(message)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit: %w\n%s", err, out)
+	}
+	return nil
+}
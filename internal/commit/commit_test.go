@@ -0,0 +1,59 @@
+package commit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+func TestMessage_Single(t *testing.T) {
+	data := NewData("", "", "go", []scanner.Module{
+		{Name: "github.com/a/b", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"},
+			VulnCurrent: scanner.VulnInfo{Total: 2}, VulnUpdate: scanner.VulnInfo{Total: 1}},
+	})
+	msg, err := Message("", data)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := "chore(deps): bump github.com/a/b from v1.0.0 to v1.1.0\n\nFixes 1 known vulnerability.\n"
+	if msg != want {
+		t.Fatalf("unexpected message:\n%q\nwant:\n%q", msg, want)
+	}
+}
+
+func TestMessage_Multiple(t *testing.T) {
+	data := NewData("build", "go", "go", []scanner.Module{
+		{Name: "github.com/a/b", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+		{Path: "github.com/c/d", Version: "v0.1.0", Update: &scanner.UpdateInfo{Version: "v0.2.0"},
+			VulnCurrent: scanner.VulnInfo{Total: 3}},
+		{Name: "github.com/e/f", Version: "v1.0.0"},
+	})
+	msg, err := Message("", data)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := "build(go): bump 2 go dependencies\n\n" +
+		"- bump github.com/a/b from v1.0.0 to v1.1.0\n" +
+		"- bump github.com/c/d from v0.1.0 to v0.2.0 (fixes 3 vulnerabilities)\n"
+	if msg != want {
+		t.Fatalf("unexpected message:\n%q\nwant:\n%q", msg, want)
+	}
+}
+
+func TestMessage_CustomTemplate(t *testing.T) {
+	data := NewData("", "", "npm", []scanner.Module{
+		{Name: "react", Version: "18.0.0", Update: &scanner.UpdateInfo{Version: "18.3.1"}},
+	})
+	msg, err := Message("deps: {{range .Bumps}}{{.Name}}@{{.To}}{{end}}", data)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if msg != "deps: react@18.3.1\n" {
+		t.Fatalf("unexpected message: %q", msg)
+	}
+
+	if _, err := Message("{{.Nope", data); err == nil || !strings.Contains(err.Error(), "invalid commit template") {
+		t.Fatalf("expected template parse error, got %v", err)
+	}
+}
@@ -0,0 +1,645 @@
+// Package config loads optional per-project settings from .faro.json and
+// the user-level settings a project may not override.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FileName is the project configuration file looked up in the working directory.
+const FileName = ".faro.json"
+
+// Config holds project-level settings. Zero values mean "use the default".
+type Config struct {
+	Commit   Commit   `json:"commit"`
+	Report   Report   `json:"report"`
+	Policy   Policy   `json:"policy"`
+	Tools    Tools    `json:"tools"`
+	GitHub   GitHub   `json:"github"`
+	GoProxy  GoProxy  `json:"goproxy"`
+	Critical Critical `json:"critical"`
+	// Prerelease opts modules in to pre-release upgrade candidates.
+	Prerelease Prerelease `json:"prerelease"`
+	Doctor     Doctor     `json:"doctor"`
+	Monorepo   Monorepo   `json:"monorepo"`
+	Bazel      Bazel      `json:"bazel"`
+	Nix        Nix        `json:"nix"`
+	Glyphs     Glyphs     `json:"glyphs"`
+	Audit      Audit      `json:"audit"`
+	// Cooldown sets the default minimum update age in days per ecosystem
+	// ("go", "npm", "pypi") or package manager ("yarn", "poetry", ...),
+	// used when --cooldown is not given.
+	Cooldown    Cooldown    `json:"cooldown,omitempty"`
+	SupplyChain SupplyChain `json:"supplyChain"`
+	Provenance  Provenance  `json:"provenance"`
+	// Vulnerabilities configures the databases queried by --vulnerabilities.
+	Vulnerabilities Vulnerabilities `json:"vulnerabilities"`
+	Tickets         Tickets         `json:"tickets"`
+	// Channels pin modules to a release line; the first matching entry wins.
+	Channels []Channel `json:"channels,omitempty"`
+	// Notes annotate modules with team knowledge, shown under their updates
+	// in the picker and text output.
+	Notes     []Note    `json:"notes,omitempty"`
+	ScanCache ScanCache `json:"scanCache"`
+	Cache     Cache     `json:"cache"`
+	Freeze    Freeze    `json:"freeze"`
+	Telemetry Telemetry `json:"telemetry"`
+}
+
+// Cooldown maps an ecosystem or package manager name to a cooldown in days.
+type Cooldown map[string]int
+
+// Days returns the cooldown for manager, falling back to its ecosystem.
+func (c Cooldown) Days(manager, ecosystem string) (int, bool) {
+	if days, ok := c[manager]; ok {
+		return days, true
+	}
+	days, ok := c[ecosystem]
+	return days, ok
+}
+
+// Commit configures messages generated by --commit.
+type Commit struct {
+	// Type is the conventional commit type (default "chore").
+	Type string `json:"type,omitempty"`
+	// Scope is the conventional commit scope (default "deps").
+	Scope string `json:"scope,omitempty"`
+	// Template is a text/template overriding the whole message.
+	Template string `json:"template,omitempty"`
+	// TemplateFile is a file holding Template, relative to the project directory.
+	TemplateFile string `json:"templateFile,omitempty"`
+}
+
+// Policy holds the rules enforced by `faro policy check`.
+type Policy struct {
+	// MaxAge is the longest a dependency's current version may trail its
+	// latest release, e.g. "365d", "52w" or "1y".
+	MaxAge string `json:"maxAge,omitempty"`
+	// Scope is "direct" (default) or "all" to include indirect and transitive dependencies.
+	Scope string `json:"scope,omitempty"`
+	// AutoApply is the largest estimated effort ("trivial", "small",
+	// "medium" or "large") that non-interactive upgrades apply; larger
+	// updates are held back for -i.
+	AutoApply string `json:"autoApply,omitempty"`
+}
+
+// Tools configures checks for Go tool dependencies (go.mod tool directives).
+type Tools struct {
+	// Platforms lists the GOOS/GOARCH pairs (e.g. "linux/amd64") a tool
+	// update must publish release binaries for before it is suggested.
+	Platforms []string `json:"platforms,omitempty"`
+}
+
+// GitHub configures the client shared by GitHub-backed features. It is
+// only read from the user configuration.
+type GitHub struct {
+	// TokenEnv names an environment variable holding the API token, checked
+	// before GITHUB_TOKEN and GH_TOKEN. Tokens are never read from a file.
+	TokenEnv string `json:"tokenEnv,omitempty"`
+	// BaseURL points at a GitHub Enterprise API, e.g. "https://ghe.example.com/api/v3".
+	BaseURL string `json:"baseURL,omitempty"`
+}
+
+// GoProxy configures faro's own module proxy requests (update lookups with
+// --proxy-scan, release channels, Go version and owner checks). The proxy
+// itself comes from GOPROXY, and credentials for its host from .netrc. It
+// is only read from the user configuration.
+type GoProxy struct {
+	// TokenEnv names an environment variable holding a bearer token for
+	// authenticated proxies (Artifactory, Athens behind a gateway). It is
+	// never sent to proxy.golang.org. Tokens are never read from a file.
+	TokenEnv string `json:"tokenEnv,omitempty"`
+	// Private is the proxy for modules matching GONOPROXY or GOPRIVATE,
+	// e.g. an internal Athens or Artifactory instance. Without it faro never
+	// asks a proxy about private modules.
+	Private string `json:"private,omitempty"`
+}
+
+// DefaultCriticalCooldown is the minimum update age, in days, for critical modules.
+const DefaultCriticalCooldown = 30
+
+// Critical tags modules (database drivers, crypto, auth libraries) that get
+// stricter upgrade rules: a longer cooldown, never upgraded by -u or
+// align, and only applied after an explicit interactive confirmation.
+type Critical struct {
+	// Modules lists module names, path prefixes ("golang.org/x/crypto") or
+	// path.Match patterns ("github.com/jackc/*") to treat as critical.
+	Modules []string `json:"modules,omitempty"`
+	// Cooldown is the minimum age in days of an update to a critical module
+	// (default DefaultCriticalCooldown). A longer --cooldown still wins.
+	Cooldown int `json:"cooldown,omitempty"`
+}
+
+// Matches reports whether name, or a path prefix of it, is tagged critical.
+func (c Critical) Matches(name string) bool {
+	return matchModule(c.Modules, name)
+}
+
+// matchModule reports whether name, or a path prefix of it, matches one of
+// patterns.
+func matchModule(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		for prefix := name; prefix != "."; prefix = path.Dir(prefix) {
+			if ok, _ := path.Match(pattern, prefix); ok {
+				return true
+			}
+			if !strings.Contains(prefix, "/") {
+				break
+			}
+		}
+	}
+	return false
+}
+
+// Prerelease lists the modules whose pre-release versions (v2.0.0-rc.1)
+// are offered as updates, which `go list -m -u` never proposes for a
+// module on a release.
+type Prerelease struct {
+	// Modules lists module names, path prefixes or path.Match patterns,
+	// matched as Critical.Modules.
+	Modules []string `json:"modules,omitempty"`
+}
+
+// Matches reports whether name, or a path prefix of it, opts in to
+// pre-releases.
+func (p Prerelease) Matches(name string) bool {
+	return matchModule(p.Modules, name)
+}
+
+// CooldownDays returns the cooldown for critical modules.
+func (c Critical) CooldownDays() int {
+	if c.Cooldown > 0 {
+		return c.Cooldown
+	}
+	return DefaultCriticalCooldown
+}
+
+// Doctor configures how doctor mode verifies each upgrade.
+type Doctor struct {
+	// Command replaces `go build ./...` and `go test`, e.g. "make test".
+	Command string `json:"command,omitempty"`
+	// ScopeTests runs `go test` only for packages that import the upgraded
+	// module, directly or transitively.
+	ScopeTests bool `json:"scopeTests,omitempty"`
+}
+
+// Report configures --format markdown output.
+type Report struct {
+	// Template is a template file replacing the default markdown report,
+	// relative to the project directory.
+	Template string `json:"template,omitempty"`
+	// Top limits text output to the highest-priority updates, like --top.
+	Top int `json:"top,omitempty"`
+}
+
+// Load reads FileName from dir and the settings reserved to the user
+// configuration from UserPath. A missing file yields an empty Config.
+func Load(dir string) (Config, error) {
+	cfg, err := loadProject(filepath.Join(dir, FileName))
+	if err != nil {
+		return cfg, err
+	}
+	user, err := LoadUser()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.GitHub = user.GitHub
+	cfg.GoProxy = user.GoProxy
+	cfg.Audit.Endpoint, cfg.Audit.TokenEnv = user.Audit.Endpoint, user.Audit.TokenEnv
+	cfg.Telemetry = user.Telemetry
+	jira := user.Tickets.Jira
+	cfg.Tickets.Jira.URL, cfg.Tickets.Jira.UserEnv, cfg.Tickets.Jira.TokenEnv = jira.URL, jira.UserEnv, jira.TokenEnv
+	return cfg, nil
+}
+
+// loadProject reads and validates the project configuration at path.
+func loadProject(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if keys := cfg.userOnly(); len(keys) > 0 {
+		return cfg, fmt.Errorf("%s cannot be set in %s: a project must not choose where credentials or usage data are sent; move it to %s", strings.Join(keys, ", "), path, UserPath())
+	}
+	for name, days := range cfg.Cooldown {
+		if days < 0 {
+			return cfg, fmt.Errorf("invalid cooldown for %s in %s: %d days", name, path, days)
+		}
+	}
+	for _, db := range cfg.Vulnerabilities.Databases {
+		if !strings.HasPrefix(db, "https://") && !strings.HasPrefix(db, "http://") && !strings.HasPrefix(db, "file://") {
+			return cfg, fmt.Errorf("invalid vulnerability database %q in %s: want an https, http or file URL", db, path)
+		}
+	}
+	for i, c := range cfg.Channels {
+		if c.Module == "" || (c.Line == "") == (c.Match == "") {
+			return cfg, fmt.Errorf("invalid channel %d in %s: want a module and exactly one of line or match", i+1, path)
+		}
+		if c.Match != "" {
+			re, err := regexp.Compile(c.Match)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid channel match for %s in %s: %w", c.Module, path, err)
+			}
+			cfg.Channels[i].re = re
+		}
+	}
+	for i, n := range cfg.Notes {
+		if n.Module == "" || n.Text == "" {
+			return cfg, fmt.Errorf("invalid note %d in %s: want a module and text", i+1, path)
+		}
+	}
+	if cfg.ScanCache.TTL != "" {
+		ttl, err := time.ParseDuration(cfg.ScanCache.TTL)
+		if err != nil || ttl < 0 {
+			return cfg, fmt.Errorf("invalid scanCache.ttl %q in %s: want a duration like \"1h\", or \"0\" to disable", cfg.ScanCache.TTL, path)
+		}
+	}
+	if cfg.Cache.TTL != "" {
+		ttl, err := time.ParseDuration(cfg.Cache.TTL)
+		if err != nil || ttl < 0 {
+			return cfg, fmt.Errorf("invalid cache.ttl %q in %s: want a duration like \"24h\", or \"0\" to disable", cfg.Cache.TTL, path)
+		}
+	}
+	if _, _, _, err := cfg.Freeze.parse(); err != nil {
+		return cfg, fmt.Errorf("%w in %s", err, path)
+	}
+	return cfg, nil
+}
+
+// UserFileName is the user-level configuration file in faro's directory
+// under the user config directory.
+const UserFileName = "config.json"
+
+// User holds the settings only the user configuration may set. They decide
+// where tokens and usage data are sent, so a cloned repository's .faro.json
+// must not be able to choose them.
+type User struct {
+	GitHub  GitHub  `json:"github"`
+	GoProxy GoProxy `json:"goproxy"`
+	// Audit holds the endpoint records are posted to; its file is read
+	// from the project configuration.
+	Audit     Audit     `json:"audit"`
+	Telemetry Telemetry `json:"telemetry"`
+	// Tickets holds the Jira site and credentials; the rest is read from
+	// the project configuration.
+	Tickets Tickets `json:"tickets"`
+}
+
+// userOnly lists the settings in a project configuration that belong in User.
+func (c Config) userOnly() []string {
+	var keys []string
+	if c.GitHub.TokenEnv != "" {
+		keys = append(keys, "github.tokenEnv")
+	}
+	if c.GitHub.BaseURL != "" {
+		keys = append(keys, "github.baseURL")
+	}
+	if c.GoProxy.TokenEnv != "" {
+		keys = append(keys, "goproxy.tokenEnv")
+	}
+	if c.GoProxy.Private != "" {
+		keys = append(keys, "goproxy.private")
+	}
+	if c.Audit.Endpoint != "" {
+		keys = append(keys, "audit.endpoint")
+	}
+	if c.Audit.TokenEnv != "" {
+		keys = append(keys, "audit.tokenEnv")
+	}
+	if c.Telemetry != (Telemetry{}) {
+		keys = append(keys, "telemetry")
+	}
+	if c.Tickets.Jira.URL != "" {
+		keys = append(keys, "tickets.jira.url")
+	}
+	if c.Tickets.Jira.UserEnv != "" {
+		keys = append(keys, "tickets.jira.userEnv")
+	}
+	if c.Tickets.Jira.TokenEnv != "" {
+		keys = append(keys, "tickets.jira.tokenEnv")
+	}
+	return keys
+}
+
+// UserPath returns the user configuration file: $FARO_CONFIG when set,
+// else UserFileName under $XDG_CONFIG_HOME/faro (~/.config/faro on Linux).
+// It returns "" when neither can be determined.
+func UserPath() string {
+	if path := os.Getenv("FARO_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "faro", UserFileName)
+}
+
+// LoadUser reads the user configuration. A missing file yields an empty User.
+func LoadUser() (User, error) {
+	var user User
+	path := UserPath()
+	if path == "" {
+		return user, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return user, nil
+	}
+	if err != nil {
+		return user, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &user); err != nil {
+		return user, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	switch user.Telemetry.Mode {
+	case "", "off", "local":
+	case "share":
+		if !strings.HasPrefix(user.Telemetry.Endpoint, "https://") && !strings.HasPrefix(user.Telemetry.Endpoint, "http://") {
+			return user, fmt.Errorf("invalid telemetry.endpoint %q in %s: share mode needs an https or http URL", user.Telemetry.Endpoint, path)
+		}
+	default:
+		return user, fmt.Errorf("invalid telemetry.mode %q in %s: want off, local or share", user.Telemetry.Mode, path)
+	}
+	return user, nil
+}
+
+// DefaultCacheTTL is how long cached vulnerability and publish-time
+// lookups are reused.
+const DefaultCacheTTL = 24 * time.Hour
+
+// Cache configures the on-disk cache of vulnerability and publish-time
+// lookups shared by all projects.
+type Cache struct {
+	// TTL is how long a lookup is reused, e.g. "6h" (default
+	// DefaultCacheTTL); "0" disables the cache.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// Duration returns the configured TTL. Load has validated it.
+func (c Cache) Duration() time.Duration {
+	if c.TTL == "" {
+		return DefaultCacheTTL
+	}
+	ttl, _ := time.ParseDuration(c.TTL)
+	return ttl
+}
+
+// DefaultScanCacheTTL is how long cached latest versions are reused.
+const DefaultScanCacheTTL = time.Hour
+
+// ScanCache configures the cache of latest versions kept between Go scans.
+type ScanCache struct {
+	// TTL is how long a module's cached latest version is trusted, e.g.
+	// "30m" (default DefaultScanCacheTTL); "0" disables the cache.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// Duration returns the configured TTL. Load has validated it.
+func (s ScanCache) Duration() time.Duration {
+	if s.TTL == "" {
+		return DefaultScanCacheTTL
+	}
+	ttl, _ := time.ParseDuration(s.TTL)
+	return ttl
+}
+
+// Monorepo lists the Go modules kept in one repository without a go.work file.
+type Monorepo struct {
+	// Modules are module directories relative to the project directory,
+	// e.g. "services/api".
+	Modules []string `json:"modules,omitempty"`
+}
+
+// Bazel keeps a Bazel workspace in sync with go.mod after Go upgrades.
+type Bazel struct {
+	// Command runs in the module directory after go.mod changes, e.g.
+	// ["bazel", "mod", "tidy"] for bzlmod or ["gazelle", "update-repos",
+	// "-from_file=go.mod", "-to_macro=deps.bzl%go_dependencies"]. Empty
+	// leaves Bazel files alone.
+	Command []string `json:"command,omitempty"`
+	// Files are the files Command rewrites, relative to the module
+	// directory. They are shown in --dry-run diffs and committed with
+	// --commit. Defaults to the MODULE.bazel, MODULE.bazel.lock, deps.bzl,
+	// WORKSPACE and WORKSPACE.bazel files that exist.
+	Files []string `json:"files,omitempty"`
+}
+
+// Nix keeps Nix expressions generated from go.mod (gomod2nix) in sync after
+// Go upgrades.
+type Nix struct {
+	// Command regenerates the files in the module directory. Defaults to
+	// ["gomod2nix", "generate"] when gomod2nix.toml exists.
+	Command []string `json:"command,omitempty"`
+	// Files are the files Command rewrites, relative to the module
+	// directory. They are shown in --dry-run diffs and committed with
+	// --commit. Defaults to gomod2nix.toml.
+	Files []string `json:"files,omitempty"`
+}
+
+// Glyphs configures the symbols used in terminal output. Empty fields keep
+// the symbol of the selected set.
+type Glyphs struct {
+	// Set is "unicode", "ascii" or "auto" (default), which picks ASCII for
+	// dumb terminals and non-UTF-8 locales.
+	Set        string `json:"set,omitempty"`
+	Arrow      string `json:"arrow,omitempty"`
+	Check      string `json:"check,omitempty"`
+	Cross      string `json:"cross,omitempty"`
+	Selected   string `json:"selected,omitempty"`
+	Unselected string `json:"unselected,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	Caret      string `json:"caret,omitempty"`
+	Warning    string `json:"warning,omitempty"`
+	Times      string `json:"times,omitempty"`
+	Ellipsis   string `json:"ellipsis,omitempty"`
+}
+
+// Audit configures records of changes applied without interactive
+// confirmation (-u, align, sync).
+type Audit struct {
+	// File is a JSON lines file records are appended to, relative to the
+	// project directory.
+	File string `json:"file,omitempty"`
+	// Endpoint is a URL each record is POSTed to as JSON. It is only read
+	// from the user configuration.
+	Endpoint string `json:"endpoint,omitempty"`
+	// TokenEnv names an environment variable holding a bearer token for
+	// Endpoint. It is only read from the user configuration, and tokens are
+	// never read from a file.
+	TokenEnv string `json:"tokenEnv,omitempty"`
+}
+
+// Telemetry opts in to recording anonymous usage statistics: which options
+// runs use and how many dependencies they scan. It is only read from the
+// user configuration, so a repository cannot opt its users in. FARO_TELEMETRY
+// overrides Mode and DO_NOT_TRACK turns it off.
+type Telemetry struct {
+	// Mode is "off" (default), "local" to keep events on this machine for
+	// `faro stats`, or "share" to also post them to Endpoint.
+	Mode string `json:"mode,omitempty"`
+	// Endpoint is a URL each event is POSTed to as JSON in share mode.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// SupplyChain configures warnings about likely typosquats and releases by
+// first-time publishers. npm projects are always checked.
+type SupplyChain struct {
+	// Go also checks Go module paths against popular modules.
+	Go bool `json:"go,omitempty"`
+	// Allow lists names never flagged: exact names, or prefixes ending in
+	// "/" or "*" (e.g. "@acme/*").
+	Allow []string `json:"allow,omitempty"`
+	// Registry is the npm registry queried for publishers
+	// (default https://registry.npmjs.org).
+	Registry string `json:"registry,omitempty"`
+	// Owners compares who controls the current and update versions of
+	// direct dependencies: npm maintainers, or the repository a Go module
+	// was fetched from.
+	Owners bool `json:"owners,omitempty"`
+}
+
+// Provenance configures --verify-provenance.
+type Provenance struct {
+	// Attest lists modules (names, path prefixes or path.Match patterns, as
+	// in critical.modules) whose target release must publish a build
+	// attestation, such as a SLSA provenance file.
+	Attest []string `json:"attest,omitempty"`
+}
+
+// RequiresAttestation reports whether name is listed in Attest.
+func (p Provenance) RequiresAttestation(name string) bool {
+	return matchModule(p.Attest, name)
+}
+
+// Vulnerabilities configures vulnerability lookups.
+type Vulnerabilities struct {
+	// Databases lists extra vulnerability databases in the Go vulnerability
+	// database format (as served to govulncheck through GOVULNDB), such as
+	// internal advisories about third-party modules. Their findings are
+	// merged with OSV results.
+	Databases []string `json:"databases,omitempty"`
+}
+
+// Tickets configures `faro tickets`, which files a tracker ticket per
+// pending major upgrade.
+type Tickets struct {
+	// Tracker is "github" (GitHub Issues, using the github settings) or "jira".
+	Tracker string `json:"tracker,omitempty"`
+	// Repo is the GitHub repository issues are filed in, "owner/name".
+	Repo string `json:"repo,omitempty"`
+	// Labels are added to new tickets. On GitHub, existing tickets are only
+	// looked up among issues carrying all of them.
+	Labels []string `json:"labels,omitempty"`
+	// Title and Body are text/template overrides for the ticket title and
+	// body; BodyFile holds Body, relative to the project directory.
+	Title    string `json:"title,omitempty"`
+	Body     string `json:"body,omitempty"`
+	BodyFile string `json:"bodyFile,omitempty"`
+	Jira     Jira   `json:"jira"`
+}
+
+// Jira configures the Jira tracker. Credentials are read from the
+// environment, never from a file, and the site and the variables naming
+// them only from the user configuration.
+type Jira struct {
+	// URL is the Jira site, e.g. "https://acme.atlassian.net".
+	URL string `json:"url,omitempty"`
+	// Project is the key of the project tickets are filed in.
+	Project string `json:"project,omitempty"`
+	// IssueType defaults to "Task".
+	IssueType string `json:"issueType,omitempty"`
+	// UserEnv names a variable holding the account for API token (basic)
+	// auth; leave it empty to send the token as a bearer token.
+	UserEnv string `json:"userEnv,omitempty"`
+	// TokenEnv names a variable holding the API token (default JIRA_API_TOKEN).
+	TokenEnv string `json:"tokenEnv,omitempty"`
+}
+
+// Channel pins matching modules to a release line, so updates stay on it
+// instead of jumping to the absolute latest version.
+type Channel struct {
+	// Module is a module name or path pattern, as in critical.modules.
+	Module string `json:"module"`
+	// Line is a version prefix such as "v1.28" (allowing v1.28.x) or "4"
+	// (allowing 4.x.y). Pre-releases are excluded unless Line names one.
+	Line string `json:"line,omitempty"`
+	// Match is a regular expression versions must match instead, e.g.
+	// "^v20\\.\\d+\\.\\d+-lts" for LTS tags.
+	Match string `json:"match,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// Note is a remark about matching modules, such as who owns them.
+type Note struct {
+	// Module is a module name or path pattern, as in critical.modules.
+	Module string `json:"module"`
+	// Text is the remark, e.g. "owned by infra, coordinate before bumping".
+	Text string `json:"text"`
+}
+
+// NotesFor returns the text of every note whose module pattern matches name,
+// in config order.
+func (c Config) NotesFor(name string) []string {
+	var notes []string
+	for _, n := range c.Notes {
+		if matchModule([]string{n.Module}, name) {
+			notes = append(notes, n.Text)
+		}
+	}
+	return notes
+}
+
+// ChannelFor returns the first channel whose module pattern matches name.
+func (c Config) ChannelFor(name string) (Channel, bool) {
+	for _, ch := range c.Channels {
+		if matchModule([]string{ch.Module}, name) {
+			return ch, true
+		}
+	}
+	return Channel{}, false
+}
+
+// Allows reports whether version is on the channel.
+func (c Channel) Allows(version string) bool {
+	if c.Match != "" {
+		re := c.re
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(c.Match); err != nil {
+				return false
+			}
+		}
+		return re.MatchString(version)
+	}
+	v, line := strings.TrimPrefix(version, "v"), strings.TrimPrefix(c.Line, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 && v[i] == '-' && !strings.Contains(line, "-") {
+		return false
+	}
+	return v == line || strings.HasPrefix(v, line+".") || strings.HasPrefix(v, line+"-") || strings.HasPrefix(v, line+"+")
+}
+
+// String describes the channel for messages, e.g. "v1.28" or "/-lts$/".
+func (c Channel) String() string {
+	if c.Match != "" {
+		return "/" + c.Match + "/"
+	}
+	return c.Line
+}
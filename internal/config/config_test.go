@@ -0,0 +1,273 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad_Missing(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.Commit != (Commit{}) || cfg.Report != (Report{}) || len(cfg.Tools.Platforms) != 0 {
+		t.Fatalf("expected empty config, got %#v", cfg)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	contents := `{"commit": {"type": "build", "scope": "go", "template": "{{.Subject}}"}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(contents), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.Commit.Type != "build" || cfg.Commit.Scope != "go" || cfg.Commit.Template != "{{.Subject}}" {
+		t.Fatalf("unexpected config: %#v", cfg)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("{"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
+
+func TestCriticalMatches(t *testing.T) {
+	c := Critical{Modules: []string{"golang.org/x/crypto", "github.com/jackc/*"}}
+	for name, want := range map[string]bool{
+		"golang.org/x/crypto":       true,
+		"golang.org/x/crypto/ssh":   true,
+		"golang.org/x/cryptography": false,
+		"github.com/jackc/pgx":      true,
+		"github.com/jackc/pgx/v5":   true,
+		"github.com/lib/pq":         false,
+	} {
+		if got := c.Matches(name); got != want {
+			t.Fatalf("Matches(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if c.CooldownDays() != DefaultCriticalCooldown {
+		t.Fatalf("expected default cooldown, got %d", c.CooldownDays())
+	}
+}
+
+func TestCooldownDays(t *testing.T) {
+	c := Cooldown{"npm": 14, "yarn": 7, "go": 0}
+	if days, ok := c.Days("yarn", "npm"); !ok || days != 7 {
+		t.Fatalf("expected the manager override, got %d %v", days, ok)
+	}
+	if days, ok := c.Days("pnpm", "npm"); !ok || days != 14 {
+		t.Fatalf("expected the ecosystem default, got %d %v", days, ok)
+	}
+	if days, ok := c.Days("go", "go"); !ok || days != 0 {
+		t.Fatalf("expected an explicit zero, got %d %v", days, ok)
+	}
+	if _, ok := c.Days("pip", "pypi"); ok {
+		t.Fatalf("expected no default for pypi")
+	}
+}
+
+func TestLoad_NegativeCooldown(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"cooldown": {"npm": -1}}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Fatalf("expected an error for a negative cooldown")
+	}
+}
+
+func TestLoad_VulnerabilityDatabases(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"vulnerabilities": {"databases": ["vulndb.internal"]}}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "vulndb.internal") {
+		t.Fatalf("expected an error for a database without a URL scheme, got %v", err)
+	}
+}
+
+func TestChannels(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"channels": [
+		{"module": "k8s.io/client-go", "line": "v0.28"},
+		{"module": "node-lts", "match": "-lts$"}
+	]}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	ch, ok := cfg.ChannelFor("k8s.io/client-go")
+	if !ok {
+		t.Fatal("expected a channel for k8s.io/client-go")
+	}
+	for v, want := range map[string]bool{"v0.28.4": true, "v0.28": true, "v0.29.0": false, "v0.280.0": false, "v0.28.5-rc.1": false} {
+		if got := ch.Allows(v); got != want {
+			t.Errorf("line v0.28 Allows(%s) = %v, want %v", v, got, want)
+		}
+	}
+	ch, _ = cfg.ChannelFor("node-lts")
+	if !ch.Allows("20.1.0-lts") || ch.Allows("21.0.0") {
+		t.Fatalf("unexpected matches for %s", ch)
+	}
+	if _, ok := cfg.ChannelFor("k8s.io/api"); ok {
+		t.Fatal("expected no channel for k8s.io/api")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"channels": [{"module": "x", "line": "1", "match": "y"}]}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Fatal("expected an error for a channel with both line and match")
+	}
+}
+
+func TestNotes(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"notes": [
+		{"module": "k8s.io/*", "text": "match the cluster version"},
+		{"module": "k8s.io/client-go", "text": "owned by platform"}
+	]}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got := cfg.NotesFor("k8s.io/client-go"); len(got) != 2 || got[1] != "owned by platform" {
+		t.Fatalf("unexpected notes for k8s.io/client-go: %v", got)
+	}
+	if got := cfg.NotesFor("golang.org/x/net"); got != nil {
+		t.Fatalf("expected no notes, got %v", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"notes": [{"module": "x"}]}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Fatal("expected an error for a note without text")
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"freeze": {
+		"days": ["fri-sun"],
+		"windows": [{"from": "2026-12-14", "to": "2026-12-18", "reason": "release freeze"}],
+		"timezone": "UTC"
+	}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	for at, want := range map[string]string{
+		"2026-10-15T12:00:00Z":      "",                                // Thursday
+		"2026-10-16T12:00:00Z":      "no upgrades on Friday",           // Friday
+		"2026-10-18T23:59:00Z":      "no upgrades on Sunday",           // Sunday
+		"2026-10-19T00:00:00Z":      "",                                // Monday
+		"2026-12-18T23:00:00Z":      "release freeze until 2026-12-18", // Last day of the window
+		"2026-12-16T01:00:00+02:00": "release freeze until 2026-12-18", // Other zone, same instant in UTC
+	} {
+		now, _ := time.Parse(time.RFC3339, at)
+		got, frozen := cfg.Freeze.Active(now)
+		if got != want || frozen != (want != "") {
+			t.Errorf("Active(%s) = %q, %v, want %q", at, got, frozen, want)
+		}
+	}
+
+	for _, bad := range []string{
+		`{"freeze": {"days": ["fry"]}}`,
+		`{"freeze": {"windows": [{"from": "2026-12-18", "to": "2026-12-14"}]}}`,
+		`{"freeze": {"windows": [{"from": "next week", "to": "2026-12-14"}]}}`,
+		`{"freeze": {"timezone": "Mars/Olympus"}}`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, FileName), []byte(bad), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if _, err := Load(dir); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+}
+
+func TestLoad_Telemetry(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(t.TempDir(), UserFileName)
+	t.Setenv("FARO_CONFIG", userPath)
+	for data, valid := range map[string]bool{
+		`{"telemetry": {"mode": "local"}}`:                                               true,
+		`{"telemetry": {"mode": "share", "endpoint": "https://usage.example.com/faro"}}`: true,
+		`{"telemetry": {"mode": "share"}}`:                                               false,
+		`{"telemetry": {"mode": "on"}}`:                                                  false,
+	} {
+		if err := os.WriteFile(userPath, []byte(data), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if _, err := Load(dir); (err == nil) != valid {
+			t.Errorf("Load(%s) = %v, want valid %v", data, err, valid)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"telemetry": {"mode": "share", "endpoint": "https://usage.example.com/faro"}}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "telemetry cannot be set") {
+		t.Fatalf("expected telemetry in the project file to be refused, got %v", err)
+	}
+}
+
+func TestLoad_UserOnly(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(t.TempDir(), UserFileName)
+	t.Setenv("FARO_CONFIG", userPath)
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"github": {"tokenEnv": "AWS_SECRET_ACCESS_KEY", "baseURL": "https://attacker.example.com"}}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "github.tokenEnv, github.baseURL") {
+		t.Fatalf("expected the project's github settings to be refused, got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"goproxy": {"private": "https://attacker.example.com", "tokenEnv": "GITHUB_TOKEN"}}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "goproxy.tokenEnv, goproxy.private") {
+		t.Fatalf("expected the project's goproxy settings to be refused, got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"tickets": {"tracker": "jira", "jira": {"url": "https://attacker.example.com", "project": "PLAT"}}}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "tickets.jira.url") {
+		t.Fatalf("expected the project's jira site to be refused, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"commit": {"type": "build"}}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(userPath, []byte(`{"github": {"tokenEnv": "FARO_GITHUB_TOKEN", "baseURL": "https://ghe.example.com/api/v3"}}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.Commit.Type != "build" || cfg.GitHub.TokenEnv != "FARO_GITHUB_TOKEN" || cfg.GitHub.BaseURL != "https://ghe.example.com/api/v3" {
+		t.Fatalf("expected project and user settings, got %#v", cfg)
+	}
+}
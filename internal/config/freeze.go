@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Freeze configures windows during which upgrades are not applied: faro
+// only reports updates, unless --override-freeze is given.
+type Freeze struct {
+	// Days are weekdays on which upgrades are frozen, by name or range, e.g.
+	// ["fri-sun"] or ["sat", "sun"].
+	Days []string `json:"days,omitempty"`
+	// Windows are explicit freezes such as a release freeze.
+	Windows []FreezeWindow `json:"windows,omitempty"`
+	// Timezone is the IANA time zone Days and dates are read in, e.g.
+	// "Europe/Berlin"; defaults to local time.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// FreezeWindow is a period during which upgrades are frozen. From and To
+// are dates (2006-01-02), both included, or RFC 3339 times.
+type FreezeWindow struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason,omitempty"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Active reports whether upgrades are frozen at now, with a description of
+// the freeze in effect. Load has validated f.
+func (f Freeze) Active(now time.Time) (string, bool) {
+	days, windows, loc, err := f.parse()
+	if err != nil {
+		return "", false
+	}
+	if loc != nil {
+		now = now.In(loc)
+	}
+	for i, w := range windows {
+		if now.Before(w.from) || !now.Before(w.to) {
+			continue
+		}
+		reason := f.Windows[i].Reason
+		if reason == "" {
+			reason = "freeze window"
+		}
+		return fmt.Sprintf("%s until %s", reason, f.Windows[i].To), true
+	}
+	if days[now.Weekday()] {
+		return fmt.Sprintf("no upgrades on %s", now.Weekday()), true
+	}
+	return "", false
+}
+
+type freezeWindow struct {
+	from, to time.Time // [from, to)
+}
+
+// parse returns the frozen weekdays, the windows in order and the time
+// zone of f.
+func (f Freeze) parse() (days [7]bool, windows []freezeWindow, loc *time.Location, err error) {
+	if f.Timezone != "" {
+		if loc, err = time.LoadLocation(f.Timezone); err != nil {
+			return days, nil, nil, fmt.Errorf("invalid freeze.timezone %q: %w", f.Timezone, err)
+		}
+	}
+	for _, d := range f.Days {
+		first, last, isRange := strings.Cut(strings.ToLower(strings.TrimSpace(d)), "-")
+		if !isRange {
+			last = first
+		}
+		from, ok1 := parseWeekday(first)
+		to, ok2 := parseWeekday(last)
+		if !ok1 || !ok2 {
+			return days, nil, nil, fmt.Errorf("invalid freeze day %q: want a weekday such as \"sat\" or a range such as \"fri-sun\"", d)
+		}
+		for wd := from; ; wd = (wd + 1) % 7 {
+			days[wd] = true
+			if wd == to {
+				break
+			}
+		}
+	}
+	in := loc
+	if in == nil {
+		in = time.Local
+	}
+	for i, w := range f.Windows {
+		from, _, err1 := parseFreezeTime(w.From, in)
+		to, date, err2 := parseFreezeTime(w.To, in)
+		if err1 != nil || err2 != nil {
+			return days, nil, nil, fmt.Errorf("invalid freeze window %d: want from and to dates (2006-01-02) or RFC 3339 times", i+1)
+		}
+		if date {
+			to = to.AddDate(0, 0, 1) // The end date is frozen all day.
+		}
+		if !from.Before(to) {
+			return days, nil, nil, fmt.Errorf("invalid freeze window %d: %s is not before %s", i+1, w.From, w.To)
+		}
+		windows = append(windows, freezeWindow{from, to})
+	}
+	return days, windows, loc, nil
+}
+
+// parseWeekday accepts English weekday names and their three-letter prefixes.
+func parseWeekday(s string) (time.Weekday, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	wd, ok := weekdays[s[:3]]
+	if !ok || !strings.HasPrefix(strings.ToLower(wd.String()), s) {
+		return 0, false
+	}
+	return wd, true
+}
+
+// parseFreezeTime parses a date at midnight in loc, or an RFC 3339 time,
+// and reports whether s was a date.
+func parseFreezeTime(s string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, loc); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, false, err
+}
@@ -0,0 +1,139 @@
+// Package coverage relates Go cover profiles to the modules a project
+// imports, to tell whether tests execute any code that uses a dependency.
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+)
+
+// File is a project source file and the import paths it uses.
+type File struct {
+	Path    string // import-path form used by cover profiles, e.g. example.com/foo/pkg/a.go
+	Imports []string
+}
+
+// Executed maps profile file paths to whether any of their blocks ran.
+type Executed map[string]bool
+
+// ReadProfile parses the cover profile at path.
+func ReadProfile(path string) (Executed, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cover profile: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return ParseProfile(f)
+}
+
+// ParseProfile parses a cover profile written by `go test -coverprofile`.
+func ParseProfile(r io.Reader) (Executed, error) {
+	executed := make(Executed)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		// file.go:12.34,15.2 3 1
+		colon := strings.LastIndex(line, ":")
+		fields := strings.Fields(line[colon+1:])
+		if colon < 0 || len(fields) != 3 {
+			return nil, fmt.Errorf("invalid cover profile line %q", line)
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cover profile line %q", line)
+		}
+		file := line[:colon]
+		executed[file] = executed[file] || count > 0
+	}
+	return executed, sc.Err()
+}
+
+// ListFiles returns the non-test Go files of every package in workDir with
+// their imports.
+func ListFiles(ctx context.Context, workDir string) ([]File, error) {
+	out, err := execx.Command(ctx, workDir, "go", "list", "-json=ImportPath,Dir,GoFiles", "./...").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run go list: %w", err)
+	}
+	var files []File
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var pkg struct {
+			ImportPath string
+			Dir        string
+			GoFiles    []string
+		}
+		if err := dec.Decode(&pkg); err != nil {
+			return nil, fmt.Errorf("failed to decode go list output: %w", err)
+		}
+		for _, name := range pkg.GoFiles {
+			f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(pkg.Dir, name), nil, parser.ImportsOnly)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			file := File{Path: path.Join(pkg.ImportPath, name)}
+			for _, imp := range f.Imports {
+				if p, err := strconv.Unquote(imp.Path.Value); err == nil {
+					file.Imports = append(file.Imports, p)
+				}
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// Surface describes how tests exercise the code importing a module.
+type Surface struct {
+	Files    int // project files importing the module
+	Executed int // of those, files with at least one executed block
+}
+
+// Surfaces computes a Surface for each of modules. An import belongs to the
+// module with the longest matching path prefix.
+func Surfaces(files []File, executed Executed, modules []string) map[string]Surface {
+	out := make(map[string]Surface, len(modules))
+	for _, f := range files {
+		seen := make(map[string]bool)
+		for _, imp := range f.Imports {
+			mod := owner(imp, modules)
+			if mod == "" || seen[mod] {
+				continue
+			}
+			seen[mod] = true
+			s := out[mod]
+			s.Files++
+			if executed[f.Path] {
+				s.Executed++
+			}
+			out[mod] = s
+		}
+	}
+	return out
+}
+
+func owner(importPath string, modules []string) string {
+	best := ""
+	for _, m := range modules {
+		if (importPath == m || strings.HasPrefix(importPath, m+"/")) && len(m) > len(best) {
+			best = m
+		}
+	}
+	return best
+}
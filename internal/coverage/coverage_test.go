@@ -0,0 +1,40 @@
+package coverage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProfileAndSurfaces(t *testing.T) {
+	profile := `mode: set
+example.com/foo/db/db.go:10.2,12.3 2 1
+example.com/foo/db/db.go:14.2,16.3 1 0
+example.com/foo/auth/auth.go:5.1,7.2 3 0
+`
+	executed, err := ParseProfile(strings.NewReader(profile))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !executed["example.com/foo/db/db.go"] || executed["example.com/foo/auth/auth.go"] {
+		t.Fatalf("unexpected executed map: %v", executed)
+	}
+
+	files := []File{
+		{Path: "example.com/foo/db/db.go", Imports: []string{"github.com/jackc/pgx/v5", "github.com/jackc/pgx/v5/pgxpool"}},
+		{Path: "example.com/foo/auth/auth.go", Imports: []string{"golang.org/x/crypto/bcrypt"}},
+	}
+	got := Surfaces(files, executed, []string{"github.com/jackc/pgx/v5", "golang.org/x/crypto", "github.com/unused/mod"})
+	if got["github.com/jackc/pgx/v5"] != (Surface{Files: 1, Executed: 1}) {
+		t.Fatalf("unexpected pgx surface: %+v", got["github.com/jackc/pgx/v5"])
+	}
+	if got["golang.org/x/crypto"] != (Surface{Files: 1, Executed: 0}) {
+		t.Fatalf("unexpected crypto surface: %+v", got["golang.org/x/crypto"])
+	}
+	if _, ok := got["github.com/unused/mod"]; ok {
+		t.Fatalf("expected unimported module to be absent")
+	}
+
+	if _, err := ParseProfile(strings.NewReader("mode: set\ngarbage\n")); err == nil {
+		t.Fatalf("expected error for malformed profile")
+	}
+}
@@ -112,6 +112,16 @@ func Detect(dir string) ([]DetectionResult, error) {
 		}
 	}
 
+	if !hasEcosystem(results, "npm") && fileExists(filepath.Join(dir, "package.json")) {
+		// A package.json without a lockfile has not been installed yet; npm
+		// is the manager that creates one.
+		results = append(results, DetectionResult{
+			Manager:    Npm,
+			ConfigFile: "package.json",
+			LockFile:   "package-lock.json",
+		})
+	}
+
 	if len(results) == 0 {
 		return nil, fmt.Errorf("no supported package manager detected in %s", dir)
 	}
@@ -119,6 +129,57 @@ func Detect(dir string) ([]DetectionResult, error) {
 	return results, nil
 }
 
+// DetectEcosystem detects the package manager of the given ecosystem ("go",
+// "npm" or "pypi") in dir, preferring the highest priority match.
+func DetectEcosystem(dir, ecosystem string) (DetectionResult, error) {
+	results, err := Detect(dir)
+	if err != nil {
+		return DetectionResult{}, err
+	}
+	for _, r := range results {
+		if r.Manager.Ecosystem() == ecosystem {
+			return r, nil
+		}
+	}
+	return DetectionResult{}, fmt.Errorf("no %s project detected in %s", ecosystem, dir)
+}
+
+// ValidateEcosystem checks if a given ecosystem name is supported.
+func ValidateEcosystem(ecosystem string) (string, error) {
+	switch ecosystem {
+	case "go", "npm", "pypi":
+		return ecosystem, nil
+	default:
+		return "", fmt.Errorf("unsupported ecosystem: %s (supported: go, npm, pypi)", ecosystem)
+	}
+}
+
+// Detects reports whether dir holds a project managed by pm, by the rules
+// Detect applies.
+func Detects(dir string, pm PackageManager) bool {
+	results, _ := Detect(dir)
+	for _, r := range results {
+		if r.Manager == pm {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEcosystem(results []DetectionResult, ecosystem string) bool {
+	for _, r := range results {
+		if r.Manager.Ecosystem() == ecosystem {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // DetectSingle detects a single package manager, preferring the highest priority match.
 // If multiple managers are detected, it returns the first one based on priority.
 func DetectSingle(dir string) (DetectionResult, error) {
@@ -129,6 +190,29 @@ func DetectSingle(dir string) (DetectionResult, error) {
 	return results[0], nil
 }
 
+// Supported returns all package managers faro can scan and update, in detection priority order.
+func Supported() []PackageManager {
+	out := make([]PackageManager, 0, len(detectors))
+	for _, d := range detectors {
+		out = append(out, d.manager)
+	}
+	return out
+}
+
+// ManifestFiles returns the config and lock files pm rewrites when upgrading.
+func ManifestFiles(pm PackageManager) []string {
+	for _, d := range detectors {
+		if d.manager != pm {
+			continue
+		}
+		if d.lockFile == "" {
+			return []string{d.configFile}
+		}
+		return []string{d.configFile, d.lockFile}
+	}
+	return nil
+}
+
 // Validate checks if a given package manager name is supported.
 func Validate(manager string) (PackageManager, error) {
 	pm := PackageManager(manager)
@@ -140,6 +224,19 @@ func Validate(manager string) (PackageManager, error) {
 	}
 }
 
+// Ecosystem returns the package registry pm installs from: "go", "npm"
+// (npm, yarn, pnpm) or "pypi" (pip, poetry, uv).
+func (pm PackageManager) Ecosystem() string {
+	switch pm {
+	case Npm, Yarn, Pnpm:
+		return "npm"
+	case Pip, Poetry, Uv:
+		return "pypi"
+	default:
+		return string(pm)
+	}
+}
+
 // String returns the string representation of PackageManager.
 func (pm PackageManager) String() string {
 	return string(pm)
@@ -171,3 +171,94 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestFiles(t *testing.T) {
+	if got := ManifestFiles(Go); len(got) != 2 || got[0] != "go.mod" || got[1] != "go.sum" {
+		t.Fatalf("unexpected go files: %v", got)
+	}
+	if got := ManifestFiles(Pip); len(got) != 1 || got[0] != "requirements.txt" {
+		t.Fatalf("unexpected pip files: %v", got)
+	}
+	if got := ManifestFiles("cargo"); got != nil {
+		t.Fatalf("expected nil for unknown manager, got %v", got)
+	}
+}
+
+func TestEcosystem(t *testing.T) {
+	for pm, want := range map[PackageManager]string{Go: "go", Npm: "npm", Yarn: "npm", Pnpm: "npm", Pip: "pypi", Poetry: "pypi", Uv: "pypi"} {
+		if got := pm.Ecosystem(); got != want {
+			t.Fatalf("%s: expected %s, got %s", pm, want, got)
+		}
+	}
+}
+
+func TestFindProjects(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"go.mod",
+		"services/api/go.mod",
+		"web/package.json",
+		"web/package-lock.json",
+		"web/node_modules/left-pad/package.json",
+		"web/node_modules/left-pad/package-lock.json",
+		"vendor/example.com/lib/go.mod",
+		".git/go.mod",
+		"docs/README.md",
+	} {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	projects, err := FindProjects(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, p := range projects {
+		rel, _ := filepath.Rel(root, p.Dir)
+		got = append(got, rel+":"+p.Managers[0].Manager.String())
+	}
+	want := []string{".:go", "services/api:go", "web:npm"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestDetectEcosystem(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"go.mod", "package.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if r, err := DetectSingle(dir); err != nil || r.Manager != Go {
+		t.Fatalf("expected go to win detection, got %v, %v", r.Manager, err)
+	}
+	r, err := DetectEcosystem(dir, "npm")
+	if err != nil || r.Manager != Npm {
+		t.Fatalf("expected npm for a package.json without a lockfile, got %v, %v", r.Manager, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "yarn.lock"), []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := DetectEcosystem(dir, "npm"); r.Manager != Yarn {
+		t.Fatalf("expected the lockfile to pick yarn, got %v", r.Manager)
+	}
+	if _, err := DetectEcosystem(dir, "pypi"); err == nil {
+		t.Fatal("expected an error without a python project")
+	}
+	if _, err := ValidateEcosystem("cargo"); err == nil {
+		t.Fatal("expected an error for an unknown ecosystem")
+	}
+}
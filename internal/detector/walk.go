@@ -0,0 +1,53 @@
+package detector
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Project is a directory holding the files of at least one supported
+// package manager.
+type Project struct {
+	Dir      string            // Absolute directory of the project
+	Managers []DetectionResult // Detected managers, in priority order
+}
+
+// skipDirs are directory names FindProjects never descends into: vendored
+// and installed dependencies and test fixtures are not projects of their own.
+var skipDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// FindProjects walks root and returns every directory (root included) in
+// which Detect finds a package manager, in lexical order. Hidden directories
+// and skipDirs are not descended into.
+func FindProjects(root string) ([]Project, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", root, err)
+	}
+	var projects []Project
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && (strings.HasPrefix(d.Name(), ".") || skipDirs[d.Name()]) {
+			return filepath.SkipDir
+		}
+		if results, err := Detect(path); err == nil {
+			projects = append(projects, Project{Dir: path, Managers: results})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+	return projects, nil
+}
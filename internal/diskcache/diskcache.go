@@ -0,0 +1,136 @@
+// Package diskcache keeps lookups that are slow to repeat, such as
+// vulnerability queries and module publish times, in JSON files under the
+// user cache directory so later runs can reuse them.
+package diskcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Dir returns faro's directory under the user cache directory
+// ($XDG_CACHE_HOME/faro or ~/.cache/faro on Linux), or "" when it cannot be
+// determined.
+func Dir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "faro")
+}
+
+// Clear removes dir and everything cached in it.
+func Clear(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+type record struct {
+	Value  json.RawMessage `json:"value"`
+	Stored time.Time       `json:"stored"`
+}
+
+// Store is one cache file of JSON values by key. Values expire ttl after
+// they were stored. A nil *Store misses every lookup and stores nothing.
+type Store struct {
+	file string
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	dirty   bool
+	records map[string]record
+}
+
+// Open loads the store name (a file name such as "lookups.json") from dir.
+// A missing or corrupt file starts empty.
+func Open(dir, name string, ttl time.Duration, now func() time.Time) *Store {
+	s := &Store{file: filepath.Join(dir, name), ttl: ttl, now: now, records: make(map[string]record)}
+	if data, err := os.ReadFile(s.file); err == nil {
+		var records map[string]record
+		if json.Unmarshal(data, &records) == nil && records != nil {
+			s.records = records
+		}
+	}
+	return s
+}
+
+// Get decodes the value stored under key into v and reports whether a
+// value that has not expired was found.
+func (s *Store) Get(key string, v any) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	r, ok := s.records[key]
+	s.mu.Unlock()
+	if !ok || s.now().Sub(r.Stored) >= s.ttl {
+		return false
+	}
+	return json.Unmarshal(r.Value, v) == nil
+}
+
+// Put stores v under key.
+func (s *Store) Put(key string, v any) {
+	if s == nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = record{Value: data, Stored: s.now()}
+	s.dirty = true
+}
+
+// Save writes the store when values were added, dropping expired ones.
+func (s *Store) Save() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+	now := s.now()
+	for key, r := range s.records {
+		if now.Sub(r.Stored) >= s.ttl {
+			delete(s.records, key)
+		}
+	}
+	data, err := json.Marshal(s.records)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.file), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.file), filepath.Base(s.file)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.file); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	s.dirty = false
+	return nil
+}
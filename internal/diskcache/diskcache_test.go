@@ -0,0 +1,66 @@
+package diskcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_GetPutAndExpiry(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	s := Open(dir, "lookups.json", time.Hour, clock)
+	s.Put("old", 1)
+	now = now.Add(30 * time.Minute)
+	s.Put("new", "v1.2.0")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reopened := Open(dir, "lookups.json", time.Hour, clock)
+	var version string
+	if !reopened.Get("new", &version) || version != "v1.2.0" {
+		t.Fatalf("expected the stored value, got %q", version)
+	}
+	now = now.Add(45 * time.Minute)
+	var n int
+	if reopened.Get("old", &n) {
+		t.Fatalf("expected an expired value to miss")
+	}
+	if !reopened.Get("new", &version) {
+		t.Fatalf("expected an unexpired value to hit")
+	}
+
+	var nilStore *Store
+	nilStore.Put("k", 1)
+	if nilStore.Get("k", &n) || nilStore.Save() != nil {
+		t.Fatalf("expected a nil store to miss and save nothing")
+	}
+}
+
+func TestStore_SaveDropsExpiredAndClear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "faro")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	s := Open(dir, "lookups.json", time.Hour, clock)
+	s.Put("stale", 1)
+	now = now.Add(2 * time.Hour)
+	s.Put("fresh", 2)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if got := len(Open(dir, "lookups.json", 10*time.Hour, clock).records); got != 1 {
+		t.Fatalf("expected only the fresh record on disk, got %d", got)
+	}
+
+	if err := Clear(dir); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected the cache directory to be removed, got %v", err)
+	}
+}
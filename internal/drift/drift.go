@@ -0,0 +1,141 @@
+// Package drift compares the requirements of several Go modules kept in one
+// repository and reports shared dependencies required at different versions.
+package drift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// Member is one module of the set.
+type Member struct {
+	Dir      string // Directory as configured, relative to the repository root
+	Path     string // Module path from the module directive
+	Requires []gomod.Require
+}
+
+// Load reads the go.mod file in each of dirs, resolved against root.
+func Load(root string, dirs []string) ([]Member, error) {
+	members := make([]Member, 0, len(dirs))
+	for _, dir := range dirs {
+		goModPath := filepath.Join(root, dir, "go.mod")
+		if filepath.IsAbs(dir) {
+			goModPath = filepath.Join(dir, "go.mod")
+		}
+		data, err := os.ReadFile(goModPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read go.mod of %s: %w", dir, err)
+		}
+		contents := string(data)
+		members = append(members, Member{
+			Dir:      dir,
+			Path:     gomod.ParseModulePath(contents),
+			Requires: gomod.ParseRequires(contents),
+		})
+	}
+	return members, nil
+}
+
+// WorkspaceModules returns the directories named by `use` directives in the
+// go.work file in root, or nil when root has no go.work.
+func WorkspaceModules(root string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.work"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+	return ParseWorkUses(string(data)), nil
+}
+
+// ParseWorkUses returns the directories of the `use` directives in
+// goWorkContents, in file order.
+func ParseWorkUses(goWorkContents string) []string {
+	var dirs []string
+	inBlock := false
+	for _, rawLine := range strings.Split(goWorkContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "use ("):
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			dirs = append(dirs, strings.Trim(line, `"`))
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`))
+		}
+	}
+	return dirs
+}
+
+// Row is a dependency required at more than one version.
+type Row struct {
+	Path     string            `json:"path"`
+	Versions map[string]string `json:"versions"` // Member dir -> required version
+	Highest  string            `json:"highest"`
+}
+
+// Matrix lists the drifting dependencies of a module set.
+type Matrix struct {
+	Modules []string `json:"modules"` // Member dirs, in configured order
+	Rows    []Row    `json:"drift"`
+}
+
+// Build returns the dependencies required by at least two members at
+// different versions, sorted by path. Requirements on other members are
+// ignored, since those are normally satisfied by replace directives.
+func Build(members []Member) Matrix {
+	m := Matrix{Modules: make([]string, 0, len(members)), Rows: []Row{}}
+	own := make(map[string]bool)
+	for _, mem := range members {
+		m.Modules = append(m.Modules, mem.Dir)
+		own[mem.Path] = true
+	}
+
+	versions := make(map[string]map[string]string)
+	for _, mem := range members {
+		for _, r := range mem.Requires {
+			if own[r.Path] {
+				continue
+			}
+			if versions[r.Path] == nil {
+				versions[r.Path] = make(map[string]string)
+			}
+			versions[r.Path][mem.Dir] = r.Version
+		}
+	}
+
+	for path, byDir := range versions {
+		if len(byDir) < 2 {
+			continue
+		}
+		highest := ""
+		distinct := make(map[string]bool)
+		for _, v := range byDir {
+			distinct[v] = true
+			if highest == "" {
+				highest = v
+			} else if c, ok := style.ComparePrecedence(v, highest); ok && c > 0 {
+				highest = v
+			}
+		}
+		if len(distinct) < 2 {
+			continue
+		}
+		m.Rows = append(m.Rows, Row{Path: path, Versions: byDir, Highest: highest})
+	}
+	sort.Slice(m.Rows, func(i, j int) bool { return m.Rows[i].Path < m.Rows[j].Path })
+	return m
+}
@@ -0,0 +1,73 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeGoMod(t *testing.T, dir, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(contents), 0644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+}
+
+func TestLoadAndBuild(t *testing.T) {
+	root := t.TempDir()
+	writeGoMod(t, filepath.Join(root, "services/api"), `module example.com/api
+
+require (
+	example.com/common v0.0.0
+	github.com/google/uuid v1.6.0
+	golang.org/x/sync v0.7.0
+	github.com/pkg/errors v0.9.1
+)
+`)
+	writeGoMod(t, filepath.Join(root, "services/worker"), `module example.com/worker
+
+require (
+	example.com/common v0.1.0
+	github.com/google/uuid v1.3.0
+	golang.org/x/sync v0.7.0 // indirect
+)
+`)
+	writeGoMod(t, filepath.Join(root, "libs/common"), `module example.com/common
+
+require github.com/google/uuid v1.10.0
+`)
+
+	members, err := Load(root, []string{"services/api", "services/worker", "libs/common"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	m := Build(members)
+	if len(m.Modules) != 3 || m.Modules[2] != "libs/common" {
+		t.Fatalf("unexpected modules: %v", m.Modules)
+	}
+	if len(m.Rows) != 1 {
+		t.Fatalf("expected only uuid to drift, got %+v", m.Rows)
+	}
+	row := m.Rows[0]
+	if row.Path != "github.com/google/uuid" || row.Highest != "v1.10.0" || row.Versions["services/worker"] != "v1.3.0" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+}
+
+func TestLoad_MissingGoMod(t *testing.T) {
+	if _, err := Load(t.TempDir(), []string{"missing"}); err == nil {
+		t.Fatalf("expected error for missing go.mod")
+	}
+}
+
+func TestParseWorkUses(t *testing.T) {
+	got := ParseWorkUses("go 1.22\n\nuse (\n\t./services/api\n\t\"./libs/common\" // shared\n)\n\nuse ./tools\n")
+	want := []string{"./services/api", "./libs/common", "./tools"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected uses: %v", got)
+	}
+}
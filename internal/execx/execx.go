@@ -0,0 +1,24 @@
+// Package execx provides helpers for running package manager commands that
+// must be torn down cleanly when a run is interrupted.
+package execx
+
+import (
+	"context"
+	"os/exec"
+	"time"
+)
+
+// waitDelay bounds how long Wait blocks on I/O after the process group is killed.
+const waitDelay = 5 * time.Second
+
+// Command returns an exec.Cmd bound to ctx that runs in dir. The child is
+// placed in its own process group so that canceling ctx terminates it along
+// with any processes it spawned (e.g. the compilers and VCS tools `go get` runs).
+// On Windows, Node.js package manager names resolve to their .cmd shims.
+func Command(ctx context.Context, dir, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, ResolveName(name), args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
+	return cmd
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package execx
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts cmd in a new process group and kills the whole
+// group on cancellation.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		// A negative pid signals every process in the group.
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// ResolveName returns the executable name to run for name. It is the
+// identity on non-Windows platforms.
+func ResolveName(name string) string {
+	return name
+}
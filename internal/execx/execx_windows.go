@@ -0,0 +1,34 @@
+//go:build windows
+
+package execx
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// cmdShims lists tools that are installed as batch-file shims on Windows.
+var cmdShims = map[string]bool{
+	"npm":  true,
+	"npx":  true,
+	"yarn": true,
+	"pnpm": true,
+}
+
+// setProcessGroup starts cmd in a new process group so console interrupts
+// are delivered to faro only; cancellation falls back to killing the process.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// ResolveName returns the executable name to run for name. Node.js package
+// managers are installed as npm.cmd/yarn.cmd/pnpm.cmd shims, which are not
+// found reliably without their extension.
+func ResolveName(name string) string {
+	if filepath.Ext(name) == "" && cmdShims[strings.ToLower(name)] {
+		return name + ".cmd"
+	}
+	return name
+}
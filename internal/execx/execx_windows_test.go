@@ -0,0 +1,24 @@
+//go:build windows
+
+package execx
+
+import "testing"
+
+func TestResolveName_NodeShims(t *testing.T) {
+	tests := map[string]string{
+		"npm":      "npm.cmd",
+		"yarn":     "yarn.cmd",
+		"pnpm":     "pnpm.cmd",
+		"npm.cmd":  "npm.cmd",
+		"go":       "go",
+		"pip":      "pip",
+		"poetry":   "poetry",
+		"NPM":      "NPM.cmd",
+		"yarn.exe": "yarn.exe",
+	}
+	for in, want := range tests {
+		if got := ResolveName(in); got != want {
+			t.Errorf("ResolveName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -24,48 +24,100 @@ import (
 	"github.com/pragmaticivan/faro/internal/vuln"
 )
 
+// Provider plugs a package manager into faro: how to recognize its
+// projects, how to scan them for updates and how to apply them.
+type Provider struct {
+	Manager detector.PackageManager
+	// Detect reports whether dir holds a project managed by Manager.
+	Detect     func(dir string) bool
+	NewScanner func(workDir string) scanner.Scanner
+	NewUpdater func(workDir string) updater.Updater
+}
+
+// Name returns the package manager's name, as accepted by --manager.
+func (p Provider) Name() string {
+	return p.Manager.String()
+}
+
+var (
+	registry = make(map[detector.PackageManager]Provider)
+	// order lists registered managers in registration order, which is the
+	// order Detect tries them in.
+	order []detector.PackageManager
+)
+
+func init() {
+	// Built-in providers are registered in detection priority order.
+	for _, pm := range detector.Supported() {
+		p := Provider{Manager: pm, Detect: func(dir string) bool { return detector.Detects(dir, pm) }}
+		switch pm {
+		case detector.Go:
+			p.NewScanner = func(dir string) scanner.Scanner { return gomod.NewScanner(dir) }
+			p.NewUpdater = func(dir string) updater.Updater { return gomodUpdater.NewUpdater(dir) }
+		case detector.Npm:
+			p.NewScanner = func(dir string) scanner.Scanner { return npm.NewScanner(dir) }
+			p.NewUpdater = func(dir string) updater.Updater { return npmUpdater.NewUpdater(dir) }
+		case detector.Yarn:
+			p.NewScanner = func(dir string) scanner.Scanner { return yarn.NewScanner(dir) }
+			p.NewUpdater = func(dir string) updater.Updater { return yarnUpdater.NewUpdater(dir) }
+		case detector.Pnpm:
+			p.NewScanner = func(dir string) scanner.Scanner { return pnpm.NewScanner(dir) }
+			p.NewUpdater = func(dir string) updater.Updater { return pnpmUpdater.NewUpdater(dir) }
+		case detector.Pip:
+			p.NewScanner = func(dir string) scanner.Scanner { return pip.NewScanner(dir) }
+			p.NewUpdater = func(dir string) updater.Updater { return pipUpdater.NewUpdater(dir) }
+		case detector.Poetry:
+			p.NewScanner = func(dir string) scanner.Scanner { return poetry.NewScanner(dir) }
+			p.NewUpdater = func(dir string) updater.Updater { return poetryUpdater.NewUpdater(dir) }
+		case detector.Uv:
+			p.NewScanner = func(dir string) scanner.Scanner { return uv.NewScanner(dir) }
+			p.NewUpdater = func(dir string) updater.Updater { return uvUpdater.NewUpdater(dir) }
+		}
+		Register(p)
+	}
+}
+
+// Register adds p to the registry, replacing any provider for the same
+// package manager. New managers are detected after those already registered.
+func Register(p Provider) {
+	if _, ok := registry[p.Manager]; !ok {
+		order = append(order, p.Manager)
+	}
+	registry[p.Manager] = p
+}
+
+// Lookup returns the provider registered for pm.
+func Lookup(pm detector.PackageManager) (Provider, bool) {
+	p, ok := registry[pm]
+	return p, ok
+}
+
+// Detect returns the first registered provider whose Detect recognizes dir.
+func Detect(dir string) (Provider, error) {
+	for _, pm := range order {
+		if p := registry[pm]; p.Detect != nil && p.Detect(dir) {
+			return p, nil
+		}
+	}
+	return Provider{}, fmt.Errorf("no supported package manager detected in %s", dir)
+}
+
 // CreateScanner creates a scanner for the specified package manager.
 func CreateScanner(pm detector.PackageManager, workDir string) (scanner.Scanner, error) {
-	switch pm {
-	case detector.Go:
-		return gomod.NewScanner(workDir), nil
-	case detector.Npm:
-		return npm.NewScanner(workDir), nil
-	case detector.Yarn:
-		return yarn.NewScanner(workDir), nil
-	case detector.Pnpm:
-		return pnpm.NewScanner(workDir), nil
-	case detector.Pip:
-		return pip.NewScanner(workDir), nil
-	case detector.Poetry:
-		return poetry.NewScanner(workDir), nil
-	case detector.Uv:
-		return uv.NewScanner(workDir), nil
-	default:
+	p, ok := Lookup(pm)
+	if !ok {
 		return nil, fmt.Errorf("unsupported package manager: %s", pm)
 	}
+	return p.NewScanner(workDir), nil
 }
 
 // CreateUpdater creates an updater for the specified package manager.
 func CreateUpdater(pm detector.PackageManager, workDir string) (updater.Updater, error) {
-	switch pm {
-	case detector.Go:
-		return gomodUpdater.NewUpdater(workDir), nil
-	case detector.Npm:
-		return npmUpdater.NewUpdater(workDir), nil
-	case detector.Yarn:
-		return yarnUpdater.NewUpdater(workDir), nil
-	case detector.Pnpm:
-		return pnpmUpdater.NewUpdater(workDir), nil
-	case detector.Pip:
-		return pipUpdater.NewUpdater(workDir), nil
-	case detector.Poetry:
-		return poetryUpdater.NewUpdater(workDir), nil
-	case detector.Uv:
-		return uvUpdater.NewUpdater(workDir), nil
-	default:
+	p, ok := Lookup(pm)
+	if !ok {
 		return nil, fmt.Errorf("unsupported package manager: %s", pm)
 	}
+	return p.NewUpdater(workDir), nil
 }
 
 // CreateVulnClient creates a vulnerability client for the specified package manager.
@@ -1,9 +1,13 @@
 package factory
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/updater"
 )
 
 func TestCreateScanner(t *testing.T) {
@@ -65,3 +69,59 @@ func TestCreateUpdater(t *testing.T) {
 		})
 	}
 }
+
+func TestRegister(t *testing.T) {
+	const cargo detector.PackageManager = "cargo"
+	builtin := order
+	t.Cleanup(func() {
+		delete(registry, cargo)
+		order = builtin
+	})
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Detect(dir); err == nil {
+		t.Fatal("expected no provider to detect a Cargo project before registering")
+	}
+
+	if _, err := CreateScanner(cargo, "/tmp"); err == nil {
+		t.Fatal("expected an error before registering")
+	}
+	Register(Provider{
+		Manager: cargo,
+		Detect: func(dir string) bool {
+			_, err := os.Stat(filepath.Join(dir, "Cargo.toml"))
+			return err == nil
+		},
+		NewScanner: func(string) scanner.Scanner { return nil },
+		NewUpdater: func(string) updater.Updater { return updater.ReadOnly{} },
+	})
+	if _, ok := Lookup(cargo); !ok {
+		t.Fatal("expected the registered provider to be found")
+	}
+	if u, err := CreateUpdater(cargo, "/tmp"); err != nil || u == nil {
+		t.Fatalf("expected the registered updater, got %v, %v", u, err)
+	}
+	if p, err := Detect(dir); err != nil || p.Name() != "cargo" {
+		t.Fatalf("expected the registered provider to detect the project, got %+v, %v", p, err)
+	}
+}
+
+func TestDetect_BuiltinPriority(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"package.json", "yarn.lock", "go.mod"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p, err := Detect(dir); err != nil || p.Manager != detector.Go {
+		t.Fatalf("expected go to win, got %+v, %v", p, err)
+	}
+	if err := os.Remove(filepath.Join(dir, "go.mod")); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := Detect(dir); err != nil || p.Manager != detector.Yarn {
+		t.Fatalf("expected yarn over the npm fallback, got %+v, %v", p, err)
+	}
+}
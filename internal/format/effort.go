@@ -0,0 +1,75 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// Effort estimates how much work applying an update is likely to take.
+type Effort int
+
+const (
+	EffortTrivial Effort = iota
+	EffortSmall
+	EffortMedium
+	EffortLarge
+)
+
+var effortNames = []string{"trivial", "small", "medium", "large"}
+
+func (e Effort) String() string {
+	if e < EffortTrivial || e > EffortLarge {
+		return "unknown"
+	}
+	return effortNames[e]
+}
+
+// ParseEffort parses an effort label such as "small".
+func ParseEffort(s string) (Effort, error) {
+	for i, name := range effortNames {
+		if strings.EqualFold(strings.TrimSpace(s), name) {
+			return Effort(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid effort %q (supported: %s)", s, strings.Join(effortNames, ", "))
+}
+
+// EstimateEffort combines the size of the semver jump, the risk notes found
+// in the changelog and the number of project files importing the module
+// (negative when unknown) into an effort estimate. Patches start out
+// trivial, minor updates small and major updates medium; every few risk
+// notes or a wide import surface moves the estimate up a step.
+func EstimateEffort(m scanner.Module, importers int) Effort {
+	score := 0
+	switch GroupForModule(m) {
+	case GroupMajor:
+		score = 3
+	case GroupMinor, GroupUnknown:
+		score = 1
+	}
+	switch n := len(m.RiskHints); {
+	case n >= 3:
+		score += 2
+	case n > 0:
+		score++
+	}
+	switch {
+	case importers > 20:
+		score += 2
+	case importers > 5:
+		score++
+	}
+
+	switch {
+	case score == 0:
+		return EffortTrivial
+	case score <= 2:
+		return EffortSmall
+	case score <= 4:
+		return EffortMedium
+	default:
+		return EffortLarge
+	}
+}
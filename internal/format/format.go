@@ -10,9 +10,21 @@ import (
 )
 
 type Options struct {
-	Group bool
-	Lines bool
-	Time  bool
+	Group    bool
+	Lines    bool
+	Time     bool
+	JSON     bool
+	JSONL    bool
+	Markdown bool
+	Upgraded bool // Show when each dependency was last upgraded (git blame of the manifest)
+	Script   bool // Print a shell script of the upgrade commands instead of a report
+
+	TimeStyle TimeStyle // How publish and upgrade times are rendered; set from --time-style
+}
+
+// Machine reports whether output must stay machine-readable (no banners or colors).
+func (o Options) Machine() bool {
+	return o.Lines || o.JSON || o.JSONL || o.Markdown || o.Script
 }
 
 func ParseFlag(s string) (Options, error) {
@@ -33,10 +45,29 @@ func ParseFlag(s string) (Options, error) {
 			out.Lines = true
 		case "time":
 			out.Time = true
+		case "json":
+			out.JSON = true
+		case "jsonl":
+			out.JSONL = true
+		case "markdown", "md":
+			out.Markdown = true
+		case "upgraded":
+			out.Upgraded = true
+		case "script":
+			out.Script = true
 		default:
-			return out, fmt.Errorf("unsupported --format value: %q (supported: group, lines, time)", v)
+			return out, fmt.Errorf("unsupported --format value: %q (supported: group, lines, time, upgraded, json, jsonl, markdown, script)", v)
 		}
 	}
+	exclusive := 0
+	for _, set := range []bool{out.Lines, out.JSON, out.JSONL, out.Markdown, out.Script} {
+		if set {
+			exclusive++
+		}
+	}
+	if exclusive > 1 {
+		return out, fmt.Errorf("--format values lines, json, jsonl, markdown and script are mutually exclusive")
+	}
 	return out, nil
 }
 
@@ -53,11 +84,61 @@ func ParseRFC3339ish(s string) (time.Time, bool) {
 	return time.Time{}, false
 }
 
-func PublishTime(updateTime string, now time.Time) string {
-	t, ok := ParseRFC3339ish(updateTime)
+// Time style layouts accepted by ParseTimeStyle.
+const (
+	TimeDate     = "date"     // 2006-01-02 (7d ago)
+	TimeRelative = "relative" // 3 weeks ago
+	TimeISO      = "iso"      // 2006-01-02T15:04:05Z
+)
+
+// TimeStyle controls how PublishTime renders timestamps. The zero value is
+// the date layout in UTC.
+type TimeStyle struct {
+	Layout string // TimeDate, TimeRelative or TimeISO; empty means TimeDate
+	Local  bool   // Render dates in the local time zone instead of UTC
+}
+
+// ParseTimeStyle parses a --time-style value: a layout (date, relative or
+// iso) optionally combined with a time zone (utc or local), comma-delimited.
+func ParseTimeStyle(s string) (TimeStyle, error) {
+	var out TimeStyle
+	for _, p := range strings.Split(s, ",") {
+		switch v := strings.ToLower(strings.TrimSpace(p)); v {
+		case "":
+		case TimeDate, TimeRelative, TimeISO:
+			if out.Layout != "" && out.Layout != v {
+				return out, fmt.Errorf("--time-style layouts %s and %s are mutually exclusive", out.Layout, v)
+			}
+			out.Layout = v
+		case "utc":
+			out.Local = false
+		case "local":
+			out.Local = true
+		default:
+			return out, fmt.Errorf("unsupported --time-style value: %q (supported: date, relative, iso, utc, local)", v)
+		}
+	}
+	return out, nil
+}
+
+// Format renders ts (RFC 3339) in the style, measuring ages from now. It
+// returns "" when ts cannot be parsed.
+func (s TimeStyle) Format(ts string, now time.Time) string {
+	t, ok := ParseRFC3339ish(ts)
 	if !ok {
 		return ""
 	}
+	if s.Local {
+		t = t.In(time.Local)
+	} else {
+		t = t.UTC()
+	}
+	switch s.Layout {
+	case TimeRelative:
+		return relativeTime(now.Sub(t))
+	case TimeISO:
+		return t.Format(time.RFC3339)
+	}
 	days := int(now.Sub(t).Hours() / 24)
 	if days < 0 {
 		days = 0
@@ -65,6 +146,36 @@ func PublishTime(updateTime string, now time.Time) string {
 	return fmt.Sprintf("%s (%dd ago)", t.Format("2006-01-02"), days)
 }
 
+// relativeTime describes an age in the largest whole unit, e.g. "3 weeks ago".
+func relativeTime(age time.Duration) string {
+	const day = 24 * time.Hour
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{365 * day, "year"},
+		{30 * day, "month"},
+		{7 * day, "week"},
+		{day, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+	}
+	for _, u := range units {
+		if n := int(age / u.size); n >= 1 {
+			if n == 1 {
+				return fmt.Sprintf("1 %s ago", u.name)
+			}
+			return fmt.Sprintf("%d %ss ago", n, u.name)
+		}
+	}
+	return "just now"
+}
+
+// PublishTime renders updateTime in the default time style.
+func PublishTime(updateTime string, now time.Time) string {
+	return TimeStyle{}.Format(updateTime, now)
+}
+
 type DiffGroup int
 
 const (
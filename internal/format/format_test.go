@@ -59,3 +59,147 @@ func TestGroupLabelAndSortKey(t *testing.T) {
 		t.Fatalf("unexpected v0 label/sort")
 	}
 }
+
+func TestParseFlag_JSON(t *testing.T) {
+	opts, err := ParseFlag("json")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !opts.JSON || !opts.Machine() {
+		t.Fatalf("unexpected opts: %+v", opts)
+	}
+	if _, err := ParseFlag("json,lines"); err == nil {
+		t.Fatalf("expected error for mutually exclusive formats")
+	}
+}
+
+func TestParseFlag_Markdown(t *testing.T) {
+	opts, err := ParseFlag("md")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !opts.Markdown || !opts.Machine() {
+		t.Fatalf("unexpected opts: %+v", opts)
+	}
+	if _, err := ParseFlag("markdown,json"); err == nil {
+		t.Fatalf("expected error for mutually exclusive formats")
+	}
+}
+
+func TestParseFlag_Script(t *testing.T) {
+	opts, err := ParseFlag("script")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !opts.Script || !opts.Machine() {
+		t.Fatalf("unexpected opts: %+v", opts)
+	}
+	if _, err := ParseFlag("script,lines"); err == nil {
+		t.Fatalf("expected error for mutually exclusive formats")
+	}
+}
+
+func TestNewRecord_Classification(t *testing.T) {
+	m := scanner.Module{Path: "a", Version: "v0.1.0", Update: &scanner.UpdateInfo{Version: "v0.2.0"}, VulnCurrent: scanner.VulnInfo{High: 1, Total: 1}}
+	r := NewRecord(m, CategoryDirect, "Direct dependencies", true)
+	if r.Name != "a" || r.Category != "direct" || r.Diff != "major" || r.GroupLabel != "Major (v0)" || r.SortKey != 0 {
+		t.Fatalf("unexpected record: %+v", r)
+	}
+	if r.VulnCurrent == nil || r.VulnCurrent.High != 1 {
+		t.Fatalf("expected vulnerability counts, got %+v", r.VulnCurrent)
+	}
+	if NewRecord(m, CategoryDirect, "", false).VulnCurrent != nil {
+		t.Fatalf("expected vulnerability counts to be omitted")
+	}
+}
+
+func TestPriority(t *testing.T) {
+	patch := scanner.Module{Name: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}}
+	directMajor := scanner.Module{Name: "b", Version: "v1.0.0", Direct: true, Update: &scanner.UpdateInfo{Version: "v2.0.0"}}
+	vulnFix := scanner.Module{Name: "c", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"},
+		VulnCurrent: scanner.VulnInfo{High: 1, Total: 1}}
+	stale := scanner.Module{Name: "d", Version: "v1.0.0", Time: "2022-01-01T00:00:00Z",
+		Update: &scanner.UpdateInfo{Version: "v1.0.1", Time: "2024-01-01T00:00:00Z"}}
+
+	if !(Priority(vulnFix) > Priority(directMajor) && Priority(directMajor) > Priority(stale) && Priority(stale) > Priority(patch)) {
+		t.Fatalf("unexpected ordering: vulnFix=%d directMajor=%d stale=%d patch=%d",
+			Priority(vulnFix), Priority(directMajor), Priority(stale), Priority(patch))
+	}
+	if Priority(scanner.Module{Name: "e"}) != 0 {
+		t.Fatalf("expected zero priority without an update")
+	}
+}
+
+func TestEstimateEffort(t *testing.T) {
+	update := func(from, to string, hints int) scanner.Module {
+		m := scanner.Module{Name: "a", Version: from, Update: &scanner.UpdateInfo{Version: to}}
+		for range hints {
+			m.RiskHints = append(m.RiskHints, "BREAKING: something")
+		}
+		return m
+	}
+	tests := []struct {
+		m         scanner.Module
+		importers int
+		want      Effort
+	}{
+		{update("v1.0.0", "v1.0.1", 0), -1, EffortTrivial},
+		{update("v1.0.0", "v1.0.1", 1), 3, EffortSmall},
+		{update("v1.0.0", "v1.1.0", 0), 6, EffortSmall},
+		{update("v1.0.0", "v1.1.0", 3), 0, EffortMedium},
+		{update("v1.0.0", "v2.0.0", 0), -1, EffortMedium},
+		{update("v0.1.0", "v0.2.0", 1), 30, EffortLarge},
+	}
+	for _, tt := range tests {
+		if got := EstimateEffort(tt.m, tt.importers); got != tt.want {
+			t.Errorf("EstimateEffort(%s→%s, %d hints, %d importers) = %s, want %s",
+				tt.m.Version, tt.m.Update.Version, len(tt.m.RiskHints), tt.importers, got, tt.want)
+		}
+	}
+	if e, err := ParseEffort("Medium"); err != nil || e != EffortMedium {
+		t.Fatalf("ParseEffort(Medium) = %v, %v", e, err)
+	}
+	if _, err := ParseEffort("huge"); err == nil {
+		t.Fatal("expected an error for an unknown effort")
+	}
+}
+
+func TestTimeStyle(t *testing.T) {
+	now := time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC)
+	ts := "2026-01-10T09:30:00+02:00"
+	cases := []struct {
+		flag string
+		want string
+	}{
+		{"", "2026-01-10 (21d ago)"},
+		{"relative", "3 weeks ago"},
+		{"iso", "2026-01-10T07:30:00Z"},
+		{"ISO, utc", "2026-01-10T07:30:00Z"},
+	}
+	for _, c := range cases {
+		style, err := ParseTimeStyle(c.flag)
+		if err != nil {
+			t.Fatalf("ParseTimeStyle(%q): %v", c.flag, err)
+		}
+		if got := style.Format(ts, now); got != c.want {
+			t.Fatalf("%q: got %q, want %q", c.flag, got, c.want)
+		}
+	}
+
+	local, err := ParseTimeStyle("iso,local")
+	if err != nil || !local.Local {
+		t.Fatalf("expected local iso style, got %+v, %v", local, err)
+	}
+	if _, err := ParseTimeStyle("iso,relative"); err == nil {
+		t.Fatalf("expected error for two layouts")
+	}
+	if _, err := ParseTimeStyle("fuzzy"); err == nil {
+		t.Fatalf("expected error for unsupported style")
+	}
+	if got := relativeTime(90 * time.Second); got != "1 minute ago" {
+		t.Fatalf("unexpected relative time: %q", got)
+	}
+	if got := relativeTime(-time.Hour); got != "just now" {
+		t.Fatalf("unexpected relative time for a future date: %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package format
+
+import (
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// Vulnerability severity weights used by Priority and the vulnerability
+// score. Vulnerabilities without a severity weigh as low.
+const (
+	WeightCritical = 10
+	WeightHigh     = 5
+	WeightMedium   = 2
+	WeightLow      = 1
+)
+
+// vulnScale multiplies the weighted count of fixed vulnerabilities so that a
+// single fixed low-severity issue outranks every other signal combined.
+const vulnScale = 60
+
+// Priority scores how important it is to apply m's update; higher scores
+// come first. Fixed vulnerabilities (weighted by severity) dominate, then
+// direct dependencies, then the size of the semver jump, then how long the
+// current version trails the update (one point per 30 days, up to two years).
+func Priority(m scanner.Module) int {
+	if m.Update == nil {
+		return 0
+	}
+	fixed := func(current, update int) int { return max(current-update, 0) }
+	score := vulnScale * (fixed(m.VulnCurrent.Critical, m.VulnUpdate.Critical)*WeightCritical +
+		fixed(m.VulnCurrent.High, m.VulnUpdate.High)*WeightHigh +
+		fixed(m.VulnCurrent.Medium, m.VulnUpdate.Medium)*WeightMedium +
+		fixed(m.VulnCurrent.Low, m.VulnUpdate.Low)*WeightLow)
+
+	if m.Direct {
+		score += 20
+	}
+	switch GroupForModule(m) {
+	case GroupMajor:
+		score += 15
+	case GroupMinor:
+		score += 10
+	case GroupPatch:
+		score += 5
+	}
+
+	current, err1 := time.Parse(time.RFC3339, m.Time)
+	update, err2 := time.Parse(time.RFC3339, m.Update.Time)
+	if err1 == nil && err2 == nil && update.After(current) {
+		score += min(int(update.Sub(current).Hours()/24/30), 24)
+	}
+	return score
+}
@@ -0,0 +1,123 @@
+package format
+
+import "github.com/pragmaticivan/faro/internal/scanner"
+
+// Category names for a module's position in the dependency graph.
+const (
+	CategoryDirect     = "direct"
+	CategoryIndirect   = "indirect"
+	CategoryTool       = "tool"
+	CategoryTransitive = "transitive"
+)
+
+// Record is the machine-readable form of an available update. It carries the
+// classification faro computes for its own rendering so that consumers of
+// JSON output do not need to reimplement grouping or ordering.
+type Record struct {
+	Name           string              `json:"name"`
+	Version        string              `json:"version"`
+	Time           string              `json:"time,omitempty"`
+	Update         *scanner.UpdateInfo `json:"update,omitempty"`
+	DependencyType string              `json:"dependencyType"`
+
+	// Category is one of CategoryDirect, CategoryIndirect, CategoryTool or CategoryTransitive.
+	Category string `json:"category"`
+	// CategoryLabel is the heading used for Category in text output.
+	CategoryLabel string `json:"categoryLabel"`
+	// Diff is the semver change: "major", "minor", "patch" or "unknown".
+	Diff string `json:"diff"`
+	// GroupLabel is the --format group heading (e.g. "Major (v0)").
+	GroupLabel string `json:"groupLabel"`
+	// SortKey orders groups; lower values are riskier and listed first.
+	SortKey int `json:"sortKey"`
+	// Priority ranks how important the update is; see Priority.
+	Priority int `json:"priority"`
+
+	VulnCurrent *scanner.VulnInfo `json:"vulnCurrent,omitempty"`
+	VulnUpdate  *scanner.VulnInfo `json:"vulnUpdate,omitempty"`
+
+	// VulnReachable counts the vulnerabilities of the current version that
+	// are reachable from the project's code (--vuln-mode callgraph).
+	VulnReachable *scanner.VulnInfo `json:"vulnReachable,omitempty"`
+
+	// VulnDetails lists the advisories affecting the current version
+	// (--vuln-details).
+	VulnDetails []scanner.VulnDetail `json:"vulnDetails,omitempty"`
+
+	// Risks lists release note lines that matched risk keywords.
+	Risks []string `json:"risks,omitempty"`
+
+	// Critical is set for modules tagged critical in .faro.json.
+	Critical bool `json:"critical,omitempty"`
+
+	// OwnerChange is set when the update is controlled by different owners
+	// than the current version.
+	OwnerChange string `json:"ownerChange,omitempty"`
+
+	// Provenance is the --verify-provenance result for the update.
+	Provenance string `json:"provenance,omitempty"`
+
+	// LastUpgraded is when the dependency was last changed in the manifest
+	// (--format upgraded).
+	LastUpgraded string `json:"lastUpgraded,omitempty"`
+
+	// Retracted is the rationale for retracting the current version.
+	Retracted string `json:"retracted,omitempty"`
+
+	// Deprecated is the module's deprecation message.
+	Deprecated string `json:"deprecated,omitempty"`
+
+	// Effort is the estimated upgrade effort; see EstimateEffort.
+	Effort string `json:"effort,omitempty"`
+}
+
+// String returns the lowercase name of the group.
+func (g DiffGroup) String() string {
+	switch g {
+	case GroupMajor:
+		return "major"
+	case GroupMinor:
+		return "minor"
+	case GroupPatch:
+		return "patch"
+	default:
+		return "unknown"
+	}
+}
+
+// NewRecord builds a Record for m. Vulnerability counts are included only when withVulns is set.
+func NewRecord(m scanner.Module, category, categoryLabel string, withVulns bool) Record {
+	name := m.Name
+	if name == "" {
+		name = m.Path
+	}
+	r := Record{
+		Name:           name,
+		Version:        m.Version,
+		Time:           m.Time,
+		Update:         m.Update,
+		DependencyType: m.DependencyType,
+		Category:       category,
+		CategoryLabel:  categoryLabel,
+		Diff:           GroupForModule(m).String(),
+		GroupLabel:     GroupLabel(m),
+		SortKey:        GroupSortKey(m),
+		Priority:       Priority(m),
+		Risks:          m.RiskHints,
+		Critical:       m.Critical,
+		OwnerChange:    m.OwnerChange,
+		Provenance:     m.Provenance,
+		LastUpgraded:   m.LastUpgraded,
+		Retracted:      m.Retracted,
+		Deprecated:     m.Deprecated,
+		Effort:         m.Effort,
+	}
+	if withVulns {
+		current, update := m.VulnCurrent, m.VulnUpdate
+		r.VulnCurrent = &current
+		r.VulnUpdate = &update
+		r.VulnReachable = m.VulnReachable
+		r.VulnDetails = m.VulnDetails
+	}
+	return r
+}
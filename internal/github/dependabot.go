@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DependabotAlert is the subset of a Dependabot alert faro reconciles.
+type DependabotAlert struct {
+	Number     int    `json:"number"`
+	State      string `json:"state"`
+	HTMLURL    string `json:"html_url"`
+	Dependency struct {
+		Package      AlertPackage `json:"package"`
+		ManifestPath string       `json:"manifest_path"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		GHSAID   string `json:"ghsa_id"`
+		CVEID    string `json:"cve_id"`
+		Summary  string `json:"summary"`
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+	SecurityVulnerability struct {
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		FirstPatchedVersion    *struct {
+			Identifier string `json:"identifier"`
+		} `json:"first_patched_version"`
+	} `json:"security_vulnerability"`
+}
+
+// AlertPackage identifies the dependency an alert is about.
+type AlertPackage struct {
+	Ecosystem string `json:"ecosystem"` // "go", "npm", "pip", ...
+	Name      string `json:"name"`
+}
+
+// PatchedVersion returns the first version fixing the alert, or "" when no
+// fix has been released.
+func (a DependabotAlert) PatchedVersion() string {
+	if p := a.SecurityVulnerability.FirstPatchedVersion; p != nil {
+		return p.Identifier
+	}
+	return ""
+}
+
+// DependabotAlerts returns the open Dependabot alerts of owner/repo. The
+// token needs access to the repository's security alerts.
+func (c *Client) DependabotAlerts(ctx context.Context, owner, repo string) ([]DependabotAlert, error) {
+	var alerts []DependabotAlert
+	err := c.getPages(ctx, fmt.Sprintf("/repos/%s/%s/dependabot/alerts?state=open&per_page=100", owner, repo), func(page []byte) error {
+		var batch []DependabotAlert
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return err
+		}
+		alerts = append(alerts, batch...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
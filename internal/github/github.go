@@ -0,0 +1,328 @@
+// Package github is a minimal client for the GitHub REST API endpoints faro
+// uses to enrich module updates.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the public GitHub REST API.
+const DefaultBaseURL = "https://api.github.com"
+
+// DefaultMaxWait bounds how long a request waits for a rate limit to reset
+// before giving up with ErrRateLimited.
+const DefaultMaxWait = time.Minute
+
+// maxAttempts is the number of tries per request when rate limited.
+const maxAttempts = 3
+
+// TokenEnvVars are the environment variables consulted for a token, in order.
+var TokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
+var (
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = errors.New("not found on GitHub")
+	// ErrRateLimited is returned when the rate limit does not reset within
+	// the client's maximum wait.
+	ErrRateLimited = errors.New("GitHub API rate limit exceeded")
+)
+
+// Client performs authenticated or anonymous GitHub API requests. It is safe
+// for concurrent use and is meant to be shared by every GitHub-backed feature
+// of a run: responses are revalidated with ETags (304 replies do not count
+// against the rate limit) and rate-limited requests are retried once the
+// limit resets.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	maxWait    time.Duration
+	now        func() time.Time
+	sleep      func(ctx context.Context, d time.Duration) error
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	etag string
+	body []byte
+	next string
+}
+
+// NewClient creates a client for api.github.com. token may be empty;
+// unauthenticated requests are subject to lower rate limits.
+func NewClient(token string) *Client {
+	return NewClientWithBaseURL(DefaultBaseURL, token)
+}
+
+// NewClientWithBaseURL creates a client for a GitHub-compatible API at baseURL.
+func NewClientWithBaseURL(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		maxWait: DefaultMaxWait,
+		now:     time.Now,
+		sleep:   sleepContext,
+		cache:   make(map[string]cachedResponse),
+	}
+}
+
+// Token returns the first non-empty token from the environment variable
+// named envVar (when set) or TokenEnvVars.
+func Token(envVar string) string {
+	names := TokenEnvVars
+	if envVar != "" {
+		names = append([]string{envVar}, names...)
+	}
+	for _, name := range names {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Release is a published GitHub release.
+type Release struct {
+	TagName string  `json:"tag_name"`
+	Body    string  `json:"body"`
+	Draft   bool    `json:"draft"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+}
+
+// Repo returns the owner and repository for a github.com module path, e.g.
+// "github.com/spf13/cobra/v2" -> ("spf13", "cobra").
+func Repo(modulePath string) (owner, repo string, ok bool) {
+	parts := strings.Split(modulePath, "/")
+	if len(parts) < 3 || parts[0] != "github.com" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// RepoResolver maps a module path, including vanity import paths, to the URL
+// of its source repository.
+type RepoResolver interface {
+	RepoURL(ctx context.Context, modulePath string) (string, error)
+}
+
+// ResolveRepo returns the GitHub owner and repository hosting modulePath.
+// github.com paths are split directly; other paths are looked up through
+// resolver, which may be nil.
+func ResolveRepo(ctx context.Context, resolver RepoResolver, modulePath string) (owner, repo string, ok bool) {
+	if owner, repo, ok := Repo(modulePath); ok {
+		return owner, repo, true
+	}
+	if resolver == nil {
+		return "", "", false
+	}
+	repoURL, err := resolver.RepoURL(ctx, modulePath)
+	if err != nil {
+		return "", "", false
+	}
+	return RepoFromURL(repoURL)
+}
+
+// RepoFromURL returns the owner and repository of a GitHub repository URL
+// such as "https://github.com/golang/tools.git".
+func RepoFromURL(repoURL string) (owner, repo string, ok bool) {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host != "github.com" {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+// Repository is the subset of GitHub repository metadata faro reads.
+type Repository struct {
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+	Archived      bool   `json:"archived"`
+	License       *struct {
+		SPDXID string `json:"spdx_id"`
+		Name   string `json:"name"`
+	} `json:"license"`
+}
+
+// Repository returns metadata for owner/repo.
+func (c *Client) Repository(ctx context.Context, owner, repo string) (Repository, error) {
+	var r Repository
+	err := c.get(ctx, fmt.Sprintf("/repos/%s/%s", owner, repo), &r)
+	return r, err
+}
+
+// Releases returns the most recent releases of owner/repo.
+func (c *Client) Releases(ctx context.Context, owner, repo string) ([]Release, error) {
+	var releases []Release
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/releases?per_page=100", owner, repo), &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// ReleaseByTag returns the release of owner/repo tagged tag.
+func (c *Client) ReleaseByTag(ctx context.Context, owner, repo, tag string) (Release, error) {
+	var release Release
+	err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/releases/tags/%s", owner, repo, url.PathEscape(tag)), &release)
+	return release, err
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	body, _, err := c.fetch(ctx, c.baseURL+path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// getPages decodes every page of a paginated list at path, following Link
+// headers, and passes each to add.
+func (c *Client) getPages(ctx context.Context, path string, add func(page []byte) error) error {
+	next := c.baseURL + path
+	for next != "" {
+		body, link, err := c.fetch(ctx, next)
+		if err != nil {
+			return err
+		}
+		if err := add(body); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		next = link
+	}
+	return nil
+}
+
+// fetch returns the body of a GET request and the URL of the next page, if
+// any, revalidating cached responses and waiting out rate limits.
+func (c *Client) fetch(ctx context.Context, rawURL string) ([]byte, string, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		c.mu.Lock()
+		cached, hasCached := c.cache[rawURL]
+		c.mu.Unlock()
+		if hasCached {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to query GitHub API: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read response: %w", err)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified && hasCached:
+			return cached.body, cached.next, nil
+		case resp.StatusCode == http.StatusOK:
+			next := nextLink(resp.Header.Get("Link"))
+			if etag := resp.Header.Get("ETag"); etag != "" {
+				c.mu.Lock()
+				c.cache[rawURL] = cachedResponse{etag: etag, body: body, next: next}
+				c.mu.Unlock()
+			}
+			return body, next, nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, "", ErrNotFound
+		}
+
+		wait, limited := c.rateLimitWait(resp)
+		if !limited {
+			return nil, "", fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		}
+		if attempt >= maxAttempts || wait > c.maxWait {
+			return nil, "", fmt.Errorf("%w (resets in %s)", ErrRateLimited, wait.Round(time.Second))
+		}
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, "", err
+		}
+	}
+}
+
+// nextLink returns the rel="next" URL of a Link header.
+func nextLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if ok && strings.Contains(params, `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(target), "<>")
+		}
+	}
+	return ""
+}
+
+// rateLimitWait reports whether resp is a primary or secondary rate limit
+// response and how long to wait before retrying.
+func (c *Client) rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if secs, err := strconv.Atoi(s); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil {
+			return time.Minute, true
+		}
+		wait := time.Unix(reset, 0).Sub(c.now())
+		if wait < time.Second {
+			wait = time.Second
+		}
+		return wait, true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return time.Minute, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
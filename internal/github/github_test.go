@@ -0,0 +1,236 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRepo(t *testing.T) {
+	owner, repo, ok := Repo("github.com/spf13/cobra/v2")
+	if !ok || owner != "spf13" || repo != "cobra" {
+		t.Fatalf("unexpected repo: %q %q %v", owner, repo, ok)
+	}
+	if _, _, ok := Repo("golang.org/x/tools"); ok {
+		t.Fatalf("expected non-GitHub path to be rejected")
+	}
+}
+
+func TestReleaseByTag(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/repos/golangci/golangci-lint/releases/tags/v1.60.0" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"tag_name":"v1.60.0","assets":[{"name":"golangci-lint-1.60.0-linux-amd64.tar.gz"}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithBaseURL(srv.URL, "secret")
+	rel, err := c.ReleaseByTag(context.Background(), "golangci", "golangci-lint", "v1.60.0")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if rel.TagName != "v1.60.0" || len(rel.Assets) != 1 || gotAuth != "Bearer secret" {
+		t.Fatalf("unexpected release %+v (auth %q)", rel, gotAuth)
+	}
+
+	if _, err := c.ReleaseByTag(context.Background(), "golangci", "golangci-lint", "v0.0.0"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+type staticResolver map[string]string
+
+func (r staticResolver) RepoURL(_ context.Context, modulePath string) (string, error) {
+	if u, ok := r[modulePath]; ok {
+		return u, nil
+	}
+	return "", errors.New("unresolved")
+}
+
+func TestResolveRepo(t *testing.T) {
+	resolver := staticResolver{
+		"golang.org/x/tools": "https://github.com/golang/tools.git",
+		"go.example.com/lib": "https://gitlab.com/example/lib",
+	}
+	owner, repo, ok := ResolveRepo(context.Background(), resolver, "golang.org/x/tools")
+	if !ok || owner != "golang" || repo != "tools" {
+		t.Fatalf("unexpected %q/%q %v", owner, repo, ok)
+	}
+	if _, _, ok := ResolveRepo(context.Background(), resolver, "go.example.com/lib"); ok {
+		t.Fatal("expected non-GitHub repository to be unsupported")
+	}
+	if owner, repo, ok := ResolveRepo(context.Background(), nil, "github.com/a/b/v2"); !ok || owner != "a" || repo != "b" {
+		t.Fatalf("unexpected %q/%q %v", owner, repo, ok)
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	var calls, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"full_name":"a/b","archived":true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithBaseURL(srv.URL, "")
+	for i := 0; i < 2; i++ {
+		repo, err := c.Repository(context.Background(), "a", "b")
+		if err != nil || !repo.Archived || repo.FullName != "a/b" {
+			t.Fatalf("unexpected repo %+v, %v", repo, err)
+		}
+	}
+	if calls != 2 || notModified != 1 {
+		t.Fatalf("expected one revalidated request, got calls=%d notModified=%d", calls, notModified)
+	}
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	limited := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limited > 0 {
+			limited--
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(30*time.Second).Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	var slept []time.Duration
+	c := NewClientWithBaseURL(srv.URL, "")
+	c.now = func() time.Time { return now }
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	if _, err := c.Releases(context.Background(), "a", "b"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(slept) != 1 || slept[0] != 30*time.Second {
+		t.Fatalf("expected one 30s wait, got %v", slept)
+	}
+
+	limited = 1
+	c.maxWait = 10 * time.Second
+	if _, err := c.Releases(context.Background(), "a", "b"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "gh")
+	t.Setenv("FARO_GITHUB_TOKEN", "custom")
+	if got := Token(""); got != "gh" {
+		t.Fatalf("expected GH_TOKEN fallback, got %q", got)
+	}
+	if got := Token("FARO_GITHUB_TOKEN"); got != "custom" {
+		t.Fatalf("expected configured variable to win, got %q", got)
+	}
+}
+
+func TestDependabotAlerts_FollowsPages(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/api/dependabot/alerts" || r.URL.Query().Get("state") != "open" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if r.URL.Query().Get("after") == "" {
+			w.Header().Set("Link", `<`+srv.URL+`/repos/acme/api/dependabot/alerts?state=open&after=abc>; rel="next"`)
+			_, _ = w.Write([]byte(`[{"number": 1, "dependency": {"package": {"ecosystem": "go", "name": "golang.org/x/net"}},
+				"security_vulnerability": {"first_patched_version": {"identifier": "0.17.0"}}}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"number": 2, "dependency": {"package": {"ecosystem": "npm", "name": "lodash"}}}]`))
+	}))
+	defer srv.Close()
+
+	alerts, err := NewClientWithBaseURL(srv.URL, "").DependabotAlerts(context.Background(), "acme", "api")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(alerts) != 2 || alerts[0].PatchedVersion() != "0.17.0" || alerts[1].PatchedVersion() != "" {
+		t.Fatalf("unexpected alerts %+v", alerts)
+	}
+}
+
+func TestCreateStatus(t *testing.T) {
+	var got CommitStatus
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/api/statuses/abc123" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c := NewClientWithBaseURL(srv.URL, "secret")
+	status := CommitStatus{State: "success", Description: "1 major behind", Context: "faro/dependencies"}
+	if err := c.CreateStatus(context.Background(), "acme", "api", "abc123", status); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != status {
+		t.Fatalf("expected %+v to be posted, got %+v", status, got)
+	}
+	if err := c.CreateStatus(context.Background(), "acme", "other", "abc123", status); err == nil {
+		t.Fatal("expected an error for a failed request")
+	}
+}
+
+func TestPullRequests(t *testing.T) {
+	var got NewPullRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/api/pulls":
+			if r.URL.Query().Get("head") == "acme:faro/open" {
+				_, _ = w.Write([]byte(`[{"number": 7, "html_url": "https://github.com/acme/api/pull/7"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/api/pulls":
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number": 8, "html_url": "https://github.com/acme/api/pull/8"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClientWithBaseURL(srv.URL, "secret")
+	if pr, ok, err := c.OpenPullRequest(context.Background(), "acme", "api", "faro/open"); err != nil || !ok || pr.Number != 7 {
+		t.Fatalf("expected open pull request 7, got %+v, %v, %v", pr, ok, err)
+	}
+	if _, ok, err := c.OpenPullRequest(context.Background(), "acme", "api", "faro/new"); err != nil || ok {
+		t.Fatalf("expected no open pull request, got %v, %v", ok, err)
+	}
+	want := NewPullRequest{Title: "chore(deps): bump x", Head: "faro/new", Base: "main", Body: "body"}
+	pr, err := c.CreatePullRequest(context.Background(), "acme", "api", want)
+	if err != nil || pr.Number != 8 {
+		t.Fatalf("expected pull request 8, got %+v, %v", pr, err)
+	}
+	if got != want {
+		t.Fatalf("expected %+v to be posted, got %+v", want, got)
+	}
+}
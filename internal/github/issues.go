@@ -0,0 +1,88 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Issue is the subset of a GitHub issue faro reads.
+type Issue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	HTMLURL     string    `json:"html_url"`
+	PullRequest *struct{} `json:"pull_request,omitempty"`
+}
+
+// NewIssue is the body of an issue creation request.
+type NewIssue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// OpenIssues returns the open issues of owner/repo carrying all of labels.
+// Pull requests are left out.
+func (c *Client) OpenIssues(ctx context.Context, owner, repo string, labels []string) ([]Issue, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues?state=open&per_page=100", owner, repo)
+	if len(labels) > 0 {
+		path += "&labels=" + url.QueryEscape(strings.Join(labels, ","))
+	}
+	var issues []Issue
+	err := c.getPages(ctx, path, func(page []byte) error {
+		var batch []Issue
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return err
+		}
+		for _, issue := range batch {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// CreateIssue opens an issue in owner/repo. The token needs write access
+// to the repository's issues.
+func (c *Client) CreateIssue(ctx context.Context, owner, repo string, issue NewIssue) (Issue, error) {
+	var created Issue
+	payload, err := json.Marshal(issue)
+	if err != nil {
+		return created, fmt.Errorf("failed to encode issue: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/repos/%s/%s/issues", c.baseURL, owner, repo), bytes.NewReader(payload))
+	if err != nil {
+		return created, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return created, fmt.Errorf("failed to query GitHub API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return created, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return created, fmt.Errorf("GitHub API returned status %d creating an issue in %s/%s", resp.StatusCode, owner, repo)
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return created, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return created, nil
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// PullRequest is the subset of a GitHub pull request faro reads.
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// NewPullRequest is the body of a pull request creation request.
+type NewPullRequest struct {
+	Title string `json:"title"`
+	Head  string `json:"head"` // Branch with the changes
+	Base  string `json:"base"` // Branch to merge into
+	Body  string `json:"body,omitempty"`
+	Draft bool   `json:"draft,omitempty"`
+}
+
+// OpenPullRequest returns the open pull request of owner/repo from branch
+// head of the same repository, if there is one.
+func (c *Client) OpenPullRequest(ctx context.Context, owner, repo, head string) (PullRequest, bool, error) {
+	var prs []PullRequest
+	path := fmt.Sprintf("/repos/%s/%s/pulls?state=open&head=%s", owner, repo, url.QueryEscape(owner+":"+head))
+	if err := c.get(ctx, path, &prs); err != nil {
+		return PullRequest{}, false, err
+	}
+	if len(prs) == 0 {
+		return PullRequest{}, false, nil
+	}
+	return prs[0], true, nil
+}
+
+// CreatePullRequest opens a pull request in owner/repo. The token needs the
+// pull requests write permission.
+func (c *Client) CreatePullRequest(ctx context.Context, owner, repo string, pr NewPullRequest) (PullRequest, error) {
+	var created PullRequest
+	payload, err := json.Marshal(pr)
+	if err != nil {
+		return created, fmt.Errorf("failed to encode pull request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/repos/%s/%s/pulls", c.baseURL, owner, repo), bytes.NewReader(payload))
+	if err != nil {
+		return created, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return created, fmt.Errorf("failed to query GitHub API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return created, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return created, fmt.Errorf("GitHub API returned status %d creating a pull request in %s/%s", resp.StatusCode, owner, repo)
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return created, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return created, nil
+}
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CommitStatus is the body of a commit status creation request.
+type CommitStatus struct {
+	State       string `json:"state"` // error, failure, pending or success
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"` // Truncated by GitHub past 140 characters
+	Context     string `json:"context,omitempty"`
+}
+
+// CreateStatus sets a status on commit sha of owner/repo. The token needs
+// the statuses write permission.
+func (c *Client) CreateStatus(ctx context.Context, owner, repo, sha string, status CommitStatus) error {
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to encode commit status: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/repos/%s/%s/statuses/%s", c.baseURL, owner, repo, sha), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query GitHub API: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("GitHub API returned status %d setting a commit status in %s/%s", resp.StatusCode, owner, repo)
+	}
+	return nil
+}
@@ -2,8 +2,14 @@ package gomod
 
 import (
 	"fmt"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
 	"os"
+	"strconv"
 	"strings"
+
+	"github.com/pragmaticivan/faro/internal/style"
 )
 
 // RequireIndex maps module path -> indirect?
@@ -12,6 +18,13 @@ import (
 // If a module appears as both indirect and direct, direct wins.
 type RequireIndex map[string]bool
 
+// Require is a single requirement from a go.mod require directive.
+type Require struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
 func ReadRequireIndex(goModPath string) (RequireIndex, error) {
 	data, err := os.ReadFile(goModPath)
 	if err != nil {
@@ -22,6 +35,33 @@ func ReadRequireIndex(goModPath string) (RequireIndex, error) {
 
 func ParseRequireIndex(goModContents string) RequireIndex {
 	idx := make(RequireIndex)
+	for _, r := range ParseRequires(goModContents) {
+		if existingIndirect, ok := idx[r.Path]; ok {
+			if !existingIndirect {
+				// already direct; keep direct
+				continue
+			}
+			// previously indirect; upgrade to direct if we see a direct require
+			idx[r.Path] = r.Indirect
+			continue
+		}
+		idx[r.Path] = r.Indirect
+	}
+	return idx
+}
+
+// ReadRequires reads goModPath and returns its requirements in file order.
+func ReadRequires(goModPath string) ([]Require, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", goModPath, err)
+	}
+	return ParseRequires(string(data)), nil
+}
+
+// ParseRequires returns every requirement in goModContents in file order.
+func ParseRequires(goModContents string) []Require {
+	var requires []Require
 
 	lines := strings.Split(goModContents, "\n")
 	inRequireBlock := false
@@ -43,19 +83,23 @@ func ParseRequireIndex(goModContents string) RequireIndex {
 
 		if strings.HasPrefix(line, "require ") {
 			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
-			parseRequireLine(idx, line)
+			if r, ok := parseRequireLine(line); ok {
+				requires = append(requires, r)
+			}
 			continue
 		}
 
 		if inRequireBlock {
-			parseRequireLine(idx, line)
+			if r, ok := parseRequireLine(line); ok {
+				requires = append(requires, r)
+			}
 		}
 	}
 
-	return idx
+	return requires
 }
 
-func parseRequireLine(dst RequireIndex, line string) {
+func parseRequireLine(line string) (Require, bool) {
 	comment := ""
 	if i := strings.Index(line, "//"); i >= 0 {
 		comment = line[i+2:]
@@ -64,22 +108,525 @@ func parseRequireLine(dst RequireIndex, line string) {
 
 	fields := strings.Fields(line)
 	if len(fields) < 2 {
-		return
+		return Require{}, false
+	}
+
+	return Require{
+		Path:     fields[0],
+		Version:  fields[1],
+		Indirect: strings.Contains(comment, "indirect"),
+	}, true
+}
+
+// ParseGoVersion returns the version from the `go` directive in goModContents,
+// or "" when the file has none.
+func ParseGoVersion(goModContents string) string {
+	for _, rawLine := range strings.Split(goModContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "go" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// ParseModulePath returns the path from the `module` directive in
+// goModContents, or "" when the file has none.
+func ParseModulePath(goModContents string) string {
+	for _, rawLine := range strings.Split(goModContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// SplitPathMajor splits a module path into its prefix and major version:
+// "github.com/jackc/pgx/v5" is ("github.com/jackc/pgx", 5) and
+// "gopkg.in/yaml.v3" is ("gopkg.in/yaml", 3). Paths without a major suffix
+// are major version 1.
+func SplitPathMajor(path string) (prefix string, major int) {
+	sep := "/v"
+	if strings.HasPrefix(path, "gopkg.in/") {
+		sep = ".v"
+	}
+	i := strings.LastIndex(path, sep)
+	if i < 0 {
+		return path, 1
+	}
+	suffix := path[i+len(sep):]
+	n, err := strconv.Atoi(suffix)
+	if err != nil || strconv.Itoa(n) != suffix || (sep == "/v" && n < 2) {
+		return path, 1
+	}
+	return path[:i], n
+}
+
+// MajorPath returns the module path of major version major of the module
+// whose path prefix is prefix, as split by SplitPathMajor.
+func MajorPath(prefix string, major int) string {
+	if strings.HasPrefix(prefix, "gopkg.in/") {
+		return fmt.Sprintf("%s.v%d", prefix, major)
+	}
+	if major < 2 {
+		return prefix
+	}
+	return fmt.Sprintf("%s/v%d", prefix, major)
+}
+
+// CompareGoVersions compares two Go language versions such as "1.21" and
+// "1.23.4". It returns -1, 0 or +1. Missing components count as zero and
+// prerelease suffixes (rc1, beta2) sort before the release they precede.
+func CompareGoVersions(a, b string) int {
+	ap, arc := splitGoVersion(a)
+	bp, brc := splitGoVersion(b)
+	for i := 0; i < len(ap) || i < len(bp); i++ {
+		var x, y int
+		if i < len(ap) {
+			x = ap[i]
+		}
+		if i < len(bp) {
+			y = bp[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case arc == brc:
+		return 0
+	case arc == "":
+		return 1
+	case brc == "":
+		return -1
+	case arc < brc:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func splitGoVersion(v string) ([]int, string) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "go")
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n := 0
+		i := 0
+		for i < len(p) && p[i] >= '0' && p[i] <= '9' {
+			n = n*10 + int(p[i]-'0')
+			i++
+		}
+		parts = append(parts, n)
+		if i < len(p) {
+			return parts, p[i:]
+		}
+	}
+	return parts, ""
+}
+
+// ParseTools returns the package paths named by `tool` directives in goModContents.
+func ParseTools(goModContents string) []string {
+	var tools []string
+	inBlock := false
+	for _, rawLine := range strings.Split(goModContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case line == "":
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			tools = append(tools, line)
+		case line == "tool (" || line == "tool(":
+			inBlock = true
+		case strings.HasPrefix(line, "tool "):
+			tools = append(tools, strings.TrimSpace(strings.TrimPrefix(line, "tool ")))
+		}
+	}
+	return tools
+}
+
+// ParseToolsFile returns the blank imports of a tools.go file, the pre-1.24
+// way of tracking tool dependencies: a file excluded from builds by a
+// `tools` build constraint that imports each tool's main package. Files
+// without the constraint yield nothing.
+func ParseToolsFile(src []byte) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "tools.go", src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	tagged := false
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, c := range group.List {
+			expr, err := constraint.Parse(c.Text)
+			if err == nil && expr.Eval(func(tag string) bool { return tag == "tools" }) {
+				tagged = true
+			}
+		}
+	}
+	if !tagged {
+		return nil
+	}
+	var tools []string
+	for _, imp := range f.Imports {
+		if imp.Name != nil && imp.Name.Name == "_" {
+			if path, err := strconv.Unquote(imp.Path.Value); err == nil {
+				tools = append(tools, path)
+			}
+		}
+	}
+	return tools
+}
+
+// AnyTagImports returns the imports of a Go file that some build
+// configuration includes: the rule go mod tidy uses, where every build tag
+// but "ignore" counts as both set and unset. Files constrained to "ignore"
+// yield nothing.
+func AnyTagImports(src []byte) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "file.go", src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, c := range group.List {
+			if expr, err := constraint.Parse(c.Text); err == nil && !anyTags(expr, true) {
+				return nil
+			}
+		}
+	}
+	var imports []string
+	for _, imp := range f.Imports {
+		if path, err := strconv.Unquote(imp.Path.Value); err == nil {
+			imports = append(imports, path)
+		}
+	}
+	return imports
+}
+
+// anyTags evaluates x with every tag but "ignore" matching whichever way
+// prefer asks, as the go command does when gathering all imports.
+func anyTags(x constraint.Expr, prefer bool) bool {
+	switch x := x.(type) {
+	case *constraint.TagExpr:
+		return x.Tag != "ignore" && prefer
+	case *constraint.NotExpr:
+		return !anyTags(x.X, !prefer)
+	case *constraint.AndExpr:
+		return anyTags(x.X, prefer) && anyTags(x.Y, prefer)
+	case *constraint.OrExpr:
+		return anyTags(x.X, prefer) || anyTags(x.Y, prefer)
+	}
+	return false
+}
+
+// RewriteImports moves the imports of module oldPath and its packages in
+// the Go source src to module newPath, the way a major version upgrade
+// needs. Imports of another major version's path (oldPath/v3 when oldPath
+// has no major suffix) are left alone. It reports whether src changed; the
+// rest of the file is kept byte for byte.
+func RewriteImports(src []byte, oldPath, newPath string) ([]byte, bool, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, false, err
+	}
+	out := src
+	changed := false
+	for i := len(f.Imports) - 1; i >= 0; i-- {
+		lit := f.Imports[i].Path
+		path, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			continue
+		}
+		rest, ok := strings.CutPrefix(path, oldPath)
+		if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+			continue
+		}
+		if seg, _, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/"); seg != "" {
+			if _, major := SplitPathMajor("m/" + seg); major > 1 {
+				continue
+			}
+		}
+		start, end := fset.Position(lit.Pos()).Offset, fset.Position(lit.End()).Offset
+		out = append(append(append([]byte{}, out[:start]...), strconv.Quote(newPath+rest)...), out[end:]...)
+		changed = true
+	}
+	return out, changed, nil
+}
+
+// ToolModules maps each required module that provides one of tools to the
+// tool packages it provides. A tool belongs to the required module with the
+// longest path prefix.
+func ToolModules(tools []string, requires []Require) map[string][]string {
+	out := make(map[string][]string)
+	for _, tool := range tools {
+		best := ""
+		for _, r := range requires {
+			if (tool == r.Path || strings.HasPrefix(tool, r.Path+"/")) && len(r.Path) > len(best) {
+				best = r.Path
+			}
+		}
+		if best != "" {
+			out[best] = append(out[best], tool)
+		}
+	}
+	return out
+}
+
+// ParseSumVersions returns the module versions recorded in go.sum contents as
+// "path version" keys. The go.mod-only hash lines (version/go.mod) count as
+// the same module version.
+func ParseSumVersions(goSumContents string) map[string]bool {
+	versions := make(map[string]bool)
+	for _, line := range strings.Split(goSumContents, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		versions[fields[0]+" "+strings.TrimSuffix(fields[1], "/go.mod")] = true
+	}
+	return versions
+}
+
+// Replace is a single replace directive. OldVersion is empty when the
+// directive replaces every version; NewVersion is empty for directory
+// replacements.
+type Replace struct {
+	Old        string
+	OldVersion string
+	New        string
+	NewVersion string
+}
+
+// ParseReplaces returns the replace directives in goModContents in file order.
+func ParseReplaces(goModContents string) []Replace {
+	var replaces []Replace
+	inBlock := false
+	for _, rawLine := range strings.Split(goModContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case line == "":
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			if r, ok := parseReplaceLine(line); ok {
+				replaces = append(replaces, r)
+			}
+		case line == "replace (" || line == "replace(":
+			inBlock = true
+		case strings.HasPrefix(line, "replace "):
+			if r, ok := parseReplaceLine(strings.TrimPrefix(line, "replace ")); ok {
+				replaces = append(replaces, r)
+			}
+		}
 	}
+	return replaces
+}
 
-	path := fields[0]
-	indirect := strings.Contains(comment, "indirect")
+func parseReplaceLine(line string) (Replace, bool) {
+	oldPart, newPart, ok := strings.Cut(line, "=>")
+	if !ok {
+		return Replace{}, false
+	}
+	oldFields, newFields := strings.Fields(oldPart), strings.Fields(newPart)
+	if len(oldFields) == 0 || len(oldFields) > 2 || len(newFields) == 0 || len(newFields) > 2 {
+		return Replace{}, false
+	}
+	r := Replace{Old: oldFields[0], New: newFields[0]}
+	if len(oldFields) == 2 {
+		r.OldVersion = oldFields[1]
+	}
+	if len(newFields) == 2 {
+		r.NewVersion = newFields[1]
+	}
+	return r, true
+}
 
-	if existingIndirect, ok := dst[path]; ok {
-		if !existingIndirect {
-			// already direct; keep direct
-			dst[path] = false
-			return
+// ParseToolchain returns the version from the `toolchain` directive in
+// goModContents (e.g. "go1.23.4"), or "" when the file has none.
+func ParseToolchain(goModContents string) string {
+	for _, rawLine := range strings.Split(goModContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "toolchain" {
+			return fields[1]
 		}
-		// previously indirect; upgrade to direct if we see a direct require
-		dst[path] = indirect
-		return
 	}
+	return ""
+}
 
-	dst[path] = indirect
+// SetRequireComment replaces the trailing comment of path's require lines
+// in goModContents with "// "+comment.
+func SetRequireComment(goModContents, path, comment string) string {
+	lines := strings.Split(goModContents, "\n")
+	inBlock := false
+	for i, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
+		switch {
+		case strings.HasPrefix(line, "require ("):
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		}
+		prefix := ""
+		if strings.HasPrefix(line, "require ") {
+			prefix = "require "
+		} else if !inBlock {
+			continue
+		}
+		r, ok := parseRequireLine(strings.TrimSpace(strings.TrimPrefix(line, prefix)))
+		if !ok || r.Path != path {
+			continue
+		}
+		indent := rawLine[:len(rawLine)-len(strings.TrimLeft(rawLine, " \t"))]
+		lines[i] = indent + prefix + r.Path + " " + r.Version + " // " + comment
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseDeprecation returns the deprecation message of the module in
+// goModContents, or "" when it is not deprecated. The message is the
+// paragraph starting with "Deprecated:" in the comments directly above the
+// module directive or after it on the same line.
+func ParseDeprecation(goModContents string) string {
+	var comments []string
+	for _, rawLine := range strings.Split(goModContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if text, ok := strings.CutPrefix(line, "//"); ok {
+			comments = append(comments, strings.TrimSpace(text))
+			continue
+		}
+		if !strings.HasPrefix(line, "module ") && !strings.HasPrefix(line, "module\t") {
+			comments = nil
+			continue
+		}
+		if _, text, ok := strings.Cut(line, "//"); ok {
+			comments = append(comments, "", strings.TrimSpace(text))
+		}
+		break
+	}
+	var paragraph []string
+	for _, c := range comments {
+		switch {
+		case c == "":
+			if len(paragraph) > 0 {
+				return strings.Join(paragraph, " ")
+			}
+		case len(paragraph) > 0:
+			paragraph = append(paragraph, c)
+		case strings.HasPrefix(c, "Deprecated:"):
+			paragraph = append(paragraph, strings.TrimSpace(strings.TrimPrefix(c, "Deprecated:")))
+		}
+	}
+	return strings.Join(paragraph, " ")
+}
+
+// Retraction is a single retract directive. Low and High are equal for a
+// single retracted version.
+type Retraction struct {
+	Low       string
+	High      string
+	Rationale string // Comments above or after the directive
+}
+
+// ParseRetractions returns the retract directives in goModContents in file
+// order.
+func ParseRetractions(goModContents string) []Retraction {
+	var retractions []Retraction
+	var comments []string
+	inBlock := false
+	for _, rawLine := range strings.Split(goModContents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if text, ok := strings.CutPrefix(line, "//"); ok {
+			comments = append(comments, strings.TrimSpace(text))
+			continue
+		}
+		rationale := comments
+		comments = nil
+		if i := strings.Index(line, "//"); i >= 0 {
+			rationale = append(rationale, strings.TrimSpace(line[i+2:]))
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			if r, ok := parseRetractLine(line); ok {
+				r.Rationale = strings.Join(rationale, " ")
+				retractions = append(retractions, r)
+			}
+		case line == "retract (" || line == "retract(":
+			inBlock = true
+		case strings.HasPrefix(line, "retract "):
+			if r, ok := parseRetractLine(strings.TrimPrefix(line, "retract ")); ok {
+				r.Rationale = strings.Join(rationale, " ")
+				retractions = append(retractions, r)
+			}
+		}
+	}
+	return retractions
+}
+
+// RetractedBy returns the retraction covering version, or nil when none
+// of retractions does.
+func RetractedBy(retractions []Retraction, version string) *Retraction {
+	if version == "" {
+		return nil
+	}
+	for i, r := range retractions {
+		low, okLow := style.ComparePrecedence(version, r.Low)
+		high, okHigh := style.ComparePrecedence(version, r.High)
+		if okLow && okHigh && low >= 0 && high <= 0 {
+			return &retractions[i]
+		}
+	}
+	return nil
+}
+
+func parseRetractLine(line string) (Retraction, bool) {
+	line = strings.TrimSpace(line)
+	if inner, ok := strings.CutPrefix(line, "["); ok {
+		inner, ok = strings.CutSuffix(inner, "]")
+		low, high, comma := strings.Cut(inner, ",")
+		if !ok || !comma {
+			return Retraction{}, false
+		}
+		low, high = strings.TrimSpace(low), strings.TrimSpace(high)
+		if low == "" || high == "" {
+			return Retraction{}, false
+		}
+		return Retraction{Low: low, High: high}, true
+	}
+	if line == "" || strings.ContainsAny(line, " \t") {
+		return Retraction{}, false
+	}
+	return Retraction{Low: line, High: line}, true
 }
@@ -3,6 +3,8 @@ package gomod
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -64,3 +66,295 @@ func TestReadRequireIndex(t *testing.T) {
 		t.Fatalf("expected direct require")
 	}
 }
+
+func TestParseRequireIndex_CRLF(t *testing.T) {
+	contents := "module example.com/foo\r\n\r\nrequire (\r\n\tgithub.com/a/b v1.2.3\r\n\tgithub.com/c/d v0.1.0 // indirect\r\n)\r\n\r\nrequire github.com/e/f v1.0.0\r\n"
+
+	idx := ParseRequireIndex(contents)
+	if len(idx) != 3 {
+		t.Fatalf("expected 3 requirements, got %d: %#v", len(idx), idx)
+	}
+	if idx["github.com/a/b"] || !idx["github.com/c/d"] || idx["github.com/e/f"] {
+		t.Fatalf("unexpected classification: %#v", idx)
+	}
+}
+
+func TestParseRequires_Versions(t *testing.T) {
+	contents := `module example.com/foo
+
+require (
+	k8s.io/api v0.29.1
+	k8s.io/client-go v0.29.0 // indirect
+)
+
+require github.com/e/f v1.0.0
+`
+	reqs := ParseRequires(contents)
+	if len(reqs) != 3 {
+		t.Fatalf("expected 3 requires, got %d", len(reqs))
+	}
+	if reqs[0] != (Require{Path: "k8s.io/api", Version: "v0.29.1"}) {
+		t.Fatalf("unexpected first require: %+v", reqs[0])
+	}
+	if reqs[1] != (Require{Path: "k8s.io/client-go", Version: "v0.29.0", Indirect: true}) {
+		t.Fatalf("unexpected second require: %+v", reqs[1])
+	}
+}
+
+func TestParseGoVersion(t *testing.T) {
+	contents := "module example.com/foo\r\n\r\ngo 1.22.3 // minimum\r\n\r\ntoolchain go1.23.0\r\n"
+	if got := ParseGoVersion(contents); got != "1.22.3" {
+		t.Fatalf("expected 1.22.3, got %q", got)
+	}
+	if got := ParseGoVersion("module example.com/foo\n"); got != "" {
+		t.Fatalf("expected empty version, got %q", got)
+	}
+}
+
+func TestParseModulePath(t *testing.T) {
+	if got := ParseModulePath("// comment\nmodule example.com/foo // main\n\ngo 1.22\n"); got != "example.com/foo" {
+		t.Fatalf("unexpected module path %q", got)
+	}
+	if got := ParseModulePath("go 1.22\n"); got != "" {
+		t.Fatalf("expected empty module path, got %q", got)
+	}
+}
+
+func TestCompareGoVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.21", "1.23", -1},
+		{"1.23", "1.23.0", 0},
+		{"1.23.4", "1.23", 1},
+		{"1.10", "1.9", 1},
+		{"1.23rc1", "1.23", -1},
+		{"1.23rc1", "1.23rc2", -1},
+		{"go1.22", "1.22", 0},
+	}
+	for _, tc := range cases {
+		if got := CompareGoVersions(tc.a, tc.b); got != tc.want {
+			t.Fatalf("CompareGoVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestParseToolsAndToolModules(t *testing.T) {
+	contents := `module example.com/foo
+
+go 1.24
+
+tool golang.org/x/tools/cmd/stringer
+
+tool (
+	github.com/golangci/golangci-lint/cmd/golangci-lint // linter
+	example.com/local/cmd/gen
+)
+
+require (
+	golang.org/x/tools v0.25.0
+	github.com/golangci/golangci-lint v1.60.0
+)
+`
+	tools := ParseTools(contents)
+	if len(tools) != 3 || tools[0] != "golang.org/x/tools/cmd/stringer" || tools[1] != "github.com/golangci/golangci-lint/cmd/golangci-lint" {
+		t.Fatalf("unexpected tools: %v", tools)
+	}
+
+	mods := ToolModules(tools, ParseRequires(contents))
+	if len(mods) != 2 || mods["golang.org/x/tools"][0] != "golang.org/x/tools/cmd/stringer" || len(mods["github.com/golangci/golangci-lint"]) != 1 {
+		t.Fatalf("unexpected tool modules: %v", mods)
+	}
+}
+
+func TestParseSumVersions(t *testing.T) {
+	sum := "example.com/a v1.0.0 h1:abc=\nexample.com/a v1.0.0/go.mod h1:def=\nexample.com/b v0.2.0/go.mod h1:ghi=\n\n"
+	got := ParseSumVersions(sum)
+	if len(got) != 2 || !got["example.com/a v1.0.0"] || !got["example.com/b v0.2.0"] {
+		t.Fatalf("unexpected versions: %v", got)
+	}
+}
+
+func TestParseReplacesAndToolchain(t *testing.T) {
+	contents := `module example.com/foo
+
+go 1.22
+
+toolchain go1.22.5 // pinned
+
+replace example.com/a => ../a
+
+replace (
+	example.com/b v1.0.0 => example.com/fork/b v1.0.1
+	example.com/c => example.com/c v1.2.0 // keep
+)
+`
+	replaces := ParseReplaces(contents)
+	want := []Replace{
+		{Old: "example.com/a", New: "../a"},
+		{Old: "example.com/b", OldVersion: "v1.0.0", New: "example.com/fork/b", NewVersion: "v1.0.1"},
+		{Old: "example.com/c", New: "example.com/c", NewVersion: "v1.2.0"},
+	}
+	if len(replaces) != len(want) {
+		t.Fatalf("unexpected replaces: %+v", replaces)
+	}
+	for i := range want {
+		if replaces[i] != want[i] {
+			t.Fatalf("replace %d: got %+v, want %+v", i, replaces[i], want[i])
+		}
+	}
+	if got := ParseToolchain(contents); got != "go1.22.5" {
+		t.Fatalf("unexpected toolchain: %q", got)
+	}
+	if got := ParseToolchain("module example.com/foo\n"); got != "" {
+		t.Fatalf("expected no toolchain, got %q", got)
+	}
+}
+
+func TestSetRequireComment(t *testing.T) {
+	contents := "module example.com/foo\n\nrequire example.com/a v1.0.0 // indirect\n\nrequire (\n\texample.com/b v1.1.0\n\texample.com/c v0.2.0 // indirect\n)\n"
+	got := SetRequireComment(contents, "example.com/c", "indirect; pinned")
+	got = SetRequireComment(got, "example.com/a", "indirect; pinned")
+	want := "module example.com/foo\n\nrequire example.com/a v1.0.0 // indirect; pinned\n\nrequire (\n\texample.com/b v1.1.0\n\texample.com/c v0.2.0 // indirect; pinned\n)\n"
+	if got != want {
+		t.Fatalf("unexpected go.mod:\n%s", got)
+	}
+	if SetRequireComment(contents, "example.com/missing", "x") != contents {
+		t.Fatalf("expected go.mod without the module to be unchanged")
+	}
+}
+
+func TestParseToolsFile(t *testing.T) {
+	src := `//go:build tools
+
+package tools
+
+import (
+	_ "github.com/golangci/golangci-lint/cmd/golangci-lint"
+	_ "golang.org/x/tools/cmd/stringer"
+	"fmt"
+)
+`
+	tools := ParseToolsFile([]byte(src))
+	if len(tools) != 2 || tools[0] != "github.com/golangci/golangci-lint/cmd/golangci-lint" || tools[1] != "golang.org/x/tools/cmd/stringer" {
+		t.Fatalf("unexpected tools: %v", tools)
+	}
+	if tools := ParseToolsFile([]byte("package main\n\nimport _ \"embed\"\n")); tools != nil {
+		t.Fatalf("expected no tools without the tools constraint, got %v", tools)
+	}
+}
+
+func TestAnyTagImports(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"platform", "//go:build windows && !arm64\n\npackage p\n\nimport \"golang.org/x/sys/windows\"\n", []string{"golang.org/x/sys/windows"}},
+		{"negated", "//go:build !linux\n\npackage p\n\nimport \"example.com/a\"\n", []string{"example.com/a"}},
+		{"ignore", "//go:build ignore\n\npackage main\n\nimport \"example.com/gen\"\n", nil},
+		{"not ignore", "//go:build !ignore\n\npackage p\n\nimport \"example.com/b\"\n", []string{"example.com/b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AnyTagImports([]byte(tt.src)); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDeprecationAndRetractions(t *testing.T) {
+	contents := `// Package foo does things.
+//
+// Deprecated: use example.com/foo/v2
+// instead.
+module example.com/foo
+
+go 1.22
+
+// Published from the wrong branch.
+retract v1.0.0
+
+retract (
+	[v1.1.0, v1.1.3] // Data race in the pool.
+	v1.2.0
+)
+`
+	if got := ParseDeprecation(contents); got != "use example.com/foo/v2 instead." {
+		t.Fatalf("unexpected deprecation: %q", got)
+	}
+	if got := ParseDeprecation("module example.com/foo // Deprecated: gone\n"); got != "gone" {
+		t.Fatalf("unexpected same-line deprecation: %q", got)
+	}
+	if got := ParseDeprecation("// Not Deprecated: really\nmodule example.com/foo\n"); got != "" {
+		t.Fatalf("expected no deprecation, got %q", got)
+	}
+
+	retractions := ParseRetractions(contents)
+	want := []Retraction{
+		{Low: "v1.0.0", High: "v1.0.0", Rationale: "Published from the wrong branch."},
+		{Low: "v1.1.0", High: "v1.1.3", Rationale: "Data race in the pool."},
+		{Low: "v1.2.0", High: "v1.2.0"},
+	}
+	if len(retractions) != len(want) {
+		t.Fatalf("unexpected retractions: %+v", retractions)
+	}
+	for i := range want {
+		if retractions[i] != want[i] {
+			t.Fatalf("retraction %d: got %+v, want %+v", i, retractions[i], want[i])
+		}
+	}
+}
+
+func TestSplitPathMajor(t *testing.T) {
+	for path, want := range map[string]struct {
+		prefix string
+		major  int
+	}{
+		"github.com/jackc/pgx/v5":     {"github.com/jackc/pgx", 5},
+		"github.com/pkg/errors":       {"github.com/pkg/errors", 1},
+		"github.com/acme/tools/v1":    {"github.com/acme/tools/v1", 1},
+		"github.com/acme/lib/v02":     {"github.com/acme/lib/v02", 1},
+		"gopkg.in/yaml.v3":            {"gopkg.in/yaml", 3},
+		"k8s.io/client-go":            {"k8s.io/client-go", 1},
+		"github.com/acme/video/vcard": {"github.com/acme/video/vcard", 1},
+	} {
+		prefix, major := SplitPathMajor(path)
+		if prefix != want.prefix || major != want.major {
+			t.Fatalf("SplitPathMajor(%q) = %q, %d, want %q, %d", path, prefix, major, want.prefix, want.major)
+		}
+		if got := MajorPath(prefix, major); want.prefix != path && got != path {
+			t.Fatalf("MajorPath(%q, %d) = %q, want %q", prefix, major, got, path)
+		}
+	}
+	if got := MajorPath("github.com/pkg/errors", 2); got != "github.com/pkg/errors/v2" {
+		t.Fatalf("unexpected v2 path %q", got)
+	}
+	if got := MajorPath("gopkg.in/yaml", 4); got != "gopkg.in/yaml.v4" {
+		t.Fatalf("unexpected gopkg.in path %q", got)
+	}
+}
+
+func TestRewriteImports(t *testing.T) {
+	src := "package main\n\nimport (\n\t\"fmt\"\n\n\tlib \"github.com/acme/lib\"\n\t\"github.com/acme/lib/sub\"\n\t\"github.com/acme/lib/v3\"\n\t\"github.com/acme/library\"\n)\n\n// github.com/acme/lib stays in comments.\nfunc main() { fmt.Println(lib.X, sub.Y) }\n"
+	got, changed, err := RewriteImports([]byte(src), "github.com/acme/lib", "github.com/acme/lib/v2")
+	if err != nil || !changed {
+		t.Fatalf("RewriteImports = %v, %v", changed, err)
+	}
+	want := strings.NewReplacer(
+		`lib "github.com/acme/lib"`, `lib "github.com/acme/lib/v2"`,
+		`"github.com/acme/lib/sub"`, `"github.com/acme/lib/v2/sub"`,
+	).Replace(src)
+	if string(got) != want {
+		t.Fatalf("unexpected rewrite:\n%s", got)
+	}
+
+	if _, changed, _ := RewriteImports([]byte("package x\n\nimport \"fmt\"\n"), "github.com/acme/lib", "github.com/acme/lib/v2"); changed {
+		t.Fatalf("expected no change without matching imports")
+	}
+	if _, _, err := RewriteImports([]byte("not go"), "a", "b"); err == nil {
+		t.Fatalf("expected a parse error")
+	}
+}
@@ -0,0 +1,118 @@
+// Package goprivate recognizes go command failures caused by private modules
+// being fetched through a public proxy or checksum database, and suggests
+// GOPRIVATE patterns that route them directly to their VCS hosts.
+package goprivate
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// signatures are error fragments the go command prints when a private module
+// is requested from proxy.golang.org or sum.golang.org, or when the VCS
+// fallback needs credentials.
+var signatures = []string{
+	"404 Not Found",
+	"410 Gone",
+	"401 Unauthorized",
+	"403 Forbidden",
+	"terminal prompts disabled",
+	"could not read Username",
+	"Permission denied (publickey)",
+	"Authentication failed",
+	"repository not found",
+}
+
+// moduleRe matches the module a "go: " error message is about, also when
+// the message is embedded in a wrapping error.
+var moduleRe = regexp.MustCompile(`(?:^|\s)go: (?:module )?([^\s@:]+\.[^\s@:]+)[@:]`)
+
+// Detect returns the modules named by go command error lines in output that
+// match a private module failure signature, sorted and deduplicated.
+func Detect(output string) []string {
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		m := moduleRe.FindStringSubmatch(line)
+		if m == nil || !hasSignature(line) {
+			continue
+		}
+		seen[m[1]] = true
+	}
+	out := make([]string, 0, len(seen))
+	for p := range seen {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func hasSignature(line string) bool {
+	for _, s := range signatures {
+		if strings.Contains(line, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// orgHosts host modules as host/owner/repo, so a GOPRIVATE entry should
+// cover the owner rather than the whole host.
+var orgHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+}
+
+// Pattern returns the GOPRIVATE entry to suggest for modulePath: the owner
+// on well-known code hosts ("github.com/acme"), otherwise the host itself.
+func Pattern(modulePath string) string {
+	parts := strings.Split(modulePath, "/")
+	if orgHosts[parts[0]] && len(parts) >= 2 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// Covered reports whether modulePath matches a pattern in the comma-separated
+// goprivate list, using the go command's path-prefix glob matching.
+func Covered(modulePath, goprivate string) bool {
+	for _, pattern := range strings.Split(goprivate, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		n := strings.Count(pattern, "/") + 1
+		parts := strings.SplitN(modulePath, "/", n+1)
+		if len(parts) < n {
+			continue
+		}
+		prefix := strings.Join(parts[:n], "/")
+		if ok, _ := path.Match(pattern, prefix); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggest returns the patterns to add to goprivate so that modules are
+// treated as private, and the resulting GOPRIVATE value. Modules already
+// covered are skipped; added is empty when nothing needs to change.
+func Suggest(goprivate string, modules []string) (value string, added []string) {
+	value = strings.TrimSpace(goprivate)
+	for _, m := range modules {
+		if Covered(m, value) {
+			continue
+		}
+		p := Pattern(m)
+		added = append(added, p)
+		if value == "" {
+			value = p
+		} else {
+			value += "," + p
+		}
+	}
+	return value, added
+}
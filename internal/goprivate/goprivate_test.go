@@ -0,0 +1,49 @@
+package goprivate
+
+import (
+	"reflect"
+	"testing"
+)
+
+const listFailure = `go: github.com/acme/secret@v1.2.0: reading https://proxy.golang.org/github.com/acme/secret/@v/v1.2.0.mod: 404 Not Found
+	server response: not found: github.com/acme/secret@v1.2.0: invalid version: git ls-remote -q origin: exit status 128:
+	fatal: could not read Username for 'https://github.com': terminal prompts disabled
+go: git.corp.example/platform/auth@v0.3.0: verifying module: git.corp.example/platform/auth@v0.3.0: reading https://sum.golang.org/lookup/git.corp.example/platform/auth@v0.3.0: 410 Gone
+go: github.com/public/lib@v1.0.0: missing go.sum entry
+`
+
+func TestDetect(t *testing.T) {
+	got := Detect(listFailure)
+	want := []string{"git.corp.example/platform/auth", "github.com/acme/secret"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestCovered(t *testing.T) {
+	cases := []struct {
+		module, goprivate string
+		want              bool
+	}{
+		{"github.com/acme/secret", "github.com/acme", true},
+		{"github.com/acme/secret/v2", "github.com/acme/*", true},
+		{"github.com/acmecorp/x", "github.com/acme", false},
+		{"git.corp.example/platform/auth", "*.corp.example", true},
+		{"github.com/acme/secret", "", false},
+	}
+	for _, c := range cases {
+		if got := Covered(c.module, c.goprivate); got != c.want {
+			t.Fatalf("Covered(%q, %q) = %v, want %v", c.module, c.goprivate, got, c.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	value, added := Suggest("github.com/other", []string{"git.corp.example/platform/auth", "github.com/acme/secret", "github.com/other/x"})
+	if value != "github.com/other,git.corp.example,github.com/acme" || !reflect.DeepEqual(added, []string{"git.corp.example", "github.com/acme"}) {
+		t.Fatalf("unexpected suggestion %q %v", value, added)
+	}
+	if _, added := Suggest("github.com/acme", []string{"github.com/acme/secret"}); len(added) != 0 {
+		t.Fatalf("expected nothing to add, got %v", added)
+	}
+}
@@ -0,0 +1,244 @@
+// Package goproxy fetches module metadata from a Go module proxy.
+package goproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/goprivate"
+)
+
+// DefaultURL is the proxy used when GOPROXY names no usable proxy.
+const DefaultURL = "https://proxy.golang.org"
+
+// ErrPrivate is returned for private modules when no private proxy is
+// configured: their paths are never sent to the public proxy.
+var ErrPrivate = errors.New("private module (GOPRIVATE/GONOPROXY) and no private proxy is configured")
+
+// ErrNotFound is returned when the proxy has no such module or version
+// (404 or 410).
+var ErrNotFound = errors.New("not found on the module proxy")
+
+// Client fetches files served by the module proxy protocol.
+type Client struct {
+	baseURL    string
+	privateURL string // Proxy for private modules; empty refuses them
+	private    string // GONOPROXY patterns
+	token      string // Bearer token for proxies other than DefaultURL
+	netrc      map[string]netrcEntry
+	httpClient *http.Client
+}
+
+// Options configures NewClientWithOptions.
+type Options struct {
+	URL string // Proxy base URL; may carry user:password credentials
+	// Private holds comma-separated GONOPROXY patterns. Matching modules are
+	// fetched from PrivateURL, or refused with ErrPrivate when it is empty.
+	Private    string
+	PrivateURL string
+	// Token is sent as a bearer token to every proxy except DefaultURL.
+	// Without it, credentials for the proxy host are read from .netrc.
+	Token string
+	// Timeout bounds each request; zero means 30 seconds.
+	Timeout time.Duration
+}
+
+// NewClient creates a client for the proxy at baseURL.
+func NewClient(baseURL string) *Client {
+	return NewClientWithOptions(Options{URL: baseURL})
+}
+
+// NewClientWithOptions creates a client configured by o.
+func NewClientWithOptions(o Options) *Client {
+	if o.Timeout <= 0 {
+		o.Timeout = 30 * time.Second
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(o.URL, "/"),
+		privateURL: strings.TrimRight(o.PrivateURL, "/"),
+		private:    o.Private,
+		token:      o.Token,
+		netrc:      readNetrc(netrcPath()),
+		httpClient: &http.Client{
+			Timeout: o.Timeout,
+		},
+	}
+}
+
+// EnvOptions returns the options the go command would use: the first proxy
+// in GOPROXY and the private modules of GONOPROXY, which defaults to
+// GOPRIVATE.
+func EnvOptions() Options {
+	private := os.Getenv("GONOPROXY")
+	if private == "" {
+		private = os.Getenv("GOPRIVATE")
+	}
+	return Options{URL: ProxyURL(os.Getenv("GOPROXY")), Private: private}
+}
+
+// NewClientFromEnv creates a client for the first proxy listed in GOPROXY,
+// falling back to DefaultURL when GOPROXY is unset or only lists direct/off.
+// Private modules are refused.
+func NewClientFromEnv() *Client {
+	return NewClientWithOptions(EnvOptions())
+}
+
+// base returns the proxy to ask about modulePath.
+func (c *Client) base(modulePath string) (string, error) {
+	if !goprivate.Covered(modulePath, c.private) {
+		return c.baseURL, nil
+	}
+	if c.privateURL == "" {
+		return "", ErrPrivate
+	}
+	return c.privateURL, nil
+}
+
+// ProxyURL returns the first proxy URL in a GOPROXY value.
+func ProxyURL(goproxy string) string {
+	for _, entry := range strings.FieldsFunc(goproxy, func(r rune) bool { return r == ',' || r == '|' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || entry == "direct" || entry == "off" {
+			continue
+		}
+		return entry
+	}
+	return DefaultURL
+}
+
+// GoMod returns the go.mod file of modulePath at version.
+func (c *Client) GoMod(ctx context.Context, modulePath, version string) ([]byte, error) {
+	return c.get(ctx, modulePath, version, ".mod")
+}
+
+// Info is the version metadata served by the proxy.
+type Info struct {
+	Version string
+	Time    string
+	Origin  *Origin // Where the version was fetched from; nil on older proxies
+}
+
+// Origin identifies the repository a module version came from.
+type Origin struct {
+	VCS  string
+	URL  string
+	Ref  string
+	Hash string
+}
+
+// Info returns the metadata of modulePath at version.
+func (c *Client) Info(ctx context.Context, modulePath, version string) (Info, error) {
+	var info Info
+	data, err := c.get(ctx, modulePath, version, ".info")
+	if err != nil {
+		return info, err
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return info, fmt.Errorf("failed to parse %s@%s info: %w", modulePath, version, err)
+	}
+	return info, nil
+}
+
+// Latest returns the metadata of the version the proxy considers latest
+// for modulePath, which is a pseudo-version when it has no tagged versions.
+func (c *Client) Latest(ctx context.Context, modulePath string) (Info, error) {
+	var info Info
+	base, err := c.base(modulePath)
+	if err != nil {
+		return info, err
+	}
+	data, err := c.fetch(ctx, fmt.Sprintf("%s/%s/@latest", base, EscapePath(modulePath)), modulePath+"@latest")
+	if err != nil {
+		return info, err
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return info, fmt.Errorf("failed to parse %s@latest info: %w", modulePath, err)
+	}
+	return info, nil
+}
+
+// Versions lists the released versions of modulePath known to the proxy,
+// in no particular order.
+func (c *Client) Versions(ctx context.Context, modulePath string) ([]string, error) {
+	data, err := c.get(ctx, modulePath, "list", "")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// get fetches the proxy file for modulePath@version with the given suffix.
+func (c *Client) get(ctx context.Context, modulePath, version, suffix string) ([]byte, error) {
+	base, err := c.base(modulePath)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/%s/@v/%s%s", base, EscapePath(modulePath), EscapePath(version), suffix)
+	return c.fetch(ctx, url, modulePath+"@"+version)
+}
+
+// fetch GETs url; what names the requested file in errors.
+func (c *Client) fetch(ctx context.Context, url, what string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query module proxy: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("module proxy returned status %d for %s: %w", resp.StatusCode, what, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("module proxy returned status %d for %s", resp.StatusCode, what)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return data, nil
+}
+
+// authorize adds credentials for the proxy req goes to: the bearer token
+// (never sent to DefaultURL), else a .netrc entry for the host. Credentials
+// in the proxy URL itself are applied by net/http.
+func (c *Client) authorize(req *http.Request) {
+	if req.URL.User != nil {
+		return
+	}
+	if c.token != "" && !strings.HasPrefix(req.URL.String(), DefaultURL+"/") {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		return
+	}
+	if e, ok := c.netrc[req.URL.Hostname()]; ok {
+		req.SetBasicAuth(e.login, e.password)
+	}
+}
+
+// EscapePath applies the proxy case-encoding: every upper-case letter is
+// replaced by '!' followed by its lower-case form.
+func EscapePath(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			b.WriteRune(r + ('a' - 'A'))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
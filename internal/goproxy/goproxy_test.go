@@ -0,0 +1,321 @@
+package goproxy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoMod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/github.com/!burnt!sushi/toml/@v/v1.4.0.mod" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("module github.com/BurntSushi/toml\n\ngo 1.18\n"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/")
+	data, err := c.GoMod(context.Background(), "github.com/BurntSushi/toml", "v1.4.0")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if string(data) != "module github.com/BurntSushi/toml\n\ngo 1.18\n" {
+		t.Fatalf("unexpected body: %q", data)
+	}
+
+	if _, err := c.GoMod(context.Background(), "example.com/missing", "v1.0.0"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing module, got %v", err)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example.com/lib/@v/v1.2.0.info" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Version":"v1.2.0","Time":"2024-01-01T00:00:00Z","Origin":{"VCS":"git","URL":"https://github.com/acme/lib"}}`))
+	}))
+	defer srv.Close()
+
+	info, err := NewClient(srv.URL).Info(context.Background(), "example.com/lib", "v1.2.0")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if info.Version != "v1.2.0" || info.Origin == nil || info.Origin.URL != "https://github.com/acme/lib" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/github.com/!azure/lib/@v/list" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("v1.1.0\nv1.0.0\n"))
+	}))
+	defer srv.Close()
+
+	versions, err := NewClient(srv.URL).Versions(context.Background(), "github.com/Azure/lib")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(versions) != 2 || versions[0] != "v1.1.0" || versions[1] != "v1.0.0" {
+		t.Fatalf("unexpected versions: %v", versions)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example.com/untagged/@latest" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Version":"v0.0.0-20240101000000-abcdef123456","Time":"2024-01-01T00:00:00Z"}`))
+	}))
+	defer srv.Close()
+
+	info, err := NewClient(srv.URL).Latest(context.Background(), "example.com/untagged")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if info.Version != "v0.0.0-20240101000000-abcdef123456" || info.Time != "2024-01-01T00:00:00Z" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
+
+func TestPrivateModules(t *testing.T) {
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "missing"))
+	var public, private []string
+	pub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		public = append(public, r.URL.Path+" "+r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("v1.0.0\n"))
+	}))
+	defer pub.Close()
+	priv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		private = append(private, r.URL.Path+" "+r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("v2.0.0\n"))
+	}))
+	defer priv.Close()
+
+	ctx := context.Background()
+	c := NewClientWithOptions(Options{URL: pub.URL, Private: "corp.example.com"})
+	if _, err := c.Versions(ctx, "corp.example.com/lib"); !errors.Is(err, ErrPrivate) {
+		t.Fatalf("expected ErrPrivate, got %v", err)
+	}
+	if len(public) != 0 {
+		t.Fatalf("private module path sent to the public proxy: %v", public)
+	}
+
+	c = NewClientWithOptions(Options{URL: pub.URL, Private: "corp.example.com", PrivateURL: priv.URL, Token: "secret"})
+	if versions, err := c.Versions(ctx, "corp.example.com/lib"); err != nil || len(versions) != 1 || versions[0] != "v2.0.0" {
+		t.Fatalf("unexpected versions %v, err %v", versions, err)
+	}
+	if _, err := c.Versions(ctx, "example.com/lib"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(private) != 1 || private[0] != "/corp.example.com/lib/@v/list Bearer secret" {
+		t.Fatalf("unexpected private proxy requests: %v", private)
+	}
+	if len(public) != 1 || public[0] != "/example.com/lib/@v/list Bearer secret" {
+		t.Fatalf("unexpected public proxy requests: %v", public)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	netrc := filepath.Join(t.TempDir(), ".netrc")
+	if err := os.WriteFile(netrc, []byte("machine athens.corp login ci password hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NETRC", netrc)
+
+	tests := []struct {
+		name  string
+		token string
+		url   string
+		want  string
+	}{
+		{"netrc", "", "https://athens.corp/x/@v/list", "Basic Y2k6aHVudGVyMg=="},
+		{"token wins", "secret", "https://athens.corp/x/@v/list", "Bearer secret"},
+		{"no token for the public proxy", "secret", DefaultURL + "/x/@v/list", ""},
+		{"unknown host", "", "https://proxy.example.com/x/@v/list", ""},
+		{"credentials in url", "secret", "https://u:p@athens.corp/x/@v/list", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			NewClientWithOptions(Options{Token: tt.token}).authorize(req)
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Fatalf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	entries := parseNetrc(`machine a.example login alice password one
+macdef init
+machine ignored login x password y
+
+machine b.example
+  login bob
+  password two
+default login anon password none
+`)
+	if len(entries) != 2 {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+	if e := entries["a.example"]; e.login != "alice" || e.password != "one" {
+		t.Fatalf("unexpected a.example entry: %+v", e)
+	}
+	if e := entries["b.example"]; e.login != "bob" || e.password != "two" {
+		t.Fatalf("unexpected b.example entry: %+v", e)
+	}
+}
+
+func TestProxyURL(t *testing.T) {
+	cases := map[string]string{
+		"":                                  DefaultURL,
+		"direct":                            DefaultURL,
+		"off":                               DefaultURL,
+		"https://goproxy.io,direct":         "https://goproxy.io",
+		"direct|https://corp.example/proxy": "https://corp.example/proxy",
+	}
+	for in, want := range cases {
+		if got := ProxyURL(in); got != want {
+			t.Fatalf("ProxyURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	got := Endpoints("https://athens.corp/,direct|https://proxy.golang.org", "sum.golang.org+033de0ae")
+	want := []Endpoint{
+		{Kind: KindProxy, URL: "https://athens.corp"},
+		{Kind: KindProxy, URL: "https://proxy.golang.org"},
+		{Kind: KindSumDB, URL: "https://sum.golang.org"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected endpoints: %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("endpoint %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if got := Endpoints("off", "off"); len(got) != 0 {
+		t.Fatalf("expected no endpoints, got %+v", got)
+	}
+	if got := SumDBURL("sum.corp+abc https://sumdb.corp/"); got != "https://sumdb.corp" {
+		t.Fatalf("unexpected sumdb URL %q", got)
+	}
+}
+
+func TestProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok/golang.org/x/mod/@v/list", "/latest":
+			_, _ = w.Write([]byte("v0.1.0\n"))
+		case "/private/golang.org/x/mod/@v/list":
+			if user, password, ok := r.BasicAuth(); !ok || user != "ci" || password != "hunter2" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "missing"))
+	client := NewClient(DefaultURL)
+	if res := client.Probe(ctx, Endpoint{Kind: KindProxy, URL: srv.URL + "/ok"}); !res.OK() || res.Status != 200 {
+		t.Fatalf("expected healthy proxy, got %+v", res)
+	}
+	if res := client.Probe(ctx, Endpoint{Kind: KindSumDB, URL: srv.URL}); !res.OK() {
+		t.Fatalf("expected healthy sumdb, got %+v", res)
+	}
+	if res := client.Probe(ctx, Endpoint{Kind: KindProxy, URL: srv.URL + "/private"}); res.OK() || !strings.Contains(res.Problem, "authentication failed") {
+		t.Fatalf("expected auth problem, got %+v", res)
+	}
+	if res := client.Probe(ctx, Endpoint{Kind: KindProxy, URL: srv.URL + "/empty"}); res.OK() || !strings.Contains(res.Problem, "mirror public modules") {
+		t.Fatalf("expected missing module problem, got %+v", res)
+	}
+	// The .netrc login the go command would use is sent along.
+	netrc := filepath.Join(t.TempDir(), ".netrc")
+	if err := os.WriteFile(netrc, []byte("machine 127.0.0.1 login ci password hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NETRC", netrc)
+	if res := NewClient(DefaultURL).Probe(ctx, Endpoint{Kind: KindProxy, URL: srv.URL + "/private"}); !res.OK() {
+		t.Fatalf("expected the .netrc credentials to authenticate, got %+v", res)
+	}
+}
+
+func testZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, contents := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", name, err)
+		}
+		_, _ = w.Write([]byte(contents))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to write zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestHashZip(t *testing.T) {
+	data := testZip(t, map[string]string{
+		"example.com/m@v1.0.0/go.mod": "module example.com/m\n",
+		"example.com/m@v1.0.0/m.go":   "package m\n",
+	})
+	got, err := HashZip(data)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got != "h1:fCHMqo5ggHEQvwcrsN81zr5orRk5lClR36KRHpfUjKg=" {
+		t.Fatalf("unexpected hash %s", got)
+	}
+}
+
+func TestVerifyZip(t *testing.T) {
+	data := testZip(t, map[string]string{"example.com/m@v1.0.0/go.mod": "module example.com/m\n"})
+	hash, _ := HashZip(data)
+	recorded := map[string]string{"v1.0.0": hash, "v1.1.0": "h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/lookup/example.com/m@"):
+			v := strings.TrimPrefix(r.URL.Path, "/lookup/example.com/m@")
+			_, _ = fmt.Fprintf(w, "123\nexample.com/m %s %s\nexample.com/m %s/go.mod h1:x\n\ngo.sum database tree\n", v, recorded[v], v)
+		case strings.HasSuffix(r.URL.Path, ".zip"):
+			_, _ = w.Write(data)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c, db := NewClient(srv.URL), NewSumDB(srv.URL)
+	if err := VerifyZip(context.Background(), c, db, "example.com/m", "v1.0.0"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := VerifyZip(context.Background(), c, db, "example.com/m", "v1.1.0"); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+}
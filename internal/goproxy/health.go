@@ -0,0 +1,156 @@
+package goproxy
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultSumDB is the checksum database used when GOSUMDB is unset.
+const DefaultSumDB = "sum.golang.org"
+
+// ProbeModule is requested from each proxy to check that it serves public modules.
+const ProbeModule = "golang.org/x/mod"
+
+// SlowLatency is the response time above which an endpoint is reported as slow.
+const SlowLatency = 2 * time.Second
+
+// Endpoint kinds.
+const (
+	KindProxy = "proxy"
+	KindSumDB = "sumdb"
+)
+
+// Endpoint is a server the go command contacts while resolving modules.
+type Endpoint struct {
+	Kind string
+	URL  string
+}
+
+// Endpoints returns the proxies listed in goproxy, in order, and the
+// checksum database named by gosumdb. direct and off entries are skipped, as
+// is the checksum database when gosumdb is "off".
+func Endpoints(goproxy, gosumdb string) []Endpoint {
+	var out []Endpoint
+	for _, entry := range strings.FieldsFunc(goproxy, func(r rune) bool { return r == ',' || r == '|' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || entry == "direct" || entry == "off" {
+			continue
+		}
+		out = append(out, Endpoint{Kind: KindProxy, URL: strings.TrimRight(entry, "/")})
+	}
+	if u := SumDBURL(gosumdb); u != "" {
+		out = append(out, Endpoint{Kind: KindSumDB, URL: u})
+	}
+	return out
+}
+
+// SumDBURL returns the URL of the checksum database named by a GOSUMDB value
+// ("name", "name+key" or "name+key url"), or "" when it is "off".
+func SumDBURL(gosumdb string) string {
+	fields := strings.Fields(gosumdb)
+	if len(fields) == 0 {
+		return "https://" + DefaultSumDB
+	}
+	if fields[0] == "off" {
+		return ""
+	}
+	if len(fields) > 1 {
+		return strings.TrimRight(fields[1], "/")
+	}
+	name, _, _ := strings.Cut(fields[0], "+")
+	return "https://" + name
+}
+
+// Result is the outcome of probing an endpoint.
+type Result struct {
+	Endpoint
+	Status  int           // HTTP status; 0 when no response was received
+	Latency time.Duration // Time until the response headers arrived
+	Err     error         // Transport error, if any
+	Problem string        // Actionable diagnosis; empty when healthy
+}
+
+// OK reports whether the endpoint responded successfully.
+func (r Result) OK() bool {
+	return r.Problem == ""
+}
+
+// Probe requests a small resource from e (the ProbeModule version list for
+// proxies, /latest for checksum databases) and diagnoses the response. The
+// request carries the credentials c sends to e's host, so proxies that
+// need the .netrc login the go command uses are not reported as failing.
+func (c *Client) Probe(ctx context.Context, e Endpoint) Result {
+	url := e.URL + "/" + ProbeModule + "/@v/list"
+	if e.Kind == KindSumDB {
+		url = e.URL + "/latest"
+	}
+	res := Result{Endpoint: e}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		res.Err = err
+		res.Problem = fmt.Sprintf("invalid URL: %v", err)
+		return res
+	}
+	c.authorize(req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	res.Latency = time.Since(start)
+	if err != nil {
+		res.Err = err
+		res.Problem = diagnoseError(err)
+		return res
+	}
+	_ = resp.Body.Close()
+	res.Status = resp.StatusCode
+	res.Problem = diagnoseStatus(e, resp.StatusCode)
+	if res.Problem == "" && res.Latency > SlowLatency {
+		res.Problem = fmt.Sprintf("slow response (%s); resolution will crawl, check the proxy's upstream or your network path", res.Latency.Round(time.Millisecond))
+	}
+	return res
+}
+
+func diagnoseError(err error) string {
+	var dnsErr *net.DNSError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostErr x509.HostnameError
+	var certErr *x509.CertificateInvalidError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		return "host not found; check the hostname, your DNS or VPN connection"
+	case errors.As(err, &unknownAuthority):
+		return "TLS certificate signed by an unknown authority; install your corporate CA or point SSL_CERT_FILE at it"
+	case errors.As(err, &hostErr), errors.As(err, &certErr):
+		return fmt.Sprintf("TLS certificate rejected: %v", err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timed out; check firewall rules, VPN, or HTTPS_PROXY"
+	case strings.Contains(err.Error(), "connection refused"):
+		return "connection refused; is the proxy running and is the port right?"
+	default:
+		return fmt.Sprintf("request failed: %v", err)
+	}
+}
+
+func diagnoseStatus(e Endpoint, status int) string {
+	switch {
+	case status == http.StatusOK:
+		return ""
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return fmt.Sprintf("authentication failed (%d); add credentials for this host to ~/.netrc or GOAUTH", status)
+	case (status == http.StatusNotFound || status == http.StatusGone) && e.Kind == KindProxy:
+		return fmt.Sprintf("%s not served (%d); the proxy may not mirror public modules, add a public proxy or direct after it in GOPROXY", ProbeModule, status)
+	case status == http.StatusProxyAuthRequired:
+		return "HTTP proxy authentication required; check the credentials in HTTPS_PROXY"
+	case status >= 500:
+		return fmt.Sprintf("server error (%d); the proxy or its upstream is failing", status)
+	default:
+		return fmt.Sprintf("unexpected status %d", status)
+	}
+}
@@ -0,0 +1,93 @@
+package goproxy
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// netrcEntry holds the credentials of one .netrc machine.
+type netrcEntry struct {
+	login    string
+	password string
+}
+
+// netrcPath returns $NETRC, or .netrc (_netrc on Windows) in the home
+// directory, the files the go command reads proxy credentials from.
+func netrcPath() string {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	name := ".netrc"
+	if runtime.GOOS == "windows" {
+		name = "_netrc"
+	}
+	return filepath.Join(home, name)
+}
+
+// readNetrc returns the machine entries of the .netrc file at path, or nil
+// when it cannot be read. default entries and macros are ignored, as the go
+// command does.
+func readNetrc(path string) map[string]netrcEntry {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return parseNetrc(string(data))
+}
+
+func parseNetrc(data string) map[string]netrcEntry {
+	entries := make(map[string]netrcEntry)
+	var machine string
+	var e netrcEntry
+	flush := func() {
+		if machine != "" && e.login != "" {
+			entries[machine] = e
+		}
+		machine, e = "", netrcEntry{}
+	}
+	inMacro := false
+	for _, line := range strings.Split(data, "\n") {
+		if inMacro {
+			// A macro body runs to the next blank line.
+			inMacro = strings.TrimSpace(line) != ""
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "macdef") {
+			inMacro = true
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			switch fields[i] {
+			case "default":
+				flush()
+			case "machine", "login", "password":
+				if i+1 >= len(fields) {
+					continue
+				}
+				value := fields[i+1]
+				switch fields[i] {
+				case "machine":
+					flush()
+					machine = value
+				case "login":
+					e.login = value
+				case "password":
+					e.password = value
+				}
+				i++
+			}
+		}
+	}
+	flush()
+	return entries
+}
@@ -0,0 +1,141 @@
+package goproxy
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrChecksumMismatch is returned when a module zip does not hash to the
+// checksum database entry.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Zip returns the module zip of modulePath at version.
+func (c *Client) Zip(ctx context.Context, modulePath, version string) ([]byte, error) {
+	return c.get(ctx, modulePath, version, ".zip")
+}
+
+// HashZip returns the "h1:" hash go.sum records for a module zip: the
+// SHA-256 of a listing of each file's SHA-256 and name, sorted by name.
+func HashZip(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open module zip: %w", err)
+	}
+	files := make([]*zip.File, 0, len(zr.File))
+	for _, f := range zr.File {
+		if strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		if strings.Contains(f.Name, "\n") {
+			return "", fmt.Errorf("module zip has a file name with a newline: %q", f.Name)
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	summary := sha256.New()
+	for _, f := range files {
+		r, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, r)
+		_ = r.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		_, _ = fmt.Fprintf(summary, "%x  %s\n", h.Sum(nil), f.Name)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+// SumDB reads module hashes from a checksum database's lookup endpoint.
+// Responses are not verified against the database's signed tree; the go
+// command does that when it downloads the module.
+type SumDB struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewSumDB returns a client for the checksum database at baseURL.
+func NewSumDB(baseURL string) *SumDB {
+	return &SumDB{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// NewSumDBFromEnv returns a client for the database named by GOSUMDB, or nil
+// when GOSUMDB is "off".
+func NewSumDBFromEnv() *SumDB {
+	u := SumDBURL(os.Getenv("GOSUMDB"))
+	if u == "" {
+		return nil
+	}
+	return NewSumDB(u)
+}
+
+// ZipHash returns the hash the checksum database records for the zip of
+// modulePath at version.
+func (s *SumDB) ZipHash(ctx context.Context, modulePath, version string) (string, error) {
+	url := fmt.Sprintf("%s/lookup/%s@%s", s.baseURL, EscapePath(modulePath), EscapePath(version))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query checksum database: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checksum database returned status %d for %s@%s", resp.StatusCode, modulePath, version)
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 3 && fields[0] == modulePath && fields[1] == version {
+			return fields[2], nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return "", fmt.Errorf("checksum database has no entry for %s@%s", modulePath, version)
+}
+
+// VerifyZip downloads the zip of modulePath at version from c and checks it
+// against db. It returns an error wrapping ErrChecksumMismatch when the
+// hashes differ.
+func VerifyZip(ctx context.Context, c *Client, db *SumDB, modulePath, version string) error {
+	want, err := db.ZipHash(ctx, modulePath, version)
+	if err != nil {
+		return err
+	}
+	data, err := c.Zip(ctx, modulePath, version)
+	if err != nil {
+		return err
+	}
+	got, err := HashZip(data)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: proxy zip hashes to %s, checksum database has %s", ErrChecksumMismatch, got, want)
+	}
+	return nil
+}
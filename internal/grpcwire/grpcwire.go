@@ -0,0 +1,217 @@
+// Package grpcwire implements the subset of gRPC over HTTP/2 that faro's
+// serve mode needs: length-prefixed message framing, status trailers and the
+// protobuf encoding of flat messages, without generated code.
+package grpcwire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of gRPC requests and responses, possibly
+// followed by a codec suffix such as "+proto".
+const ContentType = "application/grpc"
+
+// Code is a gRPC status code.
+type Code int
+
+// Status codes used by faro.
+const (
+	OK                Code = 0
+	Canceled          Code = 1
+	Unknown           Code = 2
+	InvalidArgument   Code = 3
+	PermissionDenied  Code = 7
+	ResourceExhausted Code = 8
+	Unimplemented     Code = 12
+	Unavailable       Code = 14
+)
+
+// MaxMessageSize is the largest request message ReadMessage accepts.
+const MaxMessageSize = 4 << 20
+
+// IsGRPC reports whether r is a gRPC request with the protobuf codec.
+func IsGRPC(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	return ct == ContentType || ct == ContentType+"+proto"
+}
+
+// ReadMessage reads one length-prefixed message. Compressed messages are
+// refused: faro never advertises an encoding, so clients must not send one.
+func ReadMessage(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, err
+	}
+	if prefix[0] != 0 {
+		return nil, errors.New("compressed messages are not supported")
+	}
+	n := binary.BigEndian.Uint32(prefix[1:])
+	if n > MaxMessageSize {
+		return nil, fmt.Errorf("message of %d bytes exceeds %d", n, MaxMessageSize)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, fmt.Errorf("truncated message: %w", err)
+	}
+	return msg, nil
+}
+
+// WriteMessage writes msg with its length prefix and flushes it to the
+// client, so streamed messages arrive as they are sent.
+func WriteMessage(w http.ResponseWriter, msg []byte) error {
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+	if _, err := w.Write(append(prefix[:], msg...)); err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
+
+// StartResponse writes the response headers and announces the status
+// trailers. Call it before the first WriteMessage.
+func StartResponse(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ContentType+"+proto")
+	w.Header().Add("Trailer", "Grpc-Status")
+	w.Header().Add("Trailer", "Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+}
+
+// SetStatus sets the status trailers sent when the handler returns. The
+// message is percent-encoded as the protocol requires.
+func SetStatus(w http.ResponseWriter, code Code, msg string) {
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(code)))
+	if msg != "" {
+		w.Header().Set("Grpc-Message", percentEncode(msg))
+	}
+}
+
+// percentEncode escapes '%' and the bytes outside printable ASCII.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// Wire types of protobuf fields.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Encoder builds a protobuf message. Zero values are omitted, as in proto3.
+type Encoder struct {
+	buf []byte
+}
+
+// Bytes returns the encoded message.
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+// String encodes a string field.
+func (e *Encoder) String(field int, s string) {
+	if s != "" {
+		e.Message(field, []byte(s))
+	}
+}
+
+// Message encodes a bytes or embedded message field. Unlike the scalar
+// encoders it always writes the field, so an empty message stays present.
+func (e *Encoder) Message(field int, b []byte) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(field)<<3|wireBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// Int encodes an int32 or int64 field.
+func (e *Encoder) Int(field int, v int64) {
+	if v != 0 {
+		e.buf = binary.AppendUvarint(e.buf, uint64(field)<<3|wireVarint)
+		e.buf = binary.AppendUvarint(e.buf, uint64(v))
+	}
+}
+
+// Bool encodes a bool field.
+func (e *Encoder) Bool(field int, v bool) {
+	if v {
+		e.Int(field, 1)
+	}
+}
+
+// Field is one decoded protobuf field. Varint fields set Varint; bytes,
+// string and embedded message fields set Bytes.
+type Field struct {
+	Number int
+	Varint uint64
+	Bytes  []byte
+}
+
+// String returns the field as a string.
+func (f Field) String() string {
+	return string(f.Bytes)
+}
+
+// Int returns the field as an int32 or int64 value.
+func (f Field) Int() int64 {
+	return int64(f.Varint)
+}
+
+// Decode calls fn for every field of msg in order. Fixed-width fields, which
+// faro's messages do not use, are skipped.
+func Decode(msg []byte, fn func(Field) error) error {
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 || key>>3 == 0 || key>>3 > math.MaxInt32 {
+			return errors.New("malformed field key")
+		}
+		msg = msg[n:]
+		f := Field{Number: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			f.Varint, n = binary.Uvarint(msg)
+			if n <= 0 {
+				return fmt.Errorf("malformed varint in field %d", f.Number)
+			}
+			msg = msg[n:]
+		case wireBytes:
+			size, n := binary.Uvarint(msg)
+			if n <= 0 || size > uint64(len(msg)-n) {
+				return fmt.Errorf("malformed length in field %d", f.Number)
+			}
+			f.Bytes, msg = msg[n:n+int(size)], msg[n+int(size):]
+		case wireFixed64:
+			if len(msg) < 8 {
+				return fmt.Errorf("truncated field %d", f.Number)
+			}
+			msg = msg[8:]
+			continue
+		case wireFixed32:
+			if len(msg) < 4 {
+				return fmt.Errorf("truncated field %d", f.Number)
+			}
+			msg = msg[4:]
+			continue
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", key&7, f.Number)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package grpcwire
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	var inner Encoder
+	inner.String(1, "scan_started")
+	inner.Int(7, 120)
+
+	var e Encoder
+	e.String(1, "services/api")
+	e.String(2, "") // omitted
+	e.Bool(3, true)
+	e.Int(4, -1)
+	e.Message(5, inner.Bytes())
+	e.Message(6, nil)
+
+	var got []Field
+	if err := Decode(e.Bytes(), func(f Field) error {
+		got = append(got, f)
+		return nil
+	}); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(got) != 5 {
+		t.Fatalf("expected 5 fields, got %+v", got)
+	}
+	if got[0].Number != 1 || got[0].String() != "services/api" {
+		t.Fatalf("unexpected string field: %+v", got[0])
+	}
+	if got[1].Number != 3 || got[1].Varint != 1 {
+		t.Fatalf("unexpected bool field: %+v", got[1])
+	}
+	if got[2].Number != 4 || int32(got[2].Int()) != -1 {
+		t.Fatalf("unexpected int field: %+v", got[2])
+	}
+	if got[4].Number != 6 || len(got[4].Bytes) != 0 {
+		t.Fatalf("expected the empty message to stay present: %+v", got[4])
+	}
+
+	var nested []Field
+	_ = Decode(got[3].Bytes, func(f Field) error {
+		nested = append(nested, f)
+		return nil
+	})
+	if len(nested) != 2 || nested[0].String() != "scan_started" || nested[1].Int() != 120 {
+		t.Fatalf("unexpected nested message: %+v", nested)
+	}
+}
+
+func TestDecode_RejectsMalformedMessages(t *testing.T) {
+	for name, msg := range map[string][]byte{
+		"truncated length": {0x0a, 0x05, 'a'},
+		"field zero":       {0x00, 0x01},
+		"bad wire type":    {0x0b},
+	} {
+		if err := Decode(msg, func(Field) error { return nil }); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMessageFraming(t *testing.T) {
+	rec := httptest.NewRecorder()
+	StartResponse(rec)
+	if err := WriteMessage(rec, []byte("hello")); err != nil {
+		t.Fatalf("WriteMessage: %v", err)
+	}
+	SetStatus(rec, Unknown, "go: 100% broken\n")
+
+	if got := rec.Body.Bytes(); !bytes.Equal(got, []byte{0, 0, 0, 0, 5, 'h', 'e', 'l', 'l', 'o'}) {
+		t.Fatalf("unexpected frame: %v", got)
+	}
+	if rec.Header().Get("Content-Type") != "application/grpc+proto" {
+		t.Fatalf("unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+	if got := rec.Header().Get("Grpc-Message"); got != "go: 100%25 broken%0A" {
+		t.Fatalf("unexpected message encoding %q", got)
+	}
+
+	msg, err := ReadMessage(rec.Body)
+	if err != nil || string(msg) != "hello" {
+		t.Fatalf("ReadMessage: %q, %v", msg, err)
+	}
+	if _, err := ReadMessage(strings.NewReader("\x01\x00\x00\x00\x00")); err == nil {
+		t.Fatal("expected compressed messages to be refused")
+	}
+	if _, err := ReadMessage(strings.NewReader("\x00\x7f\x00\x00\x00")); err == nil {
+		t.Fatal("expected oversized messages to be refused")
+	}
+}
@@ -0,0 +1,234 @@
+// Package jobs runs submitted work on a bounded pool of workers, coalescing
+// duplicate submissions and caching recent results per key.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status is the state of a job.
+type Status string
+
+const (
+	Queued    Status = "queued"
+	Running   Status = "running"
+	Succeeded Status = "succeeded"
+	Failed    Status = "failed"
+)
+
+// ErrFull is returned by Submit when the queue holds its maximum number of
+// waiting jobs.
+var ErrFull = errors.New("queue is full")
+
+// ErrClosed is returned by Submit after Close.
+var ErrClosed = errors.New("queue is closed")
+
+// Func is the work of one job. Its output is kept as the job result even
+// when it also returns an error.
+type Func func(ctx context.Context) ([]byte, error)
+
+// Job is a snapshot of a submitted job.
+type Job struct {
+	ID        string     `json:"id"`
+	Key       string     `json:"-"`
+	Status    Status     `json:"status"`
+	Cached    bool       `json:"cached,omitempty"` // Served from an earlier job with the same key
+	Submitted time.Time  `json:"submitted"`
+	Started   *time.Time `json:"started,omitempty"`
+	Finished  *time.Time `json:"finished,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Result    []byte     `json:"-"`
+}
+
+// Done reports whether the job has finished.
+func (j Job) Done() bool {
+	return j.Status == Succeeded || j.Status == Failed
+}
+
+type entry struct {
+	job  Job
+	run  Func
+	done chan struct{} // Closed when the job finishes or is dropped
+}
+
+// Queue runs jobs on a fixed number of workers.
+type Queue struct {
+	ttl     time.Duration
+	now     func() time.Time
+	pending chan *entry
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+	byID   map[string]*entry
+	byKey  map[string]*entry
+}
+
+// New starts workers goroutines that run at most size waiting jobs in
+// submission order. Finished jobs are kept for ttl: a successful job answers
+// later submissions with the same key until then.
+func New(ctx context.Context, workers, size int, ttl time.Duration, now func() time.Time) *Queue {
+	ctx, cancel := context.WithCancel(ctx)
+	q := &Queue{
+		ttl:     ttl,
+		now:     now,
+		pending: make(chan *entry, max(size, 1)),
+		cancel:  cancel,
+		byID:    make(map[string]*entry),
+		byKey:   make(map[string]*entry),
+	}
+	for range max(workers, 1) {
+		q.wg.Add(1)
+		go q.work(ctx)
+	}
+	return q
+}
+
+// Submit queues run under key. A queued or running job with the same key,
+// or one that succeeded within the cache TTL, is returned instead of
+// starting another.
+func (q *Queue) Submit(key string, run Func) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return Job{}, ErrClosed
+	}
+	q.prune()
+
+	if e, ok := q.byKey[key]; ok && e.job.Status != Failed {
+		job := e.job
+		job.Cached = job.Done()
+		return job, nil
+	}
+
+	e := &entry{job: Job{ID: newID(), Key: key, Status: Queued, Submitted: q.now()}, run: run, done: make(chan struct{})}
+	select {
+	case q.pending <- e:
+	default:
+		return Job{}, ErrFull
+	}
+	q.byID[e.job.ID] = e
+	q.byKey[key] = e
+	return e.job, nil
+}
+
+// Get returns the job with id.
+func (q *Queue) Get(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	e, ok := q.byID[id]
+	if !ok {
+		return Job{}, false
+	}
+	return e.job, true
+}
+
+// Wait blocks until the job with id finishes or ctx is done, and returns
+// its final state.
+func (q *Queue) Wait(ctx context.Context, id string) (Job, error) {
+	q.mu.Lock()
+	e, ok := q.byID[id]
+	q.mu.Unlock()
+	if !ok {
+		return Job{}, fmt.Errorf("no job %s", id)
+	}
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return e.job, nil
+}
+
+// Close cancels running jobs and waits for the workers to stop. Queued jobs
+// are dropped and fail with ErrClosed.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.cancel()
+	q.wg.Wait()
+
+	for {
+		select {
+		case e := <-q.pending:
+			q.drop(e)
+		default:
+			return
+		}
+	}
+}
+
+func (q *Queue) work(ctx context.Context) {
+	defer q.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-q.pending:
+			if ctx.Err() != nil {
+				q.drop(e)
+				continue
+			}
+			q.run(ctx, e)
+		}
+	}
+}
+
+// drop fails a queued job that will never run.
+func (q *Queue) drop(e *entry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	finished := q.now()
+	e.job.Status, e.job.Error, e.job.Finished = Failed, ErrClosed.Error(), &finished
+	close(e.done)
+}
+
+func (q *Queue) run(ctx context.Context, e *entry) {
+	q.mu.Lock()
+	started := q.now()
+	e.job.Status, e.job.Started = Running, &started
+	q.mu.Unlock()
+
+	result, err := e.run(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	finished := q.now()
+	e.job.Finished, e.job.Result = &finished, result
+	e.job.Status = Succeeded
+	if err != nil {
+		e.job.Status, e.job.Error = Failed, err.Error()
+	}
+	close(e.done)
+}
+
+// prune forgets jobs that finished more than ttl ago. q.mu must be held.
+func (q *Queue) prune() {
+	now := q.now()
+	for id, e := range q.byID {
+		if e.job.Finished == nil || now.Sub(*e.job.Finished) < q.ttl {
+			continue
+		}
+		delete(q.byID, id)
+		if q.byKey[e.job.Key] == e {
+			delete(q.byKey, e.job.Key)
+		}
+	}
+}
+
+// newID returns a random job ID that other tenants cannot guess.
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,190 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitDone polls q until job id finishes.
+func waitDone(t *testing.T, q *Queue, id string) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, ok := q.Get(id); ok && job.Done() {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return Job{}
+}
+
+func TestSubmit_RunsAndCachesByKey(t *testing.T) {
+	q := New(context.Background(), 2, 10, time.Minute, time.Now)
+	defer q.Close()
+
+	var runs atomic.Int32
+	run := func(context.Context) ([]byte, error) {
+		runs.Add(1)
+		return []byte("ok"), nil
+	}
+	job, err := q.Submit("repo", run)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	done := waitDone(t, q, job.ID)
+	if done.Status != Succeeded || string(done.Result) != "ok" || done.Started == nil || done.Finished == nil {
+		t.Fatalf("unexpected job: %+v", done)
+	}
+
+	again, err := q.Submit("repo", run)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if again.ID != job.ID || !again.Cached || runs.Load() != 1 {
+		t.Fatalf("expected the cached job, got %+v after %d runs", again, runs.Load())
+	}
+}
+
+func TestSubmit_CoalescesPendingAndRetriesFailures(t *testing.T) {
+	q := New(context.Background(), 1, 10, time.Minute, time.Now)
+	defer q.Close()
+
+	release := make(chan struct{})
+	first, _ := q.Submit("repo", func(context.Context) ([]byte, error) {
+		<-release
+		return nil, errors.New("boom")
+	})
+	dup, _ := q.Submit("repo", func(context.Context) ([]byte, error) { return nil, nil })
+	if dup.ID != first.ID || dup.Cached {
+		t.Fatalf("expected the pending job, got %+v", dup)
+	}
+	close(release)
+	if done := waitDone(t, q, first.ID); done.Status != Failed || done.Error != "boom" {
+		t.Fatalf("expected failure, got %+v", done)
+	}
+
+	retry, _ := q.Submit("repo", func(context.Context) ([]byte, error) { return nil, nil })
+	if retry.ID == first.ID {
+		t.Fatalf("expected failed jobs not to be cached")
+	}
+}
+
+func TestQueue_BoundsWorkersAndSize(t *testing.T) {
+	q := New(context.Background(), 2, 1, time.Minute, time.Now)
+	defer q.Close()
+
+	var mu sync.Mutex
+	active, peak := 0, 0
+	release := make(chan struct{})
+	started := make(chan struct{}, 3)
+	run := func(context.Context) ([]byte, error) {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		mu.Unlock()
+		started <- struct{}{}
+		<-release
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return nil, nil
+	}
+
+	var ids []string
+	for _, key := range []string{"a", "b"} {
+		job, err := q.Submit(key, run)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		ids = append(ids, job.ID)
+		<-started
+	}
+	job, err := q.Submit("c", run)
+	if err != nil {
+		t.Fatalf("expected a free queue slot: %v", err)
+	}
+	ids = append(ids, job.ID)
+	if _, err := q.Submit("d", run); !errors.Is(err, ErrFull) {
+		t.Fatalf("expected ErrFull, got %v", err)
+	}
+
+	close(release)
+	for _, id := range ids {
+		waitDone(t, q, id)
+	}
+	if peak != 2 {
+		t.Fatalf("expected at most 2 concurrent jobs, got %d", peak)
+	}
+}
+
+func TestPrune_ExpiresCachedResults(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+	q := New(context.Background(), 1, 10, time.Minute, clock)
+	defer q.Close()
+
+	job, _ := q.Submit("repo", func(context.Context) ([]byte, error) { return nil, nil })
+	waitDone(t, q, job.ID)
+
+	q.mu.Lock()
+	now = now.Add(2 * time.Minute)
+	q.mu.Unlock()
+	next, _ := q.Submit("repo", func(context.Context) ([]byte, error) { return nil, nil })
+	if next.ID == job.ID {
+		t.Fatalf("expected a fresh job after the cache TTL")
+	}
+	if _, ok := q.Get(job.ID); ok {
+		t.Fatalf("expected the expired job to be forgotten")
+	}
+}
+
+func TestWait_ReturnsFinishedAndDroppedJobs(t *testing.T) {
+	q := New(context.Background(), 1, 10, time.Minute, time.Now)
+
+	release := make(chan struct{})
+	running, _ := q.Submit("a", func(ctx context.Context) ([]byte, error) {
+		select {
+		case <-release:
+			return []byte("ok"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Wait(ctx, running.ID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Wait to give up with its context, got %v", err)
+	}
+
+	close(release)
+	done, err := q.Wait(context.Background(), running.ID)
+	if err != nil || done.Status != Succeeded || string(done.Result) != "ok" {
+		t.Fatalf("unexpected job: %+v, %v", done, err)
+	}
+
+	// Hold the worker so that Close drops the next job.
+	block := make(chan struct{})
+	held, _ := q.Submit("c", func(ctx context.Context) ([]byte, error) {
+		close(block)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-block
+	dropped, _ := q.Submit("d", func(context.Context) ([]byte, error) { return nil, nil })
+	q.Close()
+	if job, err := q.Wait(context.Background(), dropped.ID); err != nil || job.Status != Failed || job.Error != ErrClosed.Error() {
+		t.Fatalf("expected the queued job to fail with ErrClosed, got %+v, %v", job, err)
+	}
+	if job, _ := q.Wait(context.Background(), held.ID); job.Status != Failed {
+		t.Fatalf("expected the running job to be canceled, got %+v", job)
+	}
+	if _, err := q.Wait(context.Background(), "missing"); err == nil {
+		t.Fatal("expected an error for an unknown job")
+	}
+}
@@ -0,0 +1,237 @@
+// Package lockfile reads the installed version of every package, direct or
+// transitive, from the lockfile of a JavaScript or Python project.
+package lockfile
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/detector"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// Versions returns the installed version of each package in the lockfile
+// of pm in workDir (requirements.txt pins for pip). A package installed at
+// several versions maps to the lowest, the one an advisory affects longest.
+// Python names are normalized as in PEP 503. Go projects have no lockfile
+// to read; their versions come from the build list.
+func Versions(pm detector.PackageManager, workDir string) (map[string]string, error) {
+	var parse func([]byte, map[string]string) error
+	var name string
+	switch pm {
+	case detector.Npm:
+		name, parse = "package-lock.json", parsePackageLock
+	case detector.Yarn:
+		name, parse = "yarn.lock", parseYarnLock
+	case detector.Pnpm:
+		name, parse = "pnpm-lock.yaml", parsePnpmLock
+	case detector.Poetry:
+		name, parse = "poetry.lock", parseTOMLPackages
+	case detector.Uv:
+		name, parse = "uv.lock", parseTOMLPackages
+	case detector.Pip:
+		name, parse = "requirements.txt", parseRequirements
+	default:
+		return nil, fmt.Errorf("%s projects have no lockfile", pm)
+	}
+	data, err := os.ReadFile(filepath.Join(workDir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	versions := make(map[string]string)
+	if err := parse(data, versions); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return versions, nil
+}
+
+// add records version for name, keeping the lowest of several.
+func add(versions map[string]string, name, version string) {
+	if name == "" || version == "" {
+		return
+	}
+	if old, ok := versions[name]; ok {
+		if cmp, ok := style.ComparePrecedence(version, old); !ok || cmp >= 0 {
+			return
+		}
+	}
+	versions[name] = version
+}
+
+// packageLock is the part of package-lock.json Versions reads: "packages"
+// in lockfile versions 2 and 3, "dependencies" in version 1.
+type packageLock struct {
+	Packages map[string]struct {
+		Version string `json:"version"`
+		Link    bool   `json:"link"`
+	} `json:"packages"`
+	Dependencies map[string]lockDependency `json:"dependencies"`
+}
+
+type lockDependency struct {
+	Version      string                    `json:"version"`
+	Dependencies map[string]lockDependency `json:"dependencies"`
+}
+
+func parsePackageLock(data []byte, versions map[string]string) error {
+	var lock packageLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return err
+	}
+	if len(lock.Packages) > 0 {
+		for path, p := range lock.Packages {
+			i := strings.LastIndex(path, "node_modules/")
+			if i < 0 || p.Link {
+				continue // The root package or a workspace link.
+			}
+			add(versions, path[i+len("node_modules/"):], p.Version)
+		}
+		return nil
+	}
+	var walk func(map[string]lockDependency)
+	walk = func(deps map[string]lockDependency) {
+		for name, d := range deps {
+			add(versions, name, d.Version)
+			walk(d.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return nil
+}
+
+// parseYarnLock reads yarn.lock in both the classic format
+// (`version "1.2.3"`) and the Berry one (`version: 1.2.3`).
+func parseYarnLock(data []byte, versions map[string]string) error {
+	var names []string
+	sc := bufio.NewScanner(strings.NewReader(string(data)))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			names = names[:0]
+			for _, spec := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				if name := specName(strings.Trim(strings.TrimSpace(spec), `"`)); name != "" {
+					names = append(names, name)
+				}
+			}
+			continue
+		}
+		field := strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(field, "version"); ok && (strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, ":")) {
+			version := strings.Trim(strings.TrimSpace(strings.TrimPrefix(rest, ":")), `"`)
+			for _, name := range names {
+				add(versions, name, version)
+			}
+		}
+	}
+	return sc.Err()
+}
+
+// specName returns the package name of a yarn.lock spec such as
+// "@scope/pkg@^1.0.0" or "pkg@npm:1.0.0".
+func specName(spec string) string {
+	i := strings.LastIndex(spec, "@")
+	if i <= 0 {
+		return ""
+	}
+	return spec[:i]
+}
+
+// parsePnpmLock reads the keys of the packages section of pnpm-lock.yaml:
+// "/pkg/1.2.3" (lockfile v5), "/pkg@1.2.3" (v6) and "pkg@1.2.3" (v9), with
+// an optional peer suffix such as "(react@18.2.0)".
+func parsePnpmLock(data []byte, versions map[string]string) error {
+	inPackages := false
+	sc := bufio.NewScanner(strings.NewReader(string(data)))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			inPackages = line == "packages:"
+			continue
+		}
+		if !inPackages || strings.HasPrefix(line, "   ") || !strings.HasSuffix(line, ":") {
+			continue
+		}
+		key := strings.Trim(strings.TrimSuffix(strings.TrimSpace(line), ":"), `'"`)
+		key = strings.TrimPrefix(key, "/")
+		if i := strings.Index(key, "("); i >= 0 {
+			key = key[:i]
+		}
+		if name := specName(key); name != "" {
+			add(versions, name, key[len(name)+1:])
+		} else if i := strings.LastIndex(key, "/"); i > 0 {
+			add(versions, key[:i], key[i+1:])
+		}
+	}
+	return sc.Err()
+}
+
+// parseTOMLPackages reads the name and version of each [[package]] table,
+// the layout poetry.lock and uv.lock share.
+func parseTOMLPackages(data []byte, versions map[string]string) error {
+	var name, version string
+	inPackage := false
+	flush := func() {
+		if inPackage {
+			add(versions, Normalize(name), version)
+		}
+		name, version = "", ""
+	}
+	sc := bufio.NewScanner(strings.NewReader(string(data)))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "[") {
+			if line == "[[package]]" {
+				flush()
+				inPackage = true
+			} else if !strings.HasPrefix(line, "[package.") {
+				flush()
+				inPackage = false
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !inPackage {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "name":
+			name = value
+		case "version":
+			version = value
+		}
+	}
+	flush()
+	return sc.Err()
+}
+
+// requirementPin matches a requirements.txt line pinning an exact version.
+var requirementPin = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*===?\s*([^\s;#]+)`)
+
+func parseRequirements(data []byte, versions map[string]string) error {
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := requirementPin.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			add(versions, Normalize(m[1]), m[3])
+		}
+	}
+	return nil
+}
+
+var separators = regexp.MustCompile(`[-_.]+`)
+
+// Normalize returns the PEP 503 form of a Python package name, the form
+// Versions uses for Python projects.
+func Normalize(name string) string {
+	return separators.ReplaceAllString(strings.ToLower(name), "-")
+}
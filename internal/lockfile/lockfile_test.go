@@ -0,0 +1,148 @@
+package lockfile
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/detector"
+)
+
+func TestVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		pm       detector.PackageManager
+		file     string
+		contents string
+		want     map[string]string
+	}{
+		{
+			name: "npm v3",
+			pm:   detector.Npm,
+			file: "package-lock.json",
+			contents: `{"lockfileVersion": 3, "packages": {
+				"": {"name": "app", "version": "1.0.0"},
+				"node_modules/lodash": {"version": "4.17.21"},
+				"node_modules/@babel/core": {"version": "7.24.0"},
+				"node_modules/a/node_modules/lodash": {"version": "4.17.15"},
+				"node_modules/local": {"link": true}
+			}}`,
+			want: map[string]string{"lodash": "4.17.15", "@babel/core": "7.24.0"},
+		},
+		{
+			name: "npm v1",
+			pm:   detector.Npm,
+			file: "package-lock.json",
+			contents: `{"lockfileVersion": 1, "dependencies": {
+				"a": {"version": "1.0.0", "dependencies": {"minimist": {"version": "1.2.5"}}},
+				"minimist": {"version": "1.2.8"}
+			}}`,
+			want: map[string]string{"a": "1.0.0", "minimist": "1.2.5"},
+		},
+		{
+			name: "yarn classic",
+			pm:   detector.Yarn,
+			file: "yarn.lock",
+			contents: `# yarn lockfile v1
+
+"@babel/core@^7.0.0", "@babel/core@^7.20.0":
+  version "7.24.0"
+  resolved "https://registry.yarnpkg.com/@babel/core/-/core-7.24.0.tgz"
+
+lodash@^4.17.0:
+  version "4.17.21"
+`,
+			want: map[string]string{"@babel/core": "7.24.0", "lodash": "4.17.21"},
+		},
+		{
+			name: "yarn berry",
+			pm:   detector.Yarn,
+			file: "yarn.lock",
+			contents: `__metadata:
+  version: 8
+
+"lodash@npm:^4.17.0":
+  version: 4.17.21
+  resolution: "lodash@npm:4.17.21"
+`,
+			want: map[string]string{"lodash": "4.17.21"},
+		},
+		{
+			name: "pnpm",
+			pm:   detector.Pnpm,
+			file: "pnpm-lock.yaml",
+			contents: `lockfileVersion: '6.0'
+
+importers:
+  .:
+    dependencies:
+      lodash:
+        specifier: ^4.17.0
+
+packages:
+
+  /lodash@4.17.21:
+    resolution: {integrity: sha512-x}
+
+  /@babel/core@7.24.0(supports-color@8.1.1):
+    resolution: {integrity: sha512-y}
+
+  /minimist/1.2.8:
+    resolution: {integrity: sha512-z}
+`,
+			want: map[string]string{"lodash": "4.17.21", "@babel/core": "7.24.0", "minimist": "1.2.8"},
+		},
+		{
+			name: "poetry",
+			pm:   detector.Poetry,
+			file: "poetry.lock",
+			contents: `[[package]]
+name = "Django"
+version = "4.2.1"
+
+[package.dependencies]
+sqlparse = ">=0.3.1"
+
+[[package]]
+name = "sqlparse"
+version = "0.4.4"
+
+[metadata]
+lock-version = "2.0"
+`,
+			want: map[string]string{"django": "4.2.1", "sqlparse": "0.4.4"},
+		},
+		{
+			name:     "pip",
+			pm:       detector.Pip,
+			file:     "requirements.txt",
+			contents: "# pinned\nrequests[socks]==2.31.0\nZope.Interface == 6.0.0 ; python_version >= '3.8'\nflask>=2.0\n",
+			want:     map[string]string{"requests": "2.31.0", "zope-interface": "6.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Versions(tt.pm, dir)
+			if err != nil {
+				t.Fatalf("Versions: %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersions_Missing(t *testing.T) {
+	if _, err := Versions(detector.Npm, t.TempDir()); err == nil {
+		t.Fatal("expected an error without a lockfile")
+	}
+	if _, err := Versions(detector.Go, t.TempDir()); err == nil {
+		t.Fatal("expected an error for Go projects")
+	}
+}
@@ -0,0 +1,50 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func frame(body, extraHeaders string) string {
+	return fmt.Sprintf("Content-Length: %d\r\n%s\r\n%s", len(body), extraHeaders, body)
+}
+
+func TestConn_ReadsFramedMessages(t *testing.T) {
+	in := frame(`{"jsonrpc":"2.0","id":7,"method":"initialize","params":{}}`, "Content-Type: application/vscode-jsonrpc; charset=utf-8\r\n") +
+		frame(`{"jsonrpc":"2.0","method":"exit"}`, "")
+	c := NewConn(strings.NewReader(in), io.Discard)
+
+	req, err := c.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if req.Method != "initialize" || string(req.ID) != "7" || req.IsNotification() {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+	if req, err = c.Read(); err != nil || req.Method != "exit" || !req.IsNotification() {
+		t.Fatalf("unexpected notification: %+v, %v", req, err)
+	}
+	if _, err := c.Read(); err != io.EOF {
+		t.Fatalf("expected io.EOF at the end of the stream, got %v", err)
+	}
+}
+
+func TestConn_WritesFramedMessages(t *testing.T) {
+	var out bytes.Buffer
+	c := NewConn(strings.NewReader(""), &out)
+	if err := c.Reply(json.RawMessage("1"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReplyError(nil, CodeMethodNotFound, "nope"); err != nil {
+		t.Fatal(err)
+	}
+	want := frame(`{"jsonrpc":"2.0","id":1,"result":null}`, "") +
+		frame(`{"jsonrpc":"2.0","id":null,"error":{"code":-32601,"message":"nope"}}`, "")
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%q\nwant:\n%q", out.String(), want)
+	}
+}
@@ -0,0 +1,117 @@
+// Package modlint checks a go.mod file for hygiene problems that an update
+// scan does not report: duplicated requirements, wrong // indirect markers,
+// replace directives with no effect, retracted versions and a missing
+// toolchain directive.
+package modlint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pragmaticivan/faro/internal/gomod"
+)
+
+// Kind names a class of problem.
+type Kind string
+
+const (
+	KindDuplicate Kind = "duplicate"
+	KindIndirect  Kind = "should-be-indirect"
+	KindDirect    Kind = "should-be-direct"
+	KindReplace   Kind = "redundant-replace"
+	KindRetracted Kind = "retracted"
+	KindToolchain Kind = "missing-toolchain"
+)
+
+// toolchainSince is the first Go version that understands the toolchain
+// directive.
+const toolchainSince = "1.21"
+
+// Issue is one problem found in go.mod.
+type Issue struct {
+	Kind    Kind
+	Module  string // empty for problems with the file as a whole
+	Message string
+}
+
+// Facts are what the go command knows about the module beyond go.mod. Nil
+// fields skip the checks that need them.
+type Facts struct {
+	// Imported holds the required modules that provide a package imported
+	// by the main module's packages or tests.
+	Imported map[string]bool
+	// BuildList maps every module in the build list to its selected version.
+	BuildList map[string]string
+	// Retracted maps required modules whose version in use is retracted to
+	// the retraction rationale.
+	Retracted map[string]string
+}
+
+// Check returns the problems in goModContents, grouped by kind in a fixed
+// order and sorted by module within each kind.
+func Check(goModContents string, facts Facts) []Issue {
+	var issues []Issue
+	requires := gomod.ParseRequires(goModContents)
+	tools := gomod.ToolModules(gomod.ParseTools(goModContents), requires)
+
+	seen := make(map[string]int)
+	for _, r := range requires {
+		seen[r.Path]++
+		if seen[r.Path] == 2 {
+			issues = append(issues, Issue{Kind: KindDuplicate, Module: r.Path, Message: "required more than once"})
+		}
+	}
+
+	if facts.Imported != nil {
+		for path, indirect := range gomod.ParseRequireIndex(goModContents) {
+			if _, ok := tools[path]; ok {
+				continue
+			}
+			switch imported := facts.Imported[path]; {
+			case !indirect && !imported:
+				issues = append(issues, Issue{Kind: KindIndirect, Module: path, Message: "no package of the main module imports it; mark it // indirect"})
+			case indirect && imported:
+				issues = append(issues, Issue{Kind: KindDirect, Module: path, Message: "imported by the main module but marked // indirect"})
+			}
+		}
+	}
+
+	if facts.BuildList != nil {
+		required := make(map[string]string, len(requires))
+		for _, r := range requires {
+			required[r.Path] = r.Version
+		}
+		for _, rep := range gomod.ParseReplaces(goModContents) {
+			selected, inBuild := facts.BuildList[rep.Old]
+			switch {
+			case !inBuild:
+				issues = append(issues, Issue{Kind: KindReplace, Module: rep.Old, Message: "replaces a module that is not in the build list"})
+			case rep.OldVersion != "" && rep.OldVersion != selected:
+				issues = append(issues, Issue{Kind: KindReplace, Module: rep.Old, Message: fmt.Sprintf("replaces %s but the build selects %s", rep.OldVersion, selected)})
+			case rep.New == rep.Old && rep.NewVersion != "" && rep.NewVersion == required[rep.Old]:
+				issues = append(issues, Issue{Kind: KindReplace, Module: rep.Old, Message: "replaces the module with the version it already requires"})
+			}
+		}
+	}
+
+	for path, rationale := range facts.Retracted {
+		msg := "the version in use is retracted"
+		if rationale != "" {
+			msg += ": " + rationale
+		}
+		issues = append(issues, Issue{Kind: KindRetracted, Module: path, Message: msg})
+	}
+
+	if goVersion := gomod.ParseGoVersion(goModContents); gomod.ParseToolchain(goModContents) == "" && goVersion != "" && gomod.CompareGoVersions(goVersion, toolchainSince) >= 0 {
+		issues = append(issues, Issue{Kind: KindToolchain, Message: fmt.Sprintf("no toolchain directive; builds use any Go toolchain of at least go %s", goVersion)})
+	}
+
+	order := map[Kind]int{KindDuplicate: 0, KindIndirect: 1, KindDirect: 2, KindReplace: 3, KindRetracted: 4, KindToolchain: 5}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Kind != issues[j].Kind {
+			return order[issues[i].Kind] < order[issues[j].Kind]
+		}
+		return issues[i].Module < issues[j].Module
+	})
+	return issues
+}
@@ -0,0 +1,69 @@
+package modlint
+
+import "testing"
+
+func TestCheck(t *testing.T) {
+	goMod := `module example.com/foo
+
+go 1.22
+
+require (
+	github.com/acme/api v1.0.0
+	github.com/acme/unused v1.0.0
+	github.com/acme/util v1.2.0 // indirect
+	github.com/acme/api v1.0.0
+)
+
+tool github.com/acme/gen/cmd/gen
+
+require github.com/acme/gen v0.3.0
+
+replace github.com/acme/gone => ../gone
+
+replace github.com/acme/util v1.1.0 => github.com/fork/util v1.1.1
+
+replace github.com/acme/api => github.com/acme/api v1.0.0
+`
+	issues := Check(goMod, Facts{
+		Imported:  map[string]bool{"github.com/acme/api": true, "github.com/acme/util": true},
+		BuildList: map[string]string{"github.com/acme/api": "v1.0.0", "github.com/acme/unused": "v1.0.0", "github.com/acme/util": "v1.2.0", "github.com/acme/gen": "v0.3.0"},
+		Retracted: map[string]string{"github.com/acme/unused": "data loss bug"},
+	})
+
+	want := []struct {
+		kind   Kind
+		module string
+	}{
+		{KindDuplicate, "github.com/acme/api"},
+		{KindIndirect, "github.com/acme/unused"},
+		{KindDirect, "github.com/acme/util"},
+		{KindReplace, "github.com/acme/api"},
+		{KindReplace, "github.com/acme/gone"},
+		{KindReplace, "github.com/acme/util"},
+		{KindRetracted, "github.com/acme/unused"},
+		{KindToolchain, ""},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Kind != w.kind || issues[i].Module != w.module {
+			t.Errorf("issue %d: got %s %s, want %s %s", i, issues[i].Kind, issues[i].Module, w.kind, w.module)
+		}
+	}
+	if issues[6].Message != "the version in use is retracted: data loss bug" {
+		t.Errorf("unexpected retraction message: %q", issues[6].Message)
+	}
+}
+
+func TestCheck_CleanFileAndSkippedFacts(t *testing.T) {
+	if issues := Check("module example.com/foo\n\ngo 1.23\n\ntoolchain go1.23.4\n", Facts{}); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+	// Without facts only the static checks run; go 1.20 predates toolchain.
+	goMod := "module example.com/foo\n\ngo 1.20\n\nrequire github.com/acme/api v1.0.0\n\nrequire github.com/acme/api v1.0.0\n"
+	issues := Check(goMod, Facts{})
+	if len(issues) != 1 || issues[0].Kind != KindDuplicate {
+		t.Fatalf("expected only the duplicate, got %+v", issues)
+	}
+}
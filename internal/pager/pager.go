@@ -0,0 +1,76 @@
+// Package pager pipes long terminal output through a pager, like git does.
+package pager
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Env names the variable that selects a pager for faro only, checked before PAGER.
+const Env = "FARO_PAGER"
+
+// DefaultLess holds the less options used when LESS is unset: quit when the
+// output fits one screen (-F), keep colors (-R) and leave the output on
+// screen after quitting (-X).
+const DefaultLess = "FRX"
+
+// Command returns the pager command line from FARO_PAGER, then PAGER,
+// defaulting to less. It returns nil when paging is disabled by setting
+// either variable to an empty value or "cat". lookupEnv is os.LookupEnv.
+func Command(lookupEnv func(string) (string, bool)) []string {
+	value := "less"
+	for _, name := range []string{Env, "PAGER"} {
+		if v, ok := lookupEnv(name); ok {
+			value = v
+			break
+		}
+	}
+	args := strings.Fields(value)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+	return args
+}
+
+// Start pipes writes through the pager when out is a terminal and a pager
+// is available, returning the writer to use and a function that closes the
+// pipe and waits for the pager to exit. Otherwise it returns out and a no-op.
+func Start(out *os.File) (io.Writer, func()) {
+	noop := func() {}
+	if !term.IsTerminal(out.Fd()) {
+		return out, noop
+	}
+	args := Command(os.LookupEnv)
+	if args == nil {
+		return out, noop
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return out, noop
+	}
+
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Stdout, cmd.Stderr = out, os.Stderr
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(cmd.Env, "LESS="+DefaultLess)
+	}
+	if os.Getenv("LV") == "" {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+	w, err := cmd.StdinPipe()
+	if err != nil {
+		return out, noop
+	}
+	if err := cmd.Start(); err != nil {
+		return out, noop
+	}
+	return w, func() {
+		_ = w.Close()
+		_ = cmd.Wait()
+	}
+}
@@ -0,0 +1,44 @@
+package pager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	cases := []struct {
+		env  map[string]string
+		want []string
+	}{
+		{nil, []string{"less"}},
+		{map[string]string{"PAGER": "more -s"}, []string{"more", "-s"}},
+		{map[string]string{"PAGER": "more", Env: "less -S"}, []string{"less", "-S"}},
+		{map[string]string{"PAGER": "cat"}, nil},
+		{map[string]string{Env: "", "PAGER": "more"}, nil},
+	}
+	for _, c := range cases {
+		got := Command(func(name string) (string, bool) {
+			v, ok := c.env[name]
+			return v, ok
+		})
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("env %v: expected %v, got %v", c.env, c.want, got)
+		}
+	}
+}
+
+func TestStart_NotATerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	w, done := Start(f)
+	defer done()
+	if w != f {
+		t.Fatalf("expected output to a file to bypass the pager")
+	}
+}
@@ -0,0 +1,100 @@
+// Package platform matches release asset names against GOOS/GOARCH targets.
+package platform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Platform is a GOOS/GOARCH pair such as linux/amd64.
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// Parse parses "os/arch".
+func Parse(s string) (Platform, error) {
+	osName, arch, ok := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "/")
+	if !ok || osName == "" || arch == "" {
+		return Platform{}, fmt.Errorf("invalid platform %q: expected os/arch (e.g. linux/amd64)", s)
+	}
+	return Platform{OS: osName, Arch: arch}, nil
+}
+
+// osAliases and archAliases list the spellings release tooling commonly
+// uses in asset names for each GOOS and GOARCH.
+var osAliases = map[string][]string{
+	"linux":   {"linux"},
+	"darwin":  {"darwin", "macos", "osx", "apple"},
+	"windows": {"windows", "win64", "win32", "win"},
+	"freebsd": {"freebsd"},
+}
+
+var archAliases = map[string][]string{
+	"amd64": {"amd64", "x64", "64bit"},
+	"arm64": {"arm64", "aarch64"},
+	"386":   {"386", "i386", "i686", "x86", "32bit"},
+	"arm":   {"armv7", "armv6", "armhf", "arm"},
+}
+
+// Matches reports whether an asset file name looks like a build for p.
+// macOS universal binaries match every darwin architecture.
+func (p Platform) Matches(asset string) bool {
+	tokens := tokenize(asset)
+	if !hasAny(tokens, aliases(osAliases, p.OS)) {
+		return false
+	}
+	if p.OS == "darwin" && hasAny(tokens, []string{"universal", "all"}) {
+		return true
+	}
+	return hasAny(tokens, aliases(archAliases, p.Arch))
+}
+
+// Missing returns the platforms for which none of assets match.
+func Missing(assets []string, platforms []Platform) []Platform {
+	var missing []Platform
+	for _, p := range platforms {
+		found := false
+		for _, a := range assets {
+			if p.Matches(a) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+func aliases(table map[string][]string, key string) []string {
+	if a, ok := table[key]; ok {
+		return a
+	}
+	return []string{key}
+}
+
+// tokenize splits an asset name on the separators used in release file names.
+// x86_64 is rewritten first since its own underscore is a separator.
+func tokenize(name string) []string {
+	name = strings.NewReplacer("x86_64", "amd64", "x86-64", "amd64").Replace(strings.ToLower(name))
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' '
+	})
+}
+
+func hasAny(tokens, want []string) bool {
+	for _, t := range tokens {
+		for _, w := range want {
+			if t == w {
+				return true
+			}
+		}
+	}
+	return false
+}
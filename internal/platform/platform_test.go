@@ -0,0 +1,33 @@
+package platform
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	p, err := Parse(" Linux/AMD64 ")
+	if err != nil || p != (Platform{OS: "linux", Arch: "amd64"}) {
+		t.Fatalf("unexpected platform %v, %v", p, err)
+	}
+	if _, err := Parse("linux"); err == nil {
+		t.Fatalf("expected error for missing arch")
+	}
+}
+
+func TestMissing(t *testing.T) {
+	assets := []string{
+		"golangci-lint-1.60.0-linux-amd64.tar.gz",
+		"golangci-lint_1.60.0_Darwin_x86_64.tar.gz",
+		"tool-1.0.0-windows-arm64.zip",
+		"checksums.txt",
+	}
+	platforms := []Platform{
+		{"linux", "amd64"}, {"darwin", "amd64"}, {"windows", "arm64"}, {"linux", "arm64"}, {"darwin", "arm64"},
+	}
+	missing := Missing(assets, platforms)
+	if len(missing) != 2 || missing[0].String() != "linux/arm64" || missing[1].String() != "darwin/arm64" {
+		t.Fatalf("unexpected missing platforms: %v", missing)
+	}
+
+	if m := Missing([]string{"tool_macOS_universal.zip"}, []Platform{{"darwin", "arm64"}}); len(m) != 0 {
+		t.Fatalf("expected universal binary to cover darwin/arm64, got %v", m)
+	}
+}
@@ -0,0 +1,72 @@
+// Package policy evaluates dependency freshness rules such as a maximum
+// allowed staleness.
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// Violation is a module that is further behind its latest release than allowed.
+type Violation struct {
+	Module scanner.Module
+	// Behind is how much older the current version is than the latest release.
+	Behind time.Duration
+}
+
+// Days returns Behind in whole days.
+func (v Violation) Days() int {
+	return int(v.Behind.Hours() / 24)
+}
+
+// ParseAge parses a maximum age such as "365d", "52w", "1y" or "90" (days).
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty max age")
+	}
+	unit := 24 * time.Hour
+	switch {
+	case strings.HasSuffix(s, "d"):
+		s = strings.TrimSuffix(s, "d")
+	case strings.HasSuffix(s, "w"):
+		s, unit = strings.TrimSuffix(s, "w"), 7*24*time.Hour
+	case strings.HasSuffix(s, "y"):
+		s, unit = strings.TrimSuffix(s, "y"), 365*24*time.Hour
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid max age %q: expected a positive number of days, weeks (w) or years (y)", s)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// CheckMaxAge returns the modules whose current version was published more
+// than maxAge before their latest release, ordered from most to least stale.
+// Modules lacking either publish time are returned as unknown.
+func CheckMaxAge(modules []scanner.Module, maxAge time.Duration) (violations []Violation, unknown []scanner.Module) {
+	for _, m := range modules {
+		if m.Update == nil {
+			continue
+		}
+		current, ok1 := format.ParseRFC3339ish(m.Time)
+		latest, ok2 := format.ParseRFC3339ish(m.Update.Time)
+		if !ok1 || !ok2 {
+			unknown = append(unknown, m)
+			continue
+		}
+		if behind := latest.Sub(current); behind > maxAge {
+			violations = append(violations, Violation{Module: m, Behind: behind})
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Behind > violations[j].Behind
+	})
+	return violations, unknown
+}
@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+func TestParseAge(t *testing.T) {
+	day := 24 * time.Hour
+	cases := map[string]time.Duration{
+		"365d": 365 * day,
+		"90":   90 * day,
+		"2w":   14 * day,
+		"1Y":   365 * day,
+	}
+	for in, want := range cases {
+		got, err := ParseAge(in)
+		if err != nil || got != want {
+			t.Fatalf("ParseAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "-3d", "0"} {
+		if _, err := ParseAge(in); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
+func TestCheckMaxAge(t *testing.T) {
+	modules := []scanner.Module{
+		{Name: "fresh", Time: "2024-06-01T00:00:00Z", Update: &scanner.UpdateInfo{Version: "v1.1.0", Time: "2024-07-01T00:00:00Z"}},
+		{Name: "stale", Time: "2022-01-01T00:00:00Z", Update: &scanner.UpdateInfo{Version: "v2.0.0", Time: "2024-01-01T00:00:00Z"}},
+		{Name: "staler", Time: "2020-01-01T00:00:00Z", Update: &scanner.UpdateInfo{Version: "v3.0.0", Time: "2024-01-01T00:00:00Z"}},
+		{Name: "untimed", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+		{Name: "current"},
+	}
+	violations, unknown := CheckMaxAge(modules, 365*24*time.Hour)
+	if len(violations) != 2 || violations[0].Module.Name != "staler" || violations[1].Module.Name != "stale" {
+		t.Fatalf("unexpected violations: %+v", violations)
+	}
+	if violations[1].Days() != 730 {
+		t.Fatalf("expected 730 days behind, got %d", violations[1].Days())
+	}
+	if len(unknown) != 1 || unknown[0].Name != "untimed" {
+		t.Fatalf("unexpected unknown: %+v", unknown)
+	}
+}
@@ -0,0 +1,116 @@
+// Package report renders scan results through user-provided Go templates,
+// e.g. to produce pull request bodies in an organization's required format.
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/format"
+)
+
+// DefaultMarkdown is the template used by --format markdown.
+const DefaultMarkdown = `## Dependency updates ({{.Manager}})
+{{if .Updates}}
+| Package | From | To | Change |
+| --- | --- | --- | --- |
+{{range .Updates}}| ` + "`{{.Name}}`" + ` | {{.Version}} | {{.Update.Version}} | {{.Diff}}{{if .VulnCurrent}}{{if gt .VulnCurrent.Total .VulnUpdate.Total}}, fixes {{sub .VulnCurrent.Total .VulnUpdate.Total}} vulnerabilities{{end}}{{end}} |
+{{end}}{{else}}
+All dependencies match the latest package versions.
+{{end}}{{if .Warnings}}
+### Warnings
+
+{{range .Warnings}}- {{.}}
+{{end}}{{end}}{{with .Environment}}
+<sub>{{.}}</sub>
+{{end}}`
+
+// Data is the full result model exposed to report templates.
+type Data struct {
+	Manager   string
+	Generated time.Time
+	Updates   []format.Record
+	Warnings  []string
+	// Environment is nil when no snapshot was taken.
+	Environment *Environment
+}
+
+// Environment records the settings that affect how dependencies resolve, so
+// results can be reproduced and attached to support tickets.
+type Environment struct {
+	Faro      string `json:"faro"`
+	GoVersion string `json:"goVersion,omitempty"`
+	GOPROXY   string `json:"GOPROXY,omitempty"`
+	GOFLAGS   string `json:"GOFLAGS,omitempty"`
+	GOPRIVATE string `json:"GOPRIVATE,omitempty"`
+	GONOPROXY string `json:"GONOPROXY,omitempty"`
+	GONOSUMDB string `json:"GONOSUMDB,omitempty"`
+	GOSUMDB   string `json:"GOSUMDB,omitempty"`
+}
+
+// String returns a one-line summary listing only the settings that are set.
+func (e Environment) String() string {
+	parts := []string{"faro " + e.Faro}
+	if e.GoVersion != "" {
+		parts = append(parts, e.GoVersion)
+	}
+	for _, kv := range [][2]string{
+		{"GOPROXY", e.GOPROXY},
+		{"GOFLAGS", e.GOFLAGS},
+		{"GOPRIVATE", e.GOPRIVATE},
+		{"GONOPROXY", e.GONOPROXY},
+		{"GONOSUMDB", e.GONOSUMDB},
+		{"GOSUMDB", e.GOSUMDB},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Funcs returns the helper functions available to every template.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"trim":  strings.TrimSpace,
+		"sub":   func(a, b int) int { return a - b },
+		"date":  func(layout string, t time.Time) string { return t.Format(layout) },
+	}
+}
+
+// Parse compiles text with Funcs available.
+func Parse(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(Funcs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	return t, nil
+}
+
+// Load reads a template file.
+func Load(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	return string(data), nil
+}
+
+// Render executes text against data and writes the result to w.
+func Render(w io.Writer, text string, data Data) error {
+	t, err := Parse("report", text)
+	if err != nil {
+		return err
+	}
+	if err := t.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render report template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/format"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+func sampleData() Data {
+	return Data{
+		Manager:   "go",
+		Generated: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		Updates: []format.Record{
+			{Name: "github.com/a/b", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v2.0.0"}, Diff: "major",
+				VulnCurrent: &scanner.VulnInfo{Total: 3}, VulnUpdate: &scanner.VulnInfo{Total: 1}},
+			{Name: "github.com/c/d", Version: "v0.1.0", Update: &scanner.UpdateInfo{Version: "v0.1.1"}, Diff: "patch"},
+		},
+		Warnings:    []string{"github.com/c/d: something"},
+		Environment: &Environment{Faro: "v1.0.0", GoVersion: "go1.25.0", GOPROXY: "https://proxy.golang.org,direct", GOFLAGS: "-mod=mod"},
+	}
+}
+
+func TestRender_DefaultMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, DefaultMarkdown, sampleData()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Dependency updates (go)",
+		"| `github.com/a/b` | v1.0.0 | v2.0.0 | major, fixes 2 vulnerabilities |",
+		"| `github.com/c/d` | v0.1.0 | v0.1.1 | patch |",
+		"- github.com/c/d: something",
+		"<sub>faro v1.0.0, go1.25.0, GOPROXY=https://proxy.golang.org,direct, GOFLAGS=-mod=mod</sub>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestRender_EmptyMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, DefaultMarkdown, Data{Manager: "npm"}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !strings.Contains(buf.String(), "All dependencies match") {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestRender_CustomTemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pr.md.tmpl")
+	tmpl := `{{date "2006-01-02" .Generated}}: {{range $i, $u := .Updates}}{{if $i}}, {{end}}{{upper $u.Diff}} {{$u.Name}}{{end}}`
+	if err := os.WriteFile(path, []byte(tmpl), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	text, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, text, sampleData()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if buf.String() != "2025-01-02: MAJOR github.com/a/b, PATCH github.com/c/d" {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+
+	if err := Render(&buf, "{{.Missing}", Data{}); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
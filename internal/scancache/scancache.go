@@ -0,0 +1,140 @@
+// Package scancache remembers the latest version of each module found by
+// a scan, so the next scan only asks the module proxy about modules whose
+// entry has expired or whose current version changed.
+package scancache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry is what a scan learned about one module.
+type Entry struct {
+	// Version is the version the project used when the module was checked.
+	Version string `json:"version"`
+	// Time is when Version was published (RFC3339).
+	Time string `json:"time,omitempty"`
+	// Latest is the newest version; empty when Version was the newest.
+	Latest string `json:"latest,omitempty"`
+	// LatestTime is when Latest was published (RFC3339).
+	LatestTime string `json:"latestTime,omitempty"`
+	// Retracted is the rationale of Version's retraction; empty when it
+	// is not retracted.
+	Retracted string `json:"retracted,omitempty"`
+	// Deprecated is the module's deprecation message.
+	Deprecated string `json:"deprecated,omitempty"`
+	// Checked is when the proxy was last asked.
+	Checked time.Time `json:"checked"`
+}
+
+// Cache holds the entries of one project. A nil *Cache is a valid, empty
+// cache that never stores anything.
+type Cache struct {
+	file string
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	dirty   bool
+	entries map[string]Entry
+}
+
+// Open loads the cache of project (its directory) from dir. Entries older
+// than ttl are stale. A missing or corrupt file starts empty.
+func Open(dir, project string, ttl time.Duration, now func() time.Time) *Cache {
+	sum := sha256.Sum256([]byte(project))
+	c := &Cache{
+		file:    filepath.Join(dir, "scans", hex.EncodeToString(sum[:8])+".json"),
+		ttl:     ttl,
+		now:     now,
+		entries: make(map[string]Entry),
+	}
+	if data, err := os.ReadFile(c.file); err == nil {
+		var entries map[string]Entry
+		if json.Unmarshal(data, &entries) == nil && entries != nil {
+			c.entries = entries
+		}
+	}
+	return c
+}
+
+// Empty reports whether the cache holds no entries at all.
+func (c *Cache) Empty() bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries) == 0
+}
+
+// Lookup returns the entry for path if it was checked within the TTL while
+// the project used version.
+func (c *Cache) Lookup(path, version string) (Entry, bool) {
+	if c == nil {
+		return Entry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[path]
+	if !ok || e.Version != version || c.now().Sub(e.Checked) >= c.ttl {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Store records a fresh check of path.
+func (c *Cache) Store(path string, e Entry) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.Checked = c.now()
+	c.entries[path] = e
+	c.dirty = true
+}
+
+// Save writes the entries stored since Open.
+func (c *Cache) Save() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scan cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.file), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.file), filepath.Base(c.file)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.file); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,45 @@
+package scancache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookup_RespectsVersionAndTTL(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	c := Open(t.TempDir(), "/src/project", time.Hour, clock)
+	c.Store("example.com/lib", Entry{Version: "v1.0.0", Latest: "v1.2.0"})
+
+	if e, ok := c.Lookup("example.com/lib", "v1.0.0"); !ok || e.Latest != "v1.2.0" {
+		t.Fatalf("expected a fresh entry, got %+v, %v", e, ok)
+	}
+	if _, ok := c.Lookup("example.com/lib", "v1.1.0"); ok {
+		t.Fatalf("expected a changed version to miss")
+	}
+	now = now.Add(time.Hour)
+	if _, ok := c.Lookup("example.com/lib", "v1.0.0"); ok {
+		t.Fatalf("expected an expired entry to miss")
+	}
+}
+
+func TestSave_PersistsPerProject(t *testing.T) {
+	dir := t.TempDir()
+	now := func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	c := Open(dir, "/src/a", time.Hour, now)
+	c.Store("example.com/lib", Entry{Version: "v1.0.0"})
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if _, ok := Open(dir, "/src/a", time.Hour, now).Lookup("example.com/lib", "v1.0.0"); !ok {
+		t.Fatalf("expected the entry to be reloaded")
+	}
+	if !Open(dir, "/src/b", time.Hour, now).Empty() {
+		t.Fatalf("expected another project to start empty")
+	}
+	var nilCache *Cache
+	if !nilCache.Empty() || nilCache.Save() != nil {
+		t.Fatalf("expected a nil cache to be empty and save nothing")
+	}
+}
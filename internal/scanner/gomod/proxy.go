@@ -0,0 +1,260 @@
+package gomod
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// proxyConcurrency bounds the module proxy requests in flight.
+const proxyConcurrency = 8
+
+// ProxyClient is the part of the module proxy protocol ProxyScanner uses.
+type ProxyClient interface {
+	Versions(ctx context.Context, modulePath string) ([]string, error)
+	Latest(ctx context.Context, modulePath string) (goproxy.Info, error)
+	Info(ctx context.Context, modulePath, version string) (goproxy.Info, error)
+	GoMod(ctx context.Context, modulePath, version string) ([]byte, error)
+}
+
+// ProxyScanner implements scanner.Scanner for Go modules by querying the
+// module proxy directly instead of running `go list -m -u`, which downloads
+// every module in the build list and needs the Go toolchain. It only sees
+// the requirements in go.mod: transitive modules and test-only modules need
+// the build list. Private modules are left to the client, which routes
+// them to a private proxy or refuses them with goproxy.ErrPrivate.
+type ProxyScanner struct {
+	goModPath string
+	client    ProxyClient
+}
+
+// NewProxyScanner creates a Go module scanner backed by client.
+func NewProxyScanner(workDir string, client ProxyClient) *ProxyScanner {
+	return &ProxyScanner{
+		goModPath: filepath.Join(workDir, "go.mod"),
+		client:    client,
+	}
+}
+
+// GetUpdates returns the go.mod requirements that have available updates.
+func (s *ProxyScanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
+	data, err := os.ReadFile(s.goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	contents := string(data)
+
+	var filterRegex *regexp.Regexp
+	if opts.Filter != "" {
+		compiled, err := regexp.Compile(opts.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern: %w", err)
+		}
+		filterRegex = compiled
+	}
+	if opts.IncludeAll {
+		opts.Warn("", "the module proxy scan only checks go.mod requirements; transitive modules need go list")
+	}
+	if opts.IncludeTestDeps {
+		opts.Warn("", "the module proxy scan cannot tell which modules only tests need; --include-test-deps needs go list")
+	}
+
+	local := make(map[string]bool)
+	for _, r := range gomod.ParseReplaces(contents) {
+		if strings.HasPrefix(r.New, ".") || strings.HasPrefix(r.New, "/") {
+			local[r.Old] = true
+		}
+	}
+	wanted := make(map[string]bool, len(opts.Modules))
+	for _, name := range opts.Modules {
+		wanted[name] = true
+	}
+
+	var requires []gomod.Require
+	found := make(map[string]bool, len(wanted))
+	for _, r := range gomod.ParseRequires(contents) {
+		if len(wanted) > 0 {
+			if !wanted[r.Path] {
+				continue
+			}
+			found[r.Path] = true
+		}
+		if !local[r.Path] && matchesFilter(r.Path, opts.Filter, filterRegex) {
+			requires = append(requires, r)
+		}
+	}
+	for _, name := range opts.Modules {
+		if !found[name] {
+			opts.Warn(name, "not a dependency of this module")
+		}
+	}
+
+	goModules, err := s.queryProxy(ctx, requires, opts)
+	if err != nil {
+		return nil, err
+	}
+	return annotateAndFilter(goModules, gomod.ParseRequireIndex(contents), nil, opts, filterRegex, time.Now()), nil
+}
+
+// queryProxy resolves the current and latest versions of requires, from
+// opts.Cache where fresh. Modules the proxy cannot resolve are reported as
+// warnings and left out.
+func (s *ProxyScanner) queryProxy(ctx context.Context, requires []gomod.Require, opts scanner.Options) ([]goModule, error) {
+	goModules := make([]goModule, len(requires))
+	queried := make([]bool, len(requires))
+	errs := make([]error, len(requires))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, proxyConcurrency)
+	for i, r := range requires {
+		goModules[i] = goModule{Path: r.Path, Version: r.Version, Indirect: r.Indirect}
+		if e, ok := opts.Cache.Lookup(r.Path, r.Version); ok {
+			goModules[i].Time = e.Time
+			if e.Latest != "" {
+				goModules[i].Update = &goModule{Path: r.Path, Version: e.Latest, Time: e.LatestTime}
+			}
+			goModules[i].retain(e)
+			continue
+		}
+		queried[i] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			goModules[i], errs[i] = s.resolve(ctx, goModules[i])
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var resolved, fresh []goModule
+	for i, m := range goModules {
+		if errs[i] != nil {
+			opts.Fail(m.Path, errs[i].Error())
+			continue
+		}
+		resolved = append(resolved, m)
+		if queried[i] {
+			fresh = append(fresh, m)
+		}
+	}
+	remember(opts.Cache, fresh)
+	return resolved, nil
+}
+
+// resolve fills in m's publish time, its retraction and deprecation, and,
+// when the proxy knows a newer version, its update.
+func (s *ProxyScanner) resolve(ctx context.Context, m goModule) (goModule, error) {
+	if info, err := s.client.Info(ctx, m.Path, m.Version); err == nil {
+		m.Time = info.Time
+	}
+	latest, goMod, err := s.latest(ctx, m.Path, m.Version)
+	if err != nil {
+		return m, err
+	}
+	// Like go list, retractions and deprecations come from the go.mod of
+	// the latest version.
+	if r := gomod.RetractedBy(gomod.ParseRetractions(goMod), m.Version); r != nil {
+		rationale := r.Rationale
+		if rationale == "" {
+			rationale = "retracted by module author"
+		}
+		m.Retracted = []string{rationale}
+	}
+	m.Deprecated = gomod.ParseDeprecation(goMod)
+	if latest.Version == "" {
+		return m, nil
+	}
+	if cmp, ok := style.ComparePrecedence(latest.Version, m.Version); ok && cmp <= 0 {
+		return m, nil
+	}
+	if latest.Time == "" {
+		if info, err := s.client.Info(ctx, m.Path, latest.Version); err == nil {
+			latest.Time = info.Time
+		}
+	}
+	m.Update = &goModule{Path: m.Path, Version: latest.Version, Time: latest.Time}
+	return m, nil
+}
+
+// latest picks the version `go list -m -u` would suggest for a module at
+// current: the highest release, or the highest prerelease when there is
+// none, skipping +incompatible versions unless current is one and versions
+// the go.mod of the highest version retracts. It also returns that go.mod,
+// empty when it could not be fetched. Modules without tagged versions fall
+// back to the proxy's @latest.
+func (s *ProxyScanner) latest(ctx context.Context, path, current string) (goproxy.Info, string, error) {
+	versions, err := s.client.Versions(ctx, path)
+	if err != nil {
+		return goproxy.Info{}, "", err
+	}
+	best := pickLatest(versions, current)
+	if best == "" {
+		info, err := s.client.Latest(ctx, path)
+		if err != nil || info.Version == "" {
+			return info, "", err
+		}
+		data, _ := s.client.GoMod(ctx, path, info.Version)
+		return info, string(data), nil
+	}
+	// A failed go.mod fetch only loses the retractions, like go list does.
+	data, err := s.client.GoMod(ctx, path, best)
+	if err != nil {
+		return goproxy.Info{Version: best}, "", nil
+	}
+	goMod := string(data)
+	if retractions := gomod.ParseRetractions(goMod); len(retractions) > 0 {
+		var kept []string
+		for _, v := range versions {
+			if gomod.RetractedBy(retractions, v) == nil {
+				kept = append(kept, v)
+			}
+		}
+		// When every version is retracted go still offers the highest.
+		if v := pickLatest(kept, current); v != "" {
+			best = v
+		}
+	}
+	return goproxy.Info{Version: best}, goMod, nil
+}
+
+// pickLatest returns the highest release in versions, or the highest
+// prerelease when there is none, skipping +incompatible versions unless
+// current is one.
+func pickLatest(versions []string, current string) string {
+	incompatible := strings.HasSuffix(current, "+incompatible")
+	var release, pre string
+	for _, v := range versions {
+		if strings.HasSuffix(v, "+incompatible") && !incompatible {
+			continue
+		}
+		best := &release
+		if strings.Contains(strings.TrimSuffix(v, "+incompatible"), "-") {
+			best = &pre
+		}
+		if cmp, ok := style.ComparePrecedence(v, *best); *best == "" || (ok && cmp > 0) {
+			*best = v
+		}
+	}
+	if release != "" {
+		return release
+	}
+	return pre
+}
+
+// GetDependencyIndex returns a map of Go module paths to their dependency information.
+func (s *ProxyScanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
+	return (&Scanner{goModPath: s.goModPath}).GetDependencyIndex(ctx)
+}
@@ -3,24 +3,40 @@ package gomod
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pragmaticivan/faro/internal/cooldown"
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scancache"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
+// listBatchSize bounds the module paths passed to one go list invocation.
+const listBatchSize = 200
+
 // Scanner implements scanner.Scanner for Go modules.
 type Scanner struct {
 	workDir        string
 	goModPath      string
-	listAllModules func() ([]byte, error)
+	listAllModules func(ctx context.Context) ([]byte, error)
+	// listModules runs `go list -m -u -json` for paths only.
+	listModules func(ctx context.Context, paths []string) ([]byte, error)
+	// listCurrent runs `go list -m -json all` without -u, which needs no
+	// network, for incremental and selective scans.
+	listCurrent func(ctx context.Context) ([]byte, error)
+	// listPackageModules prints the module of every package the main
+	// module's packages depend on, including their tests' dependencies with test.
+	listPackageModules func(ctx context.Context, test bool) ([]byte, error)
 }
 
 // goModule is the internal representation from `go list` output.
@@ -30,6 +46,12 @@ type goModule struct {
 	Time     string    `json:"Time"`
 	Update   *goModule `json:"Update"`
 	Indirect bool      `json:"Indirect"`
+	Main     bool      `json:"Main"`
+	// Retracted holds the rationales when Version is retracted (go list
+	// fills in a generic one when the author gave none); Deprecated is the
+	// module's deprecation message. go list -m -u reports both.
+	Retracted  []string `json:"Retracted"`
+	Deprecated string   `json:"Deprecated"`
 }
 
 // NewScanner creates a new Go module scanner.
@@ -37,16 +59,29 @@ func NewScanner(workDir string) *Scanner {
 	return &Scanner{
 		workDir:   workDir,
 		goModPath: filepath.Join(workDir, "go.mod"),
-		listAllModules: func() ([]byte, error) {
-			cmd := exec.Command("go", "list", "-m", "-u", "-json", "all")
-			cmd.Dir = workDir
+		listAllModules: func(ctx context.Context) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "go", "list", "-m", "-u", "-json", "all")
 			return cmd.Output()
 		},
+		listModules: func(ctx context.Context, paths []string) ([]byte, error) {
+			args := append([]string{"list", "-m", "-u", "-json"}, paths...)
+			return execx.Command(ctx, workDir, "go", args...).Output()
+		},
+		listCurrent: func(ctx context.Context) ([]byte, error) {
+			return execx.Command(ctx, workDir, "go", "list", "-m", "-json", "all").Output()
+		},
+		listPackageModules: func(ctx context.Context, test bool) ([]byte, error) {
+			args := []string{"list", "-e", "-deps", "-f", "{{with .Module}}{{.Path}}{{end}}"}
+			if test {
+				args = append(args, "-test")
+			}
+			return execx.Command(ctx, workDir, "go", append(args, "./...")...).Output()
+		},
 	}
 }
 
 // GetUpdates returns all Go modules that have available updates.
-func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
+func (s *Scanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
 	idx, err := gomod.ReadRequireIndex(s.goModPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read go.mod: %w", err)
@@ -61,21 +96,277 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 		filterRegex = compiled
 	}
 
-	output, err := s.listAllModules()
+	requires, err := gomod.ReadRequires(s.goModPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to run go list: %w", err)
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	var testOnly map[string]bool
+	if opts.IncludeTestDeps {
+		if testOnly, err = s.testOnlyModules(ctx); err != nil {
+			return nil, err
+		}
 	}
 
+	// Selective scans only ask the proxy about the modules they could report.
+	if opts.Filter != "" || len(opts.Modules) > 0 {
+		output, err := s.listCurrent(ctx)
+		if err != nil {
+			return nil, goListError(err)
+		}
+		buildList, err := decodeGoListModules(output)
+		if err != nil {
+			return nil, err
+		}
+		checkConsistency(buildList, requires, opts)
+		goModules, err := s.queryModules(ctx, selectPaths(buildList, requires, testOnly, opts, filterRegex))
+		if err != nil {
+			return nil, err
+		}
+		remember(opts.Cache, goModules)
+		return annotateAndFilter(goModules, idx, testOnly, opts, filterRegex, time.Now()), nil
+	}
+
+	var goModules []goModule
+	if opts.Cache.Empty() {
+		output, err := s.listAllModules(ctx)
+		if err != nil {
+			return nil, goListError(err)
+		}
+		if goModules, err = decodeGoListModules(output); err != nil {
+			return nil, err
+		}
+		remember(opts.Cache, goModules)
+	} else if goModules, err = s.listIncremental(ctx, opts.Cache); err != nil {
+		return nil, err
+	}
+	checkConsistency(goModules, requires, opts)
+
+	return annotateAndFilter(goModules, idx, testOnly, opts, filterRegex, time.Now()), nil
+}
+
+// testOnlyModules returns the modules that provide packages only the main
+// module's tests reach: those `go list -deps -test` lists but `go list
+// -deps` does not. Whether go.mod requires them depends on its tidy state,
+// so this asks the package graph instead.
+func (s *Scanner) testOnlyModules(ctx context.Context) (map[string]bool, error) {
+	output, err := s.listPackageModules(ctx, false)
+	if err != nil {
+		return nil, goListError(err)
+	}
+	built := make(map[string]bool)
+	for _, path := range strings.Fields(string(output)) {
+		built[path] = true
+	}
+	if output, err = s.listPackageModules(ctx, true); err != nil {
+		return nil, goListError(err)
+	}
+	testOnly := make(map[string]bool)
+	for _, path := range strings.Fields(string(output)) {
+		if !built[path] {
+			testOnly[path] = true
+		}
+	}
+	return testOnly, nil
+}
+
+// queryModules runs `go list -m -u -json` for paths in batches.
+func (s *Scanner) queryModules(ctx context.Context, paths []string) ([]goModule, error) {
+	var goModules []goModule
+	for start := 0; start < len(paths); start += listBatchSize {
+		output, err := s.listModules(ctx, paths[start:min(start+listBatchSize, len(paths))])
+		if err != nil {
+			return nil, goListError(err)
+		}
+		batch, err := decodeGoListModules(output)
+		if err != nil {
+			return nil, err
+		}
+		goModules = append(goModules, batch...)
+	}
+	return goModules, nil
+}
+
+// listIncremental lists the build list without querying the proxy, then
+// fills in updates from cache entries that are fresh for the selected
+// version and queries only the remaining modules.
+func (s *Scanner) listIncremental(ctx context.Context, cache *scancache.Cache) ([]goModule, error) {
+	output, err := s.listCurrent(ctx)
+	if err != nil {
+		return nil, goListError(err)
+	}
 	goModules, err := decodeGoListModules(output)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.annotateAndFilter(goModules, idx, opts, filterRegex, time.Now()), nil
+	stale := make(map[string]int)
+	var paths []string
+	for i, m := range goModules {
+		if m.Main {
+			continue
+		}
+		e, ok := cache.Lookup(m.Path, m.Version)
+		if !ok {
+			stale[m.Path] = i
+			paths = append(paths, m.Path)
+			continue
+		}
+		if e.Latest != "" {
+			goModules[i].Update = &goModule{Path: m.Path, Version: e.Latest, Time: e.LatestTime}
+		}
+		goModules[i].retain(e)
+	}
+	if len(paths) == 0 {
+		return goModules, nil
+	}
+
+	queried, err := s.queryModules(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+	remember(cache, queried)
+	for _, m := range queried {
+		if i, ok := stale[m.Path]; ok {
+			goModules[i] = m
+		}
+	}
+	return goModules, nil
+}
+
+// remember stores what `go list -m -u` reported about modules in cache.
+func remember(cache *scancache.Cache, modules []goModule) {
+	for _, m := range modules {
+		if m.Main || m.Version == "" {
+			continue
+		}
+		e := scancache.Entry{Version: m.Version, Time: m.Time, Retracted: strings.Join(m.Retracted, "; "), Deprecated: m.Deprecated}
+		if m.Update != nil {
+			e.Latest, e.LatestTime = m.Update.Version, m.Update.Time
+		}
+		cache.Store(m.Path, e)
+	}
+}
+
+// retain restores the retraction and deprecation of m from a cache entry.
+func (m *goModule) retain(e scancache.Entry) {
+	if e.Retracted != "" {
+		m.Retracted = []string{e.Retracted}
+	}
+	m.Deprecated = e.Deprecated
+}
+
+// selectPaths returns the modules a selective scan has to query: go.mod
+// requirements and test-only modules, or the whole build list with
+// IncludeAll, that match the filter and the requested module names. The
+// others count as filtered, though whether they are outdated is unknown.
+// Requested modules that are not dependencies are reported as warnings.
+func selectPaths(buildList []goModule, requires []gomod.Require, testOnly map[string]bool, opts scanner.Options, filterRegex *regexp.Regexp) []string {
+	var candidates []string
+	if opts.IncludeAll {
+		for _, m := range buildList {
+			if !m.Main {
+				candidates = append(candidates, m.Path)
+			}
+		}
+	} else {
+		required := make(map[string]bool, len(requires))
+		for _, r := range requires {
+			candidates = append(candidates, r.Path)
+			required[r.Path] = true
+		}
+		for path := range testOnly {
+			if !required[path] {
+				candidates = append(candidates, path)
+			}
+		}
+		sort.Strings(candidates[len(requires):])
+	}
+
+	wanted := make(map[string]bool, len(opts.Modules))
+	for _, name := range opts.Modules {
+		wanted[name] = true
+	}
+	var paths []string
+	for _, path := range candidates {
+		named := len(wanted) == 0 || wanted[path]
+		delete(wanted, path)
+		if named && matchesFilter(path, opts.Filter, filterRegex) {
+			paths = append(paths, path)
+		} else {
+			opts.Skipped.Add(scanner.SkipFiltered)
+		}
+	}
+	for _, name := range opts.Modules {
+		if wanted[name] {
+			opts.Warn(name, "not a dependency of this module")
+		}
+	}
+	return paths
+}
+
+// goListError includes go list's stderr, which names the failing module.
+func goListError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("failed to run go list: %s: %w", strings.TrimSpace(string(exitErr.Stderr)), err)
+	}
+	return fmt.Errorf("failed to run go list: %w", err)
+}
+
+// matchesFilter reports whether path contains filter or matches its regex.
+func matchesFilter(path, filter string, filterRegex *regexp.Regexp) bool {
+	if filter == "" || strings.Contains(path, filter) {
+		return true
+	}
+	return filterRegex != nil && filterRegex.MatchString(path)
+}
+
+// checkConsistency warns about requirements that go.mod and `go list -m all`
+// disagree on, which usually means go.mod was edited without `go mod tidy`
+// and the direct/indirect classification may be incomplete.
+func checkConsistency(modules []goModule, requires []gomod.Require, opts scanner.Options) {
+	listed := make(map[string]goModule, len(modules))
+	for _, m := range modules {
+		if !m.Main {
+			listed[m.Path] = m
+		}
+	}
+	required := make(map[string]bool, len(requires))
+
+	mismatches := 0
+	for _, r := range requires {
+		required[r.Path] = true
+		m, ok := listed[r.Path]
+		switch {
+		case !ok:
+			opts.Warn(r.Path, "required in go.mod but missing from go list -m all")
+			mismatches++
+		case m.Version != r.Version:
+			opts.Warn(r.Path, fmt.Sprintf("go.mod requires %s but go list selects %s", r.Version, m.Version))
+			mismatches++
+		}
+	}
+	for _, m := range modules {
+		// go list marks modules indirect from go.mod's own comments, so an
+		// indirect module that go.mod doesn't list means the two disagree.
+		if !m.Main && m.Indirect && !required[m.Path] {
+			opts.Warn(m.Path, "marked indirect by go list but not required in go.mod")
+			mismatches++
+		}
+	}
+
+	if mismatches > 0 {
+		noun := "requirement"
+		if mismatches != 1 {
+			noun = "requirements"
+		}
+		opts.Warn("", fmt.Sprintf("go.mod and go list disagree on %d %s; run `go mod tidy` to fix dependency classification", mismatches, noun))
+	}
 }
 
 // GetDependencyIndex returns a map of Go module paths to their dependency information.
-func (s *Scanner) GetDependencyIndex() (scanner.DependencyIndex, error) {
+func (s *Scanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
 	idx, err := gomod.ReadRequireIndex(s.goModPath)
 	if err != nil {
 		return nil, err
@@ -110,20 +401,25 @@ func decodeGoListModules(data []byte) ([]goModule, error) {
 }
 
 // annotateAndFilter applies go.mod classification and filters modules based on opts.
-func (s *Scanner) annotateAndFilter(
+func annotateAndFilter(
 	modules []goModule,
 	idx gomod.RequireIndex,
+	testOnly map[string]bool,
 	opts scanner.Options,
 	filterRegex *regexp.Regexp,
 	now time.Time,
 ) []scanner.Module {
 	out := make([]scanner.Module, 0, len(modules))
 	for _, m := range modules {
-		if m.Update == nil {
+		// Modules without an update are only kept with KeepDeprecated, to
+		// report their retraction or deprecation, and never count as skipped.
+		if m.Update == nil && (m.Main || !opts.KeepDeprecated || (len(m.Retracted) == 0 && m.Deprecated == "")) {
 			continue
 		}
 
-		// Override classification based on go.mod
+		// Override classification based on go.mod: go list only marks
+		// "// indirect" requirements, so modules go.mod does not require at
+		// all are transitive, or indirect when only the tests need them.
 		fromGoMod := false
 		indirect := m.Indirect
 		depType := "transitive"
@@ -135,27 +431,31 @@ func (s *Scanner) annotateAndFilter(
 			} else {
 				depType = "direct"
 			}
+		} else if testOnly[m.Path] {
+			depType = "indirect"
 		}
 
-		// Filter out transitive dependencies if not including all
-		if !opts.IncludeAll && !fromGoMod {
+		// Filter out transitive dependencies if not including all; modules
+		// the tests need are kept with IncludeTestDeps.
+		if !opts.IncludeAll && !fromGoMod && !testOnly[m.Path] {
+			if m.Update != nil {
+				opts.Skipped.Add(scanner.SkipHidden)
+			}
 			continue
 		}
 
 		// Apply filter
-		if opts.Filter != "" {
-			match := strings.Contains(m.Path, opts.Filter)
-			if !match && filterRegex != nil {
-				match = filterRegex.MatchString(m.Path)
-			}
-			if !match {
-				continue
+		if !matchesFilter(m.Path, opts.Filter, filterRegex) {
+			if m.Update != nil {
+				opts.Skipped.Add(scanner.SkipFiltered)
 			}
+			continue
 		}
 
 		// Apply cooldown
-		if opts.CooldownDays > 0 {
+		if opts.CooldownDays > 0 && m.Update != nil {
 			if !cooldown.Eligible(m.Update.Time, opts.CooldownDays, now) {
+				opts.Skipped.Add(scanner.SkipCooldown)
 				continue
 			}
 		}
@@ -165,12 +465,14 @@ func (s *Scanner) annotateAndFilter(
 			Name:           m.Path,
 			Version:        m.Version,
 			Time:           m.Time,
-			Direct:         !indirect,
+			Direct:         fromGoMod && !indirect,
 			DependencyType: depType,
 			// Legacy fields for backward compatibility
-			Path:      m.Path,
-			Indirect:  indirect,
-			FromGoMod: fromGoMod,
+			Path:       m.Path,
+			Indirect:   indirect,
+			TestOnly:   testOnly[m.Path],
+			Retracted:  strings.Join(m.Retracted, "; "),
+			Deprecated: m.Deprecated,
 		}
 		if m.Update != nil {
 			module.Update = &scanner.UpdateInfo{
@@ -1,12 +1,19 @@
 package gomod
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/goproxy"
+	"github.com/pragmaticivan/faro/internal/scancache"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
@@ -50,9 +57,10 @@ require (
 			},
 		},
 		{
+			// go list only marks go.mod's "// indirect" requirements.
 			Path:     "example.com/transitive",
 			Version:  "v0.5.0",
-			Indirect: true,
+			Indirect: false,
 			Update: &goModule{
 				Path:    "example.com/transitive",
 				Version: "v0.6.0",
@@ -69,7 +77,7 @@ require (
 
 	// 3. Initialize Scanner
 	s := NewScanner(tmpDir)
-	s.listAllModules = func() ([]byte, error) {
+	s.listAllModules = func(ctx context.Context) ([]byte, error) {
 		// go list -json output is a stream of JSON objects, not an array
 		var buf []byte
 		for _, m := range mockOutput {
@@ -86,14 +94,19 @@ require (
 	// indirect is in go.mod (direct=false)
 	// transitive is NOT in go.mod
 
+	var skipped scanner.SkipStats
 	opts := scanner.Options{
 		IncludeAll: false,
+		Skipped:    &skipped,
 	}
 
-	modules, err := s.GetUpdates(opts)
+	modules, err := s.GetUpdates(context.Background(), opts)
 	if err != nil {
 		t.Fatalf("GetUpdates failed: %v", err)
 	}
+	if skipped != (scanner.SkipStats{Hidden: 1}) {
+		t.Errorf("expected 1 hidden transitive module, got %+v", skipped)
+	}
 
 	// Should have direct and indirect, but NOT transitive
 	// Check count
@@ -128,13 +141,18 @@ require (
 
 	// 5. Test Case: IncludeAll = true
 	opts.IncludeAll = true
-	modules, err = s.GetUpdates(opts)
+	modules, err = s.GetUpdates(context.Background(), opts)
 	if err != nil {
 		t.Fatalf("GetUpdates(IncludeAll) failed: %v", err)
 	}
 	if len(modules) != 3 {
 		t.Errorf("expected 3 modules with IncludeAll, got %d", len(modules))
 	}
+	for _, m := range modules {
+		if m.Name == "example.com/transitive" && (m.Direct || m.DependencyType != "transitive") {
+			t.Errorf("expected example.com/transitive to be transitive, got %+v", m)
+		}
+	}
 }
 
 func TestGetUpdates_Cooldown(t *testing.T) {
@@ -168,7 +186,7 @@ require example.com/pkg v1.0.0
 
 	// Create scanner
 	s := NewScanner(tmpDir)
-	s.listAllModules = func() ([]byte, error) {
+	s.listAllModules = func(ctx context.Context) ([]byte, error) {
 		var buf []byte
 		for _, m := range mockOutput {
 			b, _ := json.Marshal(m)
@@ -183,12 +201,14 @@ require example.com/pkg v1.0.0
 	// The test setup only put pkg in go.mod. So 'old' is not in go.mod.
 	// Let's use IncludeAll = true to test cooldown on both.
 
+	var skipped scanner.SkipStats
 	opts := scanner.Options{
 		CooldownDays: 1,
 		IncludeAll:   true,
+		Skipped:      &skipped,
 	}
 
-	modules, err := s.GetUpdates(opts)
+	modules, err := s.GetUpdates(context.Background(), opts)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -200,6 +220,9 @@ require example.com/pkg v1.0.0
 			t.Errorf("expected example.com/old, got %s", modules[0].Name)
 		}
 	}
+	if skipped.Cooldown != 1 {
+		t.Errorf("expected 1 module skipped by cooldown, got %+v", skipped)
+	}
 }
 
 func TestDecodeGoListModules(t *testing.T) {
@@ -232,3 +255,486 @@ func TestDecodeGoListModules(t *testing.T) {
 // Helper struct field need 'Refresh' was a typo in my mind?
 // No, goModule struct in scanner.go doesn't have Refresh. I added it in the test mock struct init but it's not in the type definition in scanner.go.
 // I need to be careful. The mock is creating goModule structs.
+
+func TestCheckConsistency(t *testing.T) {
+	requires := []gomod.Require{
+		{Path: "example.com/ok", Version: "v1.0.0"},
+		{Path: "example.com/stale", Version: "v1.0.0"},
+		{Path: "example.com/missing", Version: "v1.0.0", Indirect: true},
+	}
+	modules := []goModule{
+		{Path: "example.com/foo", Main: true},
+		{Path: "example.com/ok", Version: "v1.0.0"},
+		{Path: "example.com/stale", Version: "v1.2.0"},
+		{Path: "example.com/untracked", Version: "v0.1.0", Indirect: true},
+		{Path: "example.com/transitive", Version: "v0.1.0"},
+	}
+
+	got := map[string]string{}
+	checkConsistency(modules, requires, scanner.Options{
+		OnWarning: func(module, message string) { got[module] = message },
+	})
+
+	want := map[string]string{
+		"example.com/stale":     "go.mod requires v1.0.0 but go list selects v1.2.0",
+		"example.com/missing":   "required in go.mod but missing from go list -m all",
+		"example.com/untracked": "marked indirect by go list but not required in go.mod",
+		"":                      "go.mod and go list disagree on 3 requirements; run `go mod tidy` to fix dependency classification",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected warnings: %v", got)
+	}
+	for module, message := range want {
+		if got[module] != message {
+			t.Fatalf("warning for %q = %q, want %q", module, got[module], message)
+		}
+	}
+}
+
+func TestGetUpdates_IncludesGoListStderr(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/foo\n"), 0644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	s := NewScanner(tmpDir)
+	s.listAllModules = func(ctx context.Context) ([]byte, error) {
+		return nil, &exec.ExitError{Stderr: []byte("go: github.com/acme/secret@v1.0.0: 404 Not Found\n")}
+	}
+	_, err := s.GetUpdates(context.Background(), scanner.Options{})
+	if err == nil || !strings.Contains(err.Error(), "github.com/acme/secret@v1.0.0: 404 Not Found") {
+		t.Fatalf("expected go list stderr in error, got %v", err)
+	}
+}
+
+func TestGetUpdates_SelectiveScanQueriesOnlyMatches(t *testing.T) {
+	tmpDir := t.TempDir()
+	goModContent := `module example.com/foo
+
+require (
+	github.com/acme/api v1.0.0
+	github.com/acme/db v1.0.0
+	golang.org/x/text v0.1.0 // indirect
+)
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	s := NewScanner(tmpDir)
+	s.listAllModules = func(ctx context.Context) ([]byte, error) {
+		t.Fatal("selective scans must not list every module")
+		return nil, nil
+	}
+	var queried [][]string
+	s.listModules = func(ctx context.Context, paths []string) ([]byte, error) {
+		queried = append(queried, paths)
+		var buf []byte
+		for _, p := range paths {
+			b, _ := json.Marshal(goModule{Path: p, Version: "v1.0.0", Update: &goModule{Path: p, Version: "v1.1.0"}})
+			buf = append(buf, b...)
+		}
+		return buf, nil
+	}
+	buildList := []goModule{
+		{Path: "example.com/foo", Main: true},
+		{Path: "github.com/acme/api", Version: "v1.0.0"},
+		{Path: "github.com/acme/db", Version: "v1.0.0"},
+		{Path: "github.com/acme/util", Version: "v1.0.0"},
+		{Path: "golang.org/x/text", Version: "v0.1.0", Indirect: true},
+	}
+	s.listCurrent = func(ctx context.Context) ([]byte, error) {
+		var buf []byte
+		for _, m := range buildList {
+			b, _ := json.Marshal(m)
+			buf = append(buf, b...)
+		}
+		return buf, nil
+	}
+
+	var skipped scanner.SkipStats
+	modules, err := s.GetUpdates(context.Background(), scanner.Options{Filter: "acme", Skipped: &skipped})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(queried) != 1 || strings.Join(queried[0], " ") != "github.com/acme/api github.com/acme/db" || len(modules) != 2 {
+		t.Fatalf("expected only the matching requirements to be queried, got %v (%d modules)", queried, len(modules))
+	}
+	if skipped.Filtered != 1 {
+		t.Fatalf("expected the unqueried requirement counted as filtered, got %+v", skipped)
+	}
+
+	queried = nil
+	modules, err = s.GetUpdates(context.Background(), scanner.Options{Filter: "acme", IncludeAll: true})
+	if err != nil {
+		t.Fatalf("GetUpdates(IncludeAll) failed: %v", err)
+	}
+	if len(queried) != 1 || len(queried[0]) != 3 || len(modules) != 3 {
+		t.Fatalf("expected the matching build list entries to be queried, got %v", queried)
+	}
+
+	queried = nil
+	skipped = scanner.SkipStats{}
+	var warnings []string
+	modules, err = s.GetUpdates(context.Background(), scanner.Options{
+		Modules:   []string{"golang.org/x/text", "example.com/missing"},
+		Skipped:   &skipped,
+		OnWarning: func(module, message string) { warnings = append(warnings, module+": "+message) },
+	})
+	if err != nil {
+		t.Fatalf("GetUpdates(Modules) failed: %v", err)
+	}
+	if len(queried) != 1 || strings.Join(queried[0], " ") != "golang.org/x/text" || len(modules) != 1 {
+		t.Fatalf("expected only the named module to be queried, got %v", queried)
+	}
+	if skipped.Filtered != 2 {
+		t.Fatalf("expected the unnamed requirements counted as filtered, got %+v", skipped)
+	}
+	if len(warnings) != 1 || warnings[0] != "example.com/missing: not a dependency of this module" {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+
+	// Selective scans still compare go.mod with the build list.
+	buildList[2].Version = "v1.2.0"
+	warnings = nil
+	if _, err := s.GetUpdates(context.Background(), scanner.Options{
+		Filter:    "api",
+		OnWarning: func(module, message string) { warnings = append(warnings, module+": "+message) },
+	}); err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(warnings) != 2 || warnings[0] != "github.com/acme/db: go.mod requires v1.0.0 but go list selects v1.2.0" {
+		t.Fatalf("expected a consistency warning, got %v", warnings)
+	}
+}
+
+func TestGetUpdates_IncrementalScanQueriesOnlyStaleModules(t *testing.T) {
+	tmpDir := t.TempDir()
+	goModContent := `module example.com/foo
+
+require (
+	github.com/acme/api v1.1.0
+	github.com/acme/db v1.0.0
+	github.com/acme/util v1.0.0
+)
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cacheDir := t.TempDir()
+	seed := scancache.Open(cacheDir, tmpDir, time.Hour, func() time.Time { return now.Add(-2 * time.Hour) })
+	seed.Store("github.com/acme/util", scancache.Entry{Version: "v1.0.0"})
+	if err := seed.Save(); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+	cache := scancache.Open(cacheDir, tmpDir, time.Hour, func() time.Time { return now })
+	cache.Store("github.com/acme/api", scancache.Entry{Version: "v1.0.0", Latest: "v1.1.0"})
+	cache.Store("github.com/acme/db", scancache.Entry{Version: "v1.0.0", Latest: "v1.2.0", LatestTime: "2026-02-01T00:00:00Z"})
+
+	s := NewScanner(tmpDir)
+	s.listAllModules = func(ctx context.Context) ([]byte, error) {
+		t.Fatal("incremental scans must not query every module")
+		return nil, nil
+	}
+	s.listCurrent = func(ctx context.Context) ([]byte, error) {
+		var buf []byte
+		for _, m := range []goModule{
+			{Path: "example.com/foo", Main: true},
+			{Path: "github.com/acme/api", Version: "v1.1.0"},
+			{Path: "github.com/acme/db", Version: "v1.0.0"},
+			{Path: "github.com/acme/util", Version: "v1.0.0"},
+		} {
+			b, _ := json.Marshal(m)
+			buf = append(buf, b...)
+		}
+		return buf, nil
+	}
+	current := map[string]string{"github.com/acme/api": "v1.1.0", "github.com/acme/db": "v1.0.0", "github.com/acme/util": "v1.0.0"}
+	var queried []string
+	s.listModules = func(ctx context.Context, paths []string) ([]byte, error) {
+		queried = append(queried, paths...)
+		var buf []byte
+		for _, p := range paths {
+			b, _ := json.Marshal(goModule{Path: p, Version: current[p], Update: &goModule{Path: p, Version: "v1.3.0"}})
+			buf = append(buf, b...)
+		}
+		return buf, nil
+	}
+
+	modules, err := s.GetUpdates(context.Background(), scanner.Options{Cache: cache})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	// api changed version and util's entry expired; db is reused.
+	if strings.Join(queried, " ") != "github.com/acme/api github.com/acme/util" {
+		t.Fatalf("expected only stale modules to be queried, got %v", queried)
+	}
+	updates := make(map[string]string)
+	for _, m := range modules {
+		updates[m.Name] = m.Update.Version
+	}
+	if len(updates) != 3 || updates["github.com/acme/db"] != "v1.2.0" || updates["github.com/acme/api"] != "v1.3.0" {
+		t.Fatalf("expected cached and queried updates to be merged, got %v", updates)
+	}
+	if e, ok := cache.Lookup("github.com/acme/util", "v1.0.0"); !ok || e.Latest != "v1.3.0" {
+		t.Fatalf("expected the queried result to be cached, got %+v", e)
+	}
+}
+
+func TestGetUpdates_RetractedAndDeprecated(t *testing.T) {
+	tmpDir := t.TempDir()
+	goModContent := `module example.com/foo
+
+require (
+	github.com/acme/old v1.0.0
+	github.com/acme/gone v1.2.0
+	github.com/acme/archived v1.4.0
+)
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	s := NewScanner(tmpDir)
+	s.listAllModules = func(ctx context.Context) ([]byte, error) {
+		return []byte(`{"Path":"example.com/foo","Main":true}
+{"Path":"github.com/acme/old","Version":"v1.0.0","Update":{"Path":"github.com/acme/old","Version":"v1.1.0"},"Deprecated":"use github.com/acme/new"}
+{"Path":"github.com/acme/gone","Version":"v1.2.0","Update":{"Path":"github.com/acme/gone","Version":"v1.2.1"},"Retracted":["published accidentally","breaks builds"]}
+{"Path":"github.com/acme/archived","Version":"v1.4.0","Deprecated":"no longer maintained"}
+`), nil
+	}
+	cache := scancache.Open(t.TempDir(), tmpDir, time.Hour, time.Now)
+
+	// Deprecated modules without an update are only kept on request.
+	modules, err := s.GetUpdates(context.Background(), scanner.Options{KeepDeprecated: true})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(modules) != 3 || modules[2].Name != "github.com/acme/archived" || modules[2].Update != nil || modules[2].Deprecated != "no longer maintained" {
+		t.Fatalf("expected the deprecated module without an update, got %+v", modules)
+	}
+
+	modules, err = s.GetUpdates(context.Background(), scanner.Options{Cache: cache})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(modules) != 2 {
+		t.Fatalf("expected only the outdated modules, got %+v", modules)
+	}
+	got := make(map[string]scanner.Module)
+	for _, m := range modules {
+		got[m.Name] = m
+	}
+	if m := got["github.com/acme/old"]; m.Deprecated != "use github.com/acme/new" || m.Retracted != "" {
+		t.Fatalf("unexpected deprecation: %+v", m)
+	}
+	if m := got["github.com/acme/gone"]; m.Retracted != "published accidentally; breaks builds" || m.Deprecated != "" {
+		t.Fatalf("unexpected retraction: %+v", m)
+	}
+
+	// Incremental scans restore both from the cache.
+	s.listCurrent = func(ctx context.Context) ([]byte, error) {
+		return []byte(`{"Path":"example.com/foo","Main":true}
+{"Path":"github.com/acme/old","Version":"v1.0.0"}
+{"Path":"github.com/acme/gone","Version":"v1.2.0"}
+{"Path":"github.com/acme/archived","Version":"v1.4.0"}
+`), nil
+	}
+	if modules, err = s.GetUpdates(context.Background(), scanner.Options{Cache: cache}); err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	for _, m := range modules {
+		if m.Retracted == "" && m.Deprecated == "" {
+			t.Fatalf("expected cached retraction or deprecation for %s", m.Name)
+		}
+	}
+}
+
+func TestGetUpdates_IncludeTestDeps(t *testing.T) {
+	tmpDir := t.TempDir()
+	goModContent := "module example.com/foo\n\nrequire (\n\tgithub.com/acme/api v1.0.0\n\tgithub.com/stretchr/testify v1.8.0\n)\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	s := NewScanner(tmpDir)
+	s.listAllModules = func(ctx context.Context) ([]byte, error) {
+		var buf []byte
+		for _, p := range []string{"github.com/acme/api", "github.com/stretchr/testify", "github.com/davecgh/go-spew", "golang.org/x/sys"} {
+			b, _ := json.Marshal(goModule{Path: p, Version: "v1.0.0", Update: &goModule{Path: p, Version: "v1.1.0"}})
+			buf = append(buf, b...)
+		}
+		return buf, nil
+	}
+	s.listPackageModules = func(ctx context.Context, test bool) ([]byte, error) {
+		if test {
+			return []byte("example.com/foo\ngithub.com/acme/api\ngithub.com/stretchr/testify\ngithub.com/davecgh/go-spew\n"), nil
+		}
+		return []byte("example.com/foo\ngithub.com/acme/api\n"), nil
+	}
+
+	modules, err := s.GetUpdates(context.Background(), scanner.Options{IncludeTestDeps: true})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, m := range modules {
+		got[m.Name] = m.TestOnly
+		// go-spew is not in go.mod: go list does not mark it indirect,
+		// but it is not a direct dependency either.
+		if m.Name == "github.com/davecgh/go-spew" && (m.Direct || m.DependencyType != "indirect") {
+			t.Fatalf("expected the test-only module missing from go.mod to be indirect, got %+v", m)
+		}
+	}
+	want := map[string]bool{"github.com/acme/api": false, "github.com/stretchr/testify": true, "github.com/davecgh/go-spew": true}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected modules: %v", got)
+	}
+	for name, testOnly := range want {
+		if v, ok := got[name]; !ok || v != testOnly {
+			t.Fatalf("module %s: got testOnly=%v (present %v), want %v", name, v, ok, testOnly)
+		}
+	}
+}
+
+type fakeProxy struct {
+	versions map[string][]string
+	latest   map[string]string
+	times    map[string]string // module@version -> publish time
+	gomods   map[string]string // module@version -> go.mod
+}
+
+func (p fakeProxy) Versions(_ context.Context, path string) ([]string, error) {
+	if strings.HasPrefix(path, "corp.example.com/") {
+		return nil, goproxy.ErrPrivate
+	}
+	if v, ok := p.versions[path]; ok {
+		return v, nil
+	}
+	return nil, errors.New("module proxy returned status 404 for " + path + "@list")
+}
+
+func (p fakeProxy) Latest(_ context.Context, path string) (goproxy.Info, error) {
+	v := p.latest[path]
+	return goproxy.Info{Version: v, Time: p.times[path+"@"+v]}, nil
+}
+
+func (p fakeProxy) Info(_ context.Context, path, version string) (goproxy.Info, error) {
+	return goproxy.Info{Version: version, Time: p.times[path+"@"+version]}, nil
+}
+
+func (p fakeProxy) GoMod(_ context.Context, path, version string) ([]byte, error) {
+	if data, ok := p.gomods[path+"@"+version]; ok {
+		return []byte(data), nil
+	}
+	return nil, errors.New("module proxy returned status 404 for " + path + "@" + version + ".mod")
+}
+
+func TestProxyScanner_GetUpdates(t *testing.T) {
+	tmpDir := t.TempDir()
+	goModContent := `module example.com/foo
+
+require (
+	example.com/direct v1.0.0
+	example.com/indirect v1.0.0 // indirect
+	example.com/current v2.0.0
+	example.com/untagged v0.0.0-20230101000000-aaaaaaaaaaaa
+	example.com/retracted v1.0.0
+	example.com/missing v1.0.0
+	example.com/local v1.0.0
+	corp.example.com/private v1.0.0
+)
+
+replace example.com/local => ../local
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+	proxy := fakeProxy{
+		versions: map[string][]string{
+			"example.com/direct":    {"v1.0.0", "v1.3.0-rc.1", "v1.2.0", "v2.0.0+incompatible"},
+			"example.com/indirect":  {"v1.1.0-beta.1", "v1.0.0-rc.1"},
+			"example.com/current":   {"v1.9.0", "v2.0.0"},
+			"example.com/untagged":  {},
+			"example.com/retracted": {"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0"},
+		},
+		latest: map[string]string{"example.com/untagged": "v0.0.0-20240101000000-bbbbbbbbbbbb"},
+		gomods: map[string]string{
+			"example.com/direct@v1.2.0":    "// Deprecated: use example.com/direct2\nmodule example.com/direct\n",
+			"example.com/current@v2.0.0":   "module example.com/current\n\nretract v2.0.0 // tagged from the wrong branch\n",
+			"example.com/retracted@v1.3.0": "module example.com/retracted\n\nretract (\n\t[v1.2.0, v1.3.0] // broken release\n)\n",
+		},
+		times: map[string]string{
+			"example.com/direct@v1.0.0": "2022-01-01T00:00:00Z",
+			"example.com/direct@v1.2.0": "2023-01-01T00:00:00Z",
+		},
+	}
+	s := NewProxyScanner(tmpDir, proxy)
+
+	var warned []string
+	modules, err := s.GetUpdates(context.Background(), scanner.Options{
+		Skipped:   &scanner.SkipStats{},
+		OnWarning: func(module, message string) { warned = append(warned, module+": "+message) },
+	})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+
+	got := make(map[string]scanner.Module)
+	for _, m := range modules {
+		got[m.Name] = m
+	}
+	want := map[string]string{
+		"example.com/direct":    "v1.2.0",
+		"example.com/indirect":  "v1.1.0-beta.1",
+		"example.com/untagged":  "v0.0.0-20240101000000-bbbbbbbbbbbb",
+		"example.com/retracted": "v1.1.0",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected modules: %+v", modules)
+	}
+	for name, version := range want {
+		if m, ok := got[name]; !ok || m.Update.Version != version {
+			t.Fatalf("%s: expected update to %s, got %+v", name, version, m)
+		}
+	}
+	direct := got["example.com/direct"]
+	if !direct.Direct || direct.Time != "2022-01-01T00:00:00Z" || direct.Update.Time != "2023-01-01T00:00:00Z" || direct.Deprecated != "use example.com/direct2" {
+		t.Fatalf("unexpected direct module: %+v", direct)
+	}
+	if got["example.com/indirect"].DependencyType != "indirect" {
+		t.Fatalf("expected indirect classification, got %+v", got["example.com/indirect"])
+	}
+
+	joined := strings.Join(warned, "\n")
+	if !strings.Contains(joined, "example.com/missing: module proxy returned status 404") || !strings.Contains(joined, "corp.example.com/private: private module") {
+		t.Fatalf("expected warnings for the missing and private modules, got:\n%s", joined)
+	}
+	if strings.Contains(joined, "example.com/local") {
+		t.Fatalf("locally replaced modules should be skipped silently, got:\n%s", joined)
+	}
+
+	warned = nil
+	modules, err = s.GetUpdates(context.Background(), scanner.Options{
+		Modules:   []string{"example.com/direct", "example.com/indirect"},
+		OnWarning: func(module, message string) { warned = append(warned, module+": "+message) },
+	})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(modules) != 2 || modules[0].Name != "example.com/direct" || modules[1].Name != "example.com/indirect" {
+		t.Fatalf("expected only the named modules, got %+v", modules)
+	}
+	if len(warned) != 0 {
+		t.Fatalf("unexpected warnings: %q", warned)
+	}
+
+	modules, err = s.GetUpdates(context.Background(), scanner.Options{Modules: []string{"example.com/current"}, KeepDeprecated: true})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(modules) != 1 || modules[0].Update != nil || modules[0].Retracted != "tagged from the wrong branch" {
+		t.Fatalf("expected the retracted module without an update, got %+v", modules)
+	}
+}
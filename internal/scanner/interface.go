@@ -1,15 +1,21 @@
 // Package scanner provides interfaces and types for dependency scanning across different package managers.
 package scanner
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/scancache"
+)
 
 // Scanner is the interface that all package manager scanners must implement.
 type Scanner interface {
 	// GetUpdates returns all modules that have available updates.
-	GetUpdates(opts Options) ([]Module, error)
+	// Canceling ctx aborts any in-flight package manager commands.
+	GetUpdates(ctx context.Context, opts Options) ([]Module, error)
 
 	// GetDependencyIndex returns a map of package names to their dependency information.
-	GetDependencyIndex() (DependencyIndex, error)
+	GetDependencyIndex(ctx context.Context) (DependencyIndex, error)
 }
 
 // DependencyIndex maps package names to their classification.
@@ -50,10 +56,60 @@ type Module struct {
 	// VulnUpdate holds vulnerability counts for the update version
 	VulnUpdate VulnInfo `json:"-"`
 
+	// VulnReachable holds the vulnerabilities of the current version that
+	// govulncheck found reachable from the project's code; nil when
+	// reachability was not analyzed
+	VulnReachable *VulnInfo `json:"-"`
+
+	// VulnDetails lists the advisories affecting the current version; set
+	// with --vuln-details
+	VulnDetails []VulnDetail `json:"-"`
+
+	// RiskHints holds release note lines between Version and Update that matched risk keywords
+	RiskHints []string `json:"-"`
+
+	// Critical marks modules tagged critical in the project config; they are
+	// only upgraded after interactive confirmation
+	Critical bool `json:"-"`
+
+	// OwnerChange describes a change of owners between Version and Update
+	// ("alice → mallory"); such updates are only applied after review
+	OwnerChange string `json:"-"`
+
+	// Provenance is the result of --verify-provenance for the update:
+	// "verified", "attested", "unattested" or "mismatch"; empty when unchecked
+	Provenance string `json:"-"`
+
+	// LastUpgraded is when the manifest line for this module last changed
+	// (RFC3339), from git history; empty when unknown
+	LastUpgraded string `json:"-"`
+
+	// TestOnly marks Go modules that only the main module's tests need,
+	// directly or through other modules; set with IncludeTestDeps
+	TestOnly bool `json:"testOnly,omitempty"`
+
+	// Tool marks Go modules that provide a tool, through a go.mod tool
+	// directive or a tools.go import; they are listed as their own group
+	Tool bool `json:"tool,omitempty"`
+
+	// Retracted is the rationale given for retracting the current version;
+	// empty when it is not retracted (Go only)
+	Retracted string `json:"retracted,omitempty"`
+
+	// Deprecated is the module's deprecation message; empty when the module
+	// is not deprecated (Go only)
+	Deprecated string `json:"deprecated,omitempty"`
+
+	// Effort is the estimated upgrade effort ("trivial", "small", "medium"
+	// or "large"); empty when not estimated
+	Effort string `json:"-"`
+
+	// Notes are remarks about the module from the project config
+	Notes []string `json:"-"`
+
 	// Legacy fields for backward compatibility with Go scanner
-	Path      string `json:"Path,omitempty"`     // Alias for Name (Go compatibility)
-	Indirect  bool   `json:"Indirect,omitempty"` // Go-specific
-	FromGoMod bool   `json:"-"`                  // Go-specific
+	Path     string `json:"Path,omitempty"`     // Alias for Name (Go compatibility)
+	Indirect bool   `json:"Indirect,omitempty"` // Go-specific
 }
 
 // UpdateInfo contains information about an available update.
@@ -62,6 +118,15 @@ type UpdateInfo struct {
 	Time    string `json:"time,omitempty"`
 }
 
+// VulnDetail is one advisory affecting a module version.
+type VulnDetail struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Severity string   `json:"severity"`        // LOW, MEDIUM, HIGH or CRITICAL
+	Fixed    string   `json:"fixed,omitempty"` // Lowest version fixing it; empty when unknown
+}
+
 // VulnInfo contains vulnerability information for a module version.
 type VulnInfo struct {
 	Low      int `json:"low"`
@@ -76,17 +141,61 @@ type Options struct {
 	// Filter is a substring or regex pattern to filter package names
 	Filter string
 
+	// Modules, when set, restricts the scan to these exact package names
+	Modules []string
+
 	// IncludeAll determines what additional dependencies to include:
 	// - Go: include transitive dependencies not in go.mod
 	// - npm/yarn/pnpm: include devDependencies
 	// - Python: include all dependency groups
 	IncludeAll bool
 
+	// IncludeTestDeps (Go) marks modules that only the main module's tests
+	// need and reports them even when they are not in go.mod
+	IncludeTestDeps bool
+
 	// CooldownDays filters out versions published within the last N days
 	CooldownDays int
 
+	// KeepDeprecated (Go) also returns modules without an update whose
+	// current version is retracted or whose module is deprecated; their
+	// Update is nil
+	KeepDeprecated bool
+
 	// WorkDir is the working directory for the scanner
 	WorkDir string
+
+	// Skipped, when set, receives counts of outdated modules left out of the results
+	Skipped *SkipStats
+
+	// Cache, when set, lets the Go scanner reuse latest versions from
+	// earlier scans and only query modules that are stale or changed
+	Cache *scancache.Cache
+
+	// OnWarning, when set, receives non-fatal problems noticed while scanning.
+	// module is empty for warnings about the project as a whole.
+	OnWarning func(module, message string)
+
+	// OnFailure, when set, receives modules that could not be looked up,
+	// which leaves the results incomplete; unset, they go to OnWarning.
+	OnFailure func(module, message string)
+}
+
+// Fail reports a module that could not be looked up, leaving the results
+// incomplete, through OnFailure, or OnWarning when that is not set.
+func (o Options) Fail(module, message string) {
+	if o.OnFailure != nil {
+		o.OnFailure(module, message)
+		return
+	}
+	o.Warn(module, message)
+}
+
+// Warn reports a non-fatal problem through OnWarning, if set.
+func (o Options) Warn(module, message string) {
+	if o.OnWarning != nil {
+		o.OnWarning(module, message)
+	}
 }
 
 // MaxPathLength calculates the maximum name length for formatting.
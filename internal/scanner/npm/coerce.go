@@ -0,0 +1,182 @@
+package npm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// partialVersion matches a version in an npm range, where trailing parts
+// may be missing or wildcards: "1", "1.x", "1.2.*", "v1.2.3-beta.1+build".
+var partialVersion = regexp.MustCompile(`^v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// hyphenRange matches an inclusive "1.2 - 2.3.4" range.
+var hyphenRange = regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`)
+
+// coerce turns a loose npm version or range into the lowest
+// MAJOR.MINOR.PATCH it allows, like node-semver's minVersion: the
+// workspace: protocol and npm: aliases are dropped, x-ranges and missing
+// parts count as 0, and the pre-release and build of exact versions are
+// kept. Ranges may use the comparators =, <, <=, >, >=, ~ and ^, separated
+// by spaces to intersect them (">=1.2 <2"), hyphen ranges ("1.2 - 2"), and
+// alternatives joined by || ("1.x || 2.x"), of which the lowest wins. An
+// upper bound alone (<2) allows 0.0.0. It reports false when v holds no
+// version, as for "*", dist-tags like "latest", "workspace:^" and git, file
+// or link dependencies.
+func coerce(v string) (string, bool) {
+	v = strings.TrimSpace(v)
+	if rest, ok := strings.CutPrefix(v, "workspace:"); ok {
+		v = rest
+	} else if rest, ok := strings.CutPrefix(v, "npm:"); ok {
+		// npm:name@range, where name may be scoped (@scope/name).
+		i := strings.LastIndex(rest, "@")
+		if i <= 0 {
+			return "", false
+		}
+		v = rest[i+1:]
+	} else if strings.Contains(v, ":") {
+		return "", false
+	}
+
+	var lowest *rangeVersion
+	numbered := false
+	for _, set := range strings.Split(v, "||") {
+		low, ok, hasNumber := minOfSet(strings.TrimSpace(set))
+		if !ok {
+			return "", false
+		}
+		numbered = numbered || hasNumber
+		if low != nil && (lowest == nil || low.less(*lowest)) {
+			lowest = low
+		}
+	}
+	if !numbered || lowest == nil {
+		return "", false
+	}
+	return lowest.String(), true
+}
+
+// rangeVersion is a version a range starts at.
+type rangeVersion struct {
+	major, minor, patch int
+	pre, build          string
+}
+
+func (r rangeVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", r.major, r.minor, r.patch)
+	if r.pre != "" {
+		s += "-" + r.pre
+	}
+	if r.build != "" {
+		s += "+" + r.build
+	}
+	return s
+}
+
+func (r rangeVersion) less(o rangeVersion) bool {
+	cmp, _ := style.ComparePrecedence(r.String(), o.String())
+	return cmp < 0
+}
+
+// minOfSet returns the lowest version a space-separated set of comparators
+// allows, or nil when none does (">*"). ok is false when set does not parse;
+// hasNumber reports whether it names any version number.
+func minOfSet(set string) (low *rangeVersion, ok, hasNumber bool) {
+	if m := hyphenRange.FindStringSubmatch(set); m != nil {
+		// The upper end only has to parse; the lower end is the minimum.
+		if _, _, ok := parseComparator(m[2]); !ok {
+			return nil, false, false
+		}
+		set = m[1]
+	}
+
+	low = &rangeVersion{}
+	var tokens []string
+	for _, field := range strings.Fields(set) {
+		// Join operators written apart from their version: ">= 1.2".
+		if n := len(tokens); n > 0 && strings.Trim(tokens[n-1], "<>=~^") == "" {
+			tokens[n-1] += field
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	for _, token := range tokens {
+		bound, numbered, ok := parseComparator(token)
+		if !ok {
+			return nil, false, false
+		}
+		hasNumber = hasNumber || numbered
+		if bound == nil {
+			return nil, true, hasNumber
+		}
+		if low.less(*bound) {
+			low = bound
+		}
+	}
+	return low, true, hasNumber
+}
+
+// parseComparator returns the lowest version one comparator allows: its
+// version with wildcards as 0 for =, >=, ~ and ^, the next version for >,
+// and 0.0.0 for < and <=. bound is nil when no version satisfies it.
+func parseComparator(c string) (bound *rangeVersion, numbered, ok bool) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", "~>", ">", "<", "=", "~", "^"} {
+		if rest, found := strings.CutPrefix(c, prefix); found {
+			op, c = prefix, rest
+			break
+		}
+	}
+	m := partialVersion.FindStringSubmatch(c)
+	if m == nil {
+		return nil, false, false
+	}
+
+	// Parts after the first missing or wildcard one are wildcards too.
+	var parts [3]int
+	known := 0
+	for _, p := range m[1:4] {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts[known] = n
+		known++
+	}
+	v := rangeVersion{major: parts[0], minor: parts[1], patch: parts[2]}
+	if known == 3 {
+		v.pre, v.build = m[4], m[5]
+	}
+
+	switch op {
+	case "<", "<=":
+		return &rangeVersion{}, known > 0, true
+	case ">":
+		switch {
+		case known == 0:
+			return nil, false, true
+		case known == 1:
+			v = rangeVersion{major: v.major + 1}
+		case known == 2:
+			v = rangeVersion{major: v.major, minor: v.minor + 1}
+		case v.pre != "":
+			v.pre, v.build = v.pre+".0", ""
+		default:
+			v.patch++
+			v.build = ""
+		}
+	}
+	return &v, known > 0, true
+}
+
+// normalizeVersion returns the coerced form of v, or v itself when it holds
+// no version.
+func normalizeVersion(v string) string {
+	if c, ok := coerce(v); ok {
+		return c
+	}
+	return v
+}
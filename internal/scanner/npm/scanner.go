@@ -2,24 +2,25 @@
 package npm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/pragmaticivan/faro/internal/cooldown"
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Scanner implements scanner.Scanner for npm.
 type Scanner struct {
 	workDir          string
-	runNpmOutdated   func() ([]byte, error)
-	fetchPackageTime func(name, version string) (string, error)
+	runNpmOutdated   func(ctx context.Context) ([]byte, error)
+	fetchPackageTime func(ctx context.Context, name, version string) (string, error)
 }
 
 // packageJSON represents the structure of package.json.
@@ -43,21 +44,19 @@ type npmPackageInfo struct {
 func NewScanner(workDir string) *Scanner {
 	s := &Scanner{
 		workDir: workDir,
-		runNpmOutdated: func() ([]byte, error) {
-			cmd := exec.Command("npm", "outdated", "--json")
-			cmd.Dir = workDir
+		runNpmOutdated: func(ctx context.Context) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "npm", "outdated", "--json")
 			// npm outdated returns exit code 1 when there are outdated packages
 			// So we ignore the error and just get the output
 			out, _ := cmd.Output()
 			return out, nil
 		},
 	}
-	s.fetchPackageTime = func(name, version string) (string, error) {
+	s.fetchPackageTime = func(ctx context.Context, name, version string) (string, error) {
 		// npm view package time --json
 		// Note: 'npm view' returns the full time map even if we ask for a specific version,
 		// so we ask for the package time map and extract the specific version.
-		cmd := exec.Command("npm", "view", name, "time", "--json")
-		cmd.Dir = workDir
+		cmd := execx.Command(ctx, workDir, "npm", "view", name, "time", "--json")
 		out, err := cmd.Output()
 		if err != nil {
 			return "", err
@@ -77,7 +76,7 @@ func NewScanner(workDir string) *Scanner {
 }
 
 // GetUpdates returns all npm packages that have available updates.
-func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
+func (s *Scanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
 	// Read package.json to determine dependency types
 	pkgJSON, err := s.readPackageJSON()
 	if err != nil {
@@ -85,7 +84,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 	}
 
 	// Get outdated packages from npm
-	output, err := s.runNpmOutdated()
+	output, err := s.runNpmOutdated(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run npm outdated: %w", err)
 	}
@@ -108,15 +107,27 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 	var candidates []candidate
 
 	for name, info := range outdated {
+		// Determine if it's a direct dependency
+		spec, isDirect := pkgJSON.Dependencies[name]
+		devSpec, isDevDirect := pkgJSON.DevDependencies[name]
+
+		// Loose versions (1.x, workspace:^1.2.0, npm: aliases) are coerced so
+		// they compare and classify like exact ones. A package that is not
+		// installed has no current version; its range in package.json stands in.
+		current := info.Current
+		if current == "" && isDirect {
+			current = spec
+		} else if current == "" {
+			current = devSpec
+		}
+		info.Current = normalizeVersion(current)
+		info.Latest = normalizeVersion(info.Latest)
+
 		// If current version matches latest, it's not an update we care about
 		if info.Current == info.Latest {
 			continue
 		}
 
-		// Determine if it's a direct dependency
-		_, isDirect := pkgJSON.Dependencies[name]
-		_, isDevDirect := pkgJSON.DevDependencies[name]
-
 		depType := info.Type
 		if depType == "" {
 			if isDirect {
@@ -130,11 +141,13 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 
 		// Filter devDependencies if not including all
 		if !opts.IncludeAll && depType == "devDependencies" {
+			opts.Skipped.Add(scanner.SkipHidden)
 			continue
 		}
 
 		// Apply filter
 		if opts.Filter != "" && !strings.Contains(name, opts.Filter) {
+			opts.Skipped.Add(scanner.SkipFiltered)
 			continue
 		}
 
@@ -158,7 +171,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 			var updateTime string
 			// Only fetch time if we have a latest version
 			if c.Info.Latest != "" {
-				t, err := s.fetchPackageTime(c.Name, c.Info.Latest)
+				t, err := s.fetchPackageTime(ctx, c.Name, c.Info.Latest)
 				if err == nil {
 					updateTime = t
 				}
@@ -167,6 +180,9 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 			// Apply cooldown if requested and we have a time
 			if opts.CooldownDays > 0 && updateTime != "" {
 				if !cooldown.Eligible(updateTime, opts.CooldownDays, time.Now()) {
+					mu.Lock()
+					opts.Skipped.Add(scanner.SkipCooldown)
+					mu.Unlock()
 					return
 				}
 			}
@@ -193,7 +209,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 }
 
 // GetDependencyIndex returns a map of npm package names to their dependency information.
-func (s *Scanner) GetDependencyIndex() (scanner.DependencyIndex, error) {
+func (s *Scanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
 	pkgJSON, err := s.readPackageJSON()
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package npm
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
 )
 
 func TestGetUpdates_WithTime(t *testing.T) {
@@ -36,10 +38,10 @@ func TestGetUpdates_WithTime(t *testing.T) {
 		// However, we can mock runNpmOutdated.
 		// For readPackageJSON, we might need to rely on a file or refactor separation.
 		// Wait, NewScanner takes workDir. We can create a temp dir and write package.json there.
-		runNpmOutdated: func() ([]byte, error) {
+		runNpmOutdated: func(ctx context.Context) ([]byte, error) {
 			return outdatedBytes, nil
 		},
-		fetchPackageTime: func(name, version string) (string, error) {
+		fetchPackageTime: func(ctx context.Context, name, version string) (string, error) {
 			if name == "react" && version == "18.2.0" {
 				return "2023-05-01T12:00:00.000Z", nil
 			}
@@ -60,7 +62,7 @@ func TestGetUpdates_WithTime(t *testing.T) {
 		CooldownDays: 0,
 	}
 
-	modules, err := s.GetUpdates(opts)
+	modules, err := s.GetUpdates(context.Background(), opts)
 	if err != nil {
 		t.Fatalf("GetUpdates failed: %v", err)
 	}
@@ -94,10 +96,10 @@ func TestGetUpdates_Cooldown(t *testing.T) {
 	outdatedBytes, _ := json.Marshal(mockOutdated)
 
 	s := &Scanner{
-		runNpmOutdated: func() ([]byte, error) {
+		runNpmOutdated: func(ctx context.Context) ([]byte, error) {
 			return outdatedBytes, nil
 		},
-		fetchPackageTime: func(name, version string) (string, error) {
+		fetchPackageTime: func(ctx context.Context, name, version string) (string, error) {
 			now := time.Now()
 			if name == "fresh-pkg" {
 				return now.Add(-24 * time.Hour).Format(time.RFC3339), nil // 1 day old
@@ -117,7 +119,7 @@ func TestGetUpdates_Cooldown(t *testing.T) {
 
 	// 7 days cooldown
 	opts := scanner.Options{CooldownDays: 7}
-	modules, err := s.GetUpdates(opts)
+	modules, err := s.GetUpdates(context.Background(), opts)
 	if err != nil {
 		t.Fatalf("GetUpdates failed: %v", err)
 	}
@@ -150,10 +152,10 @@ func TestGetUpdates_SkipSameVersion(t *testing.T) {
 	outdatedBytes, _ := json.Marshal(mockOutdated)
 
 	s := &Scanner{
-		runNpmOutdated: func() ([]byte, error) {
+		runNpmOutdated: func(ctx context.Context) ([]byte, error) {
 			return outdatedBytes, nil
 		},
-		fetchPackageTime: func(name, version string) (string, error) {
+		fetchPackageTime: func(ctx context.Context, name, version string) (string, error) {
 			return "", nil
 		},
 	}
@@ -164,7 +166,7 @@ func TestGetUpdates_SkipSameVersion(t *testing.T) {
 		t.Fatalf("failed to write package.json: %v", err)
 	}
 
-	modules, err := s.GetUpdates(scanner.Options{})
+	modules, err := s.GetUpdates(context.Background(), scanner.Options{})
 	if err != nil {
 		t.Fatalf("GetUpdates failed: %v", err)
 	}
@@ -195,3 +197,103 @@ func TestParseNpmViewTime(t *testing.T) {
 		t.Errorf("expected 2026-01-22T10:01:38.294Z, got %s", val)
 	}
 }
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"2.0.0-beta.1", "2.0.0-beta.1", true},
+		{"1.x", "1.0.0", true},
+		{"1.2.X", "1.2.0", true},
+		{"*", "", false},
+		{"~1.2", "1.2.0", true},
+		{"^1.2.3-rc.1", "1.2.3-rc.1", true},
+		{">=1.2.0 <2.0.0", "1.2.0", true},
+		{">=1.2 <2", "1.2.0", true},
+		{">= 1.2.3", "1.2.3", true},
+		{"~1", "1.0.0", true},
+		{"=1.2.3+build.5", "1.2.3+build.5", true},
+		{"1.x || 2.x", "1.0.0", true},
+		{"2.x || ^1.4", "1.4.0", true},
+		{"1.2 - 2.3.4", "1.2.0", true},
+		{">1.2.3", "1.2.4", true},
+		{">1.2", "1.3.0", true},
+		{">1", "2.0.0", true},
+		{">1.2.3-rc.1", "1.2.3-rc.1.0", true},
+		{"<2.0.0", "0.0.0", true},
+		{"latest", "", false},
+		{"user/repo#v1.0.0", "", false},
+		{"^", "", false},
+		{"v3", "3.0.0", true},
+		{"workspace:^1.4.0", "1.4.0", true},
+		{"workspace:^", "", false},
+		{"npm:@scope/pkg@^2.1.0", "2.1.0", true},
+		{"npm:pkg", "", false},
+		{"git+https://github.com/a/b.git#v1.0.0", "", false},
+		{"file:../local", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := coerce(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("coerce(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGetUpdates_LooseVersions(t *testing.T) {
+	pkgJSONBytes, _ := json.Marshal(packageJSON{
+		Dependencies: map[string]string{
+			"ranged":    "1.x",
+			"workspace": "workspace:^1.0.0",
+			"missing":   "^3.1.0",
+			"same":      "workspace:2.0.0",
+		},
+	})
+	outdatedBytes, _ := json.Marshal(npmOutdated{
+		"ranged":    {Current: "1.x", Latest: "2.0.0-beta.1", Type: "dependencies"},
+		"workspace": {Current: "workspace:^1.0.0", Latest: "1.1.0", Type: "dependencies"},
+		"missing":   {Latest: "3.2.0", Type: "dependencies"},
+		"same":      {Current: "workspace:2.0.0", Latest: "2.0.0", Type: "dependencies"},
+	})
+
+	s := &Scanner{
+		workDir: t.TempDir(),
+		runNpmOutdated: func(ctx context.Context) ([]byte, error) {
+			return outdatedBytes, nil
+		},
+		fetchPackageTime: func(ctx context.Context, name, version string) (string, error) {
+			return "", nil
+		},
+	}
+	if err := writePackageJSON(s.workDir, pkgJSONBytes); err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+
+	modules, err := s.GetUpdates(context.Background(), scanner.Options{})
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	got := make(map[string]string)
+	for _, m := range modules {
+		got[m.Name] = m.Version + " " + m.Update.Version
+	}
+	want := map[string]string{
+		"ranged":    "1.0.0 2.0.0-beta.1",
+		"workspace": "1.0.0 1.1.0",
+		"missing":   "3.1.0 3.2.0",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for name, v := range want {
+		if got[name] != v {
+			t.Errorf("%s: expected %q, got %q", name, v, got[name])
+		}
+	}
+	if d := style.GetDiffType("1.0.0", "2.0.0-beta.1"); d != style.DiffMajor {
+		t.Errorf("expected a major diff for 1.x -> 2.0.0-beta.1, got %v", d)
+	}
+}
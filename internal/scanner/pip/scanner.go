@@ -3,20 +3,21 @@ package pip
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Scanner implements scanner.Scanner for pip.
 type Scanner struct {
 	workDir   string
-	runPipCmd func(args ...string) ([]byte, error)
+	runPipCmd func(ctx context.Context, args ...string) ([]byte, error)
 }
 
 // pipOutdated represents the structure of `pip list --outdated --format json` output.
@@ -33,16 +34,15 @@ type pipPackageInfo struct {
 func NewScanner(workDir string) *Scanner {
 	return &Scanner{
 		workDir: workDir,
-		runPipCmd: func(args ...string) ([]byte, error) {
-			cmd := exec.Command("pip", args...)
-			cmd.Dir = workDir
+		runPipCmd: func(ctx context.Context, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "pip", args...)
 			return cmd.Output()
 		},
 	}
 }
 
 // GetUpdates returns all pip packages that have available updates.
-func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
+func (s *Scanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
 	// Read requirements.txt to determine direct dependencies
 	directDeps, err := s.readRequirementsTxt()
 	if err != nil {
@@ -50,7 +50,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 	}
 
 	// Get outdated packages from pip
-	output, err := s.runPipCmd("list", "--outdated", "--format", "json")
+	output, err := s.runPipCmd(ctx, "list", "--outdated", "--format", "json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to run pip list --outdated: %w", err)
 	}
@@ -66,11 +66,13 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 
 		// Filter transitive if not including all
 		if !opts.IncludeAll && !isDirect {
+			opts.Skipped.Add(scanner.SkipHidden)
 			continue
 		}
 
 		// Apply filter
 		if opts.Filter != "" && !strings.Contains(strings.ToLower(info.Name), strings.ToLower(opts.Filter)) {
+			opts.Skipped.Add(scanner.SkipFiltered)
 			continue
 		}
 
@@ -95,7 +97,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 }
 
 // GetDependencyIndex returns a map of pip package names to their dependency information.
-func (s *Scanner) GetDependencyIndex() (scanner.DependencyIndex, error) {
+func (s *Scanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
 	directDeps, err := s.readRequirementsTxt()
 	if err != nil {
 		return nil, err
@@ -2,20 +2,21 @@
 package pnpm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Scanner implements scanner.Scanner for pnpm.
 type Scanner struct {
 	workDir         string
-	runPnpmOutdated func() ([]byte, error)
+	runPnpmOutdated func(ctx context.Context) ([]byte, error)
 }
 
 // pnpmOutdated represents the structure of `pnpm outdated --json` output.
@@ -36,9 +37,8 @@ type packageJSON struct {
 func NewScanner(workDir string) *Scanner {
 	return &Scanner{
 		workDir: workDir,
-		runPnpmOutdated: func() ([]byte, error) {
-			cmd := exec.Command("pnpm", "outdated", "--json")
-			cmd.Dir = workDir
+		runPnpmOutdated: func(ctx context.Context) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "pnpm", "outdated", "--json")
 			out, _ := cmd.Output() // pnpm outdated may return non-zero
 			return out, nil
 		},
@@ -46,13 +46,13 @@ func NewScanner(workDir string) *Scanner {
 }
 
 // GetUpdates returns all pnpm packages that have available updates.
-func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
+func (s *Scanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
 	pkgJSON, err := s.readPackageJSON()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read package.json: %w", err)
 	}
 
-	output, err := s.runPnpmOutdated()
+	output, err := s.runPnpmOutdated(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run pnpm outdated: %w", err)
 	}
@@ -80,11 +80,13 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 
 		// Filter devDependencies if not including all
 		if !opts.IncludeAll && depType == "devDependencies" {
+			opts.Skipped.Add(scanner.SkipHidden)
 			continue
 		}
 
 		// Apply filter
 		if opts.Filter != "" && !strings.Contains(name, opts.Filter) {
+			opts.Skipped.Add(scanner.SkipFiltered)
 			continue
 		}
 
@@ -104,7 +106,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 }
 
 // GetDependencyIndex returns a map of pnpm package names to their dependency information.
-func (s *Scanner) GetDependencyIndex() (scanner.DependencyIndex, error) {
+func (s *Scanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
 	pkgJSON, err := s.readPackageJSON()
 	if err != nil {
 		return nil, err
@@ -2,42 +2,42 @@
 package poetry
 
 import (
+	"context"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Scanner implements scanner.Scanner for Poetry.
 type Scanner struct {
 	workDir      string
-	runPoetryCmd func(args ...string) ([]byte, error)
+	runPoetryCmd func(ctx context.Context, args ...string) ([]byte, error)
 }
 
 // NewScanner creates a new Poetry scanner.
 func NewScanner(workDir string) *Scanner {
 	return &Scanner{
 		workDir: workDir,
-		runPoetryCmd: func(args ...string) ([]byte, error) {
-			cmd := exec.Command("poetry", args...)
-			cmd.Dir = workDir
+		runPoetryCmd: func(ctx context.Context, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "poetry", args...)
 			return cmd.Output()
 		},
 	}
 }
 
 // GetUpdates returns all Poetry packages that have available updates.
-func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
+func (s *Scanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
 	// Read pyproject.toml to determine dependency types
-	depIdx, err := s.GetDependencyIndex()
+	depIdx, err := s.GetDependencyIndex(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Run poetry show --outdated to get updates
-	output, err := s.runPoetryCmd("show", "--outdated")
+	output, err := s.runPoetryCmd(ctx, "show", "--outdated")
 	// If no outdated packages, poetry show --outdated may return error
 	if err != nil {
 		return []scanner.Module{}, nil
@@ -68,16 +68,19 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 
 		// Filter dev dependencies if not including all
 		if !opts.IncludeAll && depInfo.Type == "dev" {
+			opts.Skipped.Add(scanner.SkipHidden)
 			continue
 		}
 
 		// Filter transitive if not including all
 		if !opts.IncludeAll && !depInfo.Direct {
+			opts.Skipped.Add(scanner.SkipHidden)
 			continue
 		}
 
 		// Apply filter
 		if opts.Filter != "" && !strings.Contains(name, opts.Filter) {
+			opts.Skipped.Add(scanner.SkipFiltered)
 			continue
 		}
 
@@ -97,7 +100,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 }
 
 // GetDependencyIndex returns a map of Poetry package names to their dependency information.
-func (s *Scanner) GetDependencyIndex() (scanner.DependencyIndex, error) {
+func (s *Scanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
 	pyproject, err := s.readPyprojectToml()
 	if err != nil {
 		return nil, err
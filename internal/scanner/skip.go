@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SkipReason explains why an outdated module was left out of the results.
+type SkipReason int
+
+const (
+	SkipCooldown         SkipReason = iota // Update is newer than the cooldown window
+	SkipFiltered                           // Name does not match the filter
+	SkipHidden                             // Transitive or dev dependency shown only with --all
+	SkipIncompatibleGo                     // Update requires a newer Go than the project
+	SkipMissingPlatforms                   // Tool release lacks binaries for a required platform
+	SkipNotNewer                           // Suggested update is not newer than the current version
+	SkipChannel                            // No newer version on the module's pinned channel
+	SkipTarget                             // No newer version within --target
+	SkipAsOf                               // No newer version published before --as-of
+	SkipUnsafe                             // Not a patch update free of known vulnerabilities (--only-safe)
+)
+
+// SkipStats counts outdated modules that were not reported, by reason.
+// A nil *SkipStats ignores every Add, so scanners can record unconditionally.
+type SkipStats struct {
+	Cooldown         int `json:"cooldown,omitempty"`
+	Filtered         int `json:"filtered,omitempty"`
+	Hidden           int `json:"hidden,omitempty"`
+	IncompatibleGo   int `json:"incompatibleGo,omitempty"`
+	MissingPlatforms int `json:"missingPlatforms,omitempty"`
+	NotNewer         int `json:"notNewer,omitempty"`
+	Channel          int `json:"channel,omitempty"`
+	Target           int `json:"target,omitempty"`
+	AsOf             int `json:"asOf,omitempty"`
+	Unsafe           int `json:"unsafe,omitempty"`
+}
+
+// Add records one skipped module. It is not safe for concurrent use.
+func (s *SkipStats) Add(reason SkipReason) {
+	if s == nil {
+		return
+	}
+	switch reason {
+	case SkipCooldown:
+		s.Cooldown++
+	case SkipFiltered:
+		s.Filtered++
+	case SkipHidden:
+		s.Hidden++
+	case SkipIncompatibleGo:
+		s.IncompatibleGo++
+	case SkipMissingPlatforms:
+		s.MissingPlatforms++
+	case SkipNotNewer:
+		s.NotNewer++
+	case SkipChannel:
+		s.Channel++
+	case SkipTarget:
+		s.Target++
+	case SkipAsOf:
+		s.AsOf++
+	case SkipUnsafe:
+		s.Unsafe++
+	}
+}
+
+// Total returns the number of skipped modules.
+func (s SkipStats) Total() int {
+	return s.Cooldown + s.Filtered + s.Hidden + s.IncompatibleGo + s.MissingPlatforms + s.NotNewer + s.Channel + s.Target + s.AsOf + s.Unsafe
+}
+
+// String lists the non-zero counts, e.g. "cooldown: 12, filtered: 30".
+func (s SkipStats) String() string {
+	var parts []string
+	for _, c := range []struct {
+		label string
+		n     int
+	}{
+		{"cooldown", s.Cooldown},
+		{"filtered", s.Filtered},
+		{"hidden without --all", s.Hidden},
+		{"incompatible Go", s.IncompatibleGo},
+		{"missing platform binaries", s.MissingPlatforms},
+		{"not newer than current", s.NotNewer},
+		{"up to date on pinned channel", s.Channel},
+		{"beyond --target", s.Target},
+		{"published after --as-of", s.AsOf},
+		{"not safe for --only-safe", s.Unsafe},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", c.label, c.n))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -2,18 +2,19 @@
 package uv
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Scanner implements scanner.Scanner for uv.
 type Scanner struct {
 	workDir  string
-	runUvCmd func(args ...string) ([]byte, error)
+	runUvCmd func(ctx context.Context, args ...string) ([]byte, error)
 }
 
 // uvOutdated represents the structure of `uv pip list --outdated --format json` output.
@@ -29,18 +30,17 @@ type uvPackageInfo struct {
 func NewScanner(workDir string) *Scanner {
 	return &Scanner{
 		workDir: workDir,
-		runUvCmd: func(args ...string) ([]byte, error) {
-			cmd := exec.Command("uv", args...)
-			cmd.Dir = workDir
+		runUvCmd: func(ctx context.Context, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "uv", args...)
 			return cmd.Output()
 		},
 	}
 }
 
 // GetUpdates returns all uv packages that have available updates.
-func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
+func (s *Scanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
 	// Get outdated packages from uv
-	output, err := s.runUvCmd("pip", "list", "--outdated", "--format", "json")
+	output, err := s.runUvCmd(ctx, "pip", "list", "--outdated", "--format", "json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to run uv pip list --outdated: %w", err)
 	}
@@ -54,6 +54,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 	for _, info := range outdated {
 		// Apply filter
 		if opts.Filter != "" && !strings.Contains(strings.ToLower(info.Name), strings.ToLower(opts.Filter)) {
+			opts.Skipped.Add(scanner.SkipFiltered)
 			continue
 		}
 
@@ -73,9 +74,9 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 }
 
 // GetDependencyIndex returns a map of uv package names to their dependency information.
-func (s *Scanner) GetDependencyIndex() (scanner.DependencyIndex, error) {
+func (s *Scanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
 	// uv pip list shows installed packages
-	output, err := s.runUvCmd("pip", "list", "--format", "json")
+	output, err := s.runUvCmd(ctx, "pip", "list", "--format", "json")
 	if err != nil {
 		return nil, err
 	}
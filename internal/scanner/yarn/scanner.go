@@ -2,20 +2,21 @@
 package yarn
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Scanner implements scanner.Scanner for yarn.
 type Scanner struct {
 	workDir         string
-	runYarnOutdated func() ([]byte, error)
+	runYarnOutdated func(ctx context.Context) ([]byte, error)
 }
 
 // yarnOutdated represents the structure of `yarn outdated --json` output.
@@ -33,9 +34,8 @@ type yarnOutdatedTable struct {
 func NewScanner(workDir string) *Scanner {
 	return &Scanner{
 		workDir: workDir,
-		runYarnOutdated: func() ([]byte, error) {
-			cmd := exec.Command("yarn", "outdated", "--json")
-			cmd.Dir = workDir
+		runYarnOutdated: func(ctx context.Context) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "yarn", "outdated", "--json")
 			out, _ := cmd.Output() // yarn outdated may return non-zero
 			return out, nil
 		},
@@ -43,13 +43,13 @@ func NewScanner(workDir string) *Scanner {
 }
 
 // GetUpdates returns all yarn packages that have available updates.
-func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
+func (s *Scanner) GetUpdates(ctx context.Context, opts scanner.Options) ([]scanner.Module, error) {
 	pkgJSON, err := s.readPackageJSON()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read package.json: %w", err)
 	}
 
-	output, err := s.runYarnOutdated()
+	output, err := s.runYarnOutdated(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run yarn outdated: %w", err)
 	}
@@ -91,10 +91,12 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 				}
 
 				if !opts.IncludeAll && depType == "devDependencies" {
+					opts.Skipped.Add(scanner.SkipHidden)
 					continue
 				}
 
 				if opts.Filter != "" && !strings.Contains(name, opts.Filter) {
+					opts.Skipped.Add(scanner.SkipFiltered)
 					continue
 				}
 
@@ -117,7 +119,7 @@ func (s *Scanner) GetUpdates(opts scanner.Options) ([]scanner.Module, error) {
 }
 
 // GetDependencyIndex returns a map of yarn package names to their dependency information.
-func (s *Scanner) GetDependencyIndex() (scanner.DependencyIndex, error) {
+func (s *Scanner) GetDependencyIndex(ctx context.Context) (scanner.DependencyIndex, error) {
 	pkgJSON, err := s.readPackageJSON()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,98 @@
+package style
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// GlyphSet holds the symbols used in terminal output.
+type GlyphSet struct {
+	Arrow      string // Between current and update versions
+	Check      string // Success
+	Cross      string // Failure
+	Selected   string // Selected row in the interactive picker
+	Unselected string // Unselected row in the interactive picker
+	Cursor     string // Current row in the interactive picker
+	Caret      string // End of the filter input in the interactive picker
+	Warning    string // Warning prefix
+	Times      string // Multiplication in score formulas
+	Ellipsis   string // Truncated text
+}
+
+// Built-in glyph sets.
+var (
+	UnicodeGlyphs = GlyphSet{
+		Arrow: "→", Check: "✓", Cross: "✗", Selected: "◉", Unselected: "◯", Cursor: "❯", Caret: "█", Warning: "⚠", Times: "×", Ellipsis: "…",
+	}
+	ASCIIGlyphs = GlyphSet{
+		Arrow: "->", Check: "+", Cross: "x", Selected: "[x]", Unselected: "[ ]", Cursor: ">", Caret: "_", Warning: "!", Times: "x", Ellipsis: "...",
+	}
+)
+
+// Glyphs is the active glyph set.
+var Glyphs = UnicodeGlyphs
+
+// GlyphsEnv selects a glyph set ("unicode" or "ascii") regardless of locale.
+const GlyphsEnv = "FARO_GLYPHS"
+
+// DetectGlyphs picks a glyph set from the environment: GlyphsEnv if set,
+// otherwise ASCII for dumb terminals and non-UTF-8 locales. Windows consoles
+// rarely set a locale, so they get Unicode unless TERM is dumb.
+func DetectGlyphs(getenv func(string) string) GlyphSet {
+	if name := getenv(GlyphsEnv); name != "" {
+		if set, err := NamedGlyphs(name); err == nil {
+			return set
+		}
+	}
+	if getenv("TERM") == "dumb" {
+		return ASCIIGlyphs
+	}
+	locale := ""
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale = getenv(name); locale != "" {
+			break
+		}
+	}
+	if locale == "" && runtime.GOOS == "windows" {
+		return UnicodeGlyphs
+	}
+	lower := strings.ToLower(locale)
+	if strings.Contains(lower, "utf-8") || strings.Contains(lower, "utf8") {
+		return UnicodeGlyphs
+	}
+	return ASCIIGlyphs
+}
+
+// NamedGlyphs returns the built-in set called name ("unicode" or "ascii").
+func NamedGlyphs(name string) (GlyphSet, error) {
+	switch strings.ToLower(name) {
+	case "unicode":
+		return UnicodeGlyphs, nil
+	case "ascii":
+		return ASCIIGlyphs, nil
+	default:
+		return GlyphSet{}, fmt.Errorf("unknown glyph set %q (supported: unicode, ascii)", name)
+	}
+}
+
+// Override returns g with every non-empty field of o applied.
+func (g GlyphSet) Override(o GlyphSet) GlyphSet {
+	for _, f := range []struct{ dst, src *string }{
+		{&g.Arrow, &o.Arrow},
+		{&g.Check, &o.Check},
+		{&g.Cross, &o.Cross},
+		{&g.Selected, &o.Selected},
+		{&g.Unselected, &o.Unselected},
+		{&g.Cursor, &o.Cursor},
+		{&g.Caret, &o.Caret},
+		{&g.Warning, &o.Warning},
+		{&g.Times, &o.Times},
+		{&g.Ellipsis, &o.Ellipsis},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	return g
+}
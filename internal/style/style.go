@@ -69,6 +69,10 @@ func GetDiffType(v1, v2 string) DiffType {
 	if pa1 != pa2 {
 		return DiffPatch
 	}
+	// Python versions may differ past PATCH: 1.2.3.4, 1.2.3.post1.
+	if c, _ := CompareVersions(v1, v2); c != 0 {
+		return DiffPatch
+	}
 	return DiffSame
 }
 
@@ -104,6 +108,25 @@ func parseSemverCore(v string) (major, minor, patch int, ok bool) {
 	return ma, mi, pa, true
 }
 
+// EnableANSI turns on ANSI escape processing for the terminal attached to
+// stdout and returns a function that restores the previous console mode.
+// On consoles that cannot interpret escape sequences (legacy Windows conhost)
+// colors are disabled instead of printing raw escape codes.
+func EnableANSI() func() error {
+	restore, err := termenv.EnableVirtualTerminalProcessing(termenv.DefaultOutput())
+	if err != nil {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return func() error { return nil }
+	}
+	return restore
+}
+
+// ValidVersion reports whether v can be interpreted as a MAJOR.MINOR.PATCH version.
+func ValidVersion(v string) bool {
+	_, _, _, ok := parseSemverCore(v)
+	return ok
+}
+
 func isPseudoVersion(v string) bool {
 	// Go pseudo versions always contain two hyphen-separated suffix segments,
 	// e.g. v1.2.3-20240101000000-abcdef123456.
@@ -139,7 +162,7 @@ func FormatUpdate(path, vOld, vNew string, padPath int) string {
 	return fmt.Sprintf("%s  %s  %s  %s",
 		ColorPath.Render(pPath),
 		vOld,
-		ColorArrow.Render("→"),
+		ColorArrow.Render(Glyphs.Arrow),
 		targetStyle.Render(vNew),
 	)
 }
@@ -208,7 +231,7 @@ func FormatUpdateWithVulns(path, vOld, vNew string, padPath int, vulnCurrent, vu
 		}
 	}
 
-	line += "  " + ColorArrow.Render("→") + "  " + targetStyle.Render(vNew)
+	line += "  " + ColorArrow.Render(Glyphs.Arrow) + "  " + targetStyle.Render(vNew)
 
 	// Add update version vulnerabilities or fixed indicator
 	if showVulns && vulnCurrent.Total > 0 {
@@ -217,7 +240,7 @@ func FormatUpdateWithVulns(path, vOld, vNew string, padPath int, vulnCurrent, vu
 		if fixed > 0 {
 			// Vulnerabilities were fixed
 			if vulnUpdate.Total == 0 {
-				line += " " + green.Render(fmt.Sprintf("✓ (fixes %d)", fixed))
+				line += " " + green.Render(fmt.Sprintf("%s (fixes %d)", Glyphs.Check, fixed))
 			} else {
 				updateVulnStr := FormatVulnInfo(vulnUpdate)
 				if updateVulnStr != "" {
@@ -243,3 +266,139 @@ func FormatUpdateWithVulns(path, vOld, vNew string, padPath int, vulnCurrent, vu
 
 	return line
 }
+
+// CompareVersions compares the release numbers of a and b and returns -1, 0
+// or +1. Beyond MAJOR.MINOR.PATCH every numeric part counts (Python's
+// 1.2.3.4), and a post-release (1.2.3.post1) is newer than its release. ok
+// is false when either version cannot be parsed.
+func CompareVersions(a, b string) (cmp int, ok bool) {
+	na, pa, ok1 := releaseNumbers(a)
+	nb, pb, ok2 := releaseNumbers(b)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	for i := 0; i < len(na) || i < len(nb); i++ {
+		var x, y int
+		if i < len(na) {
+			x = na[i]
+		}
+		if i < len(nb) {
+			y = nb[i]
+		}
+		if x != y {
+			return compareInts(x, y), true
+		}
+	}
+	return compareInts(pa, pb), true
+}
+
+// releaseNumbers returns the dot-separated numbers of v, at least
+// MAJOR.MINOR.PATCH, and its post-release number plus one, or 0 when v is
+// not a post-release. Prerelease and build suffixes are ignored, as are
+// other trailing parts such as Python's .dev1.
+func releaseNumbers(v string) (nums []int, post int, ok bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	for _, part := range strings.Split(v, ".") {
+		if n, err := strconv.Atoi(part); err == nil && n >= 0 {
+			nums = append(nums, n)
+			continue
+		}
+		if len(nums) < 3 {
+			return nil, 0, false
+		}
+		if rest, found := strings.CutPrefix(part, "post"); found {
+			n, err := strconv.Atoi(rest)
+			if rest == "" {
+				n, err = 0, nil
+			}
+			if err == nil && n >= 0 {
+				post = n + 1
+			}
+		}
+		break
+	}
+	if len(nums) < 3 {
+		return nil, 0, false
+	}
+	return nums, post, true
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// ComparePrecedence compares a and b by full semver precedence: the
+// MAJOR.MINOR.PATCH core, then prerelease identifiers, where a release sorts
+// after its prereleases. Build metadata (e.g. +incompatible) is ignored.
+// ok is false when either version cannot be parsed.
+func ComparePrecedence(a, b string) (cmp int, ok bool) {
+	cmp, ok = CompareVersions(a, b)
+	if !ok || cmp != 0 {
+		return cmp, ok
+	}
+	pa, pb := prerelease(a), prerelease(b)
+	switch {
+	case pa == pb:
+		return 0, true
+	case pa == "":
+		return 1, true
+	case pb == "":
+		return -1, true
+	}
+	ia, ib := strings.Split(pa, "."), strings.Split(pb, ".")
+	for k := 0; k < len(ia) && k < len(ib); k++ {
+		if c := compareIdentifier(ia[k], ib[k]); c != 0 {
+			return c, true
+		}
+	}
+	switch {
+	case len(ia) < len(ib):
+		return -1, true
+	case len(ia) > len(ib):
+		return 1, true
+	}
+	return 0, true
+}
+
+// prerelease returns the prerelease part of v without build metadata.
+func prerelease(v string) string {
+	v = strings.TrimSpace(v)
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	if i := strings.Index(v, "-"); i >= 0 {
+		return v[i+1:]
+	}
+	return ""
+}
+
+// compareIdentifier compares prerelease identifiers: numeric ones
+// numerically and below alphanumeric ones, which compare in ASCII order.
+func compareIdentifier(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
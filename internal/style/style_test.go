@@ -3,6 +3,8 @@ package style
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestGetDiffType_Semver(t *testing.T) {
@@ -31,6 +33,19 @@ func TestGetDiffType_NonStandard(t *testing.T) {
 	}
 }
 
+func TestValidVersion(t *testing.T) {
+	for _, v := range []string{"v1.2.3", "1.2.3", "v1.2.3-rc.1", "v0.0.0-20240101000000-abcdef"} {
+		if !ValidVersion(v) {
+			t.Fatalf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []string{"", "latest", "v1.2"} {
+		if ValidVersion(v) {
+			t.Fatalf("expected %q to be invalid", v)
+		}
+	}
+}
+
 func TestFormatUpdate_IncludesPathAndVersions(t *testing.T) {
 	got := FormatUpdate("example.com/mod", "v1.0.0", "v1.0.1", 20)
 	if got == "" {
@@ -51,3 +66,104 @@ func TestGetVersionStyle_DoesNotPanic(t *testing.T) {
 	_ = GetVersionStyle(DiffUnknown)
 	_ = GetVersionStyle(DiffSame)
 }
+
+func TestCompareVersions(t *testing.T) {
+	if c, ok := CompareVersions("v1.2.3", "v1.10.0"); !ok || c != -1 {
+		t.Fatalf("expected v1.2.3 < v1.10.0, got %d %v", c, ok)
+	}
+	if c, ok := CompareVersions("2.0.0", "v1.9.9"); !ok || c != 1 {
+		t.Fatalf("expected 2.0.0 > v1.9.9, got %d %v", c, ok)
+	}
+	if c, ok := CompareVersions("v1.0.0+meta", "v1.0.0"); !ok || c != 0 {
+		t.Fatalf("expected equal cores, got %d %v", c, ok)
+	}
+	if _, ok := CompareVersions("latest", "v1.0.0"); ok {
+		t.Fatalf("expected unparsable version to fail")
+	}
+	for _, c := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3.post1", "1.2.3", 1},
+		{"1.2.3.post2", "1.2.3.post10", -1},
+		{"1.2.3.post", "1.2.3", 1},
+		{"1.2.3.4", "1.2.3.3", 1},
+		{"1.2.3.0", "1.2.3", 0},
+		{"1.2.4", "1.2.3.post1", 1},
+	} {
+		if got, ok := CompareVersions(c.a, c.b); !ok || got != c.want {
+			t.Fatalf("CompareVersions(%q, %q) = %d, %v; want %d", c.a, c.b, got, ok, c.want)
+		}
+	}
+	if GetDiffType("1.2.3", "1.2.3.post1") != DiffPatch {
+		t.Fatalf("expected a post-release to be a patch update")
+	}
+}
+
+func TestComparePrecedence(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-alpha", "v1.0.0-alpha.1", -1},
+		{"v1.0.0-alpha.2", "v1.0.0-alpha.10", -1},
+		{"v1.0.0-2", "v1.0.0-beta", -1},
+		{"v0.0.0-20230101000000-abcdef123456", "v0.0.0-20240101000000-123456abcdef", -1},
+		{"v2.0.0+incompatible", "v2.0.0", 0},
+		{"v1.3.0", "v1.2.9", 1},
+	}
+	for _, c := range cases {
+		if got, ok := ComparePrecedence(c.a, c.b); !ok || got != c.want {
+			t.Fatalf("ComparePrecedence(%q, %q) = %d, %v; want %d", c.a, c.b, got, ok, c.want)
+		}
+	}
+}
+
+func TestDetectGlyphs(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	if got := DetectGlyphs(env(map[string]string{"LANG": "en_US.UTF-8"})); got != UnicodeGlyphs {
+		t.Fatalf("expected unicode for a UTF-8 locale, got %+v", got)
+	}
+	if got := DetectGlyphs(env(map[string]string{"LANG": "en_US.UTF-8", "LC_ALL": "C"})); got != ASCIIGlyphs {
+		t.Fatalf("expected LC_ALL=C to select ascii, got %+v", got)
+	}
+	if got := DetectGlyphs(env(map[string]string{"LANG": "en_US.UTF-8", "TERM": "dumb"})); got != ASCIIGlyphs {
+		t.Fatalf("expected ascii for a dumb terminal, got %+v", got)
+	}
+	if got := DetectGlyphs(env(map[string]string{"LC_ALL": "C", GlyphsEnv: "unicode"})); got != UnicodeGlyphs {
+		t.Fatalf("expected %s to win, got %+v", GlyphsEnv, got)
+	}
+}
+
+func TestGlyphSetOverride(t *testing.T) {
+	g := ASCIIGlyphs.Override(GlyphSet{Arrow: "=>"})
+	if g.Arrow != "=>" || g.Check != ASCIIGlyphs.Check {
+		t.Fatalf("unexpected override result: %+v", g)
+	}
+}
+
+func TestFitLine(t *testing.T) {
+	head := ColorPath.Render("example.com/mod") + "  v1.0.0  ->  v1.1.0"
+	tail := []string{"  [critical]", "  3 months ago"}
+
+	if got := FitLine(head, tail, 0, 4); got != head+"  [critical]  3 months ago" {
+		t.Fatalf("expected one line without a width, got %q", got)
+	}
+
+	got := FitLine(head, tail, 50, 4)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "[critical]") || lines[1] != "    3 months ago" {
+		t.Fatalf("expected overflowing column on an indented line, got %q", got)
+	}
+
+	got = FitLine(head, nil, 25, 4)
+	if w := ansi.StringWidth(got); w != 25 || !strings.HasSuffix(got, Glyphs.Ellipsis) {
+		t.Fatalf("expected truncation to 25 cells with ellipsis, got %d: %q", w, got)
+	}
+	if strings.Count(got, "\x1b[") != strings.Count(head, "\x1b[") {
+		t.Fatalf("expected escape sequences kept intact, got %q", got)
+	}
+}
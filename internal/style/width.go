@@ -0,0 +1,74 @@
+package style
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+)
+
+// minFitWidth is the narrowest width FitLine lays lines out for; below it
+// lines are printed unchanged.
+const minFitWidth = 20
+
+// TerminalWidth returns the column count of the terminal w writes to, or 0
+// when w is not a terminal (e.g. piped to a file or pager). COLUMNS is used
+// when the terminal does not report a size.
+func TerminalWidth(w io.Writer) int {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok || !term.IsTerminal(f.Fd()) {
+		return 0
+	}
+	if width, _, err := term.GetSize(f.Fd()); err == nil && width > 0 {
+		return width
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// FitLine lays out head followed by the optional columns in tail within
+// width display cells. Columns that no longer fit move to continuation lines
+// indented by indent; text wider than a line is truncated with
+// Glyphs.Ellipsis. Escape sequences are never split. Each tail column
+// carries its own leading separator. A width of 0 (no limit) or one too
+// narrow to lay out joins everything on one line.
+func FitLine(head string, tail []string, width, indent int) string {
+	if width < minFitWidth {
+		return head + strings.Join(tail, "")
+	}
+	if indent > width/2 {
+		indent = 2
+	}
+	pad := strings.Repeat(" ", indent)
+
+	line := Truncate(head, width)
+	used := ansi.StringWidth(line)
+	var b strings.Builder
+	for _, col := range tail {
+		w := ansi.StringWidth(col)
+		if used+w <= width {
+			line += col
+			used += w
+			continue
+		}
+		b.WriteString(line + "\n")
+		line = pad + Truncate(strings.TrimLeft(col, " "), width-indent)
+		used = ansi.StringWidth(line)
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// Truncate cuts s to width display cells, ending it with Glyphs.Ellipsis,
+// without splitting escape sequences.
+func Truncate(s string, width int) string {
+	if ansi.StringWidth(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, Glyphs.Ellipsis)
+}
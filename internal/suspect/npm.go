@@ -0,0 +1,158 @@
+package suspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultNPMRegistry is the public npm registry.
+const DefaultNPMRegistry = "https://registry.npmjs.org"
+
+type npmUser struct {
+	Name string `json:"name"`
+}
+
+// packument is the subset of an npm registry document used here.
+type packument struct {
+	Time     map[string]string `json:"time"`
+	Versions map[string]struct {
+		NPMUser     npmUser   `json:"_npmUser"`
+		Maintainers []npmUser `json:"maintainers"`
+	} `json:"versions"`
+}
+
+// NPMRegistry reads package documents from an npm registry, fetching each
+// package at most once.
+type NPMRegistry struct {
+	client *http.Client
+	url    string
+
+	mu   sync.Mutex
+	docs map[string]packument
+}
+
+// NewNPMRegistry returns a client for registry (DefaultNPMRegistry when
+// empty).
+func NewNPMRegistry(client *http.Client, registry string) *NPMRegistry {
+	if registry == "" {
+		registry = DefaultNPMRegistry
+	}
+	return &NPMRegistry{client: client, url: strings.TrimSuffix(registry, "/"), docs: make(map[string]packument)}
+}
+
+// NPMReleases looks up publishers in the npm registry at registry
+// (DefaultNPMRegistry when empty).
+func NPMReleases(client *http.Client, registry string) ReleaseLookup {
+	return NewNPMRegistry(client, registry).Release
+}
+
+// Release returns the publisher details of name@version.
+func (r *NPMRegistry) Release(ctx context.Context, name, version string) (Release, error) {
+	doc, err := r.packument(ctx, name)
+	if err != nil {
+		return Release{}, err
+	}
+	return doc.release(version), nil
+}
+
+// Owners returns the maintainers and publisher of name@version.
+func (r *NPMRegistry) Owners(ctx context.Context, name, version string) ([]string, error) {
+	doc, err := r.packument(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	meta, ok := doc.Versions[version]
+	if !ok {
+		return nil, fmt.Errorf("%s@%s not found in registry", name, version)
+	}
+	seen := make(map[string]bool)
+	var owners []string
+	for _, u := range append(meta.Maintainers, meta.NPMUser) {
+		if u.Name != "" && !seen[u.Name] {
+			seen[u.Name] = true
+			owners = append(owners, u.Name)
+		}
+	}
+	sort.Strings(owners)
+	return owners, nil
+}
+
+// Versions lists the published versions of name, in no particular order.
+func (r *NPMRegistry) Versions(ctx context.Context, name string) ([]string, error) {
+	doc, err := r.packument(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(doc.Versions))
+	for v := range doc.Versions {
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+// PublishTime returns when name@version was published (RFC3339).
+func (r *NPMRegistry) PublishTime(ctx context.Context, name, version string) (string, error) {
+	doc, err := r.packument(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return doc.Time[version], nil
+}
+
+func (r *NPMRegistry) packument(ctx context.Context, name string) (packument, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if doc, ok := r.docs[name]; ok {
+		return doc, nil
+	}
+
+	// Scoped names keep their "@" but escape the slash.
+	u := r.url + "/" + strings.Replace(url.PathEscape(name), "%40", "@", 1)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return packument{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return packument{}, fmt.Errorf("failed to fetch %s: %w", name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return packument{}, fmt.Errorf("failed to fetch %s: %s", name, resp.Status)
+	}
+	var doc packument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return packument{}, fmt.Errorf("failed to parse registry document for %s: %w", name, err)
+	}
+	r.docs[name] = doc
+	return doc, nil
+}
+
+// release finds version's publisher and whether they published any
+// version released before it.
+func (d packument) release(version string) Release {
+	r := Release{Publisher: d.Versions[version].NPMUser.Name}
+	r.Time, _ = time.Parse(time.RFC3339, d.Time[version])
+	if r.Publisher == "" || r.Time.IsZero() {
+		return r
+	}
+	r.FirstTime = true
+	for v, meta := range d.Versions {
+		if v == version || meta.NPMUser.Name != r.Publisher {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, d.Time[v]); err == nil && t.Before(r.Time) {
+			r.FirstTime = false
+			break
+		}
+	}
+	return r
+}
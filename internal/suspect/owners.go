@@ -0,0 +1,38 @@
+package suspect
+
+import (
+	"context"
+	"strings"
+)
+
+// OwnerLookup returns who controls name@version: npm maintainers and
+// publisher, or the repository a Go module version was fetched from.
+type OwnerLookup func(ctx context.Context, name, version string) ([]string, error)
+
+// OwnersChanged reports whether nobody who controlled the old version
+// controls the new one. Unknown owners on either side never count as a
+// change.
+func OwnersChanged(from, to []string) bool {
+	if len(from) == 0 || len(to) == 0 {
+		return false
+	}
+	for _, a := range from {
+		for _, b := range to {
+			if strings.EqualFold(a, b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// NormalizeRepo reduces a repository URL to a comparable form, so
+// "https://github.com/Foo/bar.git" and "https://github.com/foo/bar" match.
+func NormalizeRepo(url string) string {
+	url = strings.ToLower(strings.TrimSpace(url))
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	}
+	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	return url
+}
@@ -0,0 +1,51 @@
+package suspect
+
+// PopularNPM lists widely depended-upon npm packages, the usual targets of
+// typosquatting.
+var PopularNPM = []string{
+	"react", "react-dom", "react-router", "react-router-dom", "redux", "react-redux",
+	"vue", "vue-router", "vuex", "angular", "svelte", "next", "nuxt", "gatsby",
+	"express", "koa", "fastify", "hapi", "body-parser", "cors", "helmet", "morgan",
+	"lodash", "underscore", "ramda", "moment", "dayjs", "date-fns", "luxon",
+	"axios", "node-fetch", "request", "superagent", "got", "cross-fetch",
+	"chalk", "colors", "commander", "yargs", "minimist", "inquirer", "ora", "debug",
+	"dotenv", "cross-env", "nodemon", "concurrently", "rimraf", "mkdirp", "glob",
+	"fs-extra", "graceful-fs", "chokidar", "semver", "uuid", "nanoid", "classnames",
+	"typescript", "ts-node", "tslib", "babel-core", "webpack", "webpack-cli",
+	"webpack-dev-server", "rollup", "vite", "esbuild", "parcel", "browserify",
+	"eslint", "prettier", "jest", "mocha", "chai", "sinon", "jasmine", "karma",
+	"cypress", "puppeteer", "playwright", "supertest", "nyc",
+	"mongoose", "mongodb", "mysql", "mysql2", "pg", "sequelize", "knex", "redis",
+	"ioredis", "sqlite3", "prisma", "typeorm",
+	"jsonwebtoken", "bcrypt", "bcryptjs", "passport", "crypto-js", "node-forge",
+	"socket.io", "ws", "graphql", "apollo-server", "rxjs", "immer", "zod", "yup",
+	"joi", "ajv", "styled-components", "tailwindcss", "postcss", "autoprefixer",
+	"sass", "less", "bootstrap", "jquery", "d3", "three", "chart.js", "electron",
+	"aws-sdk", "firebase", "stripe", "twilio", "nodemailer", "winston", "pino",
+	"bluebird", "async", "event-stream", "coffee-script", "core-js", "regenerator-runtime",
+	"qs", "cookie-parser", "express-session", "multer", "sharp", "jimp", "cheerio",
+	"xml2js", "yaml", "js-yaml", "marked", "handlebars", "ejs", "pug", "mustache",
+}
+
+// PopularGo lists widely used Go modules.
+var PopularGo = []string{
+	"github.com/stretchr/testify", "github.com/sirupsen/logrus", "go.uber.org/zap",
+	"github.com/spf13/cobra", "github.com/spf13/viper", "github.com/spf13/pflag",
+	"github.com/gin-gonic/gin", "github.com/labstack/echo", "github.com/gofiber/fiber",
+	"github.com/gorilla/mux", "github.com/gorilla/websocket", "github.com/go-chi/chi",
+	"github.com/pkg/errors", "github.com/google/uuid", "github.com/google/go-cmp",
+	"github.com/golang/protobuf", "google.golang.org/protobuf", "google.golang.org/grpc",
+	"github.com/prometheus/client_golang", "github.com/go-redis/redis", "github.com/redis/go-redis",
+	"github.com/lib/pq", "github.com/jackc/pgx", "github.com/go-sql-driver/mysql",
+	"github.com/mattn/go-sqlite3", "gorm.io/gorm", "github.com/jmoiron/sqlx",
+	"github.com/aws/aws-sdk-go", "github.com/aws/aws-sdk-go-v2", "cloud.google.com/go",
+	"github.com/golang-jwt/jwt", "github.com/dgrijalva/jwt-go", "golang.org/x/crypto",
+	"golang.org/x/net", "golang.org/x/sys", "golang.org/x/text", "golang.org/x/sync",
+	"golang.org/x/oauth2", "golang.org/x/tools", "golang.org/x/mod", "gopkg.in/yaml.v3",
+	"gopkg.in/yaml.v2", "github.com/BurntSushi/toml", "github.com/urfave/cli",
+	"github.com/rs/zerolog", "github.com/charmbracelet/bubbletea", "github.com/fatih/color",
+	"github.com/hashicorp/go-multierror", "github.com/hashicorp/consul", "github.com/hashicorp/vault",
+	"k8s.io/client-go", "k8s.io/apimachinery", "github.com/docker/docker",
+	"github.com/onsi/ginkgo", "github.com/onsi/gomega", "github.com/golang/mock", "go.uber.org/mock",
+	"github.com/mitchellh/mapstructure", "github.com/json-iterator/go", "github.com/valyala/fasthttp",
+}
@@ -0,0 +1,113 @@
+// Package suspect flags dependencies that look like supply-chain attacks:
+// names one typo away from a popular package, and releases published moments
+// ago by someone who never published the package before.
+package suspect
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+)
+
+// FreshWindow is how recently a release must have been published for its
+// publisher to be checked.
+const FreshWindow = 24 * time.Hour
+
+// Lookalike returns the popular name that name is a likely typo of. Names
+// in popular themselves never match. Plain names of 10 or more characters
+// tolerate two edits, everything else one. Paths (Go modules, scoped npm
+// packages) are only compared with popular paths under a different parent,
+// since one owner's sibling modules (golang.org/x/term and x/text) are not
+// typos of each other.
+func Lookalike(name string, popular []string) (string, bool) {
+	best, bestDist := "", -1
+	for _, p := range popular {
+		if p == name {
+			return "", false
+		}
+		limit := 1
+		if len(p) >= 10 && !strings.Contains(p, "/") {
+			limit = 2
+		}
+		if len(p) < 4 || abs(len(p)-len(name)) > limit {
+			continue
+		}
+		if strings.Contains(p, "/") && path.Dir(p) == path.Dir(name) {
+			continue
+		}
+		if d := Distance(name, p); d <= limit && (bestDist < 0 || d < bestDist) {
+			best, bestDist = p, d
+		}
+	}
+	return best, bestDist > 0
+}
+
+// Distance returns the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and adjacent
+// transpositions turning one into the other.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Release describes who published a version.
+type Release struct {
+	Publisher string
+	Time      time.Time
+	// FirstTime is true when Publisher had not published any earlier
+	// version of the package.
+	FirstTime bool
+}
+
+// ReleaseLookup returns publisher details for name@version.
+type ReleaseLookup func(ctx context.Context, name, version string) (Release, error)
+
+// Fresh reports whether r was published by a first-time publisher within
+// FreshWindow of now.
+func (r Release) Fresh(now time.Time) bool {
+	return r.FirstTime && r.Publisher != "" && !r.Time.IsZero() && now.Sub(r.Time) < FreshWindow
+}
+
+// Allowed reports whether name matches one of the allow-list entries,
+// which are exact names or prefixes ending in "/" or "*".
+func Allowed(name string, allow []string) bool {
+	for _, a := range allow {
+		switch {
+		case a == name:
+			return true
+		case strings.HasSuffix(a, "*") && strings.HasPrefix(name, strings.TrimSuffix(a, "*")):
+			return true
+		case strings.HasSuffix(a, "/") && strings.HasPrefix(name, a):
+			return true
+		}
+	}
+	return false
+}
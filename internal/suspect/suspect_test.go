@@ -0,0 +1,139 @@
+package suspect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDistance(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		want int
+	}{
+		{"react", "react", 0},
+		{"raect", "react", 1},
+		{"reactt", "react", 1},
+		{"expres", "express", 1},
+		{"lodahs", "lodash", 1},
+		{"kitten", "sitting", 3},
+	} {
+		if got := Distance(c.a, c.b); got != c.want {
+			t.Fatalf("Distance(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestLookalike(t *testing.T) {
+	for _, c := range []struct {
+		name, want string
+	}{
+		{"raect", "react"},
+		{"crossenv", "cross-env"},
+		{"electorn", "electron"},
+		{"github.com/sirupsen/logrus", ""},
+		{"github.com/siruspen/logrus", "github.com/sirupsen/logrus"},
+		{"golang.org/x/term", ""}, // Sibling of golang.org/x/text, not a typo
+		{"gopkg.in/yaml.v1", ""},
+		{"react", ""},
+		{"pgx", ""},
+		{"left-pad", ""},
+	} {
+		popular := PopularNPM
+		if len(c.name) > 12 {
+			popular = PopularGo
+		}
+		got, ok := Lookalike(c.name, popular)
+		if got != c.want || ok != (c.want != "") {
+			t.Fatalf("Lookalike(%q) = %q %v, want %q", c.name, got, ok, c.want)
+		}
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	allow := []string{"raect", "@acme/*", "github.com/acme/"}
+	for name, want := range map[string]bool{"raect": true, "@acme/ui": true, "github.com/acme/lib": true, "reactt": false} {
+		if got := Allowed(name, allow); got != want {
+			t.Fatalf("Allowed(%q) = %v", name, got)
+		}
+	}
+}
+
+func TestNPMReleases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/@scope%2Fpkg" {
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+		}
+		_, _ = w.Write([]byte(`{
+			"time": {"1.0.0": "2026-01-01T00:00:00Z", "1.1.0": "2026-02-01T00:00:00Z", "1.2.0": "2026-03-01T10:00:00Z"},
+			"versions": {
+				"1.0.0": {"_npmUser": {"name": "alice"}},
+				"1.1.0": {"_npmUser": {"name": "alice"}},
+				"1.2.0": {"_npmUser": {"name": "mallory"}}
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	lookup := NPMReleases(srv.Client(), srv.URL)
+	r, err := lookup(context.Background(), "@scope/pkg", "1.2.0")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if r.Publisher != "mallory" || !r.FirstTime {
+		t.Fatalf("expected a first-time publisher, got %+v", r)
+	}
+	if !r.Fresh(time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)) || r.Fresh(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the release fresh only within the window")
+	}
+
+	r, err = lookup(context.Background(), "@scope/pkg", "1.1.0")
+	if err != nil || r.FirstTime {
+		t.Fatalf("expected a returning publisher, got %+v %v", r, err)
+	}
+}
+
+func TestNPMRegistryOwners(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{
+			"versions": {
+				"1.0.0": {"_npmUser": {"name": "alice"}, "maintainers": [{"name": "bob"}, {"name": "alice"}]},
+				"2.0.0": {"_npmUser": {"name": "mallory"}, "maintainers": [{"name": "mallory"}]}
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	reg := NewNPMRegistry(srv.Client(), srv.URL)
+	from, err := reg.Owners(context.Background(), "pkg", "1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	to, err := reg.Owners(context.Background(), "pkg", "2.0.0")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if strings.Join(from, ",") != "alice,bob" || strings.Join(to, ",") != "mallory" {
+		t.Fatalf("unexpected owners %v %v", from, to)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the document to be fetched once, got %d", calls)
+	}
+	if !OwnersChanged(from, to) || OwnersChanged(from, []string{"Bob"}) || OwnersChanged(nil, to) {
+		t.Fatalf("unexpected OwnersChanged results")
+	}
+	if _, err := reg.Owners(context.Background(), "pkg", "3.0.0"); err == nil {
+		t.Fatalf("expected an error for an unknown version")
+	}
+}
+
+func TestNormalizeRepo(t *testing.T) {
+	if NormalizeRepo("https://github.com/Foo/bar.git") != NormalizeRepo("https://github.com/foo/bar/") {
+		t.Fatalf("expected URLs to normalize to the same repository")
+	}
+}
@@ -0,0 +1,236 @@
+// Package telemetry records which features faro runs use and how large
+// their scans are, for users who opt in. Events name options and counts
+// only: never paths, module names, hosts or users. They are kept in a
+// local JSON lines file and, in share mode, also posted to an endpoint.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Mode says whether and where events are recorded.
+type Mode string
+
+const (
+	Off   Mode = "off"   // Record nothing (the default)
+	Local Mode = "local" // Append events to the local file only
+	Share Mode = "share" // Also post events to the configured endpoint
+)
+
+// ParseMode validates a mode; empty means Off.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "", Off:
+		return Off, nil
+	case Local, Share:
+		return Mode(s), nil
+	}
+	return "", fmt.Errorf("invalid telemetry mode %q (supported: off, local, share)", s)
+}
+
+// FileName is the file events are appended to in Dir.
+const FileName = "usage.jsonl"
+
+// Event describes one faro run.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Faro       string    `json:"faro"`               // faro version
+	Platform   string    `json:"platform"`           // GOOS/GOARCH
+	CI         bool      `json:"ci,omitempty"`       // Run in a CI pipeline
+	Command    string    `json:"command"`            // "run", "doctor", ...
+	Manager    string    `json:"manager,omitempty"`  // Package manager scanned
+	Features   []string  `json:"features,omitempty"` // Options the run set, by name
+	Modules    int       `json:"modules"`            // Dependencies scanned
+	Updates    int       `json:"updates"`            // Dependencies with an update
+	DurationMS int64     `json:"durationMs"`
+	Failed     bool      `json:"failed,omitempty"`
+}
+
+// Dir returns faro's directory under the user config directory
+// ($XDG_CONFIG_HOME/faro or ~/.config/faro on Linux), or "" when it cannot
+// be determined. Unlike the cache, `faro cache clear` leaves it alone.
+func Dir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "faro")
+}
+
+// Record appends e to FileName in dir, creating dir if needed.
+func Record(dir string, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage event: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, FileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
+	return nil
+}
+
+// Send posts e to endpoint as JSON.
+func Send(ctx context.Context, client *http.Client, endpoint string, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to send usage event: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send usage event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to send usage event: %s returned %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// Read returns the events recorded in dir, oldest first. A missing file
+// yields none; lines that do not parse are skipped.
+func Read(dir string) ([]Event, error) {
+	f, err := os.Open(filepath.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	var events []Event
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e Event
+		if json.Unmarshal(sc.Bytes(), &e) == nil {
+			events = append(events, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage: %w", err)
+	}
+	return events, nil
+}
+
+// Clear removes the events recorded in dir.
+func Clear(dir string) error {
+	if err := os.Remove(filepath.Join(dir, FileName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to clear usage: %w", err)
+	}
+	return nil
+}
+
+// Count is how many runs used a command, manager or feature.
+type Count struct {
+	Name string `json:"name"`
+	Runs int    `json:"runs"`
+}
+
+// Summary aggregates recorded events.
+type Summary struct {
+	Runs     int       `json:"runs"`
+	Failed   int       `json:"failed"`
+	CI       int       `json:"ci"`
+	Since    time.Time `json:"since"`
+	Until    time.Time `json:"until"`
+	Commands []Count   `json:"commands"`
+	Managers []Count   `json:"managers"`
+	Features []Count   `json:"features"`
+	// Scan sizes in dependencies, over the runs that scanned any.
+	ModulesMedian int `json:"modulesMedian"`
+	ModulesP90    int `json:"modulesP90"`
+	ModulesMax    int `json:"modulesMax"`
+	// Median run time in milliseconds.
+	DurationMedianMS int64 `json:"durationMedianMs"`
+}
+
+// Summarize aggregates events. Counts are ordered by runs, most first,
+// then by name.
+func Summarize(events []Event) Summary {
+	s := Summary{Runs: len(events)}
+	commands := make(map[string]int)
+	managers := make(map[string]int)
+	features := make(map[string]int)
+	var sizes []int
+	var durations []int64
+	for i, e := range events {
+		if i == 0 || e.Time.Before(s.Since) {
+			s.Since = e.Time
+		}
+		if e.Time.After(s.Until) {
+			s.Until = e.Time
+		}
+		if e.Failed {
+			s.Failed++
+		}
+		if e.CI {
+			s.CI++
+		}
+		commands[e.Command]++
+		if e.Manager != "" {
+			managers[e.Manager]++
+		}
+		for _, f := range e.Features {
+			features[f]++
+		}
+		if e.Modules > 0 {
+			sizes = append(sizes, e.Modules)
+		}
+		durations = append(durations, e.DurationMS)
+	}
+	s.Commands, s.Managers, s.Features = counts(commands), counts(managers), counts(features)
+	if len(sizes) > 0 {
+		sort.Ints(sizes)
+		s.ModulesMedian = sizes[len(sizes)/2]
+		s.ModulesP90 = sizes[len(sizes)*9/10]
+		s.ModulesMax = sizes[len(sizes)-1]
+	}
+	if len(durations) > 0 {
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		s.DurationMedianMS = durations[len(durations)/2]
+	}
+	return s
+}
+
+func counts(m map[string]int) []Count {
+	out := make([]Count, 0, len(m))
+	for name, runs := range m {
+		out = append(out, Count{name, runs})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Runs != out[j].Runs {
+			return out[i].Runs > out[j].Runs
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
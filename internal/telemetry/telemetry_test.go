@@ -0,0 +1,79 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordAndSummarize(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "faro")
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	for i, e := range []Event{
+		{Command: "run", Manager: "go", Features: []string{"ShowVulnerabilities"}, Modules: 40, Updates: 3, DurationMS: 900},
+		{Command: "run", Manager: "npm", Features: []string{"ShowVulnerabilities", "Upgrade"}, Modules: 200, DurationMS: 2000, CI: true},
+		{Command: "doctor", Manager: "go", Modules: 10, DurationMS: 30000, Failed: true},
+	} {
+		e.Time = start.AddDate(0, 0, i)
+		if err := Record(dir, e); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "other"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := Read(dir)
+	if err != nil || len(events) != 3 {
+		t.Fatalf("Read = %d events, %v", len(events), err)
+	}
+	s := Summarize(events)
+	if s.Runs != 3 || s.Failed != 1 || s.CI != 1 || !s.Since.Equal(start) || !s.Until.Equal(start.AddDate(0, 0, 2)) {
+		t.Fatalf("unexpected totals: %+v", s)
+	}
+	if s.Commands[0] != (Count{"run", 2}) || s.Managers[0] != (Count{"go", 2}) || s.Features[0] != (Count{"ShowVulnerabilities", 2}) {
+		t.Fatalf("unexpected counts: %+v", s)
+	}
+	if s.ModulesMedian != 40 || s.ModulesMax != 200 || s.DurationMedianMS != 2000 {
+		t.Fatalf("unexpected sizes: %+v", s)
+	}
+
+	if err := Clear(dir); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if events, err := Read(dir); err != nil || events != nil {
+		t.Fatalf("expected no events after Clear, got %v, %v", events, err)
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+	}))
+	defer srv.Close()
+	if err := Send(context.Background(), srv.Client(), srv.URL, Event{Command: "run", Modules: 5}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Command != "run" || got.Modules != 5 {
+		t.Fatalf("unexpected event posted: %+v", got)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"": Off, "off": Off, "local": Local, "share": Share} {
+		if got, err := ParseMode(in); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseMode("on"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,131 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultJiraIssueType is the issue type of tickets filed in Jira.
+const DefaultJiraIssueType = "Task"
+
+// Jira files tickets through the Jira REST API (v2).
+type Jira struct {
+	baseURL    string
+	project    string
+	issueType  string
+	user       string // Basic auth user; empty for a bearer token
+	token      string
+	httpClient *http.Client
+}
+
+// NewJira returns a tracker filing issues of issueType (DefaultJiraIssueType
+// when empty) in project at baseURL. With user set the token is sent as an
+// API token with basic auth (Jira Cloud), otherwise as a bearer personal
+// access token (Jira Data Center).
+func NewJira(baseURL, project, issueType, user, token string) *Jira {
+	if issueType == "" {
+		issueType = DefaultJiraIssueType
+	}
+	return &Jira{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		project:    project,
+		issueType:  issueType,
+		user:       user,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Find looks for an unresolved issue in the project titled title.
+func (j *Jira) Find(ctx context.Context, title string) (string, bool, error) {
+	// Quoted summaries are phrase searches; exact titles are compared below.
+	jql := fmt.Sprintf(`project = "%s" AND summary ~ "\"%s\"" AND statusCategory != Done`, jqlEscape(j.project), jqlEscape(title))
+	var result struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string `json:"summary"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	path := "/rest/api/2/search?fields=summary&maxResults=50&jql=" + url.QueryEscape(jql)
+	if err := j.do(ctx, http.MethodGet, path, nil, http.StatusOK, &result); err != nil {
+		return "", false, err
+	}
+	for _, issue := range result.Issues {
+		if issue.Fields.Summary == title {
+			return j.baseURL + "/browse/" + issue.Key, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// Create files t as a new issue.
+func (j *Jira) Create(ctx context.Context, t Ticket) (string, error) {
+	fields := map[string]any{
+		"project":     map[string]string{"key": j.project},
+		"summary":     t.Title,
+		"description": t.Body,
+		"issuetype":   map[string]string{"name": j.issueType},
+	}
+	if len(t.Labels) > 0 {
+		fields["labels"] = t.Labels
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := j.do(ctx, http.MethodPost, "/rest/api/2/issue", map[string]any{"fields": fields}, http.StatusCreated, &created); err != nil {
+		return "", err
+	}
+	return j.baseURL + "/browse/" + created.Key, nil
+}
+
+func (j *Jira) do(ctx context.Context, method, path string, in any, wantStatus int, out any) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, j.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case j.user != "":
+		req.SetBasicAuth(j.user, j.token)
+	case j.token != "":
+		req.Header.Set("Authorization", "Bearer "+j.token)
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query Jira: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != wantStatus {
+		return fmt.Errorf("jira returned status %d for %s %s", resp.StatusCode, method, strings.SplitN(path, "?", 2)[0])
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Jira response: %w", err)
+	}
+	return nil
+}
+
+// jqlEscape escapes s for use inside a double-quoted JQL string.
+func jqlEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,129 @@
+// Package tracker files issue tracker tickets (GitHub Issues or Jira) for
+// pending dependency upgrades.
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/github"
+	"github.com/pragmaticivan/faro/internal/report"
+)
+
+// DefaultTitle is the ticket title template.
+const DefaultTitle = `Upgrade {{.Module}} to {{.Target}}`
+
+// DefaultBody is the ticket body template: the upgrade, risk notes and the
+// release notes published between the current and target versions.
+const DefaultBody = `{{.Module}} has a new major version: {{.Current}} → {{.Target}} ({{.Manager}}{{if .Project}}, {{.Project}}{{end}}).
+{{if .Risks}}
+## Risk notes
+
+{{range .Risks}}- {{.}}
+{{end}}{{end}}{{if .Releases}}
+## Release notes
+{{range .Releases}}
+### {{.Tag}}
+
+{{.Notes}}
+{{end}}{{end}}`
+
+// Ticket is a tracker work item.
+type Ticket struct {
+	Title  string
+	Body   string
+	Labels []string
+}
+
+// Tracker finds and files tickets.
+type Tracker interface {
+	// Find returns the URL of an open ticket titled title, if there is one.
+	Find(ctx context.Context, title string) (string, bool, error)
+	// Create files t and returns its URL.
+	Create(ctx context.Context, t Ticket) (string, error)
+}
+
+// Release is a release note section in a ticket.
+type Release struct {
+	Tag   string
+	Notes string
+}
+
+// Data is the value passed to the title and body templates.
+type Data struct {
+	Module   string
+	Current  string
+	Target   string
+	Manager  string
+	Project  string // Project directory name
+	Risks    []string
+	Releases []Release
+}
+
+// Render executes the title and body templates (DefaultTitle and
+// DefaultBody when empty) with data.
+func Render(title, body string, data Data) (Ticket, error) {
+	if title == "" {
+		title = DefaultTitle
+	}
+	if body == "" {
+		body = DefaultBody
+	}
+	var t Ticket
+	for _, part := range []struct {
+		name, text string
+		out        *string
+	}{{"ticket title", title, &t.Title}, {"ticket body", body, &t.Body}} {
+		tmpl, err := report.Parse(part.name, part.text)
+		if err != nil {
+			return t, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return t, fmt.Errorf("failed to render %s template: %w", part.name, err)
+		}
+		*part.out = strings.TrimSpace(buf.String())
+	}
+	if t.Title == "" {
+		return t, fmt.Errorf("ticket title template rendered an empty title")
+	}
+	return t, nil
+}
+
+// GitHubIssues files tickets as issues of one repository.
+type GitHubIssues struct {
+	client      *github.Client
+	owner, repo string
+	labels      []string
+}
+
+// NewGitHubIssues returns a tracker for owner/repo. Existing tickets are
+// looked up among open issues carrying labels.
+func NewGitHubIssues(client *github.Client, owner, repo string, labels []string) *GitHubIssues {
+	return &GitHubIssues{client: client, owner: owner, repo: repo, labels: labels}
+}
+
+// Find looks for an open issue titled title.
+func (g *GitHubIssues) Find(ctx context.Context, title string) (string, bool, error) {
+	issues, err := g.client.OpenIssues(ctx, g.owner, g.repo, g.labels)
+	if err != nil {
+		return "", false, err
+	}
+	for _, issue := range issues {
+		if issue.Title == title {
+			return issue.HTMLURL, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// Create opens an issue for t.
+func (g *GitHubIssues) Create(ctx context.Context, t Ticket) (string, error) {
+	issue, err := g.client.CreateIssue(ctx, g.owner, g.repo, github.NewIssue{Title: t.Title, Body: t.Body, Labels: t.Labels})
+	if err != nil {
+		return "", err
+	}
+	return issue.HTMLURL, nil
+}
@@ -0,0 +1,109 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/github"
+)
+
+func TestRender_Default(t *testing.T) {
+	ticket, err := Render("", "", Data{
+		Module:   "github.com/acme/lib",
+		Current:  "v1.4.0",
+		Target:   "v2.0.0",
+		Manager:  "go",
+		Risks:    []string{"v2.0.0: BREAKING: Client.Do removed"},
+		Releases: []Release{{Tag: "v2.0.0", Notes: "Client.Do removed"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if ticket.Title != "Upgrade github.com/acme/lib to v2.0.0" {
+		t.Fatalf("unexpected title %q", ticket.Title)
+	}
+	for _, want := range []string{"v1.4.0 → v2.0.0", "## Risk notes", "- v2.0.0: BREAKING", "### v2.0.0"} {
+		if !strings.Contains(ticket.Body, want) {
+			t.Fatalf("expected body to contain %q, got:\n%s", want, ticket.Body)
+		}
+	}
+
+	if _, err := Render("{{.Missing", "", Data{}); err == nil {
+		t.Fatalf("expected an error for an invalid template")
+	}
+}
+
+func TestGitHubIssues(t *testing.T) {
+	var created github.NewIssue
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("labels") != "dependencies" {
+				t.Errorf("expected the label filter, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"number": 1, "title": "Upgrade a to v2.0.0", "html_url": "https://github.com/acme/api/issues/1"},
+				{"number": 2, "title": "Upgrade b to v3.0.0", "html_url": "https://github.com/acme/api/pull/2", "pull_request": {}}]`))
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number": 3, "html_url": "https://github.com/acme/api/issues/3"}`))
+		}
+	}))
+	defer srv.Close()
+
+	g := NewGitHubIssues(github.NewClientWithBaseURL(srv.URL, "t"), "acme", "api", []string{"dependencies"})
+	if u, ok, err := g.Find(context.Background(), "Upgrade a to v2.0.0"); err != nil || !ok || !strings.HasSuffix(u, "/issues/1") {
+		t.Fatalf("expected the open issue, got %q %v %v", u, ok, err)
+	}
+	if _, ok, _ := g.Find(context.Background(), "Upgrade b to v3.0.0"); ok {
+		t.Fatalf("expected pull requests to be ignored")
+	}
+	u, err := g.Create(context.Background(), Ticket{Title: "Upgrade c to v2.0.0", Body: "body", Labels: []string{"dependencies"}})
+	if err != nil || !strings.HasSuffix(u, "/issues/3") {
+		t.Fatalf("unexpected create result %q %v", u, err)
+	}
+	if created.Title != "Upgrade c to v2.0.0" || len(created.Labels) != 1 {
+		t.Fatalf("unexpected issue payload %+v", created)
+	}
+}
+
+func TestJira(t *testing.T) {
+	var fields map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "bot@example.com" || token != "secret" {
+			t.Errorf("expected basic auth, got %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			if jql := r.URL.Query().Get("jql"); !strings.Contains(jql, `project = "OPS"`) || !strings.Contains(jql, `summary ~ "\"Upgrade a to v2.0.0\""`) {
+				t.Errorf("unexpected jql %s", jql)
+			}
+			_, _ = w.Write([]byte(`{"issues": [{"key": "OPS-7", "fields": {"summary": "Upgrade a to v2.0.0"}}]}`))
+		case "/rest/api/2/issue":
+			var body struct {
+				Fields map[string]any `json:"fields"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			fields = body.Fields
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"key": "OPS-8"}`))
+		}
+	}))
+	defer srv.Close()
+
+	j := NewJira(srv.URL+"/", "OPS", "", "bot@example.com", "secret")
+	if u, ok, err := j.Find(context.Background(), "Upgrade a to v2.0.0"); err != nil || !ok || u != srv.URL+"/browse/OPS-7" {
+		t.Fatalf("expected the open issue, got %q %v %v", u, ok, err)
+	}
+	u, err := j.Create(context.Background(), Ticket{Title: "Upgrade b to v2.0.0", Body: "body"})
+	if err != nil || u != srv.URL+"/browse/OPS-8" {
+		t.Fatalf("unexpected create result %q %v", u, err)
+	}
+	if fields["summary"] != "Upgrade b to v2.0.0" || fields["issuetype"].(map[string]any)["name"] != DefaultJiraIssueType {
+		t.Fatalf("unexpected issue fields %v", fields)
+	}
+}
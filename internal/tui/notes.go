@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/changelog"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// notesMaxLines bounds the release note lines shown in the detail pane.
+const notesMaxLines = 15
+
+// notes is the state of one module's release notes: loading until loaded.
+type notes struct {
+	loaded   bool
+	releases []changelog.Release // Releases between the current and proposed version
+	err      error
+}
+
+// notesMsg delivers the release notes fetched for the module update key.
+type notesMsg struct {
+	key   string
+	notes notes
+}
+
+// notesKey identifies a module update in the notes cache.
+func notesKey(c scanner.Module) string {
+	return choiceName(c) + "@" + c.Version + ".." + c.Update.Version
+}
+
+// fetchNotes returns a command that loads the release notes of the choice
+// under the cursor while the pane is open, or nil when they are already
+// loaded or loading.
+func (m model) fetchNotes() tea.Cmd {
+	if !m.showNotes || m.opts.ReleaseNotes == nil || m.cursor < 0 || m.cursor >= len(m.choices) || !m.visible(m.cursor) {
+		return nil
+	}
+	c := m.choices[m.cursor]
+	key := notesKey(c)
+	if _, ok := m.notes[key]; ok {
+		return nil
+	}
+	m.notes[key] = notes{}
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	source := m.opts.ReleaseNotes
+	return func() tea.Msg {
+		releases, err := source.Releases(ctx, choiceName(c))
+		return notesMsg{key: key, notes: notes{loaded: true, releases: changelog.Between(releases, c.Version, c.Update.Version), err: err}}
+	}
+}
+
+// notesView renders the release notes pane for the choice under the cursor.
+func (m model) notesView() string {
+	if m.cursor < 0 || m.cursor >= len(m.choices) || !m.visible(m.cursor) {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	bold := lipgloss.NewStyle().Bold(true)
+	c := m.choices[m.cursor]
+
+	s := "\n" + m.fit(bold.Render(fmt.Sprintf("Release notes: %s %s %s %s", choiceName(c), c.Version, style.Glyphs.Arrow, c.Update.Version))) + "\n"
+	n := m.notes[notesKey(c)]
+	switch {
+	case !n.loaded:
+		return s + dim.Render("Loading...") + "\n"
+	case errors.Is(n.err, changelog.ErrUnsupported):
+		return s + dim.Render("No GitHub or GitLab releases found for this module.") + "\n"
+	case n.err != nil:
+		return s + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(fmt.Sprintf("Could not load release notes: %v", n.err)) + "\n"
+	case len(n.releases) == 0:
+		return s + dim.Render(fmt.Sprintf("No releases published between %s and %s.", c.Version, c.Update.Version)) + "\n"
+	}
+
+	var lines []string
+	for _, r := range n.releases {
+		lines = append(lines, bold.Render(r.Tag))
+		for _, line := range strings.Split(strings.TrimSpace(r.Body), "\n") {
+			if line = strings.TrimRight(line, " \t\r"); line != "" {
+				lines = append(lines, "  "+line)
+			}
+		}
+	}
+	for i, line := range lines {
+		if i == notesMaxLines {
+			s += dim.Render(fmt.Sprintf("(%d more lines)", len(lines)-i)) + "\n"
+			break
+		}
+		s += m.fit(line) + "\n"
+	}
+	return s
+}
+
+// choiceName returns the module name, falling back to the Go path.
+func choiceName(c scanner.Module) string {
+	if c.Name == "" {
+		return c.Path
+	}
+	return c.Name
+}
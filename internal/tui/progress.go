@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Step is the state of one module in a long-running, per-module operation
+// such as upgrading, building and testing each update in turn.
+type Step int
+
+const (
+	StepPending Step = iota
+	StepUpgrading
+	StepBuilding
+	StepTesting
+	StepOK
+	StepReverted
+	StepFailed
+)
+
+func (s Step) String() string {
+	switch s {
+	case StepUpgrading:
+		return "upgrading"
+	case StepBuilding:
+		return "building"
+	case StepTesting:
+		return "testing"
+	case StepOK:
+		return "ok"
+	case StepReverted:
+		return "reverted"
+	case StepFailed:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// Done reports whether s is a final state.
+func (s Step) Done() bool {
+	return s >= StepOK
+}
+
+// Reporter moves module to step; detail is an optional short note such as
+// the failing command.
+type Reporter func(module string, step Step, detail string)
+
+// ProgressMsg carries a Reporter call to the progress screen.
+type ProgressMsg struct {
+	Module string
+	Step   Step
+	Detail string
+}
+
+type progressDoneMsg struct{ err error }
+
+type progressTickMsg time.Time
+
+type progressRow struct {
+	step    Step
+	detail  string
+	started time.Time
+	elapsed time.Duration
+}
+
+type progressModel struct {
+	title    string
+	modules  []string
+	rows     map[string]*progressRow
+	now      func() time.Time
+	finished bool
+	quitting bool
+}
+
+func newProgressModel(title string, modules []string, now func() time.Time) progressModel {
+	rows := make(map[string]*progressRow, len(modules))
+	for _, m := range modules {
+		rows[m] = &progressRow{}
+	}
+	return progressModel{title: title, modules: modules, rows: rows, now: now}
+}
+
+func progressTick() tea.Cmd {
+	return tea.Tick(250*time.Millisecond, func(t time.Time) tea.Msg { return progressTickMsg(t) })
+}
+
+func (m progressModel) Init() tea.Cmd {
+	return progressTick()
+}
+
+func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+	case ProgressMsg:
+		row, ok := m.rows[msg.Module]
+		if !ok {
+			row = &progressRow{}
+			m.rows[msg.Module] = row
+			m.modules = append(m.modules, msg.Module)
+		}
+		now := m.now()
+		if row.started.IsZero() && msg.Step != StepPending {
+			row.started = now
+		}
+		row.step, row.detail = msg.Step, msg.Detail
+		if !row.started.IsZero() {
+			row.elapsed = now.Sub(row.started)
+		}
+	case progressDoneMsg:
+		m.finished = true
+		return m, tea.Quit
+	case progressTickMsg:
+		now := m.now()
+		for _, row := range m.rows {
+			if !row.started.IsZero() && !row.step.Done() {
+				row.elapsed = now.Sub(row.started)
+			}
+		}
+		return m, progressTick()
+	}
+	return m, nil
+}
+
+func (m progressModel) View() string {
+	if m.finished || m.quitting {
+		return ""
+	}
+	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	s := heading.Render(m.title) + "\n\n" + m.table() + "\nPress <q> to stop.\n"
+	return s
+}
+
+// table renders one line per module with its step and elapsed time.
+func (m progressModel) table() string {
+	width := 0
+	for _, name := range m.modules {
+		width = max(width, len(name))
+	}
+	var b strings.Builder
+	for _, name := range m.modules {
+		row := m.rows[name]
+		elapsed := ""
+		if !row.started.IsZero() {
+			elapsed = row.elapsed.Round(100 * time.Millisecond).String()
+		}
+		line := fmt.Sprintf(" %-*s  %s  %8s", width, name, stepStyle(row.step).Render(fmt.Sprintf("%-9s", row.step)), elapsed)
+		if row.detail != "" {
+			line += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(row.detail)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// summary renders the final table and counts by outcome.
+func (m progressModel) summary() string {
+	counts := make(map[Step]int)
+	for _, name := range m.modules {
+		counts[m.rows[name].step]++
+	}
+	var parts []string
+	for _, s := range []Step{StepOK, StepReverted, StepFailed, StepPending} {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], s))
+		}
+	}
+	return m.table() + "\n" + strings.Join(parts, ", ") + "\n"
+}
+
+func stepStyle(s Step) lipgloss.Style {
+	st := lipgloss.NewStyle()
+	switch s {
+	case StepOK:
+		return st.Foreground(lipgloss.Color("10"))
+	case StepReverted:
+		return st.Foreground(lipgloss.Color("214"))
+	case StepFailed:
+		return st.Foreground(lipgloss.Color("9"))
+	case StepPending:
+		return st.Foreground(lipgloss.Color("240"))
+	default:
+		return st.Foreground(lipgloss.Color("6"))
+	}
+}
+
+// RunProgress shows a live status table for modules while work runs and
+// prints a summary table once it finishes. Pressing q cancels the context
+// passed to work. It returns work's error.
+func RunProgress(ctx context.Context, title string, modules []string, work func(ctx context.Context, report Reporter) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	p := tea.NewProgram(newProgressModel(title, modules, time.Now), tea.WithContext(ctx))
+	workErr := make(chan error, 1)
+	go func() {
+		err := work(ctx, func(module string, step Step, detail string) {
+			p.Send(ProgressMsg{Module: module, Step: step, Detail: detail})
+		})
+		workErr <- err
+		p.Send(progressDoneMsg{err: err})
+	}()
+
+	final, runErr := p.Run()
+	cancel()
+	err := <-workErr
+	if m, ok := final.(progressModel); ok {
+		_, _ = fmt.Fprint(os.Stdout, m.summary())
+	}
+	if err == nil && runErr != nil && ctx.Err() == nil {
+		err = runErr
+	}
+	return err
+}
+
+// PlainReporter writes one line per step change to w, for non-interactive
+// output such as CI logs.
+func PlainReporter(w io.Writer) Reporter {
+	return func(module string, step Step, detail string) {
+		if detail != "" {
+			_, _ = fmt.Fprintf(w, "%s: %s (%s)\n", module, step, detail)
+			return
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s\n", module, step)
+	}
+}
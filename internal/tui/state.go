@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// state is what the picker remembers about a project between runs.
+type state struct {
+	Cursor    string   `json:"cursor,omitempty"`    // Module under the cursor
+	Filter    string   `json:"filter,omitempty"`    // Filter typed after </>
+	Collapsed []string `json:"collapsed,omitempty"` // Collapsed sections, by sectionNames
+}
+
+// StatePath returns the file under cacheDir that remembers the picker state
+// of project (its directory), or "" when cacheDir is empty.
+func StatePath(cacheDir, project string) string {
+	if cacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(project))
+	return filepath.Join(cacheDir, "picker", hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadState reads the state saved at path. A missing or corrupt file yields
+// the zero state.
+func loadState(path string) state {
+	var s state
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &s)
+	}
+	return s
+}
+
+// saveState writes s to path, creating its directory.
+func saveState(path string, s state) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode picker state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to save picker state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save picker state: %w", err)
+	}
+	return nil
+}
+
+// state captures the parts of m worth restoring in the next run.
+func (m model) state() state {
+	s := state{Filter: m.filter}
+	if m.cursor >= 0 && m.cursor < len(m.choices) {
+		s.Cursor = choiceName(m.choices[m.cursor])
+	}
+	for sec, collapsed := range m.collapsed {
+		if collapsed {
+			s.Collapsed = append(s.Collapsed, sectionNames[sec])
+		}
+	}
+	return s
+}
+
+// restore applies a saved state. The cursor returns to the remembered
+// module when it is still listed.
+func (m *model) restore(s state) {
+	for sec, name := range sectionNames {
+		m.collapsed[sec] = slices.Contains(s.Collapsed, name)
+	}
+	for i, c := range m.choices {
+		if choiceName(c) == s.Cursor {
+			m.cursor = i
+			break
+		}
+	}
+	m.setFilter(s.Filter)
+}
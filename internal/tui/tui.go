@@ -2,21 +2,27 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/pragmaticivan/faro/internal/align"
+	"github.com/pragmaticivan/faro/internal/changelog"
 	"github.com/pragmaticivan/faro/internal/format"
 	"github.com/pragmaticivan/faro/internal/scanner"
 	"github.com/pragmaticivan/faro/internal/style"
 	"github.com/pragmaticivan/faro/internal/updater"
 )
 
-var runProgram = func(m tea.Model) (tea.Model, error) {
-	p := tea.NewProgram(m)
+var runProgram = func(ctx context.Context, m tea.Model) (tea.Model, error) {
+	p := tea.NewProgram(m, tea.WithContext(ctx))
 	return p.Run()
 }
 
@@ -24,62 +30,73 @@ var runProgram = func(m tea.Model) (tea.Model, error) {
 type Options struct {
 	FormatGroup     bool
 	FormatTime      bool
-	Updater         updater.Updater // The updater instance to use for applying updates
-	DirectLabel     string          // Label for direct dependencies
-	IndirectLabel   string          // Label for indirect/dev dependencies
-	TransitiveLabel string          // Label for transitive dependencies
+	TimeStyle       format.TimeStyle // How publish times are rendered
+	Updater         updater.Updater  // The updater instance to use for applying updates
+	DirectLabel     string           // Label for direct dependencies
+	IndirectLabel   string           // Label for indirect/dev dependencies
+	TransitiveLabel string           // Label for transitive dependencies
+	Tools           []scanner.Module // Tool dependencies, listed in their own section before transitive ones
+	ToolsLabel      string           // Label for tool dependencies
+	ReleaseNotes    changelog.Source // Source for the <n> release notes pane; nil disables it
+	VulnDetails     bool             // Enables the <v> pane listing the advisories of the choice under the cursor
+	StatePath       string           // File remembering the cursor, filter and collapsed sections between runs; "" disables it
+	Project         string           // Project heading shown above the list in --recursive runs; "" shows none
+	Ecosystem       string           // Registry of the choices ("go", "npm", "pypi"); scopes the family alignment hints
 }
 
 type model struct {
-	choices  []scanner.Module
-	selected map[int]struct{}
-	cursor   int
-	quitting bool
+	choices    []scanner.Module
+	selected   map[int]struct{}
+	cursor     int
+	quitting   bool
+	confirming bool // Showing the confirmation screen for the current selection
+	filtering  bool // Typing a filter after </>
+	filter     string
+	filterRe   *regexp.Regexp // filter compiled as a case-insensitive regex; nil when it is not one
+	showNotes  bool           // Showing the release notes pane for the choice under the cursor
+	showVulns  bool           // Showing the advisories pane for the choice under the cursor
+	notes      map[string]notes
+	ctx        context.Context // Bounds release note requests; nil means context.Background()
 
 	directEnd    int
 	indirectEnd  int
 	transitiveOn bool
+	toolsEnd     int
+	collapsed    [4]bool // Sections folded with <c>, by section index
+	width        int     // Terminal width; rows are truncated to fit (0 = unknown)
 
 	opts Options
 }
 
 func initialModel(direct, indirect, transitive []scanner.Module, opts Options) model {
 	if opts.FormatGroup {
-		sort.Slice(direct, func(i, j int) bool {
-			ai, aj := format.GroupSortKey(direct[i]), format.GroupSortKey(direct[j])
-			if ai != aj {
-				return ai < aj
-			}
-			return direct[i].Path < direct[j].Path
-		})
-		sort.Slice(indirect, func(i, j int) bool {
-			ai, aj := format.GroupSortKey(indirect[i]), format.GroupSortKey(indirect[j])
-			if ai != aj {
-				return ai < aj
-			}
-			return indirect[i].Path < indirect[j].Path
-		})
-		sort.Slice(transitive, func(i, j int) bool {
-			ai, aj := format.GroupSortKey(transitive[i]), format.GroupSortKey(transitive[j])
-			if ai != aj {
-				return ai < aj
-			}
-			return transitive[i].Path < transitive[j].Path
-		})
-	}
-
-	choices := make([]scanner.Module, 0, len(direct)+len(indirect)+len(transitive))
+		for _, mods := range [][]scanner.Module{direct, indirect, opts.Tools, transitive} {
+			sort.Slice(mods, func(i, j int) bool {
+				ai, aj := format.GroupSortKey(mods[i]), format.GroupSortKey(mods[j])
+				if ai != aj {
+					return ai < aj
+				}
+				return mods[i].Path < mods[j].Path
+			})
+		}
+	}
+
+	choices := make([]scanner.Module, 0, len(direct)+len(indirect)+len(opts.Tools)+len(transitive))
 	choices = append(choices, direct...)
 	directEnd := len(choices)
 	choices = append(choices, indirect...)
 	indirectEnd := len(choices)
+	choices = append(choices, opts.Tools...)
+	toolsEnd := len(choices)
 	choices = append(choices, transitive...)
 
 	return model{
 		choices:      choices,
 		selected:     make(map[int]struct{}),
+		notes:        make(map[string]notes),
 		directEnd:    directEnd,
 		indirectEnd:  indirectEnd,
+		toolsEnd:     toolsEnd,
 		transitiveOn: len(transitive) > 0,
 		opts:         opts,
 	}
@@ -90,6 +107,19 @@ func (m model) Init() tea.Cmd {
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = size.Width
+	}
+	if loaded, ok := msg.(notesMsg); ok {
+		m.notes[loaded.key] = loaded.notes
+		return m, nil
+	}
+	if m.confirming {
+		return m.updateConfirm(msg)
+	}
+	if m.filtering {
+		return m.updateFilter(msg)
+	}
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -97,15 +127,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.quitting = true
 			return m, tea.Quit
 		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
+			m.moveCursor(-1)
 		case "down", "j":
-			if m.cursor < len(m.choices)-1 {
-				m.cursor++
+			m.moveCursor(1)
+		case "/":
+			m.filtering = true
+		case "n":
+			if m.opts.ReleaseNotes != nil {
+				m.showNotes = !m.showNotes
 			}
+		case "v":
+			if m.opts.VulnDetails {
+				m.showVulns = !m.showVulns
+			}
+		case "esc":
+			m.setFilter("")
 		case " ", "space":
-			if m.cursor >= 0 && m.cursor < len(m.choices) {
+			if m.cursor >= 0 && m.cursor < len(m.choices) && m.visible(m.cursor) && !m.collapsed[m.section(m.cursor)] {
 				_, ok := m.selected[m.cursor]
 				if ok {
 					delete(m.selected, m.cursor)
@@ -113,23 +151,369 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.selected[m.cursor] = struct{}{}
 				}
 			}
+		case "a":
+			m.toggleRange(0, len(m.choices))
+		case "g":
+			if m.cursor >= 0 && m.cursor < len(m.choices) {
+				m.toggleRange(m.groupRange(m.cursor))
+			}
+		case "c":
+			if m.cursor >= 0 && m.cursor < len(m.choices) {
+				sec := m.section(m.cursor)
+				m.collapsed[sec] = !m.collapsed[sec]
+				m.settleCursor()
+			}
 		case "enter":
+			if len(m.selected) == 0 {
+				return m, tea.Quit
+			}
+			m.confirming = true
+		}
+	}
+	return m, m.fetchNotes()
+}
+
+// updateFilter handles keys while the filter input is open. The list
+// narrows as the filter changes; <enter> keeps it and <esc> clears it.
+func (m model) updateFilter(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.Type {
+	case tea.KeyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc:
+		m.filtering = false
+		m.setFilter("")
+	case tea.KeyUp:
+		m.moveCursor(-1)
+	case tea.KeyDown:
+		m.moveCursor(1)
+	case tea.KeyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			m.setFilter(string(r[:len(r)-1]))
+		}
+	case tea.KeySpace:
+		m.setFilter(m.filter + " ")
+	case tea.KeyRunes:
+		m.setFilter(m.filter + string(key.Runes))
+	}
+	return m, m.fetchNotes()
+}
+
+// setFilter narrows the visible choices to names containing filter or
+// matching it as a regex, and moves the cursor onto a visible choice.
+// Selections of hidden choices are kept.
+func (m *model) setFilter(filter string) {
+	m.filter, m.filterRe = filter, nil
+	if filter != "" {
+		m.filterRe, _ = regexp.Compile("(?i)" + filter)
+	}
+	m.settleCursor()
+}
+
+// settleCursor moves the cursor onto a choice that can hold it: a visible
+// one, and in a collapsed section the first visible one, where the section's
+// summary row is drawn.
+func (m *model) settleCursor() {
+	if m.cursor < 0 || m.cursor >= len(m.choices) {
+		return
+	}
+	if !m.visible(m.cursor) {
+		m.moveCursor(1)
+		if !m.visible(m.cursor) {
+			m.moveCursor(-1)
+		}
+	}
+	if sec := m.section(m.cursor); m.collapsed[sec] {
+		start, end := m.sectionRange(sec)
+		for i := start; i < end; i++ {
+			if m.visible(i) {
+				m.cursor = i
+				return
+			}
+		}
+	}
+}
+
+// visible reports whether choice i matches the filter.
+func (m model) visible(i int) bool {
+	if m.filter == "" {
+		return true
+	}
+	name := m.choices[i].Name
+	if name == "" {
+		name = m.choices[i].Path
+	}
+	if strings.Contains(strings.ToLower(name), strings.ToLower(m.filter)) {
+		return true
+	}
+	return m.filterRe != nil && m.filterRe.MatchString(name)
+}
+
+// firstVisible reports whether no choice in [start, i) is visible, i.e.
+// whether choice i is the first one shown from start on.
+func (m model) firstVisible(start, i int) bool {
+	for j := start; j < i; j++ {
+		if m.visible(j) {
+			return false
+		}
+	}
+	return true
+}
+
+// moveCursor moves the cursor to the next visible choice in direction dir
+// (-1 or 1), staying put when there is none. A collapsed section is a
+// single stop on its first visible choice.
+func (m *model) moveCursor(dir int) {
+	for i := m.cursor + dir; i >= 0 && i < len(m.choices); i += dir {
+		if !m.visible(i) {
+			continue
+		}
+		sec := m.section(i)
+		if m.collapsed[sec] {
+			if m.cursor >= 0 && m.cursor < len(m.choices) && m.section(m.cursor) == sec {
+				continue
+			}
+			start, _ := m.sectionRange(sec)
+			for !m.visible(start) {
+				start++
+			}
+			i = start
+		}
+		m.cursor = i
+		return
+	}
+}
+
+// toggleRange deselects the visible choices in [start, end) when all of
+// them are selected and selects them all otherwise. Critical modules are
+// never selected in bulk: they need their own <space>.
+func (m model) toggleRange(start, end int) {
+	all := true
+	for i := start; i < end; i++ {
+		if _, ok := m.selected[i]; !ok && m.visible(i) && !m.choices[i].Critical {
+			all = false
+			break
+		}
+	}
+	for i := start; i < end; i++ {
+		if !m.visible(i) {
+			continue
+		}
+		if all {
+			delete(m.selected, i)
+		} else if !m.choices[i].Critical {
+			m.selected[i] = struct{}{}
+		}
+	}
+}
+
+// groupRange returns the choices [start, end) shown under the same heading
+// as choice i: its section (direct, indirect or transitive) and, with
+// FormatGroup, its update type within that section.
+func (m model) groupRange(i int) (start, end int) {
+	start, end = m.sectionRange(m.section(i))
+	if !m.opts.FormatGroup || m.collapsed[m.section(i)] {
+		return start, end
+	}
+	label := format.GroupLabel(m.choices[i])
+	lo, hi := i, i+1
+	for lo > start && format.GroupLabel(m.choices[lo-1]) == label {
+		lo--
+	}
+	for hi < end && format.GroupLabel(m.choices[hi]) == label {
+		hi++
+	}
+	return lo, hi
+}
+
+// Sections of the picker, indexing model.collapsed.
+const (
+	sectionDirect = iota
+	sectionIndirect
+	sectionTools
+	sectionTransitive
+)
+
+// sectionNames name the sections in saved state.
+var sectionNames = [...]string{"direct", "indirect", "tools", "transitive"}
+
+// section returns the section of choice i.
+func (m model) section(i int) int {
+	switch {
+	case i < m.directEnd:
+		return sectionDirect
+	case i < m.indirectEnd:
+		return sectionIndirect
+	case i < m.toolsEnd:
+		return sectionTools
+	default:
+		return sectionTransitive
+	}
+}
+
+// sectionRange returns the choices [start, end) in section sec.
+func (m model) sectionRange(sec int) (start, end int) {
+	switch sec {
+	case sectionDirect:
+		return 0, m.directEnd
+	case sectionIndirect:
+		return m.directEnd, m.indirectEnd
+	case sectionTools:
+		return m.indirectEnd, m.toolsEnd
+	default:
+		return m.toolsEnd, len(m.choices)
+	}
+}
+
+// updateConfirm handles keys on the confirmation screen.
+func (m model) updateConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.quitting = true
 			return m, tea.Quit
+		case "y":
+			return m, tea.Quit
+		case "enter":
+			// Critical modules and new owners need an explicit <y>.
+			selected := m.selectedModules()
+			if len(criticalNames(selected)) == 0 && len(ownerChanges(selected)) == 0 {
+				return m, tea.Quit
+			}
+		case "n", "esc":
+			m.confirming = false
 		}
 	}
 	return m, nil
 }
 
+// selectedModules returns the selected choices in display order.
+func (m model) selectedModules() []scanner.Module {
+	idx := make([]int, 0, len(m.selected))
+	for i := range m.selected {
+		if i >= 0 && i < len(m.choices) {
+			idx = append(idx, i)
+		}
+	}
+	sort.Ints(idx)
+	out := make([]scanner.Module, 0, len(idx))
+	for _, i := range idx {
+		out = append(out, m.choices[i])
+	}
+	return out
+}
+
+// confirmView renders the selection summary with family alignment hints.
+func (m model) confirmView() string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	warn := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	toUpdate := m.selectedModules()
+	s := heading.Render(fmt.Sprintf("Update %d packages?", len(toUpdate))) + "\n\n"
+
+	maxPathLen := scanner.MaxPathLength(toUpdate)
+	for _, c := range toUpdate {
+		name := c.Name
+		if name == "" {
+			name = c.Path
+		}
+		s += m.fit("  "+style.FormatUpdate(name, c.Version, c.Update.Version, maxPathLen)) + "\n"
+	}
+
+	if suggestions := align.Suggest(m.opts.Ecosystem, toUpdate); len(suggestions) > 0 {
+		s += "\n"
+		for _, sg := range suggestions {
+			if sg.Mixed {
+				s += warn.Render(fmt.Sprintf("%s %s modules target different release lines (%s)", style.Glyphs.Warning, sg.Family, strings.Join(sg.Lines, ", "))) + "\n"
+			}
+			// faro align rewrites go.mod; other ecosystems get the target only.
+			if m.opts.Ecosystem == "go" {
+				s += dim.Render(fmt.Sprintf("  Consider aligning %d %s modules: faro align %s --to %s", len(sg.Modules), sg.Family, sg.Family, sg.Target)) + "\n"
+			} else {
+				s += dim.Render(fmt.Sprintf("  Consider aligning %d %s packages on %s.x", len(sg.Modules), sg.Family, sg.Target)) + "\n"
+			}
+		}
+	}
+
+	critical, owners := criticalNames(toUpdate), ownerChanges(toUpdate)
+	if len(critical) > 0 {
+		s += "\n" + warn.Render(fmt.Sprintf("%s Critical: %s", style.Glyphs.Warning, strings.Join(critical, ", "))) + "\n"
+	}
+	for _, o := range owners {
+		s += warn.Render(fmt.Sprintf("%s New owners: %s", style.Glyphs.Warning, o)) + "\n"
+	}
+	if len(critical) > 0 || len(owners) > 0 {
+		s += "\nPress <y> to confirm, <n>/<esc> to go back, <q> to quit.\n"
+		return s
+	}
+
+	s += "\nPress <y>/<enter> to confirm, <n>/<esc> to go back, <q> to quit.\n"
+	return s
+}
+
+// fit truncates a row to the terminal width so it never wraps.
+func (m model) fit(row string) string {
+	if m.width <= 0 {
+		return row
+	}
+	return style.Truncate(row, m.width)
+}
+
+// criticalNames returns the names of the critical modules in modules.
+func criticalNames(modules []scanner.Module) []string {
+	var names []string
+	for _, c := range modules {
+		if !c.Critical {
+			continue
+		}
+		name := c.Name
+		if name == "" {
+			name = c.Path
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// ownerChanges describes the modules in modules whose owners changed.
+func ownerChanges(modules []scanner.Module) []string {
+	var out []string
+	for _, c := range modules {
+		if c.OwnerChange == "" {
+			continue
+		}
+		name := c.Name
+		if name == "" {
+			name = c.Path
+		}
+		out = append(out, fmt.Sprintf("%s (%s)", name, c.OwnerChange))
+	}
+	return out
+}
+
 func (m model) View() string {
 	if m.quitting {
 		return "Bye!\n"
 	}
+	if m.confirming {
+		return m.confirmView()
+	}
 
 	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
 	headingMuted := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("240"))
 
 	s := "Which packages would you like to update?\n\n"
+	if m.opts.Project != "" {
+		s = heading.Render(m.opts.Project) + "\n" + s
+	}
 
 	// Find longest path for padding
 	maxPathLen := 0
@@ -144,9 +528,16 @@ func (m model) View() string {
 	}
 
 	prevGroup := ""
+	shown, hiddenSelected := 0, 0
 	for i, choice := range m.choices {
+		if !m.visible(i) {
+			if _, ok := m.selected[i]; ok {
+				hiddenSelected++
+			}
+			continue
+		}
 		// Section headings (do not affect cursor/selection indices)
-		if i == 0 {
+		if shown == 0 && (i < m.directEnd || m.filter == "") {
 			label := m.opts.DirectLabel
 			if label == "" {
 				label = "Direct dependencies"
@@ -154,7 +545,7 @@ func (m model) View() string {
 			s += heading.Render(label) + "\n"
 			prevGroup = ""
 		}
-		if i == m.directEnd && i < len(m.choices) {
+		if i >= m.directEnd && i < m.indirectEnd && m.firstVisible(m.directEnd, i) {
 			label := m.opts.IndirectLabel
 			if label == "" {
 				label = "Indirect dependencies"
@@ -162,7 +553,15 @@ func (m model) View() string {
 			s += "\n" + headingMuted.Render(label) + "\n"
 			prevGroup = ""
 		}
-		if m.transitiveOn && i == m.indirectEnd && i < len(m.choices) {
+		if i >= m.indirectEnd && i < m.toolsEnd && m.firstVisible(m.indirectEnd, i) {
+			label := m.opts.ToolsLabel
+			if label == "" {
+				label = "Tools"
+			}
+			s += "\n" + headingMuted.Render(label) + "\n"
+			prevGroup = ""
+		}
+		if m.transitiveOn && i >= m.toolsEnd && m.firstVisible(m.toolsEnd, i) {
 			label := m.opts.TransitiveLabel
 			if label == "" {
 				label = "Transitive"
@@ -170,6 +569,15 @@ func (m model) View() string {
 			s += "\n" + headingMuted.Render(label) + "\n"
 			prevGroup = ""
 		}
+		shown++
+
+		if sec := m.section(i); m.collapsed[sec] {
+			start, end := m.sectionRange(sec)
+			if m.firstVisible(start, i) {
+				s += m.collapsedRow(sec, start, end) + "\n"
+			}
+			continue
+		}
 
 		if m.opts.FormatGroup {
 			g := format.GroupLabel(choice)
@@ -180,17 +588,17 @@ func (m model) View() string {
 		}
 
 		// Cursor
-		cursor := "  "
+		cursor := strings.Repeat(" ", lipgloss.Width(style.Glyphs.Cursor)+1)
 		if m.cursor == i {
-			cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render("❯ ")
+			cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render(style.Glyphs.Cursor + " ")
 		}
 
 		// Checkbox
 		var checked string
 		if _, ok := m.selected[i]; ok {
-			checked = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("◉")
+			checked = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render(style.Glyphs.Selected)
 		} else {
-			checked = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("◯")
+			checked = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(style.Glyphs.Unselected)
 		}
 
 		// Row content
@@ -199,44 +607,115 @@ func (m model) View() string {
 			name = choice.Path
 		}
 		row := style.FormatUpdate(name, choice.Version, choice.Update.Version, maxPathLen)
+		if choice.Critical {
+			row += " " + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("[critical]")
+		}
+		if choice.Retracted != "" {
+			row += " " + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("[retracted]")
+		}
+		if choice.Deprecated != "" {
+			row += " " + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Italic(true).Render("[deprecated]")
+		}
+		if choice.OwnerChange != "" {
+			row += " " + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("[owner changed]")
+		}
 		if m.opts.FormatTime && choice.Update != nil {
-			pt := format.PublishTime(choice.Update.Time, time.Now())
+			pt := m.opts.TimeStyle.Format(choice.Update.Time, time.Now())
 			if pt != "" {
 				row += "  " + dim.Render(pt)
 			}
 		}
 
-		s += fmt.Sprintf("%s%s %s\n", cursor, checked, row)
+		s += m.fit(fmt.Sprintf("%s%s %s", cursor, checked, row)) + "\n"
+		indent := strings.Repeat(" ", lipgloss.Width(style.Glyphs.Cursor)+lipgloss.Width(style.Glyphs.Selected)+2)
+		for _, note := range choice.Notes {
+			s += m.fit(indent+dim.Render("note: "+note)) + "\n"
+		}
 	}
 
-	s += "\nPress <space> to select, <enter> to update, <q> to quit.\n"
+	if m.showVulns {
+		s += m.vulnsView()
+	}
+	if m.showNotes {
+		s += m.notesView()
+	}
+	switch {
+	case m.filtering:
+		s += "\n/" + m.filter + style.Glyphs.Caret + "\n"
+		s += dim.Render("Type to filter by substring or regex, <enter> to keep the filter, <esc> to clear it.") + "\n"
+	case m.filter != "":
+		s += "\n" + dim.Render(fmt.Sprintf("Filter %q: %d of %d shown", m.filter, shown, len(m.choices)))
+		if hiddenSelected > 0 {
+			s += dim.Render(fmt.Sprintf(", %d selected hidden", hiddenSelected))
+		}
+		s += dim.Render(". Press <esc> to clear it.") + "\n"
+	}
+	if !m.filtering {
+		notesHint := ""
+		if m.opts.VulnDetails {
+			notesHint += " <v> for vulnerabilities,"
+		}
+		if m.opts.ReleaseNotes != nil {
+			notesHint += " <n> for release notes,"
+		}
+		s += "\nPress <space> to select, <a> to toggle all, <g> to toggle the group, <c> to collapse the section, </> to filter," + notesHint + " <enter> to update, <q> to quit.\n"
+	}
 	return s
 }
 
+// collapsedRow renders the summary row standing in for collapsed section
+// sec, whose choices are [start, end).
+func (m model) collapsedRow(sec, start, end int) string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	cursor := strings.Repeat(" ", lipgloss.Width(style.Glyphs.Cursor)+1)
+	if m.cursor >= start && m.cursor < end {
+		cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render(style.Glyphs.Cursor + " ")
+	}
+	hidden, selected := 0, 0
+	for i := start; i < end; i++ {
+		if !m.visible(i) {
+			continue
+		}
+		hidden++
+		if _, ok := m.selected[i]; ok {
+			selected++
+		}
+	}
+	return m.fit(cursor + dim.Render(fmt.Sprintf("%d updates collapsed (%d selected), <c> to expand", hidden, selected)))
+}
+
 // StartInteractiveGroupedWithOptions launches the TUI with groups split by go.mod classification.
-func StartInteractiveGroupedWithOptions(direct, indirect, transitive []scanner.Module, opts Options) {
-	m, err := runProgram(initialModel(direct, indirect, transitive, opts))
+// Canceling ctx closes the TUI without applying any selection.
+func StartInteractiveGroupedWithOptions(ctx context.Context, direct, indirect, transitive []scanner.Module, opts Options) {
+	initial := initialModel(direct, indirect, transitive, opts)
+	initial.ctx = ctx
+	if opts.StatePath != "" {
+		initial.restore(loadState(opts.StatePath))
+	}
+	m, err := runProgram(ctx, initial)
 	if err != nil {
+		if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+			return
+		}
 		fmt.Printf("Error running program: %v", err)
 		os.Exit(1)
 	}
 
-	// Type assertion to get back our model
-	if finalModel, ok := m.(model); ok && !finalModel.quitting {
-		// Collect selected modules
-		var toUpdate []scanner.Module
-		for i := range finalModel.selected {
-			if i >= 0 && i < len(finalModel.choices) {
-				toUpdate = append(toUpdate, finalModel.choices[i])
-			}
+	finalModel, ok := m.(model)
+	if ok && opts.StatePath != "" {
+		if err := saveState(opts.StatePath, finalModel.state()); err != nil {
+			fmt.Printf("Warning: %v\n", err)
 		}
+	}
+	if ok && !finalModel.quitting {
+		toUpdate := finalModel.selectedModules()
 
 		if len(toUpdate) > 0 {
 			if finalModel.opts.Updater == nil {
 				fmt.Println("Error: no updater configured")
 				return
 			}
-			if err := finalModel.opts.Updater.UpdatePackages(toUpdate); err != nil {
+			if err := finalModel.opts.Updater.UpdatePackages(ctx, toUpdate); err != nil {
 				fmt.Printf("Error updating: %v\n", err)
 			} else {
 				fmt.Println("Updates complete!")
@@ -249,5 +728,5 @@ func StartInteractiveGroupedWithOptions(direct, indirect, transitive []scanner.M
 
 // StartInteractiveGrouped is a backwards-compatible helper.
 func StartInteractiveGrouped(direct, indirect, transitive []scanner.Module) {
-	StartInteractiveGroupedWithOptions(direct, indirect, transitive, Options{})
+	StartInteractiveGroupedWithOptions(context.Background(), direct, indirect, transitive, Options{})
 }
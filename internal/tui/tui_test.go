@@ -1,11 +1,16 @@
 package tui
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pragmaticivan/faro/internal/changelog"
 	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
 )
 
 type mockUpdater struct {
@@ -13,13 +18,13 @@ type mockUpdater struct {
 	lastUpdate []scanner.Module
 }
 
-func (m *mockUpdater) UpdatePackages(modules []scanner.Module) error {
+func (m *mockUpdater) UpdatePackages(ctx context.Context, modules []scanner.Module) error {
 	m.called = true
 	m.lastUpdate = modules
 	return nil
 }
 
-func (m *mockUpdater) UpdateSinglePackage(module scanner.Module) error {
+func (m *mockUpdater) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
 	return nil
 }
 
@@ -96,11 +101,11 @@ func TestStartInteractiveGroupedWithOptions_AppliesSelection(t *testing.T) {
 	base := initialModel(direct, nil, nil, Options{Updater: mock})
 	base.selected[0] = struct{}{}
 
-	runProgram = func(tea.Model) (tea.Model, error) {
+	runProgram = func(context.Context, tea.Model) (tea.Model, error) {
 		return base, nil
 	}
 
-	StartInteractiveGroupedWithOptions(direct, nil, nil, Options{Updater: mock})
+	StartInteractiveGroupedWithOptions(context.Background(), direct, nil, nil, Options{Updater: mock})
 
 	if !mock.called {
 		t.Fatalf("expected UpdatePackages to be called")
@@ -114,7 +119,7 @@ func TestStartInteractiveGrouped_BackCompat(t *testing.T) {
 	origRun := runProgram
 	defer func() { runProgram = origRun }()
 
-	runProgram = func(tea.Model) (tea.Model, error) {
+	runProgram = func(context.Context, tea.Model) (tea.Model, error) {
 		return initialModel(nil, nil, nil, Options{}), nil
 	}
 	StartInteractiveGrouped(nil, nil, nil)
@@ -155,3 +160,494 @@ func TestBoundsChecking_InvalidCursorPosition(t *testing.T) {
 		t.Fatalf("expected cursor to remain at 999, got %d", m2.cursor)
 	}
 }
+
+func TestStartInteractiveGroupedWithOptions_CanceledSkipsUpdate(t *testing.T) {
+	origRun := runProgram
+	defer func() { runProgram = origRun }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runProgram = func(context.Context, tea.Model) (tea.Model, error) {
+		return nil, tea.ErrProgramKilled
+	}
+
+	mock := &mockUpdater{}
+	direct := []scanner.Module{{Path: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+	StartInteractiveGroupedWithOptions(ctx, direct, nil, nil, Options{Updater: mock})
+
+	if mock.called {
+		t.Fatalf("did not expect UpdatePackages after cancellation")
+	}
+}
+
+func TestConfirmScreen_NpmAlignmentHint(t *testing.T) {
+	direct := []scanner.Module{
+		{Name: "@babel/core", Version: "7.20.0", Update: &scanner.UpdateInfo{Version: "7.24.0"}},
+		{Name: "@babel/preset-env", Version: "7.20.0", Update: &scanner.UpdateInfo{Version: "7.23.0"}},
+	}
+	m := initialModel(direct, nil, nil, Options{Ecosystem: "npm"})
+	m.selected[0] = struct{}{}
+	m.selected[1] = struct{}{}
+	m.confirming = true
+
+	view := m.View()
+	if !strings.Contains(view, "Consider aligning 2 @babel packages on 7.24.x") {
+		t.Fatalf("expected npm alignment hint, got:\n%s", view)
+	}
+	if strings.Contains(view, "faro align") {
+		t.Fatalf("did not expect the Go-only align command for npm, got:\n%s", view)
+	}
+}
+
+func TestConfirmScreen_SuggestsFamilyAlignment(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "k8s.io/api", Version: "v0.29.0", Update: &scanner.UpdateInfo{Version: "v0.31.1"}},
+		{Path: "k8s.io/client-go", Version: "v0.29.0", Update: &scanner.UpdateInfo{Version: "v0.30.4"}},
+	}
+	m := initialModel(direct, nil, nil, Options{Ecosystem: "go"})
+	m.selected[0] = struct{}{}
+	m.selected[1] = struct{}{}
+
+	modelAny, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2 := modelAny.(model)
+	if !m2.confirming || cmd != nil {
+		t.Fatalf("expected enter to open the confirmation screen")
+	}
+
+	view := m2.View()
+	if !strings.Contains(view, "faro align k8s.io --to v0.31") {
+		t.Fatalf("expected alignment suggestion, got:\n%s", view)
+	}
+	if !strings.Contains(view, "different release lines") {
+		t.Fatalf("expected mixed release line warning, got:\n%s", view)
+	}
+
+	modelAny, _ = m2.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if modelAny.(model).confirming {
+		t.Fatalf("expected esc to return to selection")
+	}
+
+	_, cmd = m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatalf("expected y to confirm and quit")
+	}
+}
+
+func TestEnter_WithoutSelectionQuits(t *testing.T) {
+	m := initialModel(nil, nil, nil, Options{})
+	modelAny, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if modelAny.(model).confirming || cmd == nil {
+		t.Fatalf("expected enter with empty selection to quit")
+	}
+}
+
+func TestConfirmScreen_CriticalRequiresExplicitYes(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "github.com/jackc/pgx/v5", Version: "v5.5.0", Critical: true, Update: &scanner.UpdateInfo{Version: "v5.6.0"}},
+	}
+	m := initialModel(direct, nil, nil, Options{})
+	if !strings.Contains(m.View(), "[critical]") {
+		t.Fatalf("expected critical marker, got:\n%s", m.View())
+	}
+	m.selected[0] = struct{}{}
+
+	modelAny, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2 := modelAny.(model)
+	if !strings.Contains(m2.View(), "Critical: github.com/jackc/pgx/v5") {
+		t.Fatalf("expected critical warning, got:\n%s", m2.View())
+	}
+	if _, cmd := m2.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatalf("expected enter not to confirm critical modules")
+	}
+	if _, cmd := m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}); cmd == nil {
+		t.Fatalf("expected y to confirm")
+	}
+}
+
+func TestConfirmScreen_OwnerChangeRequiresExplicitYes(t *testing.T) {
+	direct := []scanner.Module{
+		{Name: "left-pad", Version: "1.0.0", OwnerChange: "alice → mallory", Update: &scanner.UpdateInfo{Version: "1.1.0"}},
+	}
+	m := initialModel(direct, nil, nil, Options{})
+	if !strings.Contains(m.View(), "[owner changed]") {
+		t.Fatalf("expected owner marker, got:\n%s", m.View())
+	}
+	m.selected[0] = struct{}{}
+
+	modelAny, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2 := modelAny.(model)
+	if !strings.Contains(m2.View(), "New owners: left-pad (alice → mallory)") {
+		t.Fatalf("expected owner warning, got:\n%s", m2.View())
+	}
+	if _, cmd := m2.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatalf("expected enter not to confirm owner changes")
+	}
+}
+
+func TestProgressModel_TracksStepsAndSummary(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newProgressModel("Doctor", []string{"example.com/a", "example.com/b"}, func() time.Time { return now })
+
+	step := func(module string, s Step, detail string) {
+		modelAny, _ := m.Update(ProgressMsg{Module: module, Step: s, Detail: detail})
+		m = modelAny.(progressModel)
+	}
+	step("example.com/a", StepBuilding, "")
+	now = now.Add(1500 * time.Millisecond)
+	step("example.com/a", StepOK, "")
+	step("example.com/b", StepTesting, "")
+	now = now.Add(2 * time.Second)
+	modelAny, _ := m.Update(progressTickMsg(now))
+	m = modelAny.(progressModel)
+
+	view := m.View()
+	if !strings.Contains(view, "ok") || !strings.Contains(view, "1.5s") || !strings.Contains(view, "testing") || !strings.Contains(view, "2s") {
+		t.Fatalf("unexpected view:\n%s", view)
+	}
+
+	step("example.com/b", StepReverted, "go test failed")
+	if got := m.summary(); !strings.Contains(got, "1 ok, 1 reverted") || !strings.Contains(got, "go test failed") {
+		t.Fatalf("unexpected summary:\n%s", got)
+	}
+
+	modelAny, cmd := m.Update(progressDoneMsg{})
+	if cmd == nil || modelAny.(progressModel).View() != "" {
+		t.Fatalf("expected done message to quit with an empty view")
+	}
+}
+
+func TestPlainReporter(t *testing.T) {
+	var buf strings.Builder
+	report := PlainReporter(&buf)
+	report("example.com/a", StepTesting, "")
+	report("example.com/a", StepReverted, "go test failed")
+	if buf.String() != "example.com/a: testing\nexample.com/a: reverted (go test failed)\n" {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestView_TruncatesRowsToWindowWidth(t *testing.T) {
+	direct := []scanner.Module{{Path: "github.com/example/a-very-long-module-name", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+	modelAny, _ := initialModel(direct, nil, nil, Options{}).Update(tea.WindowSizeMsg{Width: 30, Height: 10})
+	view := modelAny.(model).View()
+	for _, line := range strings.Split(view, "\n") {
+		if w := ansi.StringWidth(line); w > 30 && strings.Contains(line, "a-very-long") {
+			t.Fatalf("expected row truncated to 30 cells, got %d: %q", w, line)
+		}
+	}
+	if !strings.Contains(view, "…") {
+		t.Fatalf("expected ellipsis in truncated row:\n%s", view)
+	}
+}
+
+func TestToggleAllAndGroup(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+		{Path: "b", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.2"}},
+		{Path: "c", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+	}
+	indirect := []scanner.Module{{Path: "d", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}}}
+	press := func(m model, r rune) model {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return next.(model)
+	}
+
+	m := initialModel(direct, indirect, nil, Options{})
+	m = press(m, 'a')
+	if len(m.selected) != 4 {
+		t.Fatalf("expected all selected, got %v", m.selected)
+	}
+	m = press(m, 'a')
+	if len(m.selected) != 0 {
+		t.Fatalf("expected all deselected, got %v", m.selected)
+	}
+	m = press(m, 'g')
+	if len(m.selected) != 3 {
+		t.Fatalf("expected the direct section selected, got %v", m.selected)
+	}
+
+	// With FormatGroup, g toggles the update type under the cursor: c (minor) sorts first.
+	m = initialModel(direct, indirect, nil, Options{FormatGroup: true})
+	m.cursor = 1
+	m = press(m, 'g')
+	if got := m.selectedModules(); len(got) != 2 || got[0].Path != "a" || got[1].Path != "b" {
+		t.Fatalf("expected the direct patch group selected, got %v", got)
+	}
+	m = press(m, 'g')
+	if len(m.selected) != 0 {
+		t.Fatalf("expected the group deselected, got %v", m.selected)
+	}
+}
+
+func TestToggleAll_SkipsCriticalModules(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+		{Path: "github.com/jackc/pgx/v5", Version: "v5.5.0", Critical: true, Update: &scanner.UpdateInfo{Version: "v5.6.0"}},
+	}
+	press := func(m model, r rune) model {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return next.(model)
+	}
+
+	m := initialModel(direct, nil, nil, Options{})
+	m = press(m, 'a')
+	if got := m.selectedModules(); len(got) != 1 || got[0].Path != "a" {
+		t.Fatalf("expected only the non-critical module selected, got %v", got)
+	}
+	m = press(m, 'g')
+	if len(m.selected) != 0 {
+		t.Fatalf("expected g to deselect the group, got %v", m.selected)
+	}
+
+	// A critical module selected with space is cleared with the rest.
+	m.cursor = 1
+	m = press(m, ' ')
+	m = press(m, 'a')
+	if len(m.selected) != 2 {
+		t.Fatalf("expected a to select the rest, got %v", m.selected)
+	}
+	m = press(m, 'a')
+	if len(m.selected) != 0 {
+		t.Fatalf("expected all deselected, got %v", m.selected)
+	}
+}
+
+func TestFilter_NarrowsListAndKeepsHiddenSelections(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+		{Path: "github.com/acme/db", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+	}
+	indirect := []scanner.Module{{Path: "golang.org/x/text", Version: "v0.1.0", Update: &scanner.UpdateInfo{Version: "v0.1.1"}}}
+	send := func(m model, msg tea.KeyMsg) model {
+		next, _ := m.Update(msg)
+		return next.(model)
+	}
+	typeText := func(m model, s string) model {
+		for _, r := range s {
+			m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		return m
+	}
+
+	m := initialModel(direct, indirect, nil, Options{})
+	m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}}) // select acme/api
+	m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = typeText(m, "x/t")
+	if !m.filtering || m.cursor != 2 {
+		t.Fatalf("expected filter input with cursor on the only match, got filtering=%v cursor=%d", m.filtering, m.cursor)
+	}
+	view := ansi.Strip(m.View())
+	if strings.Contains(view, "acme") || !strings.Contains(view, "golang.org/x/text") || !strings.Contains(view, "/x/t") {
+		t.Fatalf("expected only the matching module, got:\n%s", view)
+	}
+	if strings.Contains(view, "Direct dependencies") || !strings.Contains(view, "Indirect dependencies") {
+		t.Fatalf("expected only the heading of the visible section, got:\n%s", view)
+	}
+
+	m = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if len(m.selected) != 2 {
+		t.Fatalf("expected toggle all to add only the visible module, got %v", m.selected)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "1 of 3 shown, 1 selected hidden") {
+		t.Fatalf("expected filter summary, got:\n%s", view)
+	}
+
+	// Regex filters work too; <esc> clears the filter and keeps selections.
+	m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = send(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m = send(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m = send(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m = typeText(m, "acme/(db|cache)$")
+	if m.cursor != 1 {
+		t.Fatalf("expected cursor on the regex match, got %d", m.cursor)
+	}
+	m = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.filtering || m.filter != "" || len(m.selected) != 2 {
+		t.Fatalf("expected cleared filter with selections kept, got filtering=%v filter=%q selected=%v", m.filtering, m.filter, m.selected)
+	}
+}
+
+func TestFilter_InputUsesCaretGlyph(t *testing.T) {
+	defer func(g style.GlyphSet) { style.Glyphs = g }(style.Glyphs)
+	style.Glyphs = style.ASCIIGlyphs
+
+	direct := []scanner.Module{{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}}}
+	m := initialModel(direct, nil, nil, Options{})
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	next, _ = next.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	view := ansi.Strip(next.View())
+	if !strings.Contains(view, "/a_") || strings.Contains(view, "█") {
+		t.Fatalf("expected the ASCII caret after the filter, got:\n%s", view)
+	}
+}
+
+type fakeReleases map[string][]changelog.Release
+
+func (f fakeReleases) Releases(_ context.Context, modulePath string) ([]changelog.Release, error) {
+	releases, ok := f[modulePath]
+	if !ok {
+		return nil, changelog.ErrUnsupported
+	}
+	return releases, nil
+}
+
+func TestReleaseNotesPane(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.2.0"}},
+		{Path: "example.com/vanity", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+	}
+	source := fakeReleases{"github.com/acme/api": {
+		{Tag: "v1.3.0", Body: "too new"},
+		{Tag: "v1.2.0", Body: "## Changes\n\n- BREAKING: drop Foo"},
+		{Tag: "v1.1.0", Body: "- add Bar"},
+		{Tag: "v1.0.0", Body: "current"},
+	}}
+	m := initialModel(direct, nil, nil, Options{ReleaseNotes: source})
+	press := func(m model, r rune) (model, tea.Cmd) {
+		next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return next.(model), cmd
+	}
+	load := func(m model, cmd tea.Cmd) model {
+		if cmd == nil {
+			t.Fatalf("expected a command fetching release notes")
+		}
+		next, _ := m.Update(cmd())
+		return next.(model)
+	}
+
+	m, cmd := press(m, 'n')
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Loading...") {
+		t.Fatalf("expected loading state, got:\n%s", view)
+	}
+	m = load(m, cmd)
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Release notes: github.com/acme/api v1.0.0 → v1.2.0", "v1.2.0", "BREAKING: drop Foo", "v1.1.0", "add Bar"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in pane, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "too new") || strings.Contains(view, "current") {
+		t.Fatalf("expected only releases between the versions, got:\n%s", view)
+	}
+
+	m, cmd = press(m, 'j')
+	m = load(m, cmd)
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "No GitHub or GitLab releases found") {
+		t.Fatalf("expected unsupported message, got:\n%s", view)
+	}
+	if _, cmd := press(m, 'k'); cmd != nil {
+		t.Fatalf("expected cached notes not to be fetched again")
+	}
+}
+
+func TestCollapse_SkipsSectionAndRestoresSavedState(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+		{Path: "github.com/acme/db", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+	}
+	indirect := []scanner.Module{{Path: "golang.org/x/text", Version: "v0.1.0", Update: &scanner.UpdateInfo{Version: "v0.1.1"}}}
+	send := func(m model, r rune) model {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return next.(model)
+	}
+
+	m := initialModel(direct, indirect, nil, Options{})
+	m = send(m, 'j')
+	m = send(m, 'c')
+	if !m.collapsed[sectionDirect] || m.cursor != 0 {
+		t.Fatalf("expected the direct section collapsed with the cursor on it, got collapsed=%v cursor=%d", m.collapsed, m.cursor)
+	}
+	view := ansi.Strip(m.View())
+	if strings.Contains(view, "acme/api") || !strings.Contains(view, "2 updates collapsed (0 selected)") {
+		t.Fatalf("expected a summary row instead of the direct modules, got:\n%s", view)
+	}
+	if m = send(m, ' '); len(m.selected) != 0 {
+		t.Fatalf("expected <space> to do nothing on a collapsed section, got %v", m.selected)
+	}
+	if m = send(m, 'j'); m.cursor != 2 {
+		t.Fatalf("expected the cursor to skip the collapsed section, got %d", m.cursor)
+	}
+	if m = send(m, 'k'); m.cursor != 0 {
+		t.Fatalf("expected the collapsed section to be a single stop, got %d", m.cursor)
+	}
+	m = send(m, 'j')
+
+	path := StatePath(t.TempDir(), "/work/project")
+	if err := saveState(path, m.state()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	next := initialModel(direct, indirect, nil, Options{})
+	next.restore(loadState(path))
+	if !next.collapsed[sectionDirect] || next.cursor != 2 {
+		t.Fatalf("expected restored collapse and cursor, got collapsed=%v cursor=%d", next.collapsed, next.cursor)
+	}
+
+	// A remembered module that is no longer listed leaves the cursor at the top.
+	next = initialModel(indirect, nil, nil, Options{})
+	next.restore(state{Cursor: "github.com/acme/api", Filter: "x/"})
+	if next.cursor != 0 || next.filter != "x/" {
+		t.Fatalf("expected the filter restored and the cursor at the top, got cursor=%d filter=%q", next.cursor, next.filter)
+	}
+}
+
+func TestVulnerabilitiesPane(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.2.0"}, VulnDetails: []scanner.VulnDetail{
+			{ID: "GHSA-aaaa-bbbb-cccc", Summary: "Header injection", Severity: "HIGH", Fixed: "v1.1.0"},
+		}},
+		{Path: "github.com/acme/db", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+	}
+	press := func(m model, r rune) model {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return next.(model)
+	}
+
+	if m := press(initialModel(direct, nil, nil, Options{}), 'v'); strings.Contains(ansi.Strip(m.View()), "Vulnerabilities:") {
+		t.Fatalf("expected no pane without vulnerability details")
+	}
+	m := press(initialModel(direct, nil, nil, Options{VulnDetails: true}), 'v')
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Vulnerabilities: github.com/acme/api v1.0.0", "HIGH", "GHSA-aaaa-bbbb-cccc", "Header injection", "fixed in v1.1.0", "<v> for vulnerabilities"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in pane, got:\n%s", want, view)
+		}
+	}
+	if view := ansi.Strip(press(m, 'j').View()); !strings.Contains(view, "No known vulnerabilities.") {
+		t.Fatalf("expected the pane to follow the cursor, got:\n%s", view)
+	}
+}
+
+func TestView_ShowsNotesUnderRows(t *testing.T) {
+	direct := []scanner.Module{
+		{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}, Notes: []string{"owned by infra"}},
+		{Path: "github.com/acme/db", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}},
+	}
+	view := ansi.Strip(initialModel(direct, nil, nil, Options{}).View())
+	api, note, db := strings.Index(view, "acme/api"), strings.Index(view, "note: owned by infra"), strings.Index(view, "acme/db")
+	if note < 0 || api > note || note > db {
+		t.Fatalf("expected the note between the api and db rows, got:\n%s", view)
+	}
+}
+
+func TestView_ShowsProjectHeading(t *testing.T) {
+	direct := []scanner.Module{{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}}}
+	view := ansi.Strip(initialModel(direct, nil, nil, Options{Project: "services/api (go)"}).View())
+	if !strings.HasPrefix(view, "services/api (go)\n") {
+		t.Fatalf("expected the project heading first, got:\n%s", view)
+	}
+}
+
+func TestView_ListsToolsInTheirOwnSection(t *testing.T) {
+	direct := []scanner.Module{{Path: "github.com/acme/api", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.0.1"}}}
+	tools := []scanner.Module{{Path: "golang.org/x/tools", Version: "v0.25.0", Update: &scanner.UpdateInfo{Version: "v0.26.0"}}}
+	m := initialModel(direct, nil, nil, Options{Tools: tools, ToolsLabel: "Tools"})
+	view := ansi.Strip(m.View())
+	api, heading, tool := strings.Index(view, "acme/api"), strings.Index(view, "\nTools\n"), strings.Index(view, "golang.org/x/tools")
+	if heading < 0 || !(api < heading && heading < tool) {
+		t.Fatalf("expected the tool under its own heading, got:\n%s", view)
+	}
+	if m.section(1) != sectionTools {
+		t.Fatalf("expected the tool in the tools section, got %d", m.section(1))
+	}
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// vulnsView renders the advisories pane for the choice under the cursor.
+func (m model) vulnsView() string {
+	if m.cursor < 0 || m.cursor >= len(m.choices) || !m.visible(m.cursor) {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	bold := lipgloss.NewStyle().Bold(true)
+	c := m.choices[m.cursor]
+
+	s := "\n" + m.fit(bold.Render(fmt.Sprintf("Vulnerabilities: %s %s", choiceName(c), c.Version))) + "\n"
+	if len(c.VulnDetails) == 0 {
+		return s + dim.Render("No known vulnerabilities.") + "\n"
+	}
+	for _, d := range c.VulnDetails {
+		s += m.fit(fmt.Sprintf("%-8s %s", d.Severity, bold.Render(d.ID))) + "\n"
+		if d.Summary != "" {
+			s += m.fit("  "+d.Summary) + "\n"
+		}
+		if d.Fixed != "" {
+			s += m.fit(dim.Render("  fixed in "+d.Fixed)) + "\n"
+		}
+	}
+	return s
+}
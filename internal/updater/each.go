@@ -0,0 +1,35 @@
+package updater
+
+import (
+	"context"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// Result is the outcome of upgrading one module with UpdateEach.
+type Result struct {
+	Module scanner.Module
+	Err    error // nil when the upgrade was applied
+}
+
+// UpdateEach upgrades modules one at a time with UpdateSinglePackage,
+// continuing past failures, and calls report (if set) after each attempt.
+// It stops with ctx's error when ctx is canceled; the attempt in flight is
+// then not reported.
+func UpdateEach(ctx context.Context, u Updater, modules []scanner.Module, report func(Result)) ([]Result, error) {
+	results := make([]Result, 0, len(modules))
+	for _, m := range modules {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		r := Result{Module: m, Err: u.UpdateSinglePackage(ctx, m)}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, r)
+		if report != nil {
+			report(r)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,114 @@
+package gomod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// ImportRewrites returns the new contents of every Go file of the module in
+// workDir that imports oldPath or one of its packages, with those imports
+// moved to newPath, keyed by file path. Vendored code, testdata, hidden
+// directories and nested modules are not part of the module and are left
+// alone.
+func ImportRewrites(workDir, oldPath, newPath string) (map[string][]byte, error) {
+	rewrites := make(map[string][]byte)
+	err := filepath.WalkDir(workDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == workDir {
+				return nil
+			}
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		out, changed, err := gomod.RewriteImports(src, oldPath, newPath)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if changed {
+			rewrites[path] = out
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rewrites, nil
+}
+
+// MigratePath moves module to newPath at module.Update.Version: it rewrites
+// the imports of the old path, requires the new one with `go get` and lets
+// `go mod tidy` drop the old requirement. If any step fails or ctx is
+// canceled, the source files, go.mod and go.sum are restored.
+func (u *Updater) MigratePath(ctx context.Context, module scanner.Module, newPath string) (files []string, err error) {
+	if module.Update == nil || module.Update.Version == "" {
+		return nil, fmt.Errorf("no target version for %s", newPath)
+	}
+	oldPath := module.Name
+	if oldPath == "" {
+		oldPath = module.Path
+	}
+	rewrites, err := ImportRewrites(u.workDir, oldPath, newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := TakeSnapshot(u.workDir)
+	if err != nil {
+		return nil, err
+	}
+	for path := range rewrites {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", path, err)
+		}
+		snap.files[path] = data
+	}
+	defer func() {
+		if err != nil {
+			if restoreErr := snap.Restore(); restoreErr != nil {
+				err = errors.Join(err, restoreErr)
+			}
+		}
+	}()
+
+	for path, data := range rewrites {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+
+	if out, err := u.runCmd(ctx, "go", "get", newPath+"@"+module.Update.Version); err != nil {
+		return nil, fmt.Errorf("go get failed: %s: %w", string(out), err)
+	}
+	if out, err := u.runCmd(ctx, "go", "mod", "tidy"); err != nil {
+		return nil, fmt.Errorf("go mod tidy failed: %s: %w", string(out), err)
+	}
+	return files, nil
+}
@@ -0,0 +1,133 @@
+package gomod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pragmaticivan/faro/internal/gomod"
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/updater"
+)
+
+// moduleFiles are the files an upgrade rewrites.
+var moduleFiles = []string{"go.mod", "go.sum"}
+
+// PreviewPackages applies modules to a temporary copy of go.mod and go.sum
+// (via -modfile) and reports how go.sum and the build list would change. The
+// project's own files are never touched.
+func (u *Updater) PreviewPackages(ctx context.Context, modules []scanner.Module) (updater.Preview, error) {
+	preview := updater.Preview{Modules: len(modules)}
+	if len(modules) == 0 {
+		return preview, nil
+	}
+
+	var err error
+	if preview.BuildListBefore, err = u.buildListSize(ctx, ""); err != nil {
+		return preview, err
+	}
+
+	err = u.applyToCopy(ctx, modules, func(modFile string, before map[string][]byte) error {
+		size, err := u.buildListSize(ctx, modFile)
+		if err != nil {
+			return err
+		}
+		preview.BuildListAfter = size
+		after, err := os.ReadFile(filepath.Join(filepath.Dir(modFile), "go.sum"))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read preview go.sum: %w", err)
+		}
+		oldSum := gomod.ParseSumVersions(string(before["go.sum"]))
+		newSum := gomod.ParseSumVersions(string(after))
+		for v := range newSum {
+			if !oldSum[v] {
+				preview.SumAdded++
+			}
+		}
+		for v := range oldSum {
+			if !newSum[v] {
+				preview.SumRemoved++
+			}
+		}
+		return nil
+	})
+	return preview, err
+}
+
+// DryRunPackages applies modules to a temporary copy of go.mod and go.sum
+// and returns the copies' contents, leaving the project untouched.
+func (u *Updater) DryRunPackages(ctx context.Context, modules []scanner.Module) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(moduleFiles))
+	if len(modules) == 0 {
+		return files, nil
+	}
+	err := u.applyToCopy(ctx, modules, func(modFile string, _ map[string][]byte) error {
+		for _, name := range moduleFiles {
+			data, err := os.ReadFile(filepath.Join(filepath.Dir(modFile), name))
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read dry run %s: %w", name, err)
+			}
+			files[name] = data
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// applyToCopy copies go.mod and go.sum to a temporary directory, runs go get
+// and go mod tidy against the copy with -modfile, and calls inspect with the
+// copied go.mod path and the project's original file contents. The copy is
+// removed afterwards.
+func (u *Updater) applyToCopy(ctx context.Context, modules []scanner.Module, inspect func(modFile string, before map[string][]byte) error) error {
+	tmpDir, err := os.MkdirTemp("", "faro-preview-")
+	if err != nil {
+		return fmt.Errorf("failed to create preview directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	modFile := filepath.Join(tmpDir, "go.mod")
+	before := make(map[string][]byte)
+	for _, name := range moduleFiles {
+		data, err := os.ReadFile(filepath.Join(u.workDir, name))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		before[name] = data
+		if err := os.WriteFile(filepath.Join(tmpDir, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+	}
+
+	args := append([]string{"get", "-modfile=" + modFile}, u.buildGoGetArgs(modules)[1:]...)
+	if out, err := u.runCmd(ctx, "go", args...); err != nil {
+		return fmt.Errorf("go get failed: %s: %w", string(out), err)
+	}
+	if out, err := u.runCmd(ctx, "go", "mod", "tidy", "-modfile="+modFile); err != nil {
+		return fmt.Errorf("go mod tidy failed: %s: %w", string(out), err)
+	}
+	return inspect(modFile, before)
+}
+
+// buildListSize counts the modules `go list -m all` reports besides the main
+// module, using modFile instead of go.mod when set.
+func (u *Updater) buildListSize(ctx context.Context, modFile string) (int, error) {
+	args := []string{"list", "-m"}
+	if modFile != "" {
+		args = append(args, "-modfile="+modFile)
+	}
+	args = append(args, "all")
+	out, err := u.outputCmd(ctx, "go", args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to run go list: %w", err)
+	}
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return 0, nil
+	}
+	return bytes.Count(out, []byte("\n")), nil // the first line is the main module
+}
@@ -2,45 +2,66 @@
 package gomod
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
 
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/gomod"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Updater implements updater.Updater for Go modules.
 type Updater struct {
-	workDir string
-	runCmd  func(name string, args ...string) ([]byte, error)
+	workDir   string
+	runCmd    func(ctx context.Context, name string, args ...string) ([]byte, error)
+	outputCmd func(ctx context.Context, name string, args ...string) ([]byte, error) // stdout only
 }
 
 // NewUpdater creates a new Go module updater.
 func NewUpdater(workDir string) *Updater {
 	return &Updater{
 		workDir: workDir,
-		runCmd: func(name string, args ...string) ([]byte, error) {
-			cmd := exec.Command(name, args...)
-			cmd.Dir = workDir
-			return cmd.CombinedOutput()
+		runCmd: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return execx.Command(ctx, workDir, name, args...).CombinedOutput()
+		},
+		outputCmd: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return execx.Command(ctx, workDir, name, args...).Output()
 		},
 	}
 }
 
 // UpdatePackages updates multiple Go modules to their specified versions.
-func (u *Updater) UpdatePackages(modules []scanner.Module) error {
+// If any step fails or ctx is canceled, go.mod and go.sum are restored to
+// their pre-upgrade contents.
+func (u *Updater) UpdatePackages(ctx context.Context, modules []scanner.Module) (err error) {
 	if len(modules) == 0 {
 		return nil
 	}
 
+	snap, err := TakeSnapshot(u.workDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if restoreErr := snap.Restore(); restoreErr != nil {
+				err = errors.Join(err, restoreErr)
+			}
+		}
+	}()
+
 	fmt.Printf("Upgrading %d packages...\n", len(modules))
 
 	args := u.buildGoGetArgs(modules)
-	if out, err := u.runCmd("go", args...); err != nil {
+	if out, err := u.runCmd(ctx, "go", args...); err != nil {
 		return fmt.Errorf("go get failed: %s: %w", string(out), err)
 	}
 
 	// Tidy up
-	if out, err := u.runCmd("go", "mod", "tidy"); err != nil {
+	if out, err := u.runCmd(ctx, "go", "mod", "tidy"); err != nil {
 		return fmt.Errorf("go mod tidy failed: %s: %w", string(out), err)
 	}
 
@@ -48,8 +69,64 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 }
 
 // UpdateSinglePackage updates a single Go module to its specified version.
-func (u *Updater) UpdateSinglePackage(module scanner.Module) error {
-	return u.UpdatePackages([]scanner.Module{module})
+func (u *Updater) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
+	return u.UpdatePackages(ctx, []scanner.Module{module})
+}
+
+// PinComment marks requirements added by PinPackages. go mod tidy still
+// treats them as // indirect.
+const PinComment = "indirect; pinned by faro"
+
+// PinPackages requires modules at their update versions with `go get` and
+// no tidy, marking each requirement with PinComment so readers know why an
+// indirect module is held above what the dependencies need.
+func (u *Updater) PinPackages(ctx context.Context, modules []scanner.Module) (err error) {
+	if len(modules) == 0 {
+		return nil
+	}
+	snap, err := TakeSnapshot(u.workDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if restoreErr := snap.Restore(); restoreErr != nil {
+				err = errors.Join(err, restoreErr)
+			}
+		}
+	}()
+
+	if out, err := u.runCmd(ctx, "go", u.buildGoGetArgs(modules)...); err != nil {
+		return fmt.Errorf("go get failed: %s: %w", string(out), err)
+	}
+	goModPath := filepath.Join(u.workDir, "go.mod")
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	contents := string(data)
+	for _, m := range modules {
+		path := m.Name
+		if path == "" {
+			path = m.Path
+		}
+		contents = gomod.SetRequireComment(contents, path, PinComment)
+	}
+	if err := os.WriteFile(goModPath, []byte(contents), 0644); err != nil {
+		return fmt.Errorf("failed to write go.mod: %w", err)
+	}
+	return nil
+}
+
+// Commands returns the go get and go mod tidy commands UpdatePackages runs.
+func (u *Updater) Commands(modules []scanner.Module) [][]string {
+	if len(modules) == 0 {
+		return nil
+	}
+	return [][]string{
+		append([]string{"go"}, u.buildGoGetArgs(modules)...),
+		{"go", "mod", "tidy"},
+	}
 }
 
 // buildGoGetArgs constructs the arguments for `go get`.
@@ -69,3 +146,43 @@ func (u *Updater) buildGoGetArgs(modules []scanner.Module) []string {
 	}
 	return args
 }
+
+// Snapshot holds the contents of module files captured before an upgrade.
+type Snapshot struct {
+	files map[string][]byte // path -> contents; nil contents means the file did not exist
+}
+
+// TakeSnapshot records go.mod and go.sum in workDir.
+func TakeSnapshot(workDir string) (*Snapshot, error) {
+	snap := &Snapshot{files: make(map[string][]byte)}
+	for _, name := range []string{"go.mod", "go.sum"} {
+		path := filepath.Join(workDir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				snap.files[path] = nil
+				continue
+			}
+			return nil, fmt.Errorf("failed to snapshot %s: %w", name, err)
+		}
+		snap.files[path] = data
+	}
+	return snap, nil
+}
+
+// Restore writes the captured files back, removing any that did not exist before.
+func (s *Snapshot) Restore() error {
+	var errs []error
+	for path, data := range s.files {
+		if data == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", path, err))
+			}
+			continue
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,241 @@
+package gomod
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+func TestUpdatePackages_RestoresSnapshotOnFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	goMod := filepath.Join(tmpDir, "go.mod")
+	original := "module example.com/foo\n\nrequire example.com/a v1.0.0\n"
+	if err := os.WriteFile(goMod, []byte(original), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	u := NewUpdater(tmpDir)
+	u.runCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		// Simulate go get rewriting go.mod and creating go.sum before being interrupted.
+		_ = os.WriteFile(goMod, []byte("module example.com/foo\n\nrequire example.com/a v1.1.0\n"), 0644)
+		_ = os.WriteFile(filepath.Join(tmpDir, "go.sum"), []byte("example.com/a v1.1.0 h1:x\n"), 0644)
+		return nil, errors.New("signal: killed")
+	}
+
+	mods := []scanner.Module{{Name: "example.com/a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+	if err := u.UpdatePackages(context.Background(), mods); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	got, err := os.ReadFile(goMod)
+	if err != nil {
+		t.Fatalf("failed to read go.mod: %v", err)
+	}
+	if string(got) != original {
+		t.Fatalf("expected go.mod to be restored, got: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "go.sum")); !os.IsNotExist(err) {
+		t.Fatalf("expected go.sum created during upgrade to be removed, stat err: %v", err)
+	}
+}
+
+func TestUpdatePackages_KeepsChangesOnSuccess(t *testing.T) {
+	tmpDir := t.TempDir()
+	goMod := filepath.Join(tmpDir, "go.mod")
+	if err := os.WriteFile(goMod, []byte("module example.com/foo\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	u := NewUpdater(tmpDir)
+	u.runCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		_ = os.WriteFile(goMod, []byte("module example.com/foo\n// updated\n"), 0644)
+		return nil, nil
+	}
+
+	mods := []scanner.Module{{Name: "example.com/a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+	if err := u.UpdatePackages(context.Background(), mods); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	got, _ := os.ReadFile(goMod)
+	if string(got) != "module example.com/foo\n// updated\n" {
+		t.Fatalf("expected go.mod changes to be kept, got: %q", got)
+	}
+}
+
+func TestPreviewPackages(t *testing.T) {
+	tmpDir := t.TempDir()
+	goMod := "module example.com/foo\n\nrequire example.com/a v1.0.0\n"
+	goSum := "example.com/a v1.0.0 h1:a=\nexample.com/a v1.0.0/go.mod h1:b=\n"
+	for name, contents := range map[string]string{"go.mod": goMod, "go.sum": goSum} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	u := NewUpdater(tmpDir)
+	var modFile string
+	u.runCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		if args[0] == "get" {
+			modFile = strings.TrimPrefix(args[1], "-modfile=")
+			sum := "example.com/a v1.1.0 h1:c=\nexample.com/a v1.1.0/go.mod h1:d=\nexample.com/b v0.3.0 h1:e=\nexample.com/c v0.1.0/go.mod h1:f=\n"
+			_ = os.WriteFile(filepath.Join(filepath.Dir(modFile), "go.sum"), []byte(sum), 0644)
+		}
+		return nil, nil
+	}
+	u.outputCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		if strings.HasPrefix(args[2], "-modfile=") {
+			return []byte("example.com/foo\nexample.com/a v1.1.0\nexample.com/b v0.3.0\nexample.com/c v0.1.0\n"), nil
+		}
+		return []byte("example.com/foo\nexample.com/a v1.0.0\n"), nil
+	}
+
+	mods := []scanner.Module{{Name: "example.com/a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+	p, err := u.PreviewPackages(context.Background(), mods)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if p.SumAdded != 3 || p.SumRemoved != 1 || p.BuildListBefore != 1 || p.BuildListAfter != 3 {
+		t.Fatalf("unexpected preview: %+v", p)
+	}
+	if got, _ := os.ReadFile(filepath.Join(tmpDir, "go.mod")); string(got) != goMod {
+		t.Fatalf("preview modified go.mod: %q", got)
+	}
+	if _, err := os.Stat(modFile); !os.IsNotExist(err) {
+		t.Fatalf("expected preview files to be removed, got %v", err)
+	}
+}
+
+func TestDryRunPackages_ReturnsCopiedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	goMod := "module example.com/foo\n\nrequire example.com/a v1.0.0\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	u := NewUpdater(tmpDir)
+	var calls []string
+	u.runCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		if args[0] == "get" {
+			modFile := strings.TrimPrefix(args[1], "-modfile=")
+			_ = os.WriteFile(modFile, []byte("module example.com/foo\n\nrequire example.com/a v1.1.0\n"), 0644)
+			_ = os.WriteFile(filepath.Join(filepath.Dir(modFile), "go.sum"), []byte("example.com/a v1.1.0 h1:c=\n"), 0644)
+		}
+		return nil, nil
+	}
+
+	mods := []scanner.Module{{Name: "example.com/a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+	files, err := u.DryRunPackages(context.Background(), mods)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(calls) != 2 || !strings.HasSuffix(calls[0], " example.com/a@v1.1.0") || !strings.HasPrefix(calls[1], "mod tidy -modfile=") {
+		t.Fatalf("expected go get and go mod tidy against the copy, got %v", calls)
+	}
+	if !strings.Contains(string(files["go.mod"]), "example.com/a v1.1.0") || string(files["go.sum"]) != "example.com/a v1.1.0 h1:c=\n" {
+		t.Fatalf("unexpected dry run files: %q", files)
+	}
+	if got, _ := os.ReadFile(filepath.Join(tmpDir, "go.mod")); string(got) != goMod {
+		t.Fatalf("dry run modified go.mod: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "go.sum")); !os.IsNotExist(err) {
+		t.Fatalf("dry run created go.sum: %v", err)
+	}
+}
+
+func TestPinPackages_CommentsRequirementsWithoutTidy(t *testing.T) {
+	tmpDir := t.TempDir()
+	goMod := filepath.Join(tmpDir, "go.mod")
+	if err := os.WriteFile(goMod, []byte("module example.com/foo\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	u := NewUpdater(tmpDir)
+	var calls []string
+	u.runCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		_ = os.WriteFile(goMod, []byte("module example.com/foo\n\nrequire example.com/a v1.1.0 // indirect\n"), 0644)
+		return nil, nil
+	}
+
+	mods := []scanner.Module{{Name: "example.com/a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+	if err := u.PinPackages(context.Background(), mods); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "get example.com/a@v1.1.0" {
+		t.Fatalf("expected a single go get, got %v", calls)
+	}
+	got, _ := os.ReadFile(goMod)
+	if !strings.Contains(string(got), "require example.com/a v1.1.0 // "+PinComment+"\n") {
+		t.Fatalf("expected pinned requirement, got: %q", got)
+	}
+}
+
+func TestMigratePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                 "module example.com/foo\n\nrequire example.com/a v1.4.0\n",
+		"main.go":                "package main\n\nimport \"example.com/a/sub\"\n\nfunc main() { sub.Run() }\n",
+		"util/util.go":           "package util\n\nimport \"fmt\"\n\nvar _ = fmt.Sprint\n",
+		"vendor/x/x.go":          "package x\n\nimport \"example.com/a\"\n",
+		"nested/go.mod":          "module example.com/foo/nested\n",
+		"nested/nested.go":       "package nested\n\nimport \"example.com/a\"\n",
+		"testdata/fixture/in.go": "package fixture\n\nimport \"example.com/a\"\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := scanner.Module{Name: "example.com/a", Version: "v1.4.0", Update: &scanner.UpdateInfo{Version: "v2.1.0"}}
+
+	rewrites, err := ImportRewrites(tmpDir, "example.com/a", "example.com/a/v2")
+	if err != nil {
+		t.Fatalf("ImportRewrites: %v", err)
+	}
+	if len(rewrites) != 1 || !strings.Contains(string(rewrites[filepath.Join(tmpDir, "main.go")]), `"example.com/a/v2/sub"`) {
+		t.Fatalf("expected only main.go to be rewritten, got %v", rewrites)
+	}
+
+	u := NewUpdater(tmpDir)
+	var commands []string
+	u.runCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		if args[0] == "mod" {
+			return []byte("missing go.sum entry"), errors.New("exit status 1")
+		}
+		return nil, nil
+	}
+	if _, err := u.MigratePath(context.Background(), m, "example.com/a/v2"); err == nil {
+		t.Fatalf("expected the failed tidy to be reported")
+	}
+	if got, _ := os.ReadFile(filepath.Join(tmpDir, "main.go")); string(got) != files["main.go"] {
+		t.Fatalf("expected main.go to be restored, got %q", got)
+	}
+
+	commands = nil
+	u.runCmd = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		return nil, nil
+	}
+	rewritten, err := u.MigratePath(context.Background(), m, "example.com/a/v2")
+	if err != nil {
+		t.Fatalf("MigratePath: %v", err)
+	}
+	if len(rewritten) != 1 || rewritten[0] != filepath.Join(tmpDir, "main.go") {
+		t.Fatalf("unexpected rewritten files %v", rewritten)
+	}
+	if strings.Join(commands, "; ") != "go get example.com/a/v2@v2.1.0; go mod tidy" {
+		t.Fatalf("unexpected commands %v", commands)
+	}
+}
@@ -1,14 +1,71 @@
 // Package updater provides interfaces for updating dependencies across different package managers.
 package updater
 
-import "github.com/pragmaticivan/faro/internal/scanner"
+import (
+	"context"
+	"fmt"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/style"
+)
 
 // Updater is the interface that all package manager updaters must implement.
 type Updater interface {
 	// UpdatePackages updates multiple packages to their specified versions.
-	// It returns an error if any update fails.
-	UpdatePackages(modules []scanner.Module) error
+	// It returns an error if any update fails or ctx is canceled.
+	UpdatePackages(ctx context.Context, modules []scanner.Module) error
 
 	// UpdateSinglePackage updates a single package to its specified version.
-	UpdateSinglePackage(module scanner.Module) error
+	UpdateSinglePackage(ctx context.Context, module scanner.Module) error
+}
+
+// Previewer is implemented by updaters that can report the effect of an
+// upgrade on the dependency graph without modifying the project.
+type Previewer interface {
+	PreviewPackages(ctx context.Context, modules []scanner.Module) (Preview, error)
+}
+
+// DryRunner is implemented by updaters that can compute the manifest files
+// an upgrade would write without modifying the project.
+type DryRunner interface {
+	// DryRunPackages returns the contents each manifest file would have after
+	// upgrading modules, keyed by file name relative to the project.
+	DryRunPackages(ctx context.Context, modules []scanner.Module) (map[string][]byte, error)
+}
+
+// Scripter is implemented by updaters whose upgrades are plain commands,
+// so they can be exported for review instead of run.
+type Scripter interface {
+	// Commands returns the commands UpdatePackages runs for modules, each as
+	// a program followed by its arguments.
+	Commands(modules []scanner.Module) [][]string
+}
+
+// Pinner is implemented by updaters that can require a dependency at a
+// version without tidying it away afterwards (Go indirect requirements).
+type Pinner interface {
+	PinPackages(ctx context.Context, modules []scanner.Module) error
+}
+
+// PathMigrator is implemented by updaters that can move a dependency to a
+// new module path, as Go major versions (/v2, /v3) need, rewriting the
+// source files that import it.
+type PathMigrator interface {
+	// MigratePath replaces module with newPath at module.Update.Version and
+	// returns the source files it rewrote.
+	MigratePath(ctx context.Context, module scanner.Module, newPath string) ([]string, error)
+}
+
+// Preview summarizes how an upgrade would change the dependency graph.
+type Preview struct {
+	Modules         int `json:"modules"`         // upgrades previewed
+	SumAdded        int `json:"sumAdded"`        // module versions new to the checksum file
+	SumRemoved      int `json:"sumRemoved"`      // module versions dropped from the checksum file
+	BuildListBefore int `json:"buildListBefore"` // modules in the build list today
+	BuildListAfter  int `json:"buildListAfter"`  // modules in the build list after upgrading
+}
+
+func (p Preview) String() string {
+	return fmt.Sprintf("go.sum +%d/-%d module versions; build list %d %s %d modules (%+d)",
+		p.SumAdded, p.SumRemoved, p.BuildListBefore, style.Glyphs.Arrow, p.BuildListAfter, p.BuildListAfter-p.BuildListBefore)
 }
@@ -2,62 +2,47 @@
 package npm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Updater implements updater.Updater for npm.
 type Updater struct {
 	workDir string
-	runCmd  func(name string, args ...string) ([]byte, error)
+	runCmd  func(ctx context.Context, name string, args ...string) ([]byte, error)
 }
 
 // NewUpdater creates a new npm updater.
 func NewUpdater(workDir string) *Updater {
 	return &Updater{
 		workDir: workDir,
-		runCmd: func(name string, args ...string) ([]byte, error) {
-			cmd := exec.Command(name, args...)
-			cmd.Dir = workDir
+		runCmd: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, name, args...)
 			return cmd.CombinedOutput()
 		},
 	}
 }
 
 // UpdatePackages updates multiple npm packages to their specified versions.
-func (u *Updater) UpdatePackages(modules []scanner.Module) error {
+func (u *Updater) UpdatePackages(ctx context.Context, modules []scanner.Module) error {
 	if len(modules) == 0 {
 		return nil
 	}
 
 	fmt.Printf("Upgrading %d packages...\n", len(modules))
 
-	// Group by dependency type
-	deps := make([]string, 0)
-	devDeps := make([]string, 0)
-
-	for _, m := range modules {
-		pkgSpec := m.Name
-		if m.Update != nil && m.Update.Version != "" {
-			pkgSpec = fmt.Sprintf("%s@%s", m.Name, m.Update.Version)
-		}
-
-		if m.DependencyType == "devDependencies" {
-			devDeps = append(devDeps, pkgSpec)
-		} else {
-			deps = append(deps, pkgSpec)
-		}
-	}
+	deps, devDeps := specs(modules)
 
 	// Install production dependencies
 	if len(deps) > 0 {
 		args := append([]string{"install", "--save"}, deps...)
-		if out, err := u.runCmd("npm", args...); err != nil {
+		if out, err := u.runCmd(ctx, "npm", args...); err != nil {
 			return fmt.Errorf("npm install failed: %s: %w", string(out), err)
 		}
 	}
@@ -65,7 +50,7 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 	// Install dev dependencies
 	if len(devDeps) > 0 {
 		args := append([]string{"install", "--save-dev"}, devDeps...)
-		if out, err := u.runCmd("npm", args...); err != nil {
+		if out, err := u.runCmd(ctx, "npm", args...); err != nil {
 			return fmt.Errorf("npm install --save-dev failed: %s: %w", string(out), err)
 		}
 	}
@@ -73,13 +58,44 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 	return nil
 }
 
+// Commands returns the npm commands UpdatePackages runs.
+func (u *Updater) Commands(modules []scanner.Module) [][]string {
+	deps, devDeps := specs(modules)
+	var cmds [][]string
+	if len(deps) > 0 {
+		cmds = append(cmds, append([]string{"npm", "install", "--save"}, deps...))
+	}
+	if len(devDeps) > 0 {
+		cmds = append(cmds, append([]string{"npm", "install", "--save-dev"}, devDeps...))
+	}
+	return cmds
+}
+
+// specs returns the name@version arguments for modules, split into
+// dependencies and devDependencies.
+func specs(modules []scanner.Module) (deps, devDeps []string) {
+	for _, m := range modules {
+		pkgSpec := m.Name
+		if m.Update != nil && m.Update.Version != "" {
+			pkgSpec = fmt.Sprintf("%s@%s", m.Name, m.Update.Version)
+		}
+
+		if m.DependencyType == "devDependencies" {
+			devDeps = append(devDeps, pkgSpec)
+		} else {
+			deps = append(deps, pkgSpec)
+		}
+	}
+	return deps, devDeps
+}
+
 // UpdateSinglePackage updates a single npm package to its specified version.
-func (u *Updater) UpdateSinglePackage(module scanner.Module) error {
-	return u.UpdatePackages([]scanner.Module{module})
+func (u *Updater) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
+	return u.UpdatePackages(ctx, []scanner.Module{module})
 }
 
 // UpdatePackageJSON directly updates package.json with new versions (alternative approach).
-func (u *Updater) UpdatePackageJSON(modules []scanner.Module) error {
+func (u *Updater) UpdatePackageJSON(ctx context.Context, modules []scanner.Module) error {
 	pkgPath := filepath.Join(u.workDir, "package.json")
 	data, err := os.ReadFile(pkgPath)
 	if err != nil {
@@ -126,7 +142,7 @@ func (u *Updater) UpdatePackageJSON(modules []scanner.Module) error {
 	}
 
 	// Run npm install to update lockfile
-	if out, err := u.runCmd("npm", "install"); err != nil {
+	if out, err := u.runCmd(ctx, "npm", "install"); err != nil {
 		return fmt.Errorf("npm install failed after updating package.json: %s: %w", string(out), err)
 	}
 
@@ -3,35 +3,35 @@ package pip
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Updater implements updater.Updater for pip.
 type Updater struct {
 	workDir string
-	runCmd  func(name string, args ...string) ([]byte, error)
+	runCmd  func(ctx context.Context, name string, args ...string) ([]byte, error)
 }
 
 // NewUpdater creates a new pip updater.
 func NewUpdater(workDir string) *Updater {
 	return &Updater{
 		workDir: workDir,
-		runCmd: func(name string, args ...string) ([]byte, error) {
-			cmd := exec.Command(name, args...)
-			cmd.Dir = workDir
+		runCmd: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, name, args...)
 			return cmd.CombinedOutput()
 		},
 	}
 }
 
 // UpdatePackages updates multiple pip packages to their specified versions.
-func (u *Updater) UpdatePackages(modules []scanner.Module) error {
+func (u *Updater) UpdatePackages(ctx context.Context, modules []scanner.Module) error {
 	if len(modules) == 0 {
 		return nil
 	}
@@ -45,7 +45,7 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 			pkgSpec = fmt.Sprintf("%s==%s", m.Name, m.Update.Version)
 		}
 
-		if out, err := u.runCmd("pip", "install", pkgSpec); err != nil {
+		if out, err := u.runCmd(ctx, "pip", "install", pkgSpec); err != nil {
 			return fmt.Errorf("pip install %s failed: %s: %w", pkgSpec, string(out), err)
 		}
 	}
@@ -59,8 +59,8 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 }
 
 // UpdateSinglePackage updates a single pip package to its specified version.
-func (u *Updater) UpdateSinglePackage(module scanner.Module) error {
-	return u.UpdatePackages([]scanner.Module{module})
+func (u *Updater) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
+	return u.UpdatePackages(ctx, []scanner.Module{module})
 }
 
 // updateRequirementsTxt updates the requirements.txt file with new versions.
@@ -2,63 +2,50 @@
 package pnpm
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Updater implements updater.Updater for pnpm.
 type Updater struct {
 	workDir string
-	runCmd  func(name string, args ...string) ([]byte, error)
+	runCmd  func(ctx context.Context, name string, args ...string) ([]byte, error)
 }
 
 // NewUpdater creates a new pnpm updater.
 func NewUpdater(workDir string) *Updater {
 	return &Updater{
 		workDir: workDir,
-		runCmd: func(name string, args ...string) ([]byte, error) {
-			cmd := exec.Command(name, args...)
-			cmd.Dir = workDir
+		runCmd: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, name, args...)
 			return cmd.CombinedOutput()
 		},
 	}
 }
 
 // UpdatePackages updates multiple pnpm packages to their specified versions.
-func (u *Updater) UpdatePackages(modules []scanner.Module) error {
+func (u *Updater) UpdatePackages(ctx context.Context, modules []scanner.Module) error {
 	if len(modules) == 0 {
 		return nil
 	}
 
 	fmt.Printf("Upgrading %d packages...\n", len(modules))
 
-	deps := make([]string, 0)
-	devDeps := make([]string, 0)
-	for _, m := range modules {
-		pkgSpec := m.Name
-		if m.Update != nil && m.Update.Version != "" {
-			pkgSpec = fmt.Sprintf("%s@%s", m.Name, m.Update.Version)
-		}
-
-		if m.DependencyType == "devDependencies" {
-			devDeps = append(devDeps, pkgSpec)
-		} else {
-			deps = append(deps, pkgSpec)
-		}
-	}
+	deps, devDeps := specs(modules)
 
 	if len(deps) > 0 {
 		args := append([]string{"add"}, deps...)
-		if out, err := u.runCmd("pnpm", args...); err != nil {
+		if out, err := u.runCmd(ctx, "pnpm", args...); err != nil {
 			return fmt.Errorf("pnpm add failed: %s: %w", string(out), err)
 		}
 	}
 
 	if len(devDeps) > 0 {
 		args := append([]string{"add", "--save-dev"}, devDeps...)
-		if out, err := u.runCmd("pnpm", args...); err != nil {
+		if out, err := u.runCmd(ctx, "pnpm", args...); err != nil {
 			return fmt.Errorf("pnpm add --save-dev failed: %s: %w", string(out), err)
 		}
 	}
@@ -66,7 +53,38 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 	return nil
 }
 
+// Commands returns the pnpm commands UpdatePackages runs.
+func (u *Updater) Commands(modules []scanner.Module) [][]string {
+	deps, devDeps := specs(modules)
+	var cmds [][]string
+	if len(deps) > 0 {
+		cmds = append(cmds, append([]string{"pnpm", "add"}, deps...))
+	}
+	if len(devDeps) > 0 {
+		cmds = append(cmds, append([]string{"pnpm", "add", "--save-dev"}, devDeps...))
+	}
+	return cmds
+}
+
+// specs returns the name@version arguments for modules, split into
+// dependencies and devDependencies.
+func specs(modules []scanner.Module) (deps, devDeps []string) {
+	for _, m := range modules {
+		pkgSpec := m.Name
+		if m.Update != nil && m.Update.Version != "" {
+			pkgSpec = fmt.Sprintf("%s@%s", m.Name, m.Update.Version)
+		}
+
+		if m.DependencyType == "devDependencies" {
+			devDeps = append(devDeps, pkgSpec)
+		} else {
+			deps = append(deps, pkgSpec)
+		}
+	}
+	return deps, devDeps
+}
+
 // UpdateSinglePackage updates a single pnpm package to its specified version.
-func (u *Updater) UpdateSinglePackage(module scanner.Module) error {
-	return u.UpdatePackages([]scanner.Module{module})
+func (u *Updater) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
+	return u.UpdatePackages(ctx, []scanner.Module{module})
 }
@@ -2,32 +2,32 @@
 package poetry
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Updater implements updater.Updater for Poetry.
 type Updater struct {
 	workDir      string
-	runPoetryCmd func(args ...string) ([]byte, error)
+	runPoetryCmd func(ctx context.Context, args ...string) ([]byte, error)
 }
 
 // NewUpdater creates a new Poetry updater.
 func NewUpdater(workDir string) *Updater {
 	return &Updater{
 		workDir: workDir,
-		runPoetryCmd: func(args ...string) ([]byte, error) {
-			cmd := exec.Command("poetry", args...)
-			cmd.Dir = workDir
+		runPoetryCmd: func(ctx context.Context, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "poetry", args...)
 			return cmd.CombinedOutput()
 		},
 	}
 }
 
 // UpdatePackages updates multiple Poetry packages to their specified versions.
-func (u *Updater) UpdatePackages(modules []scanner.Module) error {
+func (u *Updater) UpdatePackages(ctx context.Context, modules []scanner.Module) error {
 	if len(modules) == 0 {
 		return nil
 	}
@@ -47,7 +47,7 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 			args = []string{"add", pkgSpec}
 		}
 
-		if out, err := u.runPoetryCmd(args...); err != nil {
+		if out, err := u.runPoetryCmd(ctx, args...); err != nil {
 			return fmt.Errorf("poetry add failed: %s: %w", string(out), err)
 		}
 	}
@@ -56,6 +56,6 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 }
 
 // UpdateSinglePackage updates a single Poetry package to its specified version.
-func (u *Updater) UpdateSinglePackage(module scanner.Module) error {
-	return u.UpdatePackages([]scanner.Module{module})
+func (u *Updater) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
+	return u.UpdatePackages(ctx, []scanner.Module{module})
 }
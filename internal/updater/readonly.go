@@ -0,0 +1,25 @@
+package updater
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// ErrReadOnly is returned by ReadOnly for every update attempt.
+var ErrReadOnly = errors.New("updates are disabled in read-only mode")
+
+// ReadOnly is an Updater that refuses to modify anything. It guards read-only
+// runs so that no install or get command can be reached by mistake.
+type ReadOnly struct{}
+
+// UpdatePackages always returns ErrReadOnly.
+func (ReadOnly) UpdatePackages(ctx context.Context, modules []scanner.Module) error {
+	return ErrReadOnly
+}
+
+// UpdateSinglePackage always returns ErrReadOnly.
+func (ReadOnly) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
+	return ErrReadOnly
+}
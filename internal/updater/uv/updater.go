@@ -2,32 +2,32 @@
 package uv
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 
+	"github.com/pragmaticivan/faro/internal/execx"
 	"github.com/pragmaticivan/faro/internal/scanner"
 )
 
 // Updater implements updater.Updater for uv.
 type Updater struct {
 	workDir  string
-	runUvCmd func(args ...string) ([]byte, error)
+	runUvCmd func(ctx context.Context, args ...string) ([]byte, error)
 }
 
 // NewUpdater creates a new uv updater.
 func NewUpdater(workDir string) *Updater {
 	return &Updater{
 		workDir: workDir,
-		runUvCmd: func(args ...string) ([]byte, error) {
-			cmd := exec.Command("uv", args...)
-			cmd.Dir = workDir
+		runUvCmd: func(ctx context.Context, args ...string) ([]byte, error) {
+			cmd := execx.Command(ctx, workDir, "uv", args...)
 			return cmd.CombinedOutput()
 		},
 	}
 }
 
 // UpdatePackages updates multiple uv packages to their specified versions.
-func (u *Updater) UpdatePackages(modules []scanner.Module) error {
+func (u *Updater) UpdatePackages(ctx context.Context, modules []scanner.Module) error {
 	if len(modules) == 0 {
 		return nil
 	}
@@ -41,7 +41,7 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 		}
 
 		args := []string{"pip", "install", pkgSpec}
-		if out, err := u.runUvCmd(args...); err != nil {
+		if out, err := u.runUvCmd(ctx, args...); err != nil {
 			return fmt.Errorf("uv pip install failed: %s: %w", string(out), err)
 		}
 	}
@@ -50,6 +50,6 @@ func (u *Updater) UpdatePackages(modules []scanner.Module) error {
 }
 
 // UpdateSinglePackage updates a single uv package to its specified version.
-func (u *Updater) UpdateSinglePackage(module scanner.Module) error {
-	return u.UpdatePackages([]scanner.Module{module})
+func (u *Updater) UpdateSinglePackage(ctx context.Context, module scanner.Module) error {
+	return u.UpdatePackages(ctx, []scanner.Module{module})
 }
@@ -0,0 +1,127 @@
+// Package usage reports which required modules a Go project actually imports,
+// per GOOS/GOARCH target and build tags, distinguishing regular builds from
+// test-only use.
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+	"github.com/pragmaticivan/faro/internal/platform"
+)
+
+// Lister returns the paths of the modules providing packages that the project
+// imports when built for p with tags. With tests set, test dependencies are
+// included as well.
+type Lister func(ctx context.Context, p platform.Platform, tags []string, tests bool) ([]string, error)
+
+// GoList lists module dependencies with `go list -deps` in workDir.
+func GoList(workDir string) Lister {
+	return func(ctx context.Context, p platform.Platform, tags []string, tests bool) ([]string, error) {
+		args := []string{"list", "-deps", "-f", "{{with .Module}}{{.Path}}{{end}}"}
+		if tests {
+			args = append(args, "-test")
+		}
+		if len(tags) > 0 {
+			args = append(args, "-tags", strings.Join(tags, ","))
+		}
+		args = append(args, "./...")
+
+		cmd := execx.Command(ctx, workDir, "go", args...)
+		cmd.Env = append(os.Environ(), "GOOS="+p.OS, "GOARCH="+p.Arch)
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to run go list for %s: %w", p, err)
+		}
+
+		seen := make(map[string]bool)
+		var modules []string
+		sc := bufio.NewScanner(bytes.NewReader(out))
+		for sc.Scan() {
+			path := strings.TrimSpace(sc.Text())
+			if path != "" && !seen[path] {
+				seen[path] = true
+				modules = append(modules, path)
+			}
+		}
+		return modules, sc.Err()
+	}
+}
+
+// Usage records the platforms on which a module is imported.
+type Usage struct {
+	Build []platform.Platform // imported by non-test packages
+	Test  []platform.Platform // imported only by tests
+}
+
+// Report is the result of Analyze.
+type Report struct {
+	Platforms []platform.Platform
+	Modules   map[string]*Usage
+}
+
+// Analyze lists dependencies for every platform, once without and once with
+// tests.
+func Analyze(ctx context.Context, platforms []platform.Platform, tags []string, list Lister) (Report, error) {
+	report := Report{Platforms: platforms, Modules: make(map[string]*Usage)}
+	for _, p := range platforms {
+		build, err := list(ctx, p, tags, false)
+		if err != nil {
+			return Report{}, err
+		}
+		withTests, err := list(ctx, p, tags, true)
+		if err != nil {
+			return Report{}, err
+		}
+
+		inBuild := make(map[string]bool, len(build))
+		for _, m := range build {
+			inBuild[m] = true
+			report.usage(m).Build = append(report.usage(m).Build, p)
+		}
+		for _, m := range withTests {
+			if !inBuild[m] {
+				report.usage(m).Test = append(report.usage(m).Test, p)
+			}
+		}
+	}
+	return report, nil
+}
+
+func (r Report) usage(module string) *Usage {
+	u, ok := r.Modules[module]
+	if !ok {
+		u = &Usage{}
+		r.Modules[module] = u
+	}
+	return u
+}
+
+// Describe explains how module is used when that deserves attention: unused
+// on every platform, only used by tests, or only built on some platforms.
+// It returns "" for modules built on every analyzed platform.
+func (r Report) Describe(module string) string {
+	u := r.Modules[module]
+	switch {
+	case u == nil:
+		return "not imported on " + join(r.Platforms)
+	case len(u.Build) == 0:
+		return "only imported by tests"
+	case len(u.Build) < len(r.Platforms):
+		return "only imported on " + join(u.Build)
+	}
+	return ""
+}
+
+func join(platforms []platform.Platform) string {
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.String()
+	}
+	return strings.Join(names, ", ")
+}
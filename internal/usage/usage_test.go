@@ -0,0 +1,46 @@
+package usage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/platform"
+)
+
+func TestAnalyze(t *testing.T) {
+	linux := platform.Platform{OS: "linux", Arch: "amd64"}
+	windows := platform.Platform{OS: "windows", Arch: "amd64"}
+
+	var gotTags []string
+	list := func(_ context.Context, p platform.Platform, tags []string, tests bool) ([]string, error) {
+		gotTags = tags
+		modules := []string{"example.com/core"}
+		if p.OS == "windows" {
+			modules = append(modules, "golang.org/x/sys")
+		}
+		if tests {
+			modules = append(modules, "github.com/stretchr/testify")
+		}
+		return modules, nil
+	}
+
+	report, err := Analyze(context.Background(), []platform.Platform{linux, windows}, []string{"integration"}, list)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(gotTags) != 1 || gotTags[0] != "integration" {
+		t.Fatalf("tags not passed to lister: %v", gotTags)
+	}
+
+	cases := map[string]string{
+		"example.com/core":            "",
+		"golang.org/x/sys":            "only imported on windows/amd64",
+		"github.com/stretchr/testify": "only imported by tests",
+		"example.com/unused":          "not imported on linux/amd64, windows/amd64",
+	}
+	for module, want := range cases {
+		if got := report.Describe(module); got != want {
+			t.Fatalf("Describe(%q) = %q, want %q", module, got, want)
+		}
+	}
+}
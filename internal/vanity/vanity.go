@@ -0,0 +1,252 @@
+// Package vanity resolves Go module paths, including vanity import paths
+// served with <meta name="go-import"> tags, to their source repository URLs.
+// Lookups are cached on disk because vanity servers are often slow or flaky.
+package vanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache lifetimes for successful and failed lookups.
+const (
+	DefaultTTL         = 7 * 24 * time.Hour
+	DefaultNegativeTTL = 24 * time.Hour
+)
+
+// cacheFileName is the cache file inside the resolver's cache directory.
+const cacheFileName = "vanity.json"
+
+// ErrUnresolved is returned when no repository could be determined.
+var ErrUnresolved = errors.New("could not resolve repository for module")
+
+// hostedPrefixes are code hosts whose module paths are repository paths.
+var hostedPrefixes = []string{"github.com/", "gitlab.com/", "bitbucket.org/"}
+
+// fallbacks maps well-known vanity prefixes to repository URL prefixes. They
+// are used only when a live lookup fails and nothing is cached.
+var fallbacks = []struct{ prefix, repo string }{
+	{"golang.org/x/", "https://github.com/golang/"},
+	{"google.golang.org/grpc", "https://github.com/grpc/grpc-go"},
+	{"google.golang.org/protobuf", "https://github.com/protocolbuffers/protobuf-go"},
+	{"k8s.io/", "https://github.com/kubernetes/"},
+	{"sigs.k8s.io/", "https://github.com/kubernetes-sigs/"},
+	{"go.uber.org/", "https://github.com/uber-go/"},
+	{"go.opentelemetry.io/otel", "https://github.com/open-telemetry/opentelemetry-go"},
+	{"gopkg.in/yaml.", "https://github.com/go-yaml/yaml"},
+}
+
+type entry struct {
+	Repo    string    `json:"repo,omitempty"`
+	Failed  bool      `json:"failed,omitempty"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// Resolver maps module paths to repository URLs.
+type Resolver struct {
+	cacheDir    string
+	ttl         time.Duration
+	negativeTTL time.Duration
+	httpClient  *http.Client
+	now         func() time.Time
+	baseURL     func(modulePath string) string
+
+	mu      sync.Mutex
+	loaded  bool
+	dirty   bool
+	entries map[string]entry
+}
+
+// NewResolver creates a resolver caching lookups in cacheDir. An empty
+// cacheDir disables the on-disk cache.
+func NewResolver(cacheDir string) *Resolver {
+	return &Resolver{
+		cacheDir:    cacheDir,
+		ttl:         DefaultTTL,
+		negativeTTL: DefaultNegativeTTL,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		now:     time.Now,
+		baseURL: func(modulePath string) string { return "https://" + modulePath },
+		entries: make(map[string]entry),
+	}
+}
+
+// RepoURL returns the repository URL for modulePath, e.g.
+// "https://github.com/golang/tools" for golang.org/x/tools/gopls.
+func (r *Resolver) RepoURL(ctx context.Context, modulePath string) (string, error) {
+	if repo, ok := hostedRepo(modulePath); ok {
+		return repo, nil
+	}
+
+	r.mu.Lock()
+	r.load()
+	cached, hasCached := r.entries[modulePath]
+	r.mu.Unlock()
+
+	if hasCached {
+		ttl := r.ttl
+		if cached.Failed {
+			ttl = r.negativeTTL
+		}
+		if r.now().Sub(cached.Fetched) < ttl {
+			if cached.Failed {
+				return fallbackRepo(modulePath)
+			}
+			return cached.Repo, nil
+		}
+	}
+
+	repo, err := r.fetch(ctx, modulePath)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if hasCached && !cached.Failed {
+			return cached.Repo, nil // stale but better than nothing
+		}
+		r.store(modulePath, entry{Failed: true, Fetched: r.now()})
+		return fallbackRepo(modulePath)
+	}
+	r.store(modulePath, entry{Repo: repo, Fetched: r.now()})
+	return repo, nil
+}
+
+// Save writes new lookups to the cache directory.
+func (r *Resolver) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cacheDir == "" || !r.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(r.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode vanity cache: %w", err)
+	}
+	if err := os.MkdirAll(r.cacheDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(r.cacheDir, cacheFileName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write vanity cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write vanity cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write vanity cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(r.cacheDir, cacheFileName)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write vanity cache: %w", err)
+	}
+	r.dirty = false
+	return nil
+}
+
+// load reads the cache file once; a missing or corrupt file starts empty.
+// r.mu must be held.
+func (r *Resolver) load() {
+	if r.loaded {
+		return
+	}
+	r.loaded = true
+	if r.cacheDir == "" {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(r.cacheDir, cacheFileName))
+	if err != nil {
+		return
+	}
+	var entries map[string]entry
+	if json.Unmarshal(data, &entries) == nil {
+		for k, v := range entries {
+			r.entries[k] = v
+		}
+	}
+}
+
+func (r *Resolver) store(modulePath string, e entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[modulePath] = e
+	r.dirty = true
+}
+
+var metaTag = regexp.MustCompile(`(?is)<meta\s+name=["']go-import["']\s+content=["']([^"']+)["']`)
+
+// fetch performs a ?go-get=1 request and returns the repository of the
+// go-import tag whose prefix covers modulePath.
+func (r *Resolver) fetch(ctx context.Context, modulePath string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL(modulePath)+"?go-get=1", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("go-get request returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	for _, m := range metaTag.FindAllStringSubmatch(string(body), -1) {
+		fields := strings.Fields(m[1])
+		if len(fields) != 3 || fields[1] == "mod" {
+			continue
+		}
+		prefix, repo := fields[0], fields[2]
+		if modulePath == prefix || strings.HasPrefix(modulePath, prefix+"/") {
+			return strings.TrimSuffix(repo, ".git"), nil
+		}
+	}
+	return "", ErrUnresolved
+}
+
+func hostedRepo(modulePath string) (string, bool) {
+	for _, host := range hostedPrefixes {
+		if !strings.HasPrefix(modulePath, host) {
+			continue
+		}
+		parts := strings.Split(modulePath, "/")
+		if len(parts) < 3 {
+			return "", false
+		}
+		return "https://" + strings.Join(parts[:3], "/"), true
+	}
+	return "", false
+}
+
+func fallbackRepo(modulePath string) (string, error) {
+	for _, f := range fallbacks {
+		if !strings.HasPrefix(modulePath, f.prefix) {
+			continue
+		}
+		if strings.HasSuffix(f.prefix, "/") {
+			// Map the first path element after the prefix to a repository.
+			name, _, _ := strings.Cut(strings.TrimPrefix(modulePath, f.prefix), "/")
+			return f.repo + name, nil
+		}
+		return f.repo, nil
+	}
+	return "", ErrUnresolved
+}
@@ -0,0 +1,103 @@
+package vanity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestResolver(t *testing.T, srv *httptest.Server, dir string) *Resolver {
+	t.Helper()
+	r := NewResolver(dir)
+	r.baseURL = func(modulePath string) string { return srv.URL + "/" + modulePath }
+	return r
+}
+
+func TestRepoURL_HostedPaths(t *testing.T) {
+	r := NewResolver("")
+	repo, err := r.RepoURL(context.Background(), "github.com/spf13/cobra/v2")
+	if err != nil || repo != "https://github.com/spf13/cobra" {
+		t.Fatalf("unexpected repo %q, %v", repo, err)
+	}
+}
+
+func TestRepoURL_MetaTagAndCache(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`<html><head>
+<meta name="go-import" content="example.com/lib mod https://proxy.example.com">
+<meta name="go-import" content="example.com/lib git https://git.example.com/lib.git">
+</head></html>`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	r := newTestResolver(t, srv, dir)
+	repo, err := r.RepoURL(context.Background(), "example.com/lib/sub")
+	if err != nil || repo != "https://git.example.com/lib" {
+		t.Fatalf("unexpected repo %q, %v", repo, err)
+	}
+	if err := r.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// A fresh resolver reads the cache instead of the network.
+	r2 := newTestResolver(t, srv, dir)
+	if repo, err := r2.RepoURL(context.Background(), "example.com/lib/sub"); err != nil || repo != "https://git.example.com/lib" {
+		t.Fatalf("unexpected cached repo %q, %v", repo, err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestRepoURL_StaleCacheOnFailure(t *testing.T) {
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			http.Error(w, "down", http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`<meta name="go-import" content="example.com/lib git https://git.example.com/lib">`))
+	}))
+	defer srv.Close()
+
+	r := newTestResolver(t, srv, "")
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	if _, err := r.RepoURL(context.Background(), "example.com/lib"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	fail = true
+	now = now.Add(2 * DefaultTTL)
+	if repo, err := r.RepoURL(context.Background(), "example.com/lib"); err != nil || repo != "https://git.example.com/lib" {
+		t.Fatalf("expected stale cache fallback, got %q, %v", repo, err)
+	}
+}
+
+func TestRepoURL_KnownFallbacks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	r := newTestResolver(t, srv, "")
+	cases := map[string]string{
+		"golang.org/x/tools/gopls": "https://github.com/golang/tools",
+		"k8s.io/client-go":         "https://github.com/kubernetes/client-go",
+		"google.golang.org/grpc":   "https://github.com/grpc/grpc-go",
+	}
+	for path, want := range cases {
+		if repo, err := r.RepoURL(context.Background(), path); err != nil || repo != want {
+			t.Fatalf("RepoURL(%q) = %q, %v; want %q", path, repo, err, want)
+		}
+	}
+	if _, err := r.RepoURL(context.Background(), "example.com/unknown"); !errors.Is(err, ErrUnresolved) {
+		t.Fatalf("expected ErrUnresolved, got %v", err)
+	}
+}
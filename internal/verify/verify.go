@@ -0,0 +1,171 @@
+// Package verify checks that a Go project still builds and passes its tests
+// after a dependency changes, optionally running only the tests of packages
+// that depend on the changed module.
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+)
+
+// Stage is a verification step.
+type Stage string
+
+const (
+	StageBuild   Stage = "build"
+	StageTest    Stage = "test"
+	StageCommand Stage = "command"
+)
+
+// Options configures verification.
+type Options struct {
+	// Command replaces the default build and test steps, e.g. "make test".
+	// It is split on spaces and run without a shell.
+	Command string
+	// ScopeTests limits `go test` to packages that depend on the changed
+	// module. Ignored when Command is set.
+	ScopeTests bool
+}
+
+// Runner runs name with args in dir and returns its combined output.
+type Runner func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+
+func execRunner(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	return execx.Command(ctx, dir, name, args...).CombinedOutput()
+}
+
+// execOutput runs name like execRunner but returns standard output only, so
+// warnings on stderr never mix with output that is parsed. Stderr goes into
+// the error instead.
+func execOutput(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	out, err := execx.Command(ctx, dir, name, args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		err = fmt.Errorf("%s: %w", bytes.TrimSpace(exitErr.Stderr), err)
+	}
+	return out, err
+}
+
+// Failure describes a failed verification step.
+type Failure struct {
+	Stage   Stage
+	Command string
+	Output  string
+	Err     error
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("%s failed: %v", f.Command, f.Err)
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// Verifier verifies a project in workDir.
+type Verifier struct {
+	workDir string
+	opts    Options
+	run     Runner
+	list    Runner // Like run, for commands whose standard output is parsed
+}
+
+// New creates a Verifier for the project in workDir.
+func New(workDir string, opts Options) *Verifier {
+	return &Verifier{workDir: workDir, opts: opts, run: execRunner, list: execOutput}
+}
+
+// Verify runs the configured command, or `go build ./...` followed by
+// `go test`, after module changed. onStage, if set, is called as each step
+// starts. A failing step is returned as a *Failure.
+func (v *Verifier) Verify(ctx context.Context, module string, onStage func(Stage)) error {
+	if onStage == nil {
+		onStage = func(Stage) {}
+	}
+
+	if v.opts.Command != "" {
+		fields := strings.Fields(v.opts.Command)
+		onStage(StageCommand)
+		return v.step(ctx, StageCommand, fields[0], fields[1:]...)
+	}
+
+	onStage(StageBuild)
+	if err := v.step(ctx, StageBuild, "go", "build", "./..."); err != nil {
+		return err
+	}
+
+	pkgs := []string{"./..."}
+	if v.opts.ScopeTests {
+		scoped, err := v.TestPackages(ctx, module)
+		if err != nil {
+			return err
+		}
+		if len(scoped) == 0 {
+			return nil // no package depends on module
+		}
+		pkgs = scoped
+	}
+	onStage(StageTest)
+	return v.step(ctx, StageTest, "go", append([]string{"test"}, pkgs...)...)
+}
+
+// Build runs `go build` on the project packages that import module,
+// directly or transitively, which compiles every use of it by the direct
+// dependencies in between. The configured Command is not used.
+func (v *Verifier) Build(ctx context.Context, module string) error {
+	pkgs, err := v.TestPackages(ctx, module)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) == 0 {
+		return nil
+	}
+	return v.step(ctx, StageBuild, "go", append([]string{"build"}, pkgs...)...)
+}
+
+func (v *Verifier) step(ctx context.Context, stage Stage, name string, args ...string) error {
+	out, err := v.run(ctx, v.workDir, name, args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return &Failure{Stage: stage, Command: strings.Join(append([]string{name}, args...), " "), Output: string(out), Err: err}
+	}
+	return nil
+}
+
+// testPackagesTemplate prints a package followed by everything it or its
+// tests import.
+const testPackagesTemplate = `{{.ImportPath}}{{range .Deps}} {{.}}{{end}}{{range .TestImports}} {{.}}{{end}}{{range .XTestImports}} {{.}}{{end}}`
+
+// TestPackages returns the project packages whose code or tests import a
+// package of module, directly or transitively.
+func (v *Verifier) TestPackages(ctx context.Context, module string) ([]string, error) {
+	out, err := v.list(ctx, v.workDir, "go", "list", "-f", testPackagesTemplate, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run go list: %w", err)
+	}
+	var pkgs []string
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		for _, dep := range fields[1:] {
+			if dep == module || strings.HasPrefix(dep, module+"/") {
+				pkgs = append(pkgs, fields[0])
+				break
+			}
+		}
+	}
+	return pkgs, sc.Err()
+}
@@ -0,0 +1,89 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type recorder struct {
+	calls []string
+	fail  string
+}
+
+func (r *recorder) run(_ context.Context, _, name string, args ...string) ([]byte, error) {
+	cmd := strings.Join(append([]string{name}, args...), " ")
+	r.calls = append(r.calls, cmd)
+	if strings.HasPrefix(cmd, "go list") {
+		// Combined output: stderr lines would read as packages.
+		return []byte("go: downloading github.com/jackc/pgx/v5 v5.5.0\n"), nil
+	}
+	if r.fail != "" && strings.HasPrefix(cmd, r.fail) {
+		return []byte("--- FAIL: TestX"), errors.New("exit status 1")
+	}
+	return nil, nil
+}
+
+// list returns the standard output of go list.
+func (r *recorder) list(_ context.Context, _, name string, args ...string) ([]byte, error) {
+	r.calls = append(r.calls, strings.Join(append([]string{name}, args...), " "))
+	return []byte("example.com/foo/db example.com/foo/internal github.com/jackc/pgx/v5/pgconn\n" +
+		"example.com/foo/web net/http\n" +
+		"example.com/foo/auth golang.org/x/crypto/bcrypt\n"), nil
+}
+
+func TestVerify_ScopedTests(t *testing.T) {
+	r := &recorder{}
+	v := &Verifier{workDir: ".", opts: Options{ScopeTests: true}, run: r.run, list: r.list}
+
+	var stages []Stage
+	if err := v.Verify(context.Background(), "github.com/jackc/pgx/v5", func(s Stage) { stages = append(stages, s) }); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got := r.calls[len(r.calls)-1]; got != "go test example.com/foo/db" {
+		t.Fatalf("expected scoped go test, got %q", got)
+	}
+	if len(stages) != 2 || stages[0] != StageBuild || stages[1] != StageTest {
+		t.Fatalf("unexpected stages: %v", stages)
+	}
+
+	r.calls = nil
+	if err := v.Verify(context.Background(), "example.com/unused", nil); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	for _, c := range r.calls {
+		if strings.HasPrefix(c, "go test") {
+			t.Fatalf("expected tests to be skipped when nothing imports the module, got %v", r.calls)
+		}
+	}
+}
+
+func TestVerify_CustomCommandFailure(t *testing.T) {
+	r := &recorder{fail: "make test"}
+	v := &Verifier{workDir: ".", opts: Options{Command: "make test", ScopeTests: true}, run: r.run, list: r.list}
+
+	err := v.Verify(context.Background(), "github.com/jackc/pgx/v5", nil)
+	var f *Failure
+	if !errors.As(err, &f) || f.Stage != StageCommand || f.Output != "--- FAIL: TestX" {
+		t.Fatalf("expected command failure, got %v", err)
+	}
+	if len(r.calls) != 1 || r.calls[0] != "make test" {
+		t.Fatalf("expected only the custom command to run, got %v", r.calls)
+	}
+}
+
+func TestBuild_OnlyImportingPackages(t *testing.T) {
+	r := &recorder{fail: "go build"}
+	v := &Verifier{workDir: ".", opts: Options{Command: "make test"}, run: r.run, list: r.list}
+
+	err := v.Build(context.Background(), "golang.org/x/crypto")
+	var f *Failure
+	if !errors.As(err, &f) || f.Command != "go build example.com/foo/auth" {
+		t.Fatalf("expected a scoped build failure, got %v", err)
+	}
+	r.calls = nil
+	if err := v.Build(context.Background(), "example.com/unused"); err != nil || len(r.calls) != 1 {
+		t.Fatalf("expected no build for an unused module, got %v, calls %v", err, r.calls)
+	}
+}
@@ -0,0 +1,86 @@
+// Package version reports build metadata for the faro binary.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Build metadata, set at link time via -ldflags "-X". When empty, values are
+// recovered from the module build info embedded by `go install`.
+var (
+	Version = ""
+	Commit  = ""
+	Date    = ""
+)
+
+// Info describes the running binary.
+type Info struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit,omitempty"`
+	Date      string   `json:"date,omitempty"`
+	GoVersion string   `json:"goVersion"`
+	Platform  string   `json:"platform"`
+	Features  []string `json:"features,omitempty"`
+}
+
+// readBuildInfo is overridable for testing.
+var readBuildInfo = debug.ReadBuildInfo
+
+// Get returns the build metadata, preferring link-time values over build info.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := readBuildInfo(); ok {
+		if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = s.Value
+				}
+			case "vcs.modified":
+				if s.Value == "true" && info.Commit != "" && !strings.HasSuffix(info.Commit, "-dirty") {
+					info.Commit += "-dirty"
+				}
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	return info
+}
+
+// String renders the info as a multi-line report suitable for bug reports.
+func (i Info) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "faro %s\n", i.Version)
+	if i.Commit != "" {
+		fmt.Fprintf(&b, "  commit:   %s\n", i.Commit)
+	}
+	if i.Date != "" {
+		fmt.Fprintf(&b, "  built:    %s\n", i.Date)
+	}
+	fmt.Fprintf(&b, "  go:       %s\n", i.GoVersion)
+	fmt.Fprintf(&b, "  platform: %s\n", i.Platform)
+	if len(i.Features) > 0 {
+		fmt.Fprintf(&b, "  features: %s\n", strings.Join(i.Features, ", "))
+	}
+	return b.String()
+}
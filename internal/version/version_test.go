@@ -0,0 +1,69 @@
+package version
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestGet_PrefersLinkTimeValues(t *testing.T) {
+	origV, origC, origD, origRead := Version, Commit, Date, readBuildInfo
+	defer func() { Version, Commit, Date, readBuildInfo = origV, origC, origD, origRead }()
+
+	Version, Commit, Date = "v1.2.3", "abc123", "2026-01-17T00:00:00Z"
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main:     debug.Module{Version: "v0.0.1"},
+			Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "zzz"}},
+		}, true
+	}
+
+	info := Get()
+	if info.Version != "v1.2.3" || info.Commit != "abc123" || info.Date != "2026-01-17T00:00:00Z" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
+
+func TestGet_FallsBackToBuildInfo(t *testing.T) {
+	origV, origC, origD, origRead := Version, Commit, Date, readBuildInfo
+	defer func() { Version, Commit, Date, readBuildInfo = origV, origC, origD, origRead }()
+
+	Version, Commit, Date = "", "", ""
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Version: "v0.4.0"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "deadbeef"},
+				{Key: "vcs.time", Value: "2026-01-10T00:00:00Z"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+
+	info := Get()
+	if info.Version != "v0.4.0" || info.Commit != "deadbeef-dirty" || info.Date != "2026-01-10T00:00:00Z" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
+
+func TestGet_DevWhenUnknown(t *testing.T) {
+	origV, origRead := Version, readBuildInfo
+	defer func() { Version, readBuildInfo = origV, origRead }()
+
+	Version = ""
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}}, true
+	}
+	if got := Get().Version; got != "dev" {
+		t.Fatalf("expected dev, got %q", got)
+	}
+}
+
+func TestInfo_String(t *testing.T) {
+	s := Info{Version: "v1.0.0", Commit: "abc", GoVersion: "go1.25", Platform: "linux/amd64", Features: []string{"go", "osv"}}.String()
+	for _, want := range []string{"faro v1.0.0", "commit:   abc", "go:       go1.25", "platform: linux/amd64", "features: go, osv"} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("expected %q in %q", want, s)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package vuln
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+)
+
+// DefaultConcurrency is the number of lookups AnnotateModules runs in parallel
+// when Options.Concurrency is not set.
+const DefaultConcurrency = 8
+
+// DefaultLookupTimeout bounds one module version lookup when
+// Options.Timeout is not set.
+const DefaultLookupTimeout = 20 * time.Second
+
+// Options configures AnnotateModules.
+type Options struct {
+	// Client is the vulnerability source to query. If nil, an OSV client for
+	// Ecosystem is created.
+	Client Client
+
+	// Ecosystem is the OSV ecosystem name ("Go", "npm", "PyPI"); defaults to "Go".
+	Ecosystem string
+
+	// Concurrency limits parallel lookups; defaults to DefaultConcurrency.
+	Concurrency int
+
+	// Timeout bounds each lookup, so one slow database response fails that
+	// module instead of stalling the scan; defaults to DefaultLookupTimeout.
+	Timeout time.Duration
+
+	// Skip, when set, excludes modules by name: they are not looked up and
+	// keep empty counts.
+	Skip func(module string) bool
+
+	// OnProgress, when set, is called after each unique lookup finishes
+	// with the number done so far and the total. Calls never overlap.
+	OnProgress func(done, total int)
+}
+
+// LookupError records a failed vulnerability lookup for one module version.
+type LookupError struct {
+	Module  string
+	Version string
+	Err     error
+}
+
+func (e *LookupError) Error() string {
+	return fmt.Sprintf("vulnerability check failed for %s@%s: %v", e.Module, e.Version, e.Err)
+}
+
+func (e *LookupError) Unwrap() error {
+	return e.Err
+}
+
+// lookup is a unique module@version query.
+type lookup struct {
+	name    string
+	version string
+}
+
+// AnnotateModules fills VulnCurrent and VulnUpdate for every module that has an
+// update. Identical module versions are queried once and lookups run on a
+// pool of opts.Concurrency workers, each bounded by opts.Timeout. Failed lookups leave the counts at zero and are reported in
+// the returned slice, in module order. The error is non-nil only if ctx was
+// canceled before all lookups completed.
+func AnnotateModules(ctx context.Context, modules []scanner.Module, opts Options) ([]*LookupError, error) {
+	client := opts.Client
+	if client == nil {
+		ecosystem := opts.Ecosystem
+		if ecosystem == "" {
+			ecosystem = "Go"
+		}
+		client = NewClientForEcosystem(ecosystem)
+	}
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultLookupTimeout
+	}
+
+	// Batch identical queries so shared versions are only fetched once.
+	index := make(map[lookup]int)
+	var queries []lookup
+	add := func(name, version string) {
+		q := lookup{name: name, version: version}
+		if _, ok := index[q]; !ok {
+			index[q] = len(queries)
+			queries = append(queries, q)
+		}
+	}
+	skipped := func(m scanner.Module) bool {
+		return m.Update == nil || (opts.Skip != nil && opts.Skip(moduleName(m)))
+	}
+	for _, m := range modules {
+		if skipped(m) {
+			continue
+		}
+		name := moduleName(m)
+		add(name, m.Version)
+		add(name, m.Update.Version)
+	}
+
+	counts := make([]SeverityCounts, len(queries))
+	errs := make([]error, len(queries))
+
+	var progressMu sync.Mutex
+	done := 0
+	finished := func() {
+		if opts.OnProgress == nil {
+			return
+		}
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		done++
+		opts.OnProgress(done, len(queries))
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(queries); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+				counts[i], errs[i] = client.CheckModule(lookupCtx, queries[i].name, queries[i].version)
+				if errors.Is(errs[i], context.DeadlineExceeded) && ctx.Err() == nil {
+					errs[i] = fmt.Errorf("no response within %s: %w", timeout, errs[i])
+				}
+				cancel()
+				finished()
+			}
+		}()
+	}
+
+dispatch:
+	for i := range queries {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var failures []*LookupError
+	for i := range queries {
+		if errs[i] != nil {
+			failures = append(failures, &LookupError{Module: queries[i].name, Version: queries[i].version, Err: errs[i]})
+		}
+	}
+
+	for i := range modules {
+		if skipped(modules[i]) {
+			continue
+		}
+		name := moduleName(modules[i])
+		if j := index[lookup{name, modules[i].Version}]; errs[j] == nil {
+			modules[i].VulnCurrent = toVulnInfo(counts[j])
+		}
+		if j := index[lookup{name, modules[i].Update.Version}]; errs[j] == nil {
+			modules[i].VulnUpdate = toVulnInfo(counts[j])
+		}
+	}
+
+	return failures, nil
+}
+
+func toVulnInfo(c SeverityCounts) scanner.VulnInfo {
+	return scanner.VulnInfo{
+		Low:      c.Low,
+		Medium:   c.Medium,
+		High:     c.High,
+		Critical: c.Critical,
+		Total:    c.Total,
+	}
+}
+
+// moduleName returns the package name, falling back to Path for Go compatibility.
+func moduleName(m scanner.Module) string {
+	if m.Name == "" {
+		return m.Path
+	}
+	return m.Name
+}
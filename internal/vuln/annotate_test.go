@@ -0,0 +1,173 @@
+package vuln_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/scanner"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+type stubClient struct {
+	mu      sync.Mutex
+	calls   map[string]int
+	results map[string]vuln.SeverityCounts
+	fail    map[string]error
+}
+
+func (s *stubClient) CheckModule(ctx context.Context, modulePath, version string) (vuln.SeverityCounts, error) {
+	key := modulePath + "@" + version
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calls == nil {
+		s.calls = make(map[string]int)
+	}
+	s.calls[key]++
+	if err := s.fail[key]; err != nil {
+		return vuln.SeverityCounts{}, err
+	}
+	return s.results[key], nil
+}
+
+func TestAnnotateModules_FillsCountsAndDedupes(t *testing.T) {
+	client := &stubClient{results: map[string]vuln.SeverityCounts{
+		"a@v1.0.0": {High: 1, Total: 1},
+	}}
+	mods := []scanner.Module{
+		{Name: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+		{Path: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+		{Name: "b", Version: "v1.0.0"}, // no update: skipped
+	}
+
+	failures, err := vuln.AnnotateModules(context.Background(), mods, vuln.Options{Client: client, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(failures) != 0 {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+	for i := 0; i < 2; i++ {
+		if mods[i].VulnCurrent.High != 1 || mods[i].VulnCurrent.Total != 1 {
+			t.Fatalf("module %d: unexpected current counts: %+v", i, mods[i].VulnCurrent)
+		}
+		if mods[i].VulnUpdate.Total != 0 {
+			t.Fatalf("module %d: unexpected update counts: %+v", i, mods[i].VulnUpdate)
+		}
+	}
+	if client.calls["a@v1.0.0"] != 1 || client.calls["a@v1.1.0"] != 1 {
+		t.Fatalf("expected each version queried once, got %v", client.calls)
+	}
+	if _, ok := client.calls["b@v1.0.0"]; ok {
+		t.Fatalf("did not expect module without update to be queried")
+	}
+}
+
+func TestAnnotateModules_Skip(t *testing.T) {
+	client := &stubClient{results: map[string]vuln.SeverityCounts{
+		"a@v1.0.0":                  {High: 1, Total: 1},
+		"corp.example.com/x@v1.0.0": {High: 1, Total: 1},
+	}}
+	mods := []scanner.Module{
+		{Name: "corp.example.com/x", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+		{Name: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+	}
+
+	_, err := vuln.AnnotateModules(context.Background(), mods, vuln.Options{
+		Client: client,
+		Skip:   func(name string) bool { return strings.HasPrefix(name, "corp.example.com/") },
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(client.calls) != 2 || client.calls["a@v1.0.0"] != 1 {
+		t.Fatalf("expected only module a to be queried, got %v", client.calls)
+	}
+	if mods[0].VulnCurrent.Total != 0 || mods[1].VulnCurrent.Total != 1 {
+		t.Fatalf("unexpected counts: %+v, %+v", mods[0].VulnCurrent, mods[1].VulnCurrent)
+	}
+}
+
+func TestAnnotateModules_ReportsFailures(t *testing.T) {
+	boom := errors.New("boom")
+	client := &stubClient{fail: map[string]error{"a@v1.1.0": boom}}
+	mods := []scanner.Module{{Name: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+
+	failures, err := vuln.AnnotateModules(context.Background(), mods, vuln.Options{Client: client})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 failure, got %v", failures)
+	}
+	if failures[0].Module != "a" || failures[0].Version != "v1.1.0" || !errors.Is(failures[0], boom) {
+		t.Fatalf("unexpected failure: %+v", failures[0])
+	}
+}
+
+func TestAnnotateModules_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods := []scanner.Module{{Name: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}}}
+
+	_, err := vuln.AnnotateModules(ctx, mods, vuln.Options{Client: &stubClient{}})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+// blockingClient answers slow@ lookups only when their context ends.
+type blockingClient struct{ stubClient }
+
+func (b *blockingClient) CheckModule(ctx context.Context, modulePath, version string) (vuln.SeverityCounts, error) {
+	if modulePath == "slow" {
+		<-ctx.Done()
+		return vuln.SeverityCounts{}, ctx.Err()
+	}
+	return b.stubClient.CheckModule(ctx, modulePath, version)
+}
+
+func TestAnnotateModules_TimesOutSlowLookups(t *testing.T) {
+	client := &blockingClient{stubClient{results: map[string]vuln.SeverityCounts{"fast@v1.0.0": {Low: 1, Total: 1}}}}
+	mods := []scanner.Module{
+		{Name: "slow", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+		{Name: "fast", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+	}
+
+	failures, err := vuln.AnnotateModules(context.Background(), mods, vuln.Options{Client: client, Concurrency: 1, Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(failures) != 2 || failures[0].Module != "slow" || !errors.Is(failures[0], context.DeadlineExceeded) {
+		t.Fatalf("expected both slow lookups to time out, got %v", failures)
+	}
+	if mods[1].VulnCurrent.Total != 1 {
+		t.Fatalf("expected the fast lookup to finish, got %+v", mods[1].VulnCurrent)
+	}
+}
+
+func TestAnnotateModules_ReportsProgress(t *testing.T) {
+	mods := []scanner.Module{
+		{Name: "a", Version: "v1.0.0", Update: &scanner.UpdateInfo{Version: "v1.1.0"}},
+		{Name: "b", Version: "v2.0.0", Update: &scanner.UpdateInfo{Version: "v2.0.1"}},
+	}
+	var done []int
+	total := 0
+	_, err := vuln.AnnotateModules(context.Background(), mods, vuln.Options{
+		Client:      &stubClient{},
+		Concurrency: 3,
+		OnProgress: func(d, n int) {
+			done = append(done, d)
+			total = n
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if total != 4 || len(done) != 4 || done[0] != 1 || done[3] != 4 {
+		t.Fatalf("expected 4 ordered progress calls, got %v of %d", done, total)
+	}
+}
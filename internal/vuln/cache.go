@@ -0,0 +1,51 @@
+package vuln
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/pragmaticivan/faro/internal/diskcache"
+)
+
+// cachedClient answers CheckModule from a disk store before asking client.
+type cachedClient struct {
+	client Client
+	store  *diskcache.Store
+	prefix string
+}
+
+// Cached returns a client that keeps the severity counts found by client
+// in store, keyed by ecosystem, module and version. databases are the
+// databases client queries besides OSV; a hash of them is part of the key,
+// so results from a private database stay with the projects that use it.
+// With a nil store it returns client unchanged.
+func Cached(client Client, store *diskcache.Store, ecosystem string, databases ...string) Client {
+	if store == nil {
+		return client
+	}
+	prefix := "vuln:" + ecosystem + ":"
+	if len(databases) > 0 {
+		sorted := slices.Clone(databases)
+		slices.Sort(sorted)
+		sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+		prefix += hex.EncodeToString(sum[:8]) + ":"
+	}
+	return cachedClient{client: client, store: store, prefix: prefix}
+}
+
+func (c cachedClient) CheckModule(ctx context.Context, modulePath, version string) (SeverityCounts, error) {
+	key := c.prefix + modulePath + "@" + version
+	var counts SeverityCounts
+	if c.store.Get(key, &counts) {
+		return counts, nil
+	}
+	counts, err := c.client.CheckModule(ctx, modulePath, version)
+	if err != nil {
+		return counts, err
+	}
+	c.store.Put(key, counts)
+	return counts, nil
+}
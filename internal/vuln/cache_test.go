@@ -0,0 +1,53 @@
+package vuln_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/diskcache"
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+func TestCached_ReusesStoredCounts(t *testing.T) {
+	dir := t.TempDir()
+	now := func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	inner := &stubClient{
+		results: map[string]vuln.SeverityCounts{"a@v1.0.0": {High: 2, Total: 2}},
+		fail:    map[string]error{"b@v1.0.0": errors.New("boom")},
+	}
+
+	client := vuln.Cached(inner, diskcache.Open(dir, "lookups.json", time.Hour, now), "Go")
+	for i := 0; i < 2; i++ {
+		if counts, err := client.CheckModule(context.Background(), "a", "v1.0.0"); err != nil || counts.High != 2 {
+			t.Fatalf("unexpected result: %+v, %v", counts, err)
+		}
+		if _, err := client.CheckModule(context.Background(), "b", "v1.0.0"); err == nil {
+			t.Fatalf("expected the failure to be returned")
+		}
+	}
+	if inner.calls["a@v1.0.0"] != 1 || inner.calls["b@v1.0.0"] != 2 {
+		t.Fatalf("expected successes to be cached and failures retried, got %v", inner.calls)
+	}
+}
+
+func TestCached_KeyedByDatabases(t *testing.T) {
+	dir := t.TempDir()
+	now := func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	private := &stubClient{results: map[string]vuln.SeverityCounts{"a@v1.0.0": {Critical: 1, Total: 1}}}
+	public := &stubClient{results: map[string]vuln.SeverityCounts{}}
+
+	store := diskcache.Open(dir, "lookups.json", time.Hour, now)
+	if _, err := vuln.Cached(private, store, "Go", "https://vuln.corp.example.com").CheckModule(context.Background(), "a", "v1.0.0"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	counts, err := vuln.Cached(public, store, "Go").CheckModule(context.Background(), "a", "v1.0.0")
+	if err != nil || counts.Total != 0 || public.calls["a@v1.0.0"] != 1 {
+		t.Fatalf("expected a project without the private database to query again, got %+v, %v", counts, err)
+	}
+	counts, err = vuln.Cached(private, store, "Go", "https://vuln.corp.example.com").CheckModule(context.Background(), "a", "v1.0.0")
+	if err != nil || counts.Critical != 1 || private.calls["a@v1.0.0"] != 1 {
+		t.Fatalf("expected the private result to be cached, got %+v, %v", counts, err)
+	}
+}
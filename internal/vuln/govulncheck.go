@@ -0,0 +1,91 @@
+package vuln
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/pragmaticivan/faro/internal/execx"
+)
+
+// Reachability holds, by module path, the vulnerabilities govulncheck found
+// reachable from the code of the module it analyzed.
+type Reachability map[string]SeverityCounts
+
+// govulncheckMessage is one message of the `govulncheck -json` stream; the
+// config, progress and SBOM messages are ignored.
+type govulncheckMessage struct {
+	OSV     *osvEntry `json:"osv"`
+	Finding *struct {
+		OSV   string `json:"osv"`
+		Trace []struct {
+			Module   string `json:"module"`
+			Package  string `json:"package"`
+			Function string `json:"function"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// ParseGovulncheck reads the `govulncheck -json` stream in r and counts,
+// per module, the advisories whose vulnerable functions the code calls.
+// Findings govulncheck only traced to an imported package or a required
+// module are not reachable and are left out.
+func ParseGovulncheck(r io.Reader) (Reachability, error) {
+	entries := make(map[string]osvEntry)
+	reachable := make(map[string]map[string]bool) // module -> advisory IDs
+	dec := json.NewDecoder(r)
+	for {
+		var msg govulncheckMessage
+		if err := dec.Decode(&msg); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse govulncheck output: %w", err)
+		}
+		if msg.OSV != nil {
+			entries[msg.OSV.ID] = *msg.OSV
+		}
+		// The first frame of a trace is the vulnerable symbol; it names a
+		// function only when the call graph reaches it.
+		if f := msg.Finding; f != nil && len(f.Trace) > 0 && f.Trace[0].Function != "" {
+			module := f.Trace[0].Module
+			if reachable[module] == nil {
+				reachable[module] = make(map[string]bool)
+			}
+			reachable[module][f.OSV] = true
+		}
+	}
+
+	out := make(Reachability, len(reachable))
+	for module, ids := range reachable {
+		advisories := make([]Advisory, 0, len(ids))
+		for id := range ids {
+			entry := entries[id]
+			entry.ID = id
+			advisories = append(advisories, entry.advisory(module, ""))
+		}
+		out[module] = Count(advisories)
+	}
+	return out, nil
+}
+
+// RunGovulncheck runs govulncheck at symbol level over the packages of the
+// module in dir and returns its JSON output.
+func RunGovulncheck(ctx context.Context, dir string) ([]byte, error) {
+	cmd := execx.Command(ctx, dir, "govulncheck", "-json", "-scan", "symbol", "./...")
+	out, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, fmt.Errorf("govulncheck is not installed; install it with go install golang.org/x/vuln/cmd/govulncheck@latest")
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("govulncheck failed: %s: %w", bytes.TrimSpace(exitErr.Stderr), err)
+		}
+		return nil, fmt.Errorf("govulncheck failed: %w", err)
+	}
+	return out, nil
+}
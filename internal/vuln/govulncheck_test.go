@@ -0,0 +1,42 @@
+package vuln
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGovulncheck(t *testing.T) {
+	output := `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck"}}
+{"progress":{"message":"Scanning your code..."}}
+{
+  "osv": {
+    "id": "GO-2024-0001",
+    "aliases": ["CVE-2024-0001"],
+    "database_specific": {"severity": "CRITICAL"}
+  }
+}
+{"osv":{"id":"GO-2024-0002"}}
+{"osv":{"id":"GO-2024-0003"}}
+{"finding":{"osv":"GO-2024-0001","trace":[{"module":"example.com/a","version":"v1.0.0"}]}}
+{"finding":{"osv":"GO-2024-0001","trace":[{"module":"example.com/a","version":"v1.0.0","package":"example.com/a/x"}]}}
+{"finding":{"osv":"GO-2024-0001","trace":[{"module":"example.com/a","version":"v1.0.0","package":"example.com/a/x","function":"Parse"},{"module":"example.com/foo","function":"main"}]}}
+{"finding":{"osv":"GO-2024-0001","trace":[{"module":"example.com/a","version":"v1.0.0","package":"example.com/a/x","function":"Decode"}]}}
+{"finding":{"osv":"GO-2024-0002","trace":[{"module":"example.com/a","version":"v1.0.0","package":"example.com/a/y","function":"Run"}]}}
+{"finding":{"osv":"GO-2024-0003","trace":[{"module":"example.com/b","version":"v1.0.0","package":"example.com/b"}]}}
+`
+	got, err := ParseGovulncheck(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseGovulncheck: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected only example.com/a to have reachable vulnerabilities, got %v", got)
+	}
+	want := SeverityCounts{Critical: 1, Medium: 1, Total: 2}
+	if got["example.com/a"] != want {
+		t.Fatalf("expected %+v, got %+v", want, got["example.com/a"])
+	}
+
+	if _, err := ParseGovulncheck(strings.NewReader("{not json")); err == nil {
+		t.Fatal("expected an error for malformed output")
+	}
+}
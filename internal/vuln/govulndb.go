@@ -0,0 +1,266 @@
+package vuln
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pragmaticivan/faro/internal/style"
+)
+
+// GoVulnDB is the public Go vulnerability database, whose entries OSV
+// already includes.
+const GoVulnDB = "https://vuln.go.dev"
+
+// DBClient reads a vulnerability database in the Go vulnerability database
+// format served to govulncheck through GOVULNDB: index/modules.json listing
+// advisory IDs per module, and ID/<id>.json OSV entries. URLs may use the
+// https, http or file scheme.
+type DBClient struct {
+	url        string
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	index   map[string][]string // module path -> advisory IDs; nil until loaded
+	entries map[string]osvEntry
+}
+
+// NewDBClient returns a client for the database at dbURL.
+func NewDBClient(dbURL string) *DBClient {
+	return &DBClient{
+		url:        strings.TrimRight(dbURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		entries:    make(map[string]osvEntry),
+	}
+}
+
+type dbEvent struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+// CheckModule counts the database's advisories affecting modulePath at version.
+func (c *DBClient) CheckModule(ctx context.Context, modulePath, version string) (SeverityCounts, error) {
+	advisories, err := c.Advisories(ctx, modulePath, version)
+	if err != nil {
+		return SeverityCounts{}, err
+	}
+	return Count(advisories), nil
+}
+
+// Advisories returns the database's advisories affecting modulePath at
+// version. The index and entries are fetched once and reused.
+func (c *DBClient) Advisories(ctx context.Context, modulePath, version string) ([]Advisory, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.index == nil {
+		var modules []struct {
+			Path  string `json:"path"`
+			Vulns []struct {
+				ID string `json:"id"`
+			} `json:"vulns"`
+		}
+		if err := c.get(ctx, "index/modules.json", &modules); err != nil {
+			return nil, err
+		}
+		c.index = make(map[string][]string, len(modules))
+		for _, m := range modules {
+			for _, v := range m.Vulns {
+				c.index[m.Path] = append(c.index[m.Path], v.ID)
+			}
+		}
+	}
+
+	var advisories []Advisory
+	for _, id := range c.index[modulePath] {
+		entry, ok := c.entries[id]
+		if !ok {
+			if err := c.get(ctx, "ID/"+id+".json", &entry); err != nil {
+				return nil, err
+			}
+			c.entries[id] = entry
+		}
+		if entry.affects(modulePath, version) {
+			advisories = append(advisories, entry.advisory(modulePath, version))
+		}
+	}
+	return advisories, nil
+}
+
+// get decodes the JSON document at name in the database into v.
+func (c *DBClient) get(ctx context.Context, name string, v any) error {
+	var body io.ReadCloser
+	if strings.HasPrefix(c.url, "file://") {
+		u, err := url.Parse(c.url)
+		if err != nil {
+			return fmt.Errorf("invalid vulnerability database URL %s: %w", c.url, err)
+		}
+		f, err := os.Open(filepath.Join(filepath.FromSlash(u.Path), filepath.FromSlash(name)))
+		if err != nil {
+			return fmt.Errorf("failed to read vulnerability database: %w", err)
+		}
+		body = f
+	} else {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/"+name, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to query vulnerability database %s: %w", c.url, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+			return fmt.Errorf("vulnerability database %s returned status %d for %s", c.url, resp.StatusCode, name)
+		}
+		body = resp.Body
+	}
+	defer func() { _ = body.Close() }()
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s from %s: %w", name, c.url, err)
+	}
+	return nil
+}
+
+// affects reports whether e covers modulePath at version. Entries without
+// SEMVER ranges affect every version.
+func (e osvEntry) affects(modulePath, version string) bool {
+	for _, a := range e.Affected {
+		if a.Package.Name != modulePath {
+			continue
+		}
+		if len(a.Ranges) == 0 {
+			return true
+		}
+		for _, r := range a.Ranges {
+			if r.Type == "SEMVER" && inRange(r.Events, version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inRange applies OSV range events in version order: version is affected
+// when the last event at or below it is an introduction.
+func inRange(events []dbEvent, version string) bool {
+	at := func(e dbEvent) string { return e.Introduced + e.Fixed }
+	sorted := append([]dbEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return compareOSV(at(sorted[i]), at(sorted[j])) < 0 })
+
+	affected := false
+	for _, e := range sorted {
+		if compareOSV(version, at(e)) < 0 {
+			break
+		}
+		affected = e.Introduced != ""
+	}
+	return affected
+}
+
+// compareOSV compares OSV SEMVER versions, where "0" precedes everything.
+// Unparsable versions compare equal.
+func compareOSV(a, b string) int {
+	switch {
+	case a == "0" && b == "0":
+		return 0
+	case a == "0":
+		return -1
+	case b == "0":
+		return 1
+	}
+	cmp, _ := style.ComparePrecedence(a, b)
+	return cmp
+}
+
+// Merge combines clients into one reporting the union of their findings,
+// such as OSV and internal advisory databases. Advisories from clients
+// implementing AdvisoryLister are counted once even when several databases
+// report them under the same ID or an alias; other clients' counts are
+// added as is.
+func Merge(clients ...Client) Client {
+	return mergedClient(clients)
+}
+
+type mergedClient []Client
+
+func (m mergedClient) CheckModule(ctx context.Context, modulePath, version string) (SeverityCounts, error) {
+	var (
+		advisories []Advisory
+		extra      SeverityCounts
+	)
+	for _, c := range m {
+		if lister, ok := c.(AdvisoryLister); ok {
+			found, err := lister.Advisories(ctx, modulePath, version)
+			if err != nil {
+				return SeverityCounts{}, err
+			}
+			advisories = append(advisories, found...)
+			continue
+		}
+		counts, err := c.CheckModule(ctx, modulePath, version)
+		if err != nil {
+			return SeverityCounts{}, err
+		}
+		extra.Low += counts.Low
+		extra.Medium += counts.Medium
+		extra.High += counts.High
+		extra.Critical += counts.Critical
+		extra.Total += counts.Total
+	}
+
+	counts := Count(dedupeAdvisories(advisories))
+	counts.Low += extra.Low
+	counts.Medium += extra.Medium
+	counts.High += extra.High
+	counts.Critical += extra.Critical
+	counts.Total += extra.Total
+	return counts, nil
+}
+
+// Advisories lists the advisories found by the clients that implement
+// AdvisoryLister, once each across databases; other clients are skipped.
+func (m mergedClient) Advisories(ctx context.Context, modulePath, version string) ([]Advisory, error) {
+	var advisories []Advisory
+	for _, c := range m {
+		if lister, ok := c.(AdvisoryLister); ok {
+			found, err := lister.Advisories(ctx, modulePath, version)
+			if err != nil {
+				return nil, err
+			}
+			advisories = append(advisories, found...)
+		}
+	}
+	return dedupeAdvisories(advisories), nil
+}
+
+// dedupeAdvisories drops advisories already listed under their ID or an
+// alias, keeping the first.
+func dedupeAdvisories(advisories []Advisory) []Advisory {
+	seen := make(map[string]bool)
+	unique := advisories[:0]
+	for _, a := range advisories {
+		ids := append([]string{a.ID}, a.Aliases...)
+		dup := false
+		for _, id := range ids {
+			dup = dup || seen[id]
+		}
+		for _, id := range ids {
+			seen[id] = true
+		}
+		if !dup {
+			unique = append(unique, a)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,150 @@
+package vuln_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pragmaticivan/faro/internal/vuln"
+)
+
+var testDB = map[string]string{
+	"index/modules.json": `[{"path": "example.com/lib", "vulns": [{"id": "ACME-2024-0001"}, {"id": "ACME-2024-0002"}]}]`,
+	"ID/ACME-2024-0001.json": `{
+		"id": "ACME-2024-0001",
+		"summary": "Request smuggling in lib",
+		"aliases": ["GHSA-xxxx-yyyy-zzzz"],
+		"database_specific": {"severity": "HIGH"},
+		"affected": [{"package": {"name": "example.com/lib", "ecosystem": "Go"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.2.0"}, {"introduced": "1.4.0"}, {"fixed": "1.4.2"}]}]}]
+	}`,
+	"ID/ACME-2024-0002.json": `{
+		"id": "ACME-2024-0002",
+		"affected": [{"package": {"name": "example.com/lib", "ecosystem": "Go"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "1.1.0-rc.1"}, {"fixed": "1.1.3"}]}]}]
+	}`,
+}
+
+func writeTestDB(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range testDB {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestDBClient_Ranges(t *testing.T) {
+	client := vuln.NewDBClient("file://" + filepath.ToSlash(writeTestDB(t)))
+	ctx := context.Background()
+
+	tests := map[string]vuln.SeverityCounts{
+		"v1.0.0": {High: 1, Total: 1},
+		"v1.1.0": {High: 1, Medium: 1, Total: 2},
+		"v1.2.0": {},
+		"v1.3.0": {},
+		"v1.4.1": {High: 1, Total: 1},
+		"v1.4.2": {},
+	}
+	for version, want := range tests {
+		got, err := client.CheckModule(ctx, "example.com/lib", version)
+		if err != nil {
+			t.Fatalf("CheckModule(%s) returned error: %v", version, err)
+		}
+		if got != want {
+			t.Errorf("CheckModule(%s) = %+v, want %+v", version, got, want)
+		}
+	}
+
+	if got, err := client.CheckModule(ctx, "example.com/other", "v1.0.0"); err != nil || got.Total != 0 {
+		t.Fatalf("expected no advisories for an unlisted module, got %+v %v", got, err)
+	}
+}
+
+func TestDBClient_AdvisoryDetails(t *testing.T) {
+	client := vuln.NewDBClient("file://" + filepath.ToSlash(writeTestDB(t)))
+	ctx := context.Background()
+
+	advisories, err := client.Advisories(ctx, "example.com/lib", "v1.4.1")
+	if err != nil {
+		t.Fatalf("Advisories returned error: %v", err)
+	}
+	if len(advisories) != 1 {
+		t.Fatalf("expected 1 advisory, got %+v", advisories)
+	}
+	a := advisories[0]
+	if a.ID != "ACME-2024-0001" || a.Summary != "Request smuggling in lib" || a.Severity != "HIGH" || a.Fixed != "v1.4.2" {
+		t.Fatalf("unexpected advisory: %+v", a)
+	}
+
+	advisories, err = client.Advisories(ctx, "example.com/lib", "v1.1.0")
+	if err != nil {
+		t.Fatalf("Advisories returned error: %v", err)
+	}
+	fixed := make(map[string]string)
+	for _, a := range advisories {
+		fixed[a.ID] = a.Fixed
+	}
+	if fixed["ACME-2024-0001"] != "v1.2.0" || fixed["ACME-2024-0002"] != "v1.1.3" {
+		t.Fatalf("expected the lowest fix above v1.1.0, got %v", fixed)
+	}
+}
+
+func TestDBClient_HTTP(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, ok := testDB[r.URL.Path[1:]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	client := vuln.NewDBClient(srv.URL + "/")
+	for range 2 {
+		got, err := client.CheckModule(context.Background(), "example.com/lib", "v1.1.0")
+		if err != nil || got.Total != 2 {
+			t.Fatalf("unexpected result %+v %v", got, err)
+		}
+	}
+	if requests != 3 {
+		t.Fatalf("expected the index and each entry fetched once, got %d requests", requests)
+	}
+}
+
+type listerClient []vuln.Advisory
+
+func (l listerClient) CheckModule(ctx context.Context, modulePath, version string) (vuln.SeverityCounts, error) {
+	return vuln.Count(l), nil
+}
+
+func (l listerClient) Advisories(ctx context.Context, modulePath, version string) ([]vuln.Advisory, error) {
+	return l, nil
+}
+
+func TestMerge_DedupesAliases(t *testing.T) {
+	public := listerClient{{ID: "GHSA-xxxx-yyyy-zzzz", Severity: "HIGH"}, {ID: "GO-2024-0100", Severity: "LOW"}}
+	internal := vuln.NewDBClient("file://" + filepath.ToSlash(writeTestDB(t)))
+	counted := &stubClient{results: map[string]vuln.SeverityCounts{"example.com/lib@v1.1.0": {Critical: 1, Total: 1}}}
+
+	got, err := vuln.Merge(public, internal, counted).CheckModule(context.Background(), "example.com/lib", "v1.1.0")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := vuln.SeverityCounts{Low: 1, Medium: 1, High: 1, Critical: 1, Total: 4}
+	if got != want {
+		t.Fatalf("Merge = %+v, want %+v", got, want)
+	}
+}
@@ -25,6 +25,102 @@ type Client interface {
 	CheckModule(ctx context.Context, modulePath, version string) (SeverityCounts, error)
 }
 
+// Advisory is one vulnerability entry affecting a module version.
+type Advisory struct {
+	ID       string
+	Aliases  []string
+	Summary  string
+	Severity string // LOW, MEDIUM, HIGH or CRITICAL
+	Fixed    string // Lowest version fixing it above the affected one; empty when unknown
+}
+
+// AdvisoryLister is implemented by clients that can list the advisories
+// behind their counts, letting Merge drop entries several databases share.
+type AdvisoryLister interface {
+	Advisories(ctx context.Context, modulePath, version string) ([]Advisory, error)
+}
+
+// Count tallies advisories by severity. Unknown severities count as medium.
+func Count(advisories []Advisory) SeverityCounts {
+	var counts SeverityCounts
+	for _, a := range advisories {
+		counts.Total++
+		switch a.Severity {
+		case "LOW":
+			counts.Low++
+		case "HIGH":
+			counts.High++
+		case "CRITICAL":
+			counts.Critical++
+		default:
+			counts.Medium++
+		}
+	}
+	return counts
+}
+
+// osvEntry is the subset of an OSV record used to classify it.
+type osvEntry struct {
+	ID               string   `json:"id"`
+	Aliases          []string `json:"aliases"`
+	Summary          string   `json:"summary"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	Affected []struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string    `json:"type"`
+			Events []dbEvent `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+}
+
+// advisory converts e as it affects modulePath at version, deriving the
+// severity from the CVSS vector when the database does not state one.
+func (e osvEntry) advisory(modulePath, version string) Advisory {
+	severity := strings.ToUpper(e.DatabaseSpecific.Severity)
+	if severity == "" && len(e.Severity) > 0 {
+		severity = ExtractSeverityFromCVSS(e.Severity[0].Score)
+	}
+	if severity == "MODERATE" {
+		severity = "MEDIUM"
+	}
+	return Advisory{ID: e.ID, Aliases: e.Aliases, Summary: e.Summary, Severity: severity, Fixed: e.fixedIn(modulePath, version)}
+}
+
+// fixedIn returns the lowest version of modulePath above version that e
+// lists as fixed, or "" when there is none.
+func (e osvEntry) fixedIn(modulePath, version string) string {
+	fixed := ""
+	for _, a := range e.Affected {
+		if a.Package.Name != modulePath {
+			continue
+		}
+		for _, r := range a.Ranges {
+			for _, ev := range r.Events {
+				if ev.Fixed == "" || compareOSV(ev.Fixed, version) <= 0 {
+					continue
+				}
+				if fixed == "" || compareOSV(ev.Fixed, fixed) < 0 {
+					fixed = ev.Fixed
+				}
+			}
+		}
+	}
+	// OSV lists Go versions without the "v" the module versions carry.
+	if fixed != "" && strings.HasPrefix(version, "v") && !strings.HasPrefix(fixed, "v") {
+		fixed = "v" + fixed
+	}
+	return fixed
+}
+
 // RealClient implements Client using OSV API
 type RealClient struct {
 	cache      map[string]SeverityCounts
@@ -60,17 +156,7 @@ type osvQuery struct {
 
 // osvResponse represents the response from OSV API
 type osvResponse struct {
-	Vulns []struct {
-		ID               string `json:"id"`
-		Summary          string `json:"summary"`
-		DatabaseSpecific struct {
-			Severity string `json:"severity"`
-		} `json:"database_specific"`
-		Severity []struct {
-			Type  string `json:"type"`
-			Score string `json:"score"`
-		} `json:"severity"`
-	} `json:"vulns"`
+	Vulns []osvEntry `json:"vulns"`
 }
 
 // CheckModule fetches vulnerability data for a specific module version using OSV API
@@ -85,8 +171,23 @@ func (c *RealClient) CheckModule(ctx context.Context, modulePath, version string
 	}
 	c.cacheMu.RUnlock()
 
-	counts := SeverityCounts{}
+	advisories, err := c.Advisories(ctx, modulePath, version)
+	if err != nil {
+		return SeverityCounts{}, err
+	}
+	counts := Count(advisories)
+
+	// Cache the result
+	c.cacheMu.Lock()
+	c.cache[cacheKey] = counts
+	c.cacheMu.Unlock()
 
+	return counts, nil
+}
+
+// Advisories queries the OSV API for the advisories affecting modulePath at
+// version. Results are not cached.
+func (c *RealClient) Advisories(ctx context.Context, modulePath, version string) ([]Advisory, error) {
 	// Prepare OSV API query
 	query := osvQuery{}
 	query.Package.Name = modulePath
@@ -95,61 +196,36 @@ func (c *RealClient) CheckModule(ctx context.Context, modulePath, version string
 
 	jsonData, err := json.Marshal(query)
 	if err != nil {
-		return counts, fmt.Errorf("failed to marshal query: %w", err)
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
 	}
 
 	// Query OSV API
 	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.osv.dev/v1/query", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return counts, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return counts, fmt.Errorf("failed to query OSV API: %w", err)
+		return nil, fmt.Errorf("failed to query OSV API: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return counts, fmt.Errorf("OSV API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("OSV API returned status %d", resp.StatusCode)
 	}
 
 	var osvResp osvResponse
 	if err := json.NewDecoder(resp.Body).Decode(&osvResp); err != nil {
-		return counts, fmt.Errorf("failed to decode OSV response: %w", err)
+		return nil, fmt.Errorf("failed to decode OSV response: %w", err)
 	}
 
-	// Count vulnerabilities by severity
-	for _, vuln := range osvResp.Vulns {
-		counts.Total++
-
-		severity := strings.ToUpper(vuln.DatabaseSpecific.Severity)
-		if severity == "" && len(vuln.Severity) > 0 {
-			// Try to extract severity from CVSS score
-			severity = ExtractSeverityFromCVSS(vuln.Severity[0].Score)
-		}
-
-		switch severity {
-		case "LOW":
-			counts.Low++
-		case "MODERATE", "MEDIUM":
-			counts.Medium++
-		case "HIGH":
-			counts.High++
-		case "CRITICAL":
-			counts.Critical++
-		default:
-			counts.Medium++ // Default to medium if unknown
-		}
+	advisories := make([]Advisory, 0, len(osvResp.Vulns))
+	for _, v := range osvResp.Vulns {
+		advisories = append(advisories, v.advisory(modulePath, version))
 	}
-
-	// Cache the result
-	c.cacheMu.Lock()
-	c.cache[cacheKey] = counts
-	c.cacheMu.Unlock()
-
-	return counts, nil
+	return advisories, nil
 }
 
 // ExtractSeverityFromCVSS extracts severity level from CVSS score string